@@ -12,12 +12,133 @@ import (
 )
 
 type Config struct {
-	Profile    string           `mapstructure:"profile" json:"profile,omitempty"`
-	Server     ServerConfig     `mapstructure:"server" json:"server,omitempty"`
-	Log        LogConfig        `mapstructure:"log" json:"log,omitempty"`
-	Database   DatabaseConfig   `mapstructure:"database" json:"database,omitempty"`
-	Redis      RedisConfig      `mapstructure:"redis" json:"redis,omitempty"`
-	Middleware MiddlewareConfig `mapstructure:"middleware" json:"middleware,omitempty"`
+	Profile      string             `mapstructure:"profile" json:"profile,omitempty"`
+	Server       ServerConfig       `mapstructure:"server" json:"server,omitempty"`
+	Log          LogConfig          `mapstructure:"log" json:"log,omitempty"`
+	Database     DatabaseConfig     `mapstructure:"database" json:"database,omitempty"`
+	Redis        RedisConfig        `mapstructure:"redis" json:"redis,omitempty"`
+	Middleware   MiddlewareConfig   `mapstructure:"middleware" json:"middleware,omitempty"`
+	Pagination   PaginationConfig   `mapstructure:"pagination" json:"pagination,omitempty"`
+	Storage      StorageConfig      `mapstructure:"storage" json:"storage,omitempty"`
+	Avatar       AvatarConfig       `mapstructure:"avatar" json:"avatar,omitempty"`
+	Notification NotificationConfig `mapstructure:"notification" json:"notification,omitempty"`
+	Export       ExportConfig       `mapstructure:"export" json:"export,omitempty"`
+	Account      AccountConfig      `mapstructure:"account" json:"account,omitempty"`
+	Settings     SettingsConfig     `mapstructure:"settings" json:"settings,omitempty"`
+	Organization OrganizationConfig `mapstructure:"organization" json:"organization,omitempty"`
+	Tenancy      TenancyConfig      `mapstructure:"tenancy" json:"tenancy,omitempty"`
+	Username     UsernameConfig     `mapstructure:"username" json:"username,omitempty"`
+	Import       ImportConfig       `mapstructure:"import" json:"import,omitempty"`
+}
+
+type PaginationConfig struct {
+	// CursorSecret signs keyset pagination cursors; share it across instances.
+	// Empty uses a random per-process key.
+	CursorSecret string `mapstructure:"cursorSecret" json:"-"`
+}
+
+type StorageConfig struct {
+	Driver string             `mapstructure:"driver" json:"driver,omitempty"` // "local" (default) or "s3"
+	Local  LocalStorageConfig `mapstructure:"local" json:"local,omitempty"`
+	S3     S3StorageConfig    `mapstructure:"s3" json:"s3,omitempty"`
+}
+
+type LocalStorageConfig struct {
+	// Root is the directory holding one sub-directory per bucket.
+	Root string `mapstructure:"root" json:"root,omitempty"`
+	// BaseURL is the public URL of the file route signed URLs point to.
+	BaseURL string `mapstructure:"baseURL" json:"base_url,omitempty"`
+	// SigningSecret signs download URLs; share it across instances.
+	SigningSecret string `mapstructure:"signingSecret" json:"-"`
+}
+
+type S3StorageConfig struct {
+	// Endpoint is the S3 API URL; empty uses AWS (https://s3.<region>.amazonaws.com).
+	Endpoint        string `mapstructure:"endpoint" json:"endpoint,omitempty"`
+	Region          string `mapstructure:"region" json:"region,omitempty"`
+	AccessKeyID     string `mapstructure:"accessKeyID" json:"access_key_id,omitempty"`
+	SecretAccessKey string `mapstructure:"secretAccessKey" json:"-"`
+	SessionToken    string `mapstructure:"sessionToken" json:"-"`
+	// UsePathStyle addresses buckets as <endpoint>/<bucket> instead of
+	// <bucket>.<endpoint>; required by MinIO and most S3-compatible servers.
+	UsePathStyle bool          `mapstructure:"usePathStyle" json:"use_path_style,omitempty"`
+	PartSize     int64         `mapstructure:"partSize" json:"part_size,omitempty"` // Multipart upload threshold and part size in bytes
+	Timeout      time.Duration `mapstructure:"timeout" json:"timeout,omitempty"`    // Per request
+	// HealthBucket is checked by health checks; empty lists the buckets instead.
+	HealthBucket string `mapstructure:"healthBucket" json:"health_bucket,omitempty"`
+}
+
+type AvatarConfig struct {
+	Bucket        string        `mapstructure:"bucket" json:"bucket,omitempty"`
+	MaxSize       int64         `mapstructure:"maxSize" json:"max_size,omitempty"`             // Max upload size in bytes
+	MaxDimension  int           `mapstructure:"maxDimension" json:"max_dimension,omitempty"`   // Max source width and height in pixels
+	Size          int           `mapstructure:"size" json:"size,omitempty"`                    // Edge of the square avatar in pixels
+	ThumbnailSize int           `mapstructure:"thumbnailSize" json:"thumbnail_size,omitempty"` // Edge of the square thumbnail in pixels
+	URLExpiry     time.Duration `mapstructure:"urlExpiry" json:"url_expiry,omitempty"`         // Lifetime of signed avatar URLs
+}
+
+type NotificationConfig struct {
+	Driver string     `mapstructure:"driver" json:"driver,omitempty"` // "log" (default, development) or "smtp"
+	SMTP   SMTPConfig `mapstructure:"smtp" json:"smtp,omitempty"`
+}
+
+type SMTPConfig struct {
+	Host     string `mapstructure:"host" json:"host,omitempty"`
+	Port     int    `mapstructure:"port" json:"port,omitempty"`
+	Username string `mapstructure:"username" json:"username,omitempty"`
+	Password string `mapstructure:"password" json:"-"`
+	From     string `mapstructure:"from" json:"from,omitempty"` // Sender address, e.g. "Base Service <no-reply@example.com>"
+}
+
+type ExportConfig struct {
+	Bucket     string        `mapstructure:"bucket" json:"bucket,omitempty"`
+	LinkExpiry time.Duration `mapstructure:"linkExpiry" json:"link_expiry,omitempty"` // Lifetime of emailed download links
+	Timeout    time.Duration `mapstructure:"timeout" json:"timeout,omitempty"`        // Limit for building one export
+}
+
+type AccountConfig struct {
+	GracePeriod      time.Duration `mapstructure:"gracePeriod" json:"grace_period,omitempty"`           // Self-deleted accounts can be restored by logging in until then
+	ReauthMaxAge     time.Duration `mapstructure:"reauthMaxAge" json:"reauth_max_age,omitempty"`        // Max age of the authentication that deletes an account
+	DeletedRetention time.Duration `mapstructure:"deletedRetention" json:"deleted_retention,omitempty"` // Purge delay of admin-deleted users; 0 keeps them
+	PurgeInterval    time.Duration `mapstructure:"purgeInterval" json:"purge_interval,omitempty"`
+	PurgeBatchSize   int           `mapstructure:"purgeBatchSize" json:"purge_batch_size,omitempty"`
+	PurgeRetryDelay  time.Duration `mapstructure:"purgeRetryDelay" json:"purge_retry_delay,omitempty"` // A failed purge is retried after this
+}
+
+type SettingsConfig struct {
+	CacheTTL time.Duration `mapstructure:"cacheTTL" json:"cache_ttl,omitempty"` // Redis read-through cache of user settings; 0 uses 10m
+}
+
+type OrganizationConfig struct {
+	InvitationExpiry time.Duration `mapstructure:"invitationExpiry" json:"invitation_expiry,omitempty"` // How long an emailed invitation can be accepted; 0 uses 7 days
+	InvitationURL    string        `mapstructure:"invitationURL" json:"invitation_url,omitempty"`       // Page accepting invitations (?token=...); empty emails the bare token
+}
+
+type UsernameConfig struct {
+	ChangeCooldown time.Duration `mapstructure:"changeCooldown" json:"change_cooldown,omitempty"` // Minimum time between two username changes; 0 uses 30 days
+	Reservation    time.Duration `mapstructure:"reservation" json:"reservation,omitempty"`        // How long an old username stays reserved and redirects; 0 uses 90 days
+}
+
+type ImportConfig struct {
+	Bucket     string        `mapstructure:"bucket" json:"bucket,omitempty"`          // Receives uploaded import files
+	BatchSize  int           `mapstructure:"batchSize" json:"batch_size,omitempty"`   // Rows inserted, and checkpointed, per transaction; 0 uses 500
+	StaleAfter time.Duration `mapstructure:"staleAfter" json:"stale_after,omitempty"` // A running import without progress for this long can be resumed; 0 uses 5m
+	Timeout    time.Duration `mapstructure:"timeout" json:"timeout,omitempty"`        // Limit for one run of a background import; 0 uses 1h
+}
+
+type TenancyConfig struct {
+	// Enabled resolves a tenant for every request and isolates the database
+	// rows and Redis keys of tenants. Disabled, everything is in one tenant.
+	Enabled bool `mapstructure:"enabled" json:"enabled,omitempty"`
+	// BaseDomain resolves the tenant from the subdomain of requests to
+	// <tenant>.<baseDomain>; empty skips subdomains.
+	BaseDomain string `mapstructure:"baseDomain" json:"base_domain,omitempty"`
+	// Header names the request header holding the tenant slug or ID; empty uses X-Tenant-ID.
+	Header string `mapstructure:"header" json:"header,omitempty"`
+	// DefaultTenant is the slug used when a request names no tenant; empty rejects such requests.
+	DefaultTenant string `mapstructure:"defaultTenant" json:"default_tenant,omitempty"`
+	// CacheTTL is how long looked up tenants are cached; 0 uses 5 minutes.
+	CacheTTL time.Duration `mapstructure:"cacheTTL" json:"cache_ttl,omitempty"`
 }
 
 type LogConfig struct {
@@ -53,10 +174,16 @@ type DatabaseConfig struct {
 	MaxIdleConnections int32         `mapstructure:"maxIdleConnections" json:"max_idle_connections,omitempty"`
 	MaxConnLifetime    time.Duration `mapstructure:"maxConnLifetime" json:"max_conn_lifetime,omitempty"`
 	MaxConnIdleTime    time.Duration `mapstructure:"maxConnIdleTime" json:"max_conn_idle_time,omitempty"`
+	// SystemUserName and SystemPassword log in a role with BYPASSRLS, used
+	// only by jobs working across tenants. Empty disables them when tenancy
+	// is enabled.
+	SystemUserName string `mapstructure:"systemUserName" json:"system_user_name,omitempty"`
+	SystemPassword string `mapstructure:"systemPassword" json:"system_password,omitempty"`
 }
 
 type MiddlewareConfig struct {
 	Token     TokenConfig     `mapstructure:"token" json:"token,omitempty"`
+	Session   SessionConfig   `mapstructure:"session" json:"session,omitempty"`
 	CORS      CORSConfig      `mapstructure:"cors" json:"cors,omitempty"`
 	RateLimit RateLimitConfig `mapstructure:"rateLimit" json:"rate_limit,omitempty"`
 }
@@ -67,6 +194,33 @@ type TokenConfig struct {
 	AccessTokenExp     time.Duration `mapstructure:"accessTokenExp" json:"access_token_exp,omitempty"`
 	RefreshTokenSecret string        `mapstructure:"refreshTokenSecret" json:"refresh_token_secret,omitempty"`
 	RefreshTokenExp    time.Duration `mapstructure:"refreshTokenExp" json:"refresh_token_exp,omitempty"`
+	StepUpTokenExp     time.Duration `mapstructure:"stepUpTokenExp" json:"step_up_token_exp,omitempty"` // Lifetime of elevated tokens from re-auth
+
+	// Issuer is written to the "iss" claim of every minted token.
+	Issuer string `mapstructure:"issuer" json:"issuer,omitempty"`
+	// Audience is written to the "aud" claim of every minted token.
+	Audience []string `mapstructure:"audience" json:"audience,omitempty"`
+	// ValidIssuers lists the issuers accepted by ValidateToken (defaults to Issuer).
+	ValidIssuers []string `mapstructure:"validIssuers" json:"valid_issuers,omitempty"`
+	// ValidAudiences lists the audiences accepted by ValidateToken (defaults to Audience).
+	ValidAudiences []string `mapstructure:"validAudiences" json:"valid_audiences,omitempty"`
+
+	// MaxCustomClaimsSize bounds the encoded size (bytes) of enriched custom claims.
+	MaxCustomClaimsSize int `mapstructure:"maxCustomClaimsSize" json:"max_custom_claims_size,omitempty"`
+
+	// ExternalIssuers lists trusted issuers whose tokens are verified against their JWKS.
+	ExternalIssuers []ExternalIssuerConfig `mapstructure:"externalIssuers" json:"external_issuers,omitempty"`
+}
+
+// ExternalIssuerConfig describes a trusted external token issuer.
+type ExternalIssuerConfig struct {
+	Issuer             string        `mapstructure:"issuer" json:"issuer,omitempty"`
+	JWKSURL            string        `mapstructure:"jwksUrl" json:"jwks_url,omitempty"`
+	Audience           []string      `mapstructure:"audience" json:"audience,omitempty"`                       // Accepted "aud" values
+	Algorithms         []string      `mapstructure:"algorithms" json:"algorithms,omitempty"`                   // Allowed signing algorithms (default RS256)
+	RefreshInterval    time.Duration `mapstructure:"refreshInterval" json:"refresh_interval,omitempty"`        // Background JWKS refresh period
+	MinRefetchInterval time.Duration `mapstructure:"minRefetchInterval" json:"min_refetch_interval,omitempty"` // Minimum gap between kid-miss refetches
+	StepUp             bool          `mapstructure:"stepUp" json:"step_up,omitempty"`                          // A recent auth_time of its tokens passes RequireRecentAuth
 }
 
 type CORSConfig struct {
@@ -78,19 +232,53 @@ type CORSConfig struct {
 	MaxAge           int      `mapstructure:"maxAge" json:"max_age,omitempty"`
 }
 
+// Session limit policies applied when a user reaches MaxConcurrent sessions.
+const (
+	SessionLimitEvictOldest = "evict_oldest"
+	SessionLimitReject      = "reject"
+)
+
+// SessionConfig configures server-side session tracking (requires Redis).
+type SessionConfig struct {
+	Enabled               bool          `mapstructure:"enabled" json:"enabled,omitempty"`
+	IdleTimeout           time.Duration `mapstructure:"idleTimeout" json:"idle_timeout,omitempty"`                      // Sliding inactivity timeout (0 = disabled)
+	MaxConcurrent         int           `mapstructure:"maxConcurrent" json:"max_concurrent,omitempty"`                  // Max sessions per user (0 = unlimited)
+	LimitPolicy           string        `mapstructure:"limitPolicy" json:"limit_policy,omitempty"`                      // "evict_oldest" or "reject"
+	ActivityWriteInterval time.Duration `mapstructure:"activityWriteInterval" json:"activity_write_interval,omitempty"` // Min time between activity writes per session
+}
+
+// GetLimitPolicy returns the configured limit policy, defaulting to evict_oldest.
+func (s SessionConfig) GetLimitPolicy() string {
+	if s.LimitPolicy == SessionLimitReject {
+		return SessionLimitReject
+	}
+	return SessionLimitEvictOldest
+}
+
+// GetActivityWriteInterval returns the activity write interval, defaulting to 1 minute.
+func (s SessionConfig) GetActivityWriteInterval() time.Duration {
+	if s.ActivityWriteInterval > 0 {
+		return s.ActivityWriteInterval
+	}
+	return time.Minute
+}
+
 type RateLimitConfig struct {
 	Enabled        bool          `mapstructure:"enabled" json:"enabled,omitempty"`
 	Max            int           `mapstructure:"max" json:"max,omitempty"`                         // Max requests per window
 	Expiration     time.Duration `mapstructure:"expiration" json:"expiration,omitempty"`           // Time window (e.g., 1 minute)
 	SkipFailedReq  bool          `mapstructure:"skipFailedReq" json:"skip_failed_req,omitempty"`   // Skip failed requests
 	SkipSuccessReq bool          `mapstructure:"skipSuccessReq" json:"skip_success_req,omitempty"` // Skip successful requests
-	LimitReached   string        `mapstructure:"limitReached" json:"limit_reached,omitempty"`      // Custom message when limit is reached
+	LimitReached   string        `mapstructure:"limitReached" json:"limit_reached,omitempty"`      // Deprecated: messages come from the error catalog (RATE_LIMITED)
 
 	// Auth-specific rate limits (stricter)
 	AuthEnabled    bool          `mapstructure:"authEnabled" json:"auth_enabled,omitempty"`
 	AuthMax        int           `mapstructure:"authMax" json:"auth_max,omitempty"`
 	AuthExpiration time.Duration `mapstructure:"authExpiration" json:"auth_expiration,omitempty"`
 
+	// Per-user limits of the caller's tier (tiers.rate_limit), within the window of Expiration
+	TierEnabled bool `mapstructure:"tierEnabled" json:"tier_enabled,omitempty"`
+
 	// Distributed rate limiting (Redis storage)
 	UseRedis bool `mapstructure:"useRedis" json:"use_redis,omitempty"` // Use Redis for distributed rate limiting
 	RedisDB  int  `mapstructure:"redisDB" json:"redis_db,omitempty"`   // Redis database number for rate limiting
@@ -173,10 +361,80 @@ func (r *DatabaseConfig) BuildConnectionStringPostgres() string {
 	)
 }
 
+// SystemConfig returns the configuration of the system role's connections,
+// nil when no system role is configured.
+func (r *DatabaseConfig) SystemConfig() *DatabaseConfig {
+	if r.SystemUserName == "" {
+		return nil
+	}
+	system := *r
+	system.UserName = r.SystemUserName
+	system.Password = r.SystemPassword
+	return &system
+}
+
 func (r *RedisConfig) BuildRedisConnectionString() string {
 	return fmt.Sprintf("redis://%s:%s@%s:%d", "default", "", r.Host, r.Port)
 }
 
+// GetIssuer returns the configured token issuer or the default one.
+func (t *TokenConfig) GetIssuer() string {
+	if len(t.Issuer) == 0 {
+		return "base-service"
+	}
+	return t.Issuer
+}
+
+// GetValidIssuers returns the issuers accepted during token validation.
+func (t *TokenConfig) GetValidIssuers() []string {
+	if len(t.ValidIssuers) == 0 {
+		return []string{t.GetIssuer()}
+	}
+	return t.ValidIssuers
+}
+
+// GetValidAudiences returns the audiences accepted during token validation.
+func (t *TokenConfig) GetValidAudiences() []string {
+	if len(t.ValidAudiences) == 0 {
+		return t.Audience
+	}
+	return t.ValidAudiences
+}
+
+// FindExternalIssuer returns the external issuer configuration for iss, if trusted.
+func (t *TokenConfig) FindExternalIssuer(iss string) (*ExternalIssuerConfig, bool) {
+	for i := range t.ExternalIssuers {
+		if t.ExternalIssuers[i].Issuer == iss {
+			return &t.ExternalIssuers[i], true
+		}
+	}
+	return nil, false
+}
+
+// GetAlgorithms returns the allowed signing algorithms for the issuer.
+func (e *ExternalIssuerConfig) GetAlgorithms() []string {
+	if len(e.Algorithms) == 0 {
+		return []string{"RS256"}
+	}
+	return e.Algorithms
+}
+
+// GetRefreshInterval returns the background JWKS refresh period.
+func (e *ExternalIssuerConfig) GetRefreshInterval() time.Duration {
+	if e.RefreshInterval <= 0 {
+		return 15 * time.Minute
+	}
+	return e.RefreshInterval
+}
+
+// GetMinRefetchInterval returns the minimum gap between refetches on unknown kid.
+func (e *ExternalIssuerConfig) GetMinRefetchInterval() time.Duration {
+	if e.MinRefetchInterval <= 0 {
+		return 30 * time.Second
+	}
+	return e.MinRefetchInterval
+}
+
 func (c *CORSConfig) GetOriginsString() string {
 	if len(c.AllowedOrigins) == 0 {
 		return "*"
@@ -186,14 +444,14 @@ func (c *CORSConfig) GetOriginsString() string {
 
 func (c *CORSConfig) GetMethodsString() string {
 	if len(c.AllowedMethods) == 0 {
-		return "GET, POST, PUT, DELETE, OPTIONS"
+		return "GET, POST, PUT, PATCH, DELETE, OPTIONS"
 	}
 	return strings.Join(c.AllowedMethods, ", ")
 }
 
 func (c *CORSConfig) GetHeadersString() string {
 	if len(c.AllowedHeaders) == 0 {
-		return "Origin, Content-Type, Accept, Authorization"
+		return "Origin, Content-Type, Accept, Authorization, If-Match"
 	}
 	return strings.Join(c.AllowedHeaders, ", ")
 }
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"base-service/config"
+	adapterRepository "base-service/internal/adapter/repository"
+	"base-service/internal/domain/entity"
+	"base-service/internal/infra"
+	"base-service/internal/middleware"
+	"base-service/internal/route"
+	"base-service/internal/usecase/port"
+	"base-service/internal/usecase/tenant"
+)
+
+// importUsage documents the import command.
+const importUsage = `Usage:
+  base-service [-config dir] [-env env] import [-format csv|ndjson] [-dry-run] [-tenant id|slug] FILE
+  base-service [-config dir] [-env env] import -resume ID [-tenant id|slug]
+
+Imports users from a CSV or NDJSON file, or resumes a failed or stalled
+import after its last checkpoint. Rejected rows are listed by
+GET /api/v1/admin/users/imports/ID/errors.
+
+`
+
+// runImport runs the import command without starting the HTTP server and
+// returns the process exit code. Interrupting it fails the import, which can
+// then be resumed.
+func runImport(cfg *config.Config, args []string) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), importUsage)
+		flags.PrintDefaults()
+	}
+	format := flags.String("format", "", "file format, csv or ndjson (default: from the file extension)")
+	dryRun := flags.Bool("dry-run", false, "validate and check every row without creating users")
+	resumeID := flags.Int64("resume", 0, "ID of a failed or stalled import to resume")
+	tenantKey := flags.String("tenant", "", "ID or slug of the tenant to import into, required when tenancy is enabled")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if (*resumeID == 0) == (flags.NArg() == 0) || flags.NArg() > 1 {
+		flags.Usage()
+		return 2
+	}
+
+	// Without a tenant, row-level security hides every tenant's data
+	if cfg.Tenancy.Enabled && *tenantKey == "" {
+		slog.Error("Tenancy is enabled: name the tenant to import into with -tenant")
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	db, err := initDatabase(&cfg.Database, cfg.Tenancy)
+	if err != nil {
+		slog.Error("Failed to initialize database", "error", err)
+		return 1
+	}
+	defer db.Close()
+
+	storage, err := infra.NewObjectStorage(cfg.Storage)
+	if err != nil {
+		slog.Error("Failed to initialize object storage", "error", err)
+		return 1
+	}
+	defer storage.Close()
+
+	if *tenantKey != "" {
+		t, err := tenant.NewTenantUseCase(adapterRepository.NewTenantRepository(db.GetPool())).ResolveTenant(ctx, *tenantKey)
+		if err != nil {
+			slog.Error("Failed to resolve tenant", "error", err, "tenant", *tenantKey)
+			return 1
+		}
+		ctx = entity.ContextWithTenant(ctx, t)
+	}
+
+	// Only password hashing is used, which needs no token store
+	authHandler := middleware.NewAuthMiddleware(cfg.Middleware, nil, nil)
+	importUseCase := route.NewUserImportUseCase(cfg, db, storage, authHandler)
+
+	importID := *resumeID
+	if importID == 0 {
+		imp, err := createImport(ctx, importUseCase, flags.Arg(0), *format, *dryRun)
+		if err != nil {
+			slog.Error("Failed to create user import", "error", err, "file", flags.Arg(0))
+			return 1
+		}
+		importID = imp.ID
+		slog.Info("User import created", "import_id", importID, "dry_run", imp.DryRun)
+	}
+
+	imp, err := importUseCase.RunImport(ctx, importID)
+	if imp != nil {
+		fmt.Printf("import %d %s: %d rows processed, %d imported, %d rejected\n",
+			imp.ID, imp.Status, imp.RowsProcessed, imp.RowsImported, imp.RowsFailed)
+	}
+	if err != nil {
+		slog.Error("User import did not complete", "error", err, "import_id", importID)
+		if imp != nil && imp.Status == entity.ImportStatusFailed {
+			fmt.Printf("resume it with: import -resume %d\n", importID)
+		}
+		return 1
+	}
+	return 0
+}
+
+// createImport uploads file and creates a pending import of it.
+func createImport(ctx context.Context, importUseCase port.UserImportUseCase, path, format string, dryRun bool) (*entity.UserImport, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+		if format == "" {
+			return nil, errors.New("cannot tell the format from the file name, use -format")
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return importUseCase.CreateImport(ctx, &port.UserImportInput{
+		Format: format,
+		File:   file,
+		DryRun: dryRun,
+	})
+}
@@ -1,6 +1,10 @@
 package auth
 
 import (
+	"context"
+	"time"
+
+	"base-service/internal/domain/entity"
 	"base-service/internal/middleware"
 	"base-service/internal/usecase/port"
 )
@@ -8,11 +12,15 @@ import (
 // AuthAdapter wraps the existing AuthMiddleware to implement usecase interfaces.
 type AuthAdapter struct {
 	authen *middleware.AuthMiddleware
+	totp   *middleware.TOTPValidator
 }
 
 // NewAuthAdapter creates a new auth adapter.
 func NewAuthAdapter(authen *middleware.AuthMiddleware) *AuthAdapter {
-	return &AuthAdapter{authen: authen}
+	return &AuthAdapter{
+		authen: authen,
+		totp:   middleware.NewTOTPValidator(),
+	}
 }
 
 // HashPassword implements auth.PasswordHasher.
@@ -25,9 +33,19 @@ func (a *AuthAdapter) VerifyPassword(password, hash string) (bool, error) {
 	return a.authen.VerifyPassword(password, hash)
 }
 
+// NeedsRehash implements auth.PasswordHasher.
+func (a *AuthAdapter) NeedsRehash(hash string) bool {
+	return a.authen.NeedsRehash(hash)
+}
+
+// ValidatePasswordHash implements userimport.PasswordHasher.
+func (a *AuthAdapter) ValidatePasswordHash(hash string) error {
+	return a.authen.ValidatePasswordHash(hash)
+}
+
 // GenerateTokenPair implements auth.TokenGenerator.
-func (a *AuthAdapter) GenerateTokenPair(userID int64, username string) (*port.TokenPair, error) {
-	pair, err := a.authen.GenerateTokenPair(userID, username)
+func (a *AuthAdapter) GenerateTokenPair(ctx context.Context, user *entity.User, auth port.Authentication) (*port.TokenPair, error) {
+	pair, err := a.authen.GenerateTokenPair(ctx, user, toMiddlewareAuth(auth))
 	if err != nil {
 		return nil, err
 	}
@@ -38,8 +56,8 @@ func (a *AuthAdapter) GenerateTokenPair(userID int64, username string) (*port.To
 }
 
 // GenerateAccessToken implements auth.TokenGenerator.
-func (a *AuthAdapter) GenerateAccessToken(userID int64, username string) (*port.TokenPair, error) {
-	pair, err := a.authen.GenerateAcessToken(userID, username)
+func (a *AuthAdapter) GenerateAccessToken(ctx context.Context, user *entity.User, auth port.Authentication) (*port.TokenPair, error) {
+	pair, err := a.authen.GenerateAccessToken(ctx, user, toMiddlewareAuth(auth))
 	if err != nil {
 		return nil, err
 	}
@@ -49,13 +67,67 @@ func (a *AuthAdapter) GenerateAccessToken(userID int64, username string) (*port.
 	}, nil
 }
 
+// GenerateStepUpToken implements auth.TokenGenerator.
+func (a *AuthAdapter) GenerateStepUpToken(ctx context.Context, user *entity.User, auth port.Authentication) (*port.StepUpOutput, error) {
+	pair, err := a.authen.GenerateStepUpToken(ctx, user, toMiddlewareAuth(auth))
+	if err != nil {
+		return nil, err
+	}
+	return &port.StepUpOutput{
+		AccessToken: pair.AccessToken,
+		ExpiresAt:   pair.ExpiresAt,
+	}, nil
+}
+
 // ValidateRefreshToken implements auth.TokenGenerator.
-func (a *AuthAdapter) ValidateRefreshToken(token string) (int64, string, error) {
+func (a *AuthAdapter) ValidateRefreshToken(token string) (int64, string, port.Authentication, error) {
 	claims, err := a.authen.ValidateRefreshToken(token)
 	if err != nil {
-		return 0, "", err
+		return 0, "", port.Authentication{}, err
+	}
+	auth := claims.Authentication()
+	return claims.UserId, claims.UserName, port.Authentication{
+		Time:      auth.Time,
+		Methods:   auth.Methods,
+		SessionID: auth.SessionID,
+	}, nil
+}
+
+// RevokeUserSessions implements user.SessionRevoker.
+func (a *AuthAdapter) RevokeUserSessions(ctx context.Context, userID int64) error {
+	return a.authen.RevokeUserSessions(ctx, userID)
+}
+
+// SetUserStatus implements user.StatusEnforcer.
+func (a *AuthAdapter) SetUserStatus(ctx context.Context, userID int64, status string) error {
+	return a.authen.SetUserStatus(ctx, userID, status)
+}
+
+// RevokeOrgAccess implements organization.AccessRevoker.
+func (a *AuthAdapter) RevokeOrgAccess(ctx context.Context, orgID, userID int64) error {
+	return a.authen.RevokeOrgAccess(ctx, orgID, userID)
+}
+
+// ListUserSessions implements auth.SessionLister.
+func (a *AuthAdapter) ListUserSessions(ctx context.Context, userID int64) ([]port.Session, error) {
+	infos, err := a.authen.ListUserSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]port.Session, 0, len(infos))
+	for _, info := range infos {
+		sessions = append(sessions, port.Session{
+			ID:        info.ID,
+			StartedAt: info.StartedAt,
+			ExpiresAt: info.ExpiresAt,
+		})
 	}
-	return claims.UserId, claims.UserName, nil
+	return sessions, nil
+}
+
+// VerifyOTP implements auth.OTPVerifier.
+func (a *AuthAdapter) VerifyOTP(secret, code string, at time.Time) (int64, bool) {
+	return a.totp.Validate(secret, code, at)
 }
 
 // InvalidateToken implements auth.TokenGenerator.
@@ -65,3 +137,12 @@ func (a *AuthAdapter) InvalidateToken(token string) error {
 	// which requires fiber.Ctx. For use case layer, we return nil.
 	return nil
 }
+
+// toMiddlewareAuth converts the use case authentication to token claims input.
+func toMiddlewareAuth(auth port.Authentication) middleware.Authentication {
+	return middleware.Authentication{
+		Time:      auth.Time,
+		Methods:   auth.Methods,
+		SessionID: auth.SessionID,
+	}
+}
@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+
+	"base-service/internal/domain/entity"
+	"base-service/internal/middleware"
+	"base-service/internal/usecase/port"
+)
+
+// Compile-time interface compliance check
+var _ middleware.ClaimsEnricher = (*OrgClaimsEnricher)(nil)
+
+// OrgClaimsEnricher adds the user's active organization and role to access
+// tokens, for RequireOrgRole.
+type OrgClaimsEnricher struct {
+	organizations port.OrganizationUseCase
+}
+
+// NewOrgClaimsEnricher creates the organization claims enricher.
+func NewOrgClaimsEnricher(organizations port.OrganizationUseCase) *OrgClaimsEnricher {
+	return &OrgClaimsEnricher{organizations: organizations}
+}
+
+// Namespace returns the key the organization claims are stored under.
+func (e *OrgClaimsEnricher) Namespace() string {
+	return middleware.OrgClaimsNamespace
+}
+
+// Enrich returns the claims of the user's active organization, nil if none.
+func (e *OrgClaimsEnricher) Enrich(ctx context.Context, user *entity.User) (any, error) {
+	active, err := e.organizations.ActiveOrganization(ctx, user.ID)
+	if err != nil || active == nil {
+		return nil, err
+	}
+	return middleware.OrgClaims{
+		ID:   active.OrganizationID,
+		Slug: active.Organization.Slug,
+		Role: active.Role,
+	}, nil
+}
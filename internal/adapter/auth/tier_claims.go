@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+
+	"base-service/internal/domain/entity"
+	"base-service/internal/middleware"
+	"base-service/internal/usecase/port"
+)
+
+// Compile-time interface compliance check
+var _ middleware.ClaimsEnricher = (*TierClaimsEnricher)(nil)
+
+// TierClaimsEnricher adds the user's current tier to access tokens, for
+// RequireFeature and TierRateLimitFilter.
+type TierClaimsEnricher struct {
+	tiers port.TierUseCase
+}
+
+// NewTierClaimsEnricher creates the tier claims enricher.
+func NewTierClaimsEnricher(tiers port.TierUseCase) *TierClaimsEnricher {
+	return &TierClaimsEnricher{tiers: tiers}
+}
+
+// Namespace returns the key the tier claims are stored under.
+func (e *TierClaimsEnricher) Namespace() string {
+	return middleware.TierClaimsNamespace
+}
+
+// Enrich returns the claims of the user's current tier, nil if none.
+func (e *TierClaimsEnricher) Enrich(ctx context.Context, user *entity.User) (any, error) {
+	current, err := e.tiers.CurrentTier(ctx, user.ID)
+	if err != nil || current == nil {
+		return nil, err
+	}
+	return middleware.TierClaims{
+		Code:      current.Tier.Code,
+		RateLimit: current.Tier.RateLimit,
+		Features:  current.Tier.Features,
+	}, nil
+}
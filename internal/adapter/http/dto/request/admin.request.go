@@ -0,0 +1,71 @@
+package request
+
+// ListUsersRequest represents the query parameters of the admin user list.
+type ListUsersRequest struct {
+	Deleted     string `query:"deleted" json:"deleted" validate:"omitempty,oneof=exclude only include"`
+	Status      string `query:"status" json:"status" validate:"omitempty,oneof=pending active suspended banned"`
+	CreatedFrom string `query:"created_from" json:"created_from" validate:"omitempty,datetime"`
+	CreatedTo   string `query:"created_to" json:"created_to" validate:"omitempty,datetime"`
+	EmailDomain string `query:"email_domain" json:"email_domain" validate:"omitempty,max=253"`
+	Role        string `query:"role" json:"role" validate:"omitempty,oneof=user admin"`
+	Sort        string `query:"sort" json:"sort" validate:"omitempty,oneof=id created_at updated_at username email"`
+	Order       string `query:"order" json:"order" validate:"omitempty,oneof=asc desc"`
+	Page        int    `query:"page" json:"page" validate:"omitempty,min=1"`
+	PageSize    int    `query:"page_size" json:"page_size" validate:"omitempty,min=1,max=100"`
+	Cursor      string `query:"cursor" json:"cursor" validate:"omitempty,max=512"`
+}
+
+// SearchUsersRequest represents the query parameters of the admin user search.
+type SearchUsersRequest struct {
+	Query          string `query:"q" json:"q" validate:"required,min=2,max=100"`
+	IncludeDeleted bool   `query:"include_deleted" json:"include_deleted"`
+	Page           int    `query:"page" json:"page" validate:"omitempty,min=1"`
+	PageSize       int    `query:"page_size" json:"page_size" validate:"omitempty,min=1,max=100"`
+}
+
+// AdminUpdateUserRequest represents the admin user update request body.
+// Empty fields are left unchanged.
+type AdminUpdateUserRequest struct {
+	Email     string `json:"email" validate:"omitempty,email"`
+	Phone     string `json:"phone" validate:"omitempty,max=20,regex=^\\+?[0-9 ]+$"`
+	FirstName string `json:"first_name" validate:"max=50"`
+	LastName  string `json:"last_name" validate:"max=50"`
+	Role      string `json:"role" validate:"omitempty,oneof=user admin"`
+}
+
+// ChangeUserStatusRequest represents the body of a suspend or ban request.
+type ChangeUserStatusRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+// ReinstateUserRequest represents the body of a reinstate request.
+type ReinstateUserRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+// AssignTierRequest represents the body of a tier assignment. An empty
+// valid_from starts it now; an empty valid_to never ends it.
+type AssignTierRequest struct {
+	Tier      string `json:"tier" validate:"required,max=50"`
+	ValidFrom string `json:"valid_from" validate:"omitempty,datetime"`
+	ValidTo   string `json:"valid_to" validate:"omitempty,datetime"`
+}
+
+// CreateUserImportRequest represents the form fields of a user import upload;
+// the file itself is the "file" part.
+type CreateUserImportRequest struct {
+	Format string `form:"format" json:"format" validate:"required,oneof=csv ndjson"`
+	DryRun bool   `form:"dry_run" json:"dry_run"`
+}
+
+// ListUserImportsRequest represents the query parameters of the import list.
+type ListUserImportsRequest struct {
+	Limit int `query:"limit" json:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// ListUserImportErrorsRequest represents the query parameters of an import's
+// error report.
+type ListUserImportErrorsRequest struct {
+	Page     int `query:"page" json:"page" validate:"omitempty,min=1"`
+	PageSize int `query:"page_size" json:"page_size" validate:"omitempty,min=1,max=500"`
+}
@@ -0,0 +1,25 @@
+package request
+
+// CreateOrganizationRequest represents the create organization request body.
+type CreateOrganizationRequest struct {
+	Slug string `json:"slug" validate:"required,min=3,max=50,regex=^[a-z0-9]+(-[a-z0-9]+)*$"`
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// ChangeOrgMemberRoleRequest represents the body of a member role change.
+type ChangeOrgMemberRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=owner admin member"`
+}
+
+// InviteMemberRequest represents the body of an invitation. An empty role
+// invites a member.
+type InviteMemberRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+	Role  string `json:"role" validate:"omitempty,oneof=admin member"`
+}
+
+// InvitationTokenRequest represents the body of an invitation accept or
+// decline request.
+type InvitationTokenRequest struct {
+	Token string `json:"token" validate:"required,max=100"`
+}
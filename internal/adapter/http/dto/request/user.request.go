@@ -1,17 +1,84 @@
 package request
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 // RegisterRequest represents the registration request body.
 type RegisterRequest struct {
-	UserName  string `json:"user_name" validate:"required,min=3,max=16"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Phone     string `json:"phone"`
+	UserName  string `json:"user_name" validate:"required,min=3,max=16,username"`
+	FirstName string `json:"first_name" validate:"max=50"`
+	LastName  string `json:"last_name" validate:"max=50"`
+	Phone     string `json:"phone" validate:"omitempty,max=20,regex=^\\+?[0-9 ]+$"`
 	Email     string `json:"email" validate:"required,email"`
-	Password  string `json:"password" validate:"required,min=8,max=16"`
+	Password  string `json:"password" validate:"required,password"`
 }
 
 // LoginRequest represents the login request body.
 type LoginRequest struct {
-	UsernameOrEmail string `json:"username_email"`
-	Password        string `json:"password"`
+	UsernameOrEmail string `json:"username_email" validate:"required,max=254"`
+	Password        string `json:"password" validate:"required,max=128"`
+}
+
+// ReauthRequest represents the re-authentication request body.
+// Provide either the password or a TOTP code.
+type ReauthRequest struct {
+	Password string `json:"password" validate:"max=128"`
+	TOTPCode string `json:"totp_code" validate:"omitempty,len=6,regex=^[0-9]+$"`
+}
+
+// PatchProfileRequest represents a JSON Merge Patch (RFC 7396) of the profile.
+// Absent members stay nil and are left unchanged; null members become empty
+// strings and remove the value. Avatar takes external URLs only; images are
+// uploaded through POST /v1/user/avatar.
+type PatchProfileRequest struct {
+	FirstName *string `json:"first_name" validate:"max=50"`
+	LastName  *string `json:"last_name" validate:"max=50"`
+	Phone     *string `json:"phone" validate:"omitempty,max=20,regex=^\\+?[0-9 ]+$"`
+	Avatar    *string `json:"avatar" validate:"omitempty,max=255,regex=^https?://[^ ]+$"`
+}
+
+// UnmarshalJSON keeps null members apart from absent ones and rejects members
+// that cannot be patched.
+func (r *PatchProfileRequest) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	for name, raw := range members {
+		var field **string
+		switch name {
+		case "first_name":
+			field = &r.FirstName
+		case "last_name":
+			field = &r.LastName
+		case "phone":
+			field = &r.Phone
+		case "avatar":
+			field = &r.Avatar
+		default:
+			return fmt.Errorf("%s cannot be patched", name)
+		}
+
+		var value string
+		if string(raw) != "null" {
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		*field = &value
+	}
+	return nil
 }
+
+// ChangeUsernameRequest represents the username change request body.
+type ChangeUsernameRequest struct {
+	UserName string `json:"user_name" validate:"required,min=3,max=16,username"`
+}
+
+// SettingsRequest carries user settings keyed by name, e.g.
+// {"theme": "dark", "notifications.email": false}. In a patch, null resets a
+// setting to its default.
+type SettingsRequest map[string]any
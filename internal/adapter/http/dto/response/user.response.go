@@ -18,27 +18,63 @@ type LoginResponse struct {
 	User         UserResponse `json:"user,omitempty"`
 	Token        string       `json:"token,omitempty"`
 	RefreshToken string       `json:"refresh_token,omitempty"`
+	Restored     bool         `json:"restored,omitempty"` // the login cancelled a pending account deletion
 }
 
 // ProfileResponse represents a user profile in API responses.
 type ProfileResponse struct {
-	Id          int64                `json:"id,omitempty"`
-	Email       string               `json:"email,omitempty"`
-	Active      bool                 `json:"active,omitempty"`
-	DisplayName string               `json:"display_name,omitempty"`
-	Description string               `json:"description,omitempty"`
-	Avatar      string               `json:"avatar,omitempty"`
-	Username    string               `json:"username,omitempty"`
-	Tier        *ProfileTierResponse `json:"tier,omitempty"`
-	CreatedAt   int64                `json:"created_at,omitempty"`
-	UpdatedAt   int64                `json:"updated_at,omitempty"`
+	Id              int64                `json:"id,omitempty"`
+	Email           string               `json:"email,omitempty"`
+	Active          bool                 `json:"active,omitempty"`
+	DisplayName     string               `json:"display_name,omitempty"`
+	FirstName       string               `json:"first_name,omitempty"`
+	LastName        string               `json:"last_name,omitempty"`
+	Phone           string               `json:"phone,omitempty"`
+	Description     string               `json:"description,omitempty"`
+	Avatar          string               `json:"avatar,omitempty"`
+	AvatarThumbnail string               `json:"avatar_thumbnail,omitempty"`
+	Username        string               `json:"username,omitempty"`
+	Tier            *ProfileTierResponse `json:"tier,omitempty"`
+	CreatedAt       int64                `json:"created_at,omitempty"`
+	UpdatedAt       int64                `json:"updated_at,omitempty"`
+}
+
+// PublicProfileResponse represents the profile of a user as other users see it.
+type PublicProfileResponse struct {
+	Id              int64  `json:"id"`
+	Username        string `json:"username"`
+	DisplayName     string `json:"display_name,omitempty"`
+	FirstName       string `json:"first_name,omitempty"`
+	LastName        string `json:"last_name,omitempty"`
+	Avatar          string `json:"avatar,omitempty"`
+	AvatarThumbnail string `json:"avatar_thumbnail,omitempty"`
+}
+
+// ChangeUsernameResponse represents the response to a username change: the
+// tokens replace the revoked ones. They are omitted if they could not be
+// issued, the user then signs in again.
+type ChangeUsernameResponse struct {
+	Profile      ProfileResponse `json:"profile"`
+	Token        string          `json:"token,omitempty"`
+	RefreshToken string          `json:"refresh_token,omitempty"`
+}
+
+// UsernameChangeResponse represents a username the user changed away from.
+type UsernameChangeResponse struct {
+	Username      string `json:"username"`
+	ChangedAt     int64  `json:"changed_at"`
+	ReservedUntil int64  `json:"reserved_until"` // until then the username redirects to the user
 }
 
 // ProfileTierResponse represents user tier information.
 type ProfileTierResponse struct {
-	Id        int64  `json:"id,omitempty"`
-	Code      string `json:"code,omitempty"`
-	CreatedAt int64  `json:"created_at,omitempty"`
+	Id        int64    `json:"id,omitempty"`
+	Code      string   `json:"code,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	Features  []string `json:"features,omitempty"`
+	ValidFrom int64    `json:"valid_from,omitempty"`
+	ValidTo   int64    `json:"valid_to,omitempty"` // absent if the tier never ends
+	CreatedAt int64    `json:"created_at,omitempty"`
 }
 
 // RegisterResponse represents the response from a registration request.
@@ -47,3 +83,125 @@ type RegisterResponse struct {
 	Token        string          `json:"token"`
 	RefreshToken string          `json:"refresh_token"`
 }
+
+// AdminUserResponse represents a user in admin API responses.
+type AdminUserResponse struct {
+	Id              int64  `json:"id"`
+	Username        string `json:"username"`
+	Email           string `json:"email"`
+	Phone           string `json:"phone"`
+	FirstName       string `json:"first_name"`
+	LastName        string `json:"last_name"`
+	Avatar          string `json:"avatar,omitempty"`
+	Role            string `json:"role"`
+	Active          bool   `json:"active"`
+	Status          string `json:"status"`
+	StatusReason    string `json:"status_reason,omitempty"`
+	StatusChangedAt int64  `json:"status_changed_at,omitempty"`
+	CreatedAt       int64  `json:"created_at"`
+	UpdatedAt       int64  `json:"updated_at"`
+	DeletedAt       int64  `json:"deleted_at,omitempty"`
+}
+
+// AdminUserSearchResponse represents a user search result in admin API responses.
+type AdminUserSearchResponse struct {
+	AdminUserResponse
+	Rank      float64 `json:"rank"`
+	Highlight string  `json:"highlight"`
+}
+
+// DataExportResponse represents an accepted personal data export request.
+type DataExportResponse struct {
+	Status string `json:"status"`
+}
+
+// AccountDeletionResponse represents a scheduled account deletion.
+type AccountDeletionResponse struct {
+	Status  string `json:"status"`
+	PurgeAt int64  `json:"purge_at"` // Unix time of the permanent deletion
+}
+
+// TierResponse represents a tier in admin API responses.
+type TierResponse struct {
+	Id        int64    `json:"id"`
+	Code      string   `json:"code"`
+	Name      string   `json:"name"`
+	RateLimit int      `json:"rate_limit"`
+	Features  []string `json:"features"`
+	CreatedAt int64    `json:"created_at"`
+	UpdatedAt int64    `json:"updated_at"`
+}
+
+// UserTierResponse represents a tier assignment in admin API responses.
+type UserTierResponse struct {
+	Id         int64  `json:"id"`
+	UserId     int64  `json:"user_id"`
+	Tier       string `json:"tier"`
+	ValidFrom  int64  `json:"valid_from"`
+	ValidTo    int64  `json:"valid_to,omitempty"`
+	AssignedBy int64  `json:"assigned_by,omitempty"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
+// SettingsResponse represents every user setting, defaults included, keyed
+// by name.
+type SettingsResponse map[string]any
+
+// OrganizationResponse represents an organization.
+type OrganizationResponse struct {
+	Id        int64  `json:"id"`
+	Slug      string `json:"slug"`
+	Name      string `json:"name"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// MembershipResponse represents an organization the user belongs to. Active
+// marks the organization of the caller's token.
+type MembershipResponse struct {
+	Organization OrganizationResponse `json:"organization"`
+	Role         string               `json:"role"`
+	Active       bool                 `json:"active"`
+	JoinedAt     int64                `json:"joined_at"`
+}
+
+// OrgMemberResponse represents a member of an organization.
+type OrgMemberResponse struct {
+	UserId   int64  `json:"user_id"`
+	Username string `json:"username,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Role     string `json:"role"`
+	JoinedAt int64  `json:"joined_at"`
+}
+
+// InvitationResponse represents a pending organization invitation.
+type InvitationResponse struct {
+	Id        int64  `json:"id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	InvitedBy int64  `json:"invited_by,omitempty"`
+	ExpiresAt int64  `json:"expires_at"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// UserImportResponse represents a bulk user import and its progress.
+type UserImportResponse struct {
+	Id            int64  `json:"id"`
+	Format        string `json:"format"`
+	DryRun        bool   `json:"dry_run"`
+	Status        string `json:"status"`
+	RowsProcessed int64  `json:"rows_processed"`
+	RowsImported  int64  `json:"rows_imported"`
+	RowsFailed    int64  `json:"rows_failed"`
+	Error         string `json:"error,omitempty"`
+	CreatedBy     int64  `json:"created_by,omitempty"`
+	CreatedAt     int64  `json:"created_at"`
+	UpdatedAt     int64  `json:"updated_at"`
+	FinishedAt    int64  `json:"finished_at,omitempty"`
+}
+
+// UserImportErrorResponse represents a rejected row of a user import.
+type UserImportErrorResponse struct {
+	Row     int64  `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"base-service/internal/adapter/http/dto/response"
+	"base-service/internal/common"
+	"base-service/internal/middleware"
+	"base-service/internal/usecase/port"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// accountStatusDeletionScheduled is reported once an account awaits its purge.
+const accountStatusDeletionScheduled = "deletion_scheduled"
+
+// AccountHandler handles self-service account HTTP requests.
+type AccountHandler struct {
+	accountUseCase port.AccountUseCase
+	auth           *middleware.AuthMiddleware
+}
+
+// NewAccountHandler creates a new account handler.
+func NewAccountHandler(accountUseCase port.AccountUseCase, auth *middleware.AuthMiddleware) *AccountHandler {
+	return &AccountHandler{
+		accountUseCase: accountUseCase,
+		auth:           auth,
+	}
+}
+
+// @Summary Delete own account
+// @Description Delete the authenticated user's account. The account is deactivated and logged out everywhere at once, and permanently deleted with all personal data at purge_at. Logging in before then restores it. Requires a recent authentication (step-up token from /v1/auth/reauth).
+// @Tags User
+// @Produce json
+// @Security Bearer
+// @Success 202 {object} common.Response{data=response.AccountDeletionResponse} "Deletion scheduled"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/user/account [delete]
+func (h *AccountHandler) DeleteAccount(c *fiber.Ctx) error {
+	userID, err := h.auth.GetUserIdFromContext(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	purgeAt, err := h.accountUseCase.DeleteAccount(c.Context(), userID)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	resp := response.AccountDeletionResponse{
+		Status:  accountStatusDeletionScheduled,
+		PurgeAt: purgeAt.Unix(),
+	}
+	return c.Status(fiber.StatusAccepted).JSON(common.ApiResponse(resp, nil, nil))
+}
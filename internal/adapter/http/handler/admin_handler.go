@@ -0,0 +1,372 @@
+package handler
+
+import (
+	"time"
+
+	"base-service/internal/adapter/http/dto/request"
+	"base-service/internal/adapter/http/mapper"
+	"base-service/internal/common"
+	"base-service/internal/domain/entity"
+	"base-service/internal/middleware"
+	"base-service/internal/usecase/port"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminHandler handles user management HTTP requests of administrators.
+// clean-arch: Adapter layer - routes are protected by RequireRole(admin)
+type AdminHandler struct {
+	adminUseCase port.AdminUserUseCase
+	auth         *middleware.AuthMiddleware
+}
+
+// NewAdminHandler creates a new admin handler.
+func NewAdminHandler(adminUseCase port.AdminUserUseCase, auth *middleware.AuthMiddleware) *AdminHandler {
+	return &AdminHandler{
+		adminUseCase: adminUseCase,
+		auth:         auth,
+	}
+}
+
+// @Summary List users
+// @Description List users with filters, sorting and pagination. Pass cursor.next_cursor back as cursor for stable pages that do not shift under concurrent writes.
+// @Tags Admin
+// @Produce json
+// @Security Bearer
+// @Param deleted query string false "Soft-deleted users" Enums(exclude, only, include) default(exclude)
+// @Param status query string false "Lifecycle status" Enums(pending, active, suspended, banned)
+// @Param created_from query string false "Created at or after (RFC 3339)"
+// @Param created_to query string false "Created before (RFC 3339)"
+// @Param email_domain query string false "Email domain, e.g. example.com"
+// @Param role query string false "Role" Enums(user, admin)
+// @Param sort query string false "Sort column" Enums(id, created_at, updated_at, username, email) default(id)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Param page query int false "Page number, ignored with a cursor" default(1)
+// @Param page_size query int false "Page size (max 100)" default(20)
+// @Param cursor query string false "Opaque cursor.next_cursor of the previous page"
+// @Success 200 {object} common.Response{data=[]response.AdminUserResponse,pagination=common.Pagination,cursor=common.CursorPagination} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/users [get]
+func (h *AdminHandler) ListUsers(c *fiber.Ctx) error {
+	req, err := BindQueryAndValidate[request.ListUsersRequest](c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	input := &port.ListUsersInput{
+		Deleted:     req.Deleted,
+		Status:      req.Status,
+		CreatedFrom: parseTime(req.CreatedFrom),
+		CreatedTo:   parseTime(req.CreatedTo),
+		EmailDomain: req.EmailDomain,
+		Role:        req.Role,
+		SortBy:      req.Sort,
+		SortDesc:    req.Order == "desc",
+		Page:        req.Page,
+		PageSize:    req.PageSize,
+		Cursor:      req.Cursor,
+	}
+
+	output, err := h.adminUseCase.ListUsers(c.Context(), input)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	pagination := &common.Pagination{
+		PageSize: output.PageSize,
+		Total:    int(output.Total),
+	}
+	if req.Cursor == "" {
+		pagination.Page = output.Page // pages are meaningless once seeking
+	}
+	cursor := &common.CursorPagination{
+		NextCursor: output.NextCursor,
+		HasMore:    output.NextCursor != "",
+		Limit:      output.PageSize,
+	}
+	return common.ResponseApiCursor(c, mapper.UsersToAdminUserResponses(output.Users), pagination, cursor, nil)
+}
+
+// @Summary Search users
+// @Description Search users by partial name, username, email or phone number, best matches first. Matched terms are wrapped in <mark></mark> in highlight.
+// @Tags Admin
+// @Produce json
+// @Security Bearer
+// @Param q query string true "Search text (2-100 characters)"
+// @Param include_deleted query bool false "Include soft-deleted users" default(false)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size (max 100)" default(20)
+// @Success 200 {object} common.Response{data=[]response.AdminUserSearchResponse,pagination=common.Pagination} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/users/search [get]
+func (h *AdminHandler) SearchUsers(c *fiber.Ctx) error {
+	req, err := BindQueryAndValidate[request.SearchUsersRequest](c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	output, err := h.adminUseCase.SearchUsers(c.Context(), &port.SearchUsersInput{
+		Query:          req.Query,
+		IncludeDeleted: req.IncludeDeleted,
+		Page:           req.Page,
+		PageSize:       req.PageSize,
+	})
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	pagination := &common.Pagination{
+		Page:     output.Page,
+		PageSize: output.PageSize,
+		Total:    int(output.Total),
+	}
+	return common.ResponseApiPagination(c, mapper.SearchResultsToAdminUserSearchResponses(output.Results), pagination, nil)
+}
+
+// @Summary Get user
+// @Description Get a user by ID, including soft-deleted users
+// @Tags Admin
+// @Produce json
+// @Security Bearer
+// @Param id path int true "User ID"
+// @Success 200 {object} common.Response{data=response.AdminUserResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/users/{id} [get]
+func (h *AdminHandler) GetUser(c *fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	user, err := h.adminUseCase.GetUser(c.Context(), id)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, mapper.UserToAdminUserResponse(user), nil)
+}
+
+// @Summary Update user
+// @Description Update a user's profile or role; empty fields are left unchanged. A role change logs the user out.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "User ID"
+// @Param request body request.AdminUpdateUserRequest true "Fields to update"
+// @Success 200 {object} common.Response{data=response.AdminUserResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/users/{id} [put]
+func (h *AdminHandler) UpdateUser(c *fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	req, err := BindAndValidate[request.AdminUpdateUserRequest](c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	user, err := h.adminUseCase.UpdateUser(c.Context(), &entity.User{
+		ID:          id,
+		Email:       req.Email,
+		PhoneNumber: req.Phone,
+		FirstName:   req.FirstName,
+		LastName:    req.LastName,
+		Role:        req.Role,
+	})
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, mapper.UserToAdminUserResponse(user), nil)
+}
+
+// @Summary Delete user
+// @Description Soft-delete a user and log them out everywhere
+// @Tags Admin
+// @Produce json
+// @Security Bearer
+// @Param id path int true "User ID"
+// @Success 200 {object} common.Response "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/users/{id} [delete]
+func (h *AdminHandler) DeleteUser(c *fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	if err := h.adminUseCase.DeleteUser(c.Context(), id); err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, nil, nil)
+}
+
+// @Summary Restore user
+// @Description Undo the soft delete of a user
+// @Tags Admin
+// @Produce json
+// @Security Bearer
+// @Param id path int true "User ID"
+// @Success 200 {object} common.Response{data=response.AdminUserResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/users/{id}/restore [post]
+func (h *AdminHandler) RestoreUser(c *fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	user, err := h.adminUseCase.RestoreUser(c.Context(), id)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, mapper.UserToAdminUserResponse(user), nil)
+}
+
+// @Summary Force logout
+// @Description Revoke every token and session of a user
+// @Tags Admin
+// @Produce json
+// @Security Bearer
+// @Param id path int true "User ID"
+// @Success 200 {object} common.Response "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/users/{id}/logout [post]
+func (h *AdminHandler) ForceLogout(c *fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	if err := h.adminUseCase.ForceLogout(c.Context(), id); err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, nil, nil)
+}
+
+// @Summary Suspend user
+// @Description Temporarily block a user: they are logged out everywhere and their tokens are refused with USER_SUSPENDED until reinstated
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "User ID"
+// @Param request body request.ChangeUserStatusRequest true "Reason"
+// @Success 200 {object} common.Response{data=response.AdminUserResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/users/{id}/suspend [post]
+func (h *AdminHandler) SuspendUser(c *fiber.Ctx) error {
+	input, err := h.statusInput(c, true)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	user, err := h.adminUseCase.SuspendUser(c.Context(), input)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, mapper.UserToAdminUserResponse(user), nil)
+}
+
+// @Summary Ban user
+// @Description Permanently block a user: they are logged out everywhere and their tokens are refused with USER_BANNED until reinstated
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "User ID"
+// @Param request body request.ChangeUserStatusRequest true "Reason"
+// @Success 200 {object} common.Response{data=response.AdminUserResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/users/{id}/ban [post]
+func (h *AdminHandler) BanUser(c *fiber.Ctx) error {
+	input, err := h.statusInput(c, true)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	user, err := h.adminUseCase.BanUser(c.Context(), input)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, mapper.UserToAdminUserResponse(user), nil)
+}
+
+// @Summary Reinstate user
+// @Description Make a pending, suspended or banned user active again
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "User ID"
+// @Param request body request.ReinstateUserRequest false "Optional reason"
+// @Success 200 {object} common.Response{data=response.AdminUserResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/users/{id}/reinstate [post]
+func (h *AdminHandler) ReinstateUser(c *fiber.Ctx) error {
+	input, err := h.statusInput(c, false)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	user, err := h.adminUseCase.ReinstateUser(c.Context(), input)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, mapper.UserToAdminUserResponse(user), nil)
+}
+
+// statusInput reads a status change of the user in the path by the signed-in
+// administrator. The reason is required unless reinstating.
+func (h *AdminHandler) statusInput(c *fiber.Ctx, reasonRequired bool) (*port.UserStatusInput, error) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	actorID, err := h.auth.GetUserIdFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var reason string
+	if reasonRequired {
+		req, err := BindAndValidate[request.ChangeUserStatusRequest](c)
+		if err != nil {
+			return nil, err
+		}
+		reason = req.Reason
+	} else if len(c.Body()) > 0 {
+		req, err := BindAndValidate[request.ReinstateUserRequest](c)
+		if err != nil {
+			return nil, err
+		}
+		reason = req.Reason
+	}
+
+	return &port.UserStatusInput{
+		UserID:  id,
+		ActorID: actorID,
+		Reason:  reason,
+	}, nil
+}
+
+// parseTime parses an RFC 3339 value already checked by the datetime rule.
+func parseTime(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
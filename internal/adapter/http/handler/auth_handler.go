@@ -34,15 +34,14 @@ func NewAuthHandler(authUseCase port.AuthUseCase, auth *middleware.AuthMiddlewar
 // @Produce     json
 // @Param       request body request.RegisterRequest true "Registration credentials"
 // @Success 200 {object} common.Response{data=response.RegisterResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
 // @Router      /v1/auth/register [post]
 func (h *AuthHandler) RegisterUser(c *fiber.Ctx) error {
-	var req request.RegisterRequest
-	if err := c.BodyParser(&req); err != nil {
+	req, err := BindAndValidate[request.RegisterRequest](c)
+	if err != nil {
 		return common.ResponseApi(c, nil, err)
 	}
 
-	// TODO: Add validation using validator package
-
 	input := &port.RegisterInput{
 		Username:    req.UserName,
 		Email:       req.Email,
@@ -67,16 +66,17 @@ func (h *AuthHandler) RegisterUser(c *fiber.Ctx) error {
 }
 
 // @Summary Login user with username and password
-// @Description Login user with username and password in the system
+// @Description Login user with username and password in the system. Logging in to an account deleted by its owner within the grace period restores it.
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param request body request.LoginRequest true "Login request"
 // @Success 200 {object} common.Response{data=response.LoginResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
 // @Router /v1/auth/login [post]
 func (h *AuthHandler) LoginUser(c *fiber.Ctx) error {
-	var req request.LoginRequest
-	if err := c.BodyParser(&req); err != nil {
+	req, err := BindAndValidate[request.LoginRequest](c)
+	if err != nil {
 		return common.ResponseApi(c, nil, err)
 	}
 
@@ -94,6 +94,7 @@ func (h *AuthHandler) LoginUser(c *fiber.Ctx) error {
 		User:         *mapper.UserToUserResponse(output.User),
 		Token:        output.AccessToken,
 		RefreshToken: output.RefreshToken,
+		Restored:     output.Restored,
 	}
 
 	return common.ResponseApi(c, resp, nil)
@@ -106,6 +107,7 @@ func (h *AuthHandler) LoginUser(c *fiber.Ctx) error {
 // @Produce json
 // @Param RefreshToken header string true "Refresh token"
 // @Success 200 {object} common.Response{data=port.TokenPair} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
 // @Router /v1/auth/refresh [post]
 func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 	refreshToken := c.Get(middleware.RefreshTokenHeader)
@@ -123,6 +125,42 @@ func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 	return common.ResponseApi(c, tokenPair, nil)
 }
 
+// @Summary Re-authenticate user
+// @Description Verify the signed-in user's password or TOTP code again and return a short-lived elevated token for sensitive operations
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body request.ReauthRequest true "Password or TOTP code"
+// @Success 200 {object} common.Response{data=port.StepUpOutput} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/auth/reauth [post]
+func (h *AuthHandler) Reauthenticate(c *fiber.Ctx) error {
+	claims, ok := middleware.GetUserFromContext(c)
+	if !ok {
+		return common.ResponseApi(c, nil, middleware.ErrMissingToken)
+	}
+
+	req, err := BindAndValidate[request.ReauthRequest](c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	input := &port.ReauthInput{
+		UserID:    claims.UserId,
+		SessionID: claims.SessionID,
+		Password:  req.Password,
+		TOTPCode:  req.TOTPCode,
+	}
+
+	output, err := h.authUseCase.Reauthenticate(c.Context(), input)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, output, nil)
+}
+
 // Logout delegates to the middleware's logout handler.
 // This is kept in middleware as it requires direct access to token caching.
 func (h *AuthHandler) Logout(c *fiber.Ctx) error {
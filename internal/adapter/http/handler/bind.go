@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"fmt"
+
+	"base-service/internal/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BindAndValidate decodes the request body into a T and validates it against
+// its `validate` tags. Decoding failures wrap validator.ErrMalformedBody (400);
+// rule failures are validator.ValidationErrors (422) listing every field.
+func BindAndValidate[T any](c *fiber.Ctx) (*T, error) {
+	req := new(T)
+	if err := c.BodyParser(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", validator.ErrMalformedBody, err)
+	}
+	if err := validator.Struct(req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// BindQueryAndValidate is BindAndValidate for query parameters (`query` tags).
+func BindQueryAndValidate[T any](c *fiber.Ctx) (*T, error) {
+	req := new(T)
+	if err := c.QueryParser(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", validator.ErrMalformedBody, err)
+	}
+	if err := validator.Struct(req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// paramID returns the positive integer path parameter name.
+func paramID(c *fiber.Ctx, name string) (int64, error) {
+	id, err := c.ParamsInt(name)
+	if err != nil || id <= 0 {
+		return 0, validator.ValidationError{
+			Field:   name,
+			Message: fmt.Sprintf("%s must be a positive integer", name),
+			Rule:    "min",
+		}
+	}
+	return int64(id), nil
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"base-service/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ErrorCatalogHandler exposes the error catalog so frontends can sync codes
+// and translations.
+type ErrorCatalogHandler struct{}
+
+// NewErrorCatalogHandler creates a new error catalog handler.
+func NewErrorCatalogHandler() *ErrorCatalogHandler {
+	return &ErrorCatalogHandler{}
+}
+
+// @Summary Error catalog
+// @Description List every error code with its HTTP status and localized messages
+// @Tags Errors
+// @Produce json
+// @Success 200 {array} common.ErrorDefinition
+// @Router /errors [get]
+func (h *ErrorCatalogHandler) ErrorCatalog(c *fiber.Ctx) error {
+	return c.JSON(common.ErrorCatalog())
+}
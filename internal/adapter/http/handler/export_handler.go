@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"base-service/internal/adapter/http/dto/response"
+	"base-service/internal/common"
+	"base-service/internal/middleware"
+	"base-service/internal/usecase/port"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// exportStatusProcessing is reported while an export is being prepared.
+const exportStatusProcessing = "processing"
+
+// ExportHandler handles personal data export HTTP requests.
+type ExportHandler struct {
+	exportUseCase port.ExportUseCase
+	auth          *middleware.AuthMiddleware
+}
+
+// NewExportHandler creates a new export handler.
+func NewExportHandler(exportUseCase port.ExportUseCase, auth *middleware.AuthMiddleware) *ExportHandler {
+	return &ExportHandler{
+		exportUseCase: exportUseCase,
+		auth:          auth,
+	}
+}
+
+// @Summary Export personal data
+// @Description Start exporting the authenticated user's data (profile, uploaded files, sessions, security settings) as a ZIP of JSON files. The export runs in the background; a time-limited download link is emailed when it is ready. Only one export per user runs at a time.
+// @Tags User
+// @Produce json
+// @Security Bearer
+// @Success 202 {object} common.Response{data=response.DataExportResponse} "Export started"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/user/export [post]
+func (h *ExportHandler) RequestExport(c *fiber.Ctx) error {
+	userID, err := h.auth.GetUserIdFromContext(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	if err := h.exportUseCase.RequestExport(c.Context(), userID); err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	resp := response.DataExportResponse{Status: exportStatusProcessing}
+	return c.Status(fiber.StatusAccepted).JSON(common.ApiResponse(resp, nil, nil))
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"base-service/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SignedObjectReader opens objects addressed by a signed URL.
+type SignedObjectReader interface {
+	OpenSigned(ctx context.Context, bucket, key string, expires int64, signature string) (io.ReadCloser, string, error)
+}
+
+// FileHandler serves files referenced by signed URLs of the local object
+// storage.
+// clean-arch: Adapter layer - converts signed URL requests to storage reads
+type FileHandler struct {
+	objects SignedObjectReader
+}
+
+// NewFileHandler creates a new file handler.
+func NewFileHandler(objects SignedObjectReader) *FileHandler {
+	return &FileHandler{
+		objects: objects,
+	}
+}
+
+// @Summary Download file
+// @Description Download a stored file through a signed URL returned by the API (e.g. avatar URLs)
+// @Tags File
+// @Produce octet-stream
+// @Param bucket path string true "Bucket name"
+// @Param key path string true "Object key"
+// @Param expires query int true "Expiry as a Unix timestamp"
+// @Param signature query string true "URL signature"
+// @Success 200 {file} file "File content"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /files/{bucket}/{key} [get]
+func (h *FileHandler) Download(c *fiber.Ctx) error {
+	// An unparsable expiry is left at 0 and fails the signature check
+	expires, _ := strconv.ParseInt(c.Query("expires"), 10, 64)
+
+	object, contentType, err := h.objects.OpenSigned(c.Context(), c.Params("bucket"), c.Params("*"), expires, c.Query("signature"))
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	// Cacheable until the URL expires
+	maxAge := max(time.Until(time.Unix(expires, 0)), 0)
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("private, max-age=%d", int(maxAge.Seconds())))
+	c.Set(fiber.HeaderContentType, contentType)
+	return c.SendStream(object)
+}
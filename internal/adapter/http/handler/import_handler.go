@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"base-service/internal/adapter/http/dto/request"
+	"base-service/internal/adapter/http/mapper"
+	"base-service/internal/common"
+	"base-service/internal/middleware"
+	"base-service/internal/usecase/port"
+	"base-service/internal/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultImportListLimit is the number of imports listed without a limit.
+const defaultImportListLimit = 20
+
+// ImportHandler handles bulk user import HTTP requests of administrators.
+// clean-arch: Adapter layer - routes are protected by RequireRole(admin)
+type ImportHandler struct {
+	importUseCase port.UserImportUseCase
+	auth          *middleware.AuthMiddleware
+}
+
+// NewImportHandler creates a new import handler.
+func NewImportHandler(importUseCase port.UserImportUseCase, auth *middleware.AuthMiddleware) *ImportHandler {
+	return &ImportHandler{
+		importUseCase: importUseCase,
+		auth:          auth,
+	}
+}
+
+// @Summary Import users
+// @Description Upload a CSV (with a header) or NDJSON file of users and start importing it in the background. Columns: username, email, phone, first_name, last_name, created_at (RFC 3339) and either password or password_hash. A password_hash is an argon2id hash or a legacy hash with a scheme marker, e.g. {bcrypt}$2a$10$..., upgraded at the user's next login. Rows are validated like registrations; rejected rows are listed in the import's error report. A dry run validates and checks every row without creating users.
+// @Tags Admin
+// @Accept multipart/form-data
+// @Produce json
+// @Security Bearer
+// @Param file formData file true "CSV or NDJSON file"
+// @Param format formData string true "File format" Enums(csv, ndjson)
+// @Param dry_run formData bool false "Validate without creating users"
+// @Success 202 {object} common.Response{data=response.UserImportResponse} "Import started"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/users/imports [post]
+func (h *ImportHandler) CreateImport(c *fiber.Ctx) error {
+	actorID, err := h.auth.GetUserIdFromContext(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	req, err := BindAndValidate[request.CreateUserImportRequest](c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return common.ResponseApi(c, nil, validator.ValidationError{
+			Field:   "file",
+			Message: "file is required",
+			Rule:    "required",
+		})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	defer file.Close()
+
+	imp, err := h.importUseCase.CreateImport(c.Context(), &port.UserImportInput{
+		Format:    req.Format,
+		File:      file,
+		DryRun:    req.DryRun,
+		CreatedBy: actorID,
+	})
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	imp, err = h.importUseCase.StartImport(c.Context(), imp.ID)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	resp := mapper.UserImportToUserImportResponse(imp)
+	return c.Status(fiber.StatusAccepted).JSON(common.ApiResponse(resp, nil, nil))
+}
+
+// @Summary List user imports
+// @Description List the most recent user imports with their progress, newest first
+// @Tags Admin
+// @Produce json
+// @Security Bearer
+// @Param limit query int false "Number of imports (default 20, max 100)"
+// @Success 200 {object} common.Response{data=[]response.UserImportResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/users/imports [get]
+func (h *ImportHandler) ListImports(c *fiber.Ctx) error {
+	req, err := BindQueryAndValidate[request.ListUserImportsRequest](c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultImportListLimit
+	}
+
+	imports, err := h.importUseCase.ListImports(c.Context(), req.Limit)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, mapper.UserImportsToUserImportResponses(imports), nil)
+}
+
+// @Summary Get user import
+// @Description Get a user import and its progress. rows_processed is the checkpoint a resumed import continues after.
+// @Tags Admin
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Import ID"
+// @Success 200 {object} common.Response{data=response.UserImportResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/users/imports/{id} [get]
+func (h *ImportHandler) GetImport(c *fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	imp, err := h.importUseCase.GetImport(c.Context(), id)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, mapper.UserImportToUserImportResponse(imp), nil)
+}
+
+// @Summary List user import errors
+// @Description List the rejected rows of a user import by row number, with the offending field and the reason
+// @Tags Admin
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Import ID"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 50, max 500)"
+// @Success 200 {object} common.Response{data=[]response.UserImportErrorResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/users/imports/{id}/errors [get]
+func (h *ImportHandler) ListImportErrors(c *fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	req, err := BindQueryAndValidate[request.ListUserImportErrorsRequest](c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	output, err := h.importUseCase.ListImportErrors(c.Context(), id, req.Page, req.PageSize)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	pagination := &common.Pagination{
+		Page:     output.Page,
+		PageSize: output.PageSize,
+		Total:    int(output.Total),
+	}
+	return common.ResponseApiPagination(c, mapper.UserImportErrorsToResponses(output.Errors), pagination, nil)
+}
+
+// @Summary Resume user import
+// @Description Resume a failed import, or a running one that made no progress for a while (its instance stopped), after its last checkpoint. Rows before the checkpoint are not imported again.
+// @Tags Admin
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Import ID"
+// @Success 202 {object} common.Response{data=response.UserImportResponse} "Import resumed"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/users/imports/{id}/resume [post]
+func (h *ImportHandler) ResumeImport(c *fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	imp, err := h.importUseCase.StartImport(c.Context(), id)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	resp := mapper.UserImportToUserImportResponse(imp)
+	return c.Status(fiber.StatusAccepted).JSON(common.ApiResponse(resp, nil, nil))
+}
@@ -0,0 +1,391 @@
+package handler
+
+import (
+	"base-service/internal/adapter/http/dto/request"
+	"base-service/internal/adapter/http/mapper"
+	"base-service/internal/common"
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/middleware"
+	"base-service/internal/usecase/port"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// OrganizationHandler handles organization, membership and invitation HTTP
+// requests. Routes under /organizations/current act on the caller's active
+// organization.
+type OrganizationHandler struct {
+	orgUseCase port.OrganizationUseCase
+	auth       *middleware.AuthMiddleware
+}
+
+// NewOrganizationHandler creates a new organization handler.
+func NewOrganizationHandler(orgUseCase port.OrganizationUseCase, auth *middleware.AuthMiddleware) *OrganizationHandler {
+	return &OrganizationHandler{
+		orgUseCase: orgUseCase,
+		auth:       auth,
+	}
+}
+
+// @Summary Create organization
+// @Description Create an organization. The caller becomes its first owner; switch to it to manage it.
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body request.CreateOrganizationRequest true "Slug and name"
+// @Success 200 {object} common.Response{data=response.OrganizationResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/organizations [post]
+func (h *OrganizationHandler) CreateOrganization(c *fiber.Ctx) error {
+	userID, err := h.auth.GetUserIdFromContext(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	req, err := BindAndValidate[request.CreateOrganizationRequest](c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	org, err := h.orgUseCase.CreateOrganization(c.Context(), &port.CreateOrganizationInput{
+		UserID: userID,
+		Slug:   req.Slug,
+		Name:   req.Name,
+	})
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, mapper.OrganizationToResponse(org), nil)
+}
+
+// @Summary List my organizations
+// @Description List the organizations the caller belongs to and their role in each. The organization of the caller's token is marked active.
+// @Tags Organization
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} common.Response{data=[]response.MembershipResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/organizations [get]
+func (h *OrganizationHandler) ListOrganizations(c *fiber.Ctx) error {
+	userID, err := h.auth.GetUserIdFromContext(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	memberships, err := h.orgUseCase.ListOrganizations(c.Context(), userID)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	active, _ := middleware.ActiveOrganization(c)
+	return common.ResponseApi(c, mapper.MembershipsToResponses(memberships, active.ID), nil)
+}
+
+// @Summary Switch active organization
+// @Description Make an organization of the caller their active one. Returns an access token carrying it in the "ext.org" claim; refreshed tokens keep carrying it. The refresh token is unchanged.
+// @Tags Organization
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Organization ID"
+// @Success 200 {object} common.Response{data=port.TokenPair} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/organizations/{id}/switch [post]
+func (h *OrganizationHandler) SwitchOrganization(c *fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	claims, ok := middleware.GetUserFromContext(c)
+	if !ok {
+		return common.ResponseApi(c, nil, middleware.ErrMissingToken)
+	}
+
+	auth := claims.Authentication()
+	tokenPair, err := h.orgUseCase.SwitchOrganization(c.Context(), &port.SwitchOrganizationInput{
+		UserID:         claims.UserId,
+		OrganizationID: id,
+		Auth: port.Authentication{
+			Time:      auth.Time,
+			Methods:   auth.Methods,
+			SessionID: auth.SessionID,
+		},
+	})
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, tokenPair, nil)
+}
+
+// @Summary Accept invitation
+// @Description Join the organization of an emailed invitation with the invited role. The invitation must have been sent to the caller's email.
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body request.InvitationTokenRequest true "Invitation token"
+// @Success 200 {object} common.Response{data=response.OrgMemberResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/invitations/accept [post]
+func (h *OrganizationHandler) AcceptInvitation(c *fiber.Ctx) error {
+	userID, err := h.auth.GetUserIdFromContext(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	req, err := BindAndValidate[request.InvitationTokenRequest](c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	member, err := h.orgUseCase.AcceptInvitation(c.Context(), userID, req.Token)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, mapper.OrgMemberToResponse(member), nil)
+}
+
+// @Summary Decline invitation
+// @Description Decline an emailed invitation. No account is needed.
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Param request body request.InvitationTokenRequest true "Invitation token"
+// @Success 200 {object} common.Response "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/invitations/decline [post]
+func (h *OrganizationHandler) DeclineInvitation(c *fiber.Ctx) error {
+	req, err := BindAndValidate[request.InvitationTokenRequest](c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	if err := h.orgUseCase.DeclineInvitation(c.Context(), req.Token); err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, nil, nil)
+}
+
+// @Summary Get active organization
+// @Description Get the caller's active organization
+// @Tags Organization
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} common.Response{data=response.OrganizationResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/organizations/current [get]
+func (h *OrganizationHandler) GetOrganization(c *fiber.Ctx) error {
+	orgID, err := activeOrgID(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	org, err := h.orgUseCase.GetOrganization(c.Context(), orgID)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, mapper.OrganizationToResponse(org), nil)
+}
+
+// @Summary List members
+// @Description List the members of the caller's active organization, oldest first
+// @Tags Organization
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} common.Response{data=[]response.OrgMemberResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/organizations/current/members [get]
+func (h *OrganizationHandler) ListMembers(c *fiber.Ctx) error {
+	orgID, err := activeOrgID(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	members, err := h.orgUseCase.ListMembers(c.Context(), orgID)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, mapper.OrgMembersToResponses(members), nil)
+}
+
+// @Summary Change member role
+// @Description Change the role of a member of the caller's active organization (admin or owner). Admins cannot grant or change ownership, and the last owner cannot step down. The member's tokens lose access to the organization until refreshed.
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "User ID"
+// @Param request body request.ChangeOrgMemberRoleRequest true "New role"
+// @Success 200 {object} common.Response{data=response.OrgMemberResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/organizations/current/members/{id} [put]
+func (h *OrganizationHandler) ChangeMemberRole(c *fiber.Ctx) error {
+	input, err := h.memberInput(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	req, err := BindAndValidate[request.ChangeOrgMemberRoleRequest](c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	input.Role = req.Role
+
+	member, err := h.orgUseCase.ChangeMemberRole(c.Context(), input)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, mapper.OrgMemberToResponse(member), nil)
+}
+
+// @Summary Remove member
+// @Description Remove a member from the caller's active organization (admin or owner), or leave it by passing the caller's own ID. The member loses access to the organization immediately.
+// @Tags Organization
+// @Produce json
+// @Security Bearer
+// @Param id path int true "User ID"
+// @Success 200 {object} common.Response "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/organizations/current/members/{id} [delete]
+func (h *OrganizationHandler) RemoveMember(c *fiber.Ctx) error {
+	input, err := h.memberInput(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	if err := h.orgUseCase.RemoveMember(c.Context(), input); err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, nil, nil)
+}
+
+// @Summary List invitations
+// @Description List the pending invitations of the caller's active organization (admin or owner), newest first
+// @Tags Organization
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} common.Response{data=[]response.InvitationResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/organizations/current/invitations [get]
+func (h *OrganizationHandler) ListInvitations(c *fiber.Ctx) error {
+	orgID, err := activeOrgID(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	invitations, err := h.orgUseCase.ListInvitations(c.Context(), orgID)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, mapper.InvitationsToResponses(invitations), nil)
+}
+
+// @Summary Invite member
+// @Description Email an invitation to join the caller's active organization (admin or owner). Inviting an address again replaces its pending invitation.
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body request.InviteMemberRequest true "Email and role"
+// @Success 200 {object} common.Response{data=response.InvitationResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/organizations/current/invitations [post]
+func (h *OrganizationHandler) InviteMember(c *fiber.Ctx) error {
+	orgID, err := activeOrgID(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	actorID, err := h.auth.GetUserIdFromContext(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	req, err := BindAndValidate[request.InviteMemberRequest](c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	role := req.Role
+	if role == "" {
+		role = entity.OrgRoleMember
+	}
+
+	invitation, err := h.orgUseCase.InviteMember(c.Context(), &port.InviteMemberInput{
+		OrganizationID: orgID,
+		Email:          req.Email,
+		Role:           role,
+		ActorID:        actorID,
+	})
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, mapper.InvitationToResponse(invitation), nil)
+}
+
+// @Summary Revoke invitation
+// @Description Delete a pending invitation of the caller's active organization (admin or owner)
+// @Tags Organization
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Invitation ID"
+// @Success 200 {object} common.Response "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/organizations/current/invitations/{id} [delete]
+func (h *OrganizationHandler) RevokeInvitation(c *fiber.Ctx) error {
+	orgID, err := activeOrgID(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	id, err := paramID(c, "id")
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	if err := h.orgUseCase.RevokeInvitation(c.Context(), orgID, id); err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, nil, nil)
+}
+
+// memberInput reads the active organization, the member ID path parameter
+// and the caller of a member request.
+func (h *OrganizationHandler) memberInput(c *fiber.Ctx) (*port.OrgMemberInput, error) {
+	orgID, err := activeOrgID(c)
+	if err != nil {
+		return nil, err
+	}
+	id, err := paramID(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	actorID, err := h.auth.GetUserIdFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+	return &port.OrgMemberInput{
+		OrganizationID: orgID,
+		UserID:         id,
+		ActorID:        actorID,
+	}, nil
+}
+
+// activeOrgID returns the ID of the caller's active organization, from the
+// token claims checked by RequireOrgRole.
+func activeOrgID(c *fiber.Ctx) (int64, error) {
+	org, ok := middleware.ActiveOrganization(c)
+	if !ok {
+		return 0, domainerrors.ErrNoActiveOrganization
+	}
+	return org.ID, nil
+}
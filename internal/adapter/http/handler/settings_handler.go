@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"fmt"
+
+	"base-service/internal/adapter/http/dto/request"
+	"base-service/internal/adapter/http/dto/response"
+	"base-service/internal/common"
+	"base-service/internal/domain/entity"
+	"base-service/internal/middleware"
+	"base-service/internal/usecase/port"
+	"base-service/internal/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SettingsHandler handles user settings HTTP requests.
+type SettingsHandler struct {
+	settingsUseCase port.SettingsUseCase
+	auth            *middleware.AuthMiddleware
+}
+
+// NewSettingsHandler creates a new settings handler.
+func NewSettingsHandler(settingsUseCase port.SettingsUseCase, auth *middleware.AuthMiddleware) *SettingsHandler {
+	return &SettingsHandler{
+		settingsUseCase: settingsUseCase,
+		auth:            auth,
+	}
+}
+
+// @Summary Get user settings
+// @Description Get every setting of the authenticated user. Settings the user has not set carry their default.
+// @Tags User
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} common.Response{data=response.SettingsResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/user/settings [get]
+func (h *SettingsHandler) GetSettings(c *fiber.Ctx) error {
+	userID, err := h.auth.GetUserIdFromContext(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	settings, err := h.settingsUseCase.GetSettings(c.Context(), userID)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	return common.ResponseApi(c, response.SettingsResponse(settings), nil)
+}
+
+// @Summary Replace user settings
+// @Description Replace the authenticated user's settings: the given settings are set and every other setting is reset to its default. Unknown settings and invalid values fail with 422.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body request.SettingsRequest true "Settings keyed by name"
+// @Success 200 {object} common.Response{data=response.SettingsResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/user/settings [put]
+func (h *SettingsHandler) ReplaceSettings(c *fiber.Ctx) error {
+	userID, err := h.auth.GetUserIdFromContext(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	values, err := bindSettings(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	settings, err := h.settingsUseCase.ReplaceSettings(c.Context(), userID, values)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	return common.ResponseApi(c, response.SettingsResponse(settings), nil)
+}
+
+// @Summary Update user settings
+// @Description Partially update the authenticated user's settings with JSON Merge Patch (RFC 7396): absent settings are left unchanged, null resets a setting to its default. Patches from several devices changing different settings do not overwrite each other.
+// @Tags User
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Security Bearer
+// @Param request body request.SettingsRequest true "Settings to change keyed by name"
+// @Success 200 {object} common.Response{data=response.SettingsResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/user/settings [patch]
+func (h *SettingsHandler) PatchSettings(c *fiber.Ctx) error {
+	userID, err := h.auth.GetUserIdFromContext(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	patch, err := bindSettings(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	settings, err := h.settingsUseCase.PatchSettings(c.Context(), userID, patch)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	return common.ResponseApi(c, response.SettingsResponse(settings), nil)
+}
+
+// bindSettings decodes a settings object. Values are checked against the
+// settings schema by the use case, so there are no tags to validate.
+func bindSettings(c *fiber.Ctx) (entity.Settings, error) {
+	req := request.SettingsRequest{}
+	if err := c.BodyParser(&req); err != nil {
+		return nil, fmt.Errorf("%w: %w", validator.ErrMalformedBody, err)
+	}
+	return entity.Settings(req), nil
+}
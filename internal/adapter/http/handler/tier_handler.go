@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"base-service/internal/adapter/http/dto/request"
+	"base-service/internal/adapter/http/mapper"
+	"base-service/internal/common"
+	"base-service/internal/middleware"
+	"base-service/internal/usecase/port"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TierHandler handles tier management HTTP requests of administrators.
+// clean-arch: Adapter layer - routes are protected by RequireRole(admin)
+type TierHandler struct {
+	tierUseCase port.TierUseCase
+	auth        *middleware.AuthMiddleware
+}
+
+// NewTierHandler creates a new tier handler.
+func NewTierHandler(tierUseCase port.TierUseCase, auth *middleware.AuthMiddleware) *TierHandler {
+	return &TierHandler{
+		tierUseCase: tierUseCase,
+		auth:        auth,
+	}
+}
+
+// @Summary List tiers
+// @Description List the subscription tiers with their rate limits and features
+// @Tags Admin
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} common.Response{data=[]response.TierResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/tiers [get]
+func (h *TierHandler) ListTiers(c *fiber.Ctx) error {
+	tiers, err := h.tierUseCase.ListTiers(c.Context())
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, mapper.TiersToTierResponses(tiers), nil)
+}
+
+// @Summary List user tiers
+// @Description List every tier assignment of a user, newest first. The latest assignment valid now is the current tier.
+// @Tags Admin
+// @Produce json
+// @Security Bearer
+// @Param id path int true "User ID"
+// @Success 200 {object} common.Response{data=[]response.UserTierResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/users/{id}/tiers [get]
+func (h *TierHandler) ListUserTiers(c *fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	userTiers, err := h.tierUseCase.ListUserTiers(c.Context(), id)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, mapper.UserTiersToUserTierResponses(userTiers), nil)
+}
+
+// @Summary Assign tier
+// @Description Assign a tier to a user for a period. An assignment with an end temporarily overrides an open-ended one. Access tokens carry the new tier from their next refresh.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "User ID"
+// @Param request body request.AssignTierRequest true "Tier and validity period"
+// @Success 200 {object} common.Response{data=response.UserTierResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/users/{id}/tiers [post]
+func (h *TierHandler) AssignTier(c *fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	actorID, err := h.auth.GetUserIdFromContext(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	req, err := BindAndValidate[request.AssignTierRequest](c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	userTier, err := h.tierUseCase.AssignTier(c.Context(), &port.AssignTierInput{
+		UserID:    id,
+		TierCode:  req.Tier,
+		ValidFrom: parseTime(req.ValidFrom),
+		ValidTo:   parseTime(req.ValidTo),
+		ActorID:   actorID,
+	})
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, mapper.UserTierToUserTierResponse(userTier), nil)
+}
+
+// @Summary End current tier
+// @Description End the user's current tier assignments now. Scheduled assignments are kept.
+// @Tags Admin
+// @Produce json
+// @Security Bearer
+// @Param id path int true "User ID"
+// @Success 200 {object} common.Response "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/admin/users/{id}/tiers/current [delete]
+func (h *TierHandler) EndTier(c *fiber.Ctx) error {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	if err := h.tierUseCase.EndTier(c.Context(), id); err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	return common.ResponseApi(c, nil, nil)
+}
@@ -1,25 +1,36 @@
 package handler
 
 import (
+	"net/url"
+	"strings"
+
+	"base-service/internal/adapter/http/dto/request"
+	"base-service/internal/adapter/http/dto/response"
 	"base-service/internal/adapter/http/mapper"
 	"base-service/internal/common"
+	"base-service/internal/domain/entity"
 	"base-service/internal/middleware"
 	"base-service/internal/usecase/port"
+	"base-service/internal/validator"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 // UserHandler handles user-related HTTP requests.
 type UserHandler struct {
-	userUseCase port.UserUseCase
-	auth        *middleware.AuthMiddleware
+	userUseCase     port.UserUseCase
+	tierUseCase     port.TierUseCase
+	usernameUseCase port.UsernameUseCase
+	auth            *middleware.AuthMiddleware
 }
 
 // NewUserHandler creates a new user handler.
-func NewUserHandler(userUseCase port.UserUseCase, auth *middleware.AuthMiddleware) *UserHandler {
+func NewUserHandler(userUseCase port.UserUseCase, tierUseCase port.TierUseCase, usernameUseCase port.UsernameUseCase, auth *middleware.AuthMiddleware) *UserHandler {
 	return &UserHandler{
-		userUseCase: userUseCase,
-		auth:        auth,
+		userUseCase:     userUseCase,
+		tierUseCase:     tierUseCase,
+		usernameUseCase: usernameUseCase,
+		auth:            auth,
 	}
 }
 
@@ -30,18 +41,262 @@ func NewUserHandler(userUseCase port.UserUseCase, auth *middleware.AuthMiddlewar
 // @Produce json
 // @Security Bearer
 // @Success 200 {object} common.Response{data=response.ProfileResponse} "Successful response"
+// @Header 200 {string} ETag "Profile version, send it back in If-Match to update"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
 // @Router /v1/user/profile [get]
 func (h *UserHandler) Profile(c *fiber.Ctx) error {
-	username, err := h.auth.GetUserNameFromContext(c)
+	// By ID: the username of the token may predate a username change
+	userID, err := h.auth.GetUserIdFromContext(c)
 	if err != nil {
 		return common.ResponseApi(c, nil, err)
 	}
 
-	user, err := h.userUseCase.GetProfile(c.Context(), username)
+	user, err := h.userUseCase.GetByID(c.Context(), userID)
 	if err != nil {
 		return common.ResponseApi(c, nil, err)
 	}
 
-	resp := mapper.UserToProfileResponse(user)
+	resp, err := h.profileResponse(c, user)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	common.SetVersionETag(c, user.Version)
+	return common.ResponseApi(c, resp, nil)
+}
+
+// @Summary Update user profile
+// @Description Partially update the authenticated user's profile with JSON Merge Patch (RFC 7396): absent members are left unchanged, null removes a value. If-Match must carry the ETag of the profile being edited; a concurrent change fails with 412.
+// @Tags User
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Security Bearer
+// @Param If-Match header string true "ETag from GET /v1/user/profile, or * to overwrite any version"
+// @Param request body request.PatchProfileRequest true "Profile members to change"
+// @Success 200 {object} common.Response{data=response.ProfileResponse} "Successful response"
+// @Header 200 {string} ETag "New profile version"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/user/profile [patch]
+func (h *UserHandler) PatchProfile(c *fiber.Ctx) error {
+	userID, err := h.auth.GetUserIdFromContext(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	ifMatch, err := common.IfMatchVersions(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	req, err := BindAndValidate[request.PatchProfileRequest](c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	user, err := h.userUseCase.PatchProfile(c.Context(), &port.PatchProfileInput{
+		UserID:      userID,
+		FirstName:   req.FirstName,
+		LastName:    req.LastName,
+		PhoneNumber: req.Phone,
+		Avatar:      req.Avatar,
+		IfMatch:     ifMatch,
+	})
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	resp, err := h.profileResponse(c, user)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	common.SetVersionETag(c, user.Version)
+	return common.ResponseApi(c, resp, nil)
+}
+
+// @Summary Upload avatar
+// @Description Replace the authenticated user's avatar with a JPEG, PNG or GIF image. The image is cropped to a square and resized; a thumbnail is generated. The previous avatar is deleted.
+// @Tags User
+// @Accept multipart/form-data
+// @Produce json
+// @Security Bearer
+// @Param avatar formData file true "Image file"
+// @Success 200 {object} common.Response{data=response.ProfileResponse} "Successful response"
+// @Header 200 {string} ETag "New profile version"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/user/avatar [post]
+func (h *UserHandler) UploadAvatar(c *fiber.Ctx) error {
+	userID, err := h.auth.GetUserIdFromContext(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	fileHeader, err := c.FormFile("avatar")
+	if err != nil {
+		return common.ResponseApi(c, nil, validator.ValidationError{
+			Field:   "avatar",
+			Message: "avatar file is required",
+			Rule:    "required",
+		})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	defer file.Close()
+
+	user, err := h.userUseCase.UploadAvatar(c.Context(), userID, file)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	resp, err := h.profileResponse(c, user)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	common.SetVersionETag(c, user.Version)
+	return common.ResponseApi(c, resp, nil)
+}
+
+// @Summary Delete avatar
+// @Description Remove the authenticated user's avatar
+// @Tags User
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} common.Response{data=response.ProfileResponse} "Successful response"
+// @Header 200 {string} ETag "New profile version"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/user/avatar [delete]
+func (h *UserHandler) DeleteAvatar(c *fiber.Ctx) error {
+	userID, err := h.auth.GetUserIdFromContext(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	user, err := h.userUseCase.RemoveAvatar(c.Context(), userID)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	resp, err := h.profileResponse(c, user)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	common.SetVersionETag(c, user.Version)
 	return common.ResponseApi(c, resp, nil)
 }
+
+// @Summary Change username
+// @Description Change the authenticated user's username. Usernames can be changed once per cooldown period; the old one stays reserved for the user and redirects to the new one for a while. Every token and session of the user is revoked; the response carries the tokens of a new session with the new username.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body request.ChangeUsernameRequest true "New username"
+// @Success 200 {object} common.Response{data=response.ChangeUsernameResponse} "Successful response"
+// @Header 200 {string} ETag "New profile version"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/user/username [put]
+func (h *UserHandler) ChangeUsername(c *fiber.Ctx) error {
+	claims, ok := middleware.GetUserFromContext(c)
+	if !ok {
+		return common.ResponseApi(c, nil, middleware.ErrMissingToken)
+	}
+
+	req, err := BindAndValidate[request.ChangeUsernameRequest](c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	auth := claims.Authentication()
+	output, err := h.usernameUseCase.ChangeUsername(c.Context(), &port.ChangeUsernameInput{
+		UserID:   claims.UserId,
+		Username: req.UserName,
+		Auth: port.Authentication{
+			Time:    auth.Time,
+			Methods: auth.Methods,
+		},
+	})
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	profile, err := h.profileResponse(c, output.User)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	common.SetVersionETag(c, output.User.Version)
+	return common.ResponseApi(c, response.ChangeUsernameResponse{
+		Profile:      *profile,
+		Token:        output.AccessToken,
+		RefreshToken: output.RefreshToken,
+	}, nil)
+}
+
+// @Summary List previous usernames
+// @Description List the usernames the authenticated user changed away from, newest first
+// @Tags User
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} common.Response{data=[]response.UsernameChangeResponse} "Successful response"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/user/username/history [get]
+func (h *UserHandler) UsernameHistory(c *fiber.Ctx) error {
+	userID, err := h.auth.GetUserIdFromContext(c)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+
+	changes, err := h.usernameUseCase.ListUsernameHistory(c.Context(), userID)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	return common.ResponseApi(c, mapper.UsernameChangesToResponses(changes), nil)
+}
+
+// @Summary Get a user's public profile
+// @Description Get the public profile of a user by username. A username changed away from recently redirects (307) to the user's current username.
+// @Tags User
+// @Produce json
+// @Security Bearer
+// @Param username path string true "Username"
+// @Success 200 {object} common.Response{data=response.PublicProfileResponse} "Successful response"
+// @Success 307 "The username was changed; Location holds the current profile URL"
+// @Failure default {object} common.Problem "Error details (application/problem+json)"
+// @Router /v1/user/profiles/{username} [get]
+func (h *UserHandler) PublicProfile(c *fiber.Ctx) error {
+	user, renamed, err := h.usernameUseCase.ResolveUsername(c.Context(), c.Params("username"))
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	if renamed {
+		path := c.Path()
+		return c.Redirect(path[:strings.LastIndex(path, "/")+1]+url.PathEscape(user.Username), fiber.StatusTemporaryRedirect)
+	}
+
+	avatar, err := h.userUseCase.AvatarURLs(c.Context(), user)
+	if err != nil {
+		return common.ResponseApi(c, nil, err)
+	}
+	resp := mapper.UserToPublicProfileResponse(user)
+	resp.Avatar = avatar.URL
+	resp.AvatarThumbnail = avatar.ThumbnailURL
+	return common.ResponseApi(c, resp, nil)
+}
+
+// profileResponse maps user to a profile response with download URLs for
+// its avatar and the current tier.
+func (h *UserHandler) profileResponse(c *fiber.Ctx, user *entity.User) (*response.ProfileResponse, error) {
+	avatar, err := h.userUseCase.AvatarURLs(c.Context(), user)
+	if err != nil {
+		return nil, err
+	}
+	tier, err := h.tierUseCase.CurrentTier(c.Context(), user.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp := mapper.UserToProfileResponse(user)
+	resp.Avatar = avatar.URL
+	resp.AvatarThumbnail = avatar.ThumbnailURL
+	resp.Tier = mapper.UserTierToProfileTierResponse(tier)
+	return resp, nil
+}
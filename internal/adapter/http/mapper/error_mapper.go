@@ -0,0 +1,438 @@
+package mapper
+
+import (
+	"base-service/internal/common"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// init registers every domain and validation error in the error catalog.
+// Errors without a registration are answered with a masked 500.
+// Codes are part of the API contract: never change or reuse them.
+func init() {
+	// User
+	common.RegisterError(domainerrors.ErrUserNotFound, common.ErrorDefinition{
+		Code:   "USER_NOT_FOUND",
+		Status: fiber.StatusNotFound,
+		Messages: common.Messages{
+			"en": "User not found",
+			"vi": "Không tìm thấy người dùng",
+		},
+	})
+	common.RegisterError(domainerrors.ErrUserDeleted, common.ErrorDefinition{
+		Code:   "USER_DELETED",
+		Status: fiber.StatusGone,
+		Messages: common.Messages{
+			"en": "User has been deleted",
+			"vi": "Người dùng đã bị xóa",
+		},
+	})
+	common.RegisterError(domainerrors.ErrUserNotDeleted, common.ErrorDefinition{
+		Code:   "USER_NOT_DELETED",
+		Status: fiber.StatusConflict,
+		Messages: common.Messages{
+			"en": "User is not deleted",
+			"vi": "Người dùng chưa bị xóa",
+		},
+	})
+	common.RegisterError(domainerrors.ErrInvalidRole, common.ErrorDefinition{
+		Code:   "USER_INVALID_ROLE",
+		Status: fiber.StatusUnprocessableEntity,
+		Messages: common.Messages{
+			"en": "Invalid role",
+			"vi": "Vai trò không hợp lệ",
+		},
+	})
+	common.RegisterError(domainerrors.ErrDuplicateEmail, common.ErrorDefinition{
+		Code:   "USER_DUPLICATE_EMAIL",
+		Status: fiber.StatusConflict,
+		Messages: common.Messages{
+			"en": "Email already exists",
+			"vi": "Email đã tồn tại",
+		},
+	})
+	common.RegisterError(domainerrors.ErrDuplicateUsername, common.ErrorDefinition{
+		Code:   "USER_DUPLICATE_USERNAME",
+		Status: fiber.StatusConflict,
+		Messages: common.Messages{
+			"en": "Username already exists",
+			"vi": "Tên đăng nhập đã tồn tại",
+		},
+	})
+
+	// Authentication
+	common.RegisterError(domainerrors.ErrInvalidCredentials, common.ErrorDefinition{
+		Code:   "AUTH_INVALID_CREDENTIALS",
+		Status: fiber.StatusUnauthorized,
+		Messages: common.Messages{
+			"en": "Invalid username or password",
+			"vi": "Tên đăng nhập hoặc mật khẩu không đúng",
+		},
+	})
+	common.RegisterError(domainerrors.ErrInvalidPassword, common.ErrorDefinition{
+		Code:   "AUTH_INVALID_PASSWORD",
+		Status: fiber.StatusBadRequest,
+		Messages: common.Messages{
+			"en": "Invalid password",
+			"vi": "Mật khẩu không hợp lệ",
+		},
+	})
+	common.RegisterError(domainerrors.ErrTOTPNotEnabled, common.ErrorDefinition{
+		Code:   "AUTH_TOTP_NOT_ENABLED",
+		Status: fiber.StatusBadRequest,
+		Messages: common.Messages{
+			"en": "One-time password authentication is not enabled",
+			"vi": "Xác thực bằng mã dùng một lần chưa được bật",
+		},
+	})
+
+	// Images
+	common.RegisterError(domainerrors.ErrImageTooLarge, common.ErrorDefinition{
+		Code:   "IMAGE_TOO_LARGE",
+		Status: fiber.StatusRequestEntityTooLarge,
+		Messages: common.Messages{
+			"en": "The image file is too large",
+			"vi": "Tệp ảnh quá lớn",
+		},
+	})
+	common.RegisterError(domainerrors.ErrUnsupportedImageType, common.ErrorDefinition{
+		Code:   "IMAGE_UNSUPPORTED_TYPE",
+		Status: fiber.StatusUnsupportedMediaType,
+		Messages: common.Messages{
+			"en": "Unsupported image type, use JPEG, PNG or GIF",
+			"vi": "Định dạng ảnh không được hỗ trợ, hãy dùng JPEG, PNG hoặc GIF",
+		},
+	})
+	common.RegisterError(domainerrors.ErrInvalidImage, common.ErrorDefinition{
+		Code:   "IMAGE_INVALID",
+		Status: fiber.StatusUnprocessableEntity,
+		Messages: common.Messages{
+			"en": "The image is corrupt or its dimensions are too large",
+			"vi": "Ảnh bị lỗi hoặc kích thước quá lớn",
+		},
+	})
+
+	// Data export
+	common.RegisterError(domainerrors.ErrExportInProgress, common.ErrorDefinition{
+		Code:   "EXPORT_IN_PROGRESS",
+		Status: fiber.StatusConflict,
+		Messages: common.Messages{
+			"en": "Your data export is already being prepared, you will receive an email when it is ready",
+			"vi": "Dữ liệu của bạn đang được chuẩn bị, bạn sẽ nhận được email khi hoàn tất",
+		},
+	})
+
+	// Lifecycle status
+	common.RegisterError(domainerrors.ErrUserPending, common.ErrorDefinition{
+		Code:   "USER_PENDING",
+		Status: fiber.StatusForbidden,
+		Messages: common.Messages{
+			"en": "Your account has not been activated yet",
+			"vi": "Tài khoản của bạn chưa được kích hoạt",
+		},
+	})
+	common.RegisterError(domainerrors.ErrUserSuspended, common.ErrorDefinition{
+		Code:   "USER_SUSPENDED",
+		Status: fiber.StatusForbidden,
+		Messages: common.Messages{
+			"en": "Your account has been suspended",
+			"vi": "Tài khoản của bạn đã bị tạm khóa",
+		},
+	})
+	common.RegisterError(domainerrors.ErrUserBanned, common.ErrorDefinition{
+		Code:   "USER_BANNED",
+		Status: fiber.StatusForbidden,
+		Messages: common.Messages{
+			"en": "Your account has been banned",
+			"vi": "Tài khoản của bạn đã bị cấm",
+		},
+	})
+	common.RegisterError(domainerrors.ErrInvalidStatusTransition, common.ErrorDefinition{
+		Code:   "USER_STATUS_TRANSITION_INVALID",
+		Status: fiber.StatusConflict,
+		Messages: common.Messages{
+			"en": "The user's status cannot be changed to the requested status",
+			"vi": "Không thể chuyển trạng thái người dùng sang trạng thái yêu cầu",
+		},
+	})
+	common.RegisterError(domainerrors.ErrOwnStatusChange, common.ErrorDefinition{
+		Code:   "USER_OWN_STATUS_CHANGE",
+		Status: fiber.StatusForbidden,
+		Messages: common.Messages{
+			"en": "You cannot change the status of your own account",
+			"vi": "Bạn không thể thay đổi trạng thái tài khoản của chính mình",
+		},
+	})
+	common.RegisterError(domainerrors.ErrUsernameUnchanged, common.ErrorDefinition{
+		Code:   "USER_USERNAME_UNCHANGED",
+		Status: fiber.StatusBadRequest,
+		Messages: common.Messages{
+			"en": "The new username is the same as the current one",
+			"vi": "Tên đăng nhập mới trùng với tên hiện tại",
+		},
+	})
+	common.RegisterError(domainerrors.ErrUsernameChangeTooSoon, common.ErrorDefinition{
+		Code:   "USER_USERNAME_CHANGE_TOO_SOON",
+		Status: fiber.StatusTooManyRequests,
+		Messages: common.Messages{
+			"en": "Your username was changed too recently, please try again later",
+			"vi": "Tên đăng nhập vừa được thay đổi gần đây, vui lòng thử lại sau",
+		},
+	})
+	common.RegisterError(domainerrors.ErrUsernameReserved, common.ErrorDefinition{
+		Code:   "USER_USERNAME_RESERVED",
+		Status: fiber.StatusConflict,
+		Messages: common.Messages{
+			"en": "This username was used recently and is reserved",
+			"vi": "Tên đăng nhập này vừa được sử dụng và đang được giữ lại",
+		},
+	})
+
+	// Tiers
+	common.RegisterError(domainerrors.ErrTierNotFound, common.ErrorDefinition{
+		Code:   "TIER_NOT_FOUND",
+		Status: fiber.StatusNotFound,
+		Messages: common.Messages{
+			"en": "Tier not found",
+			"vi": "Không tìm thấy gói dịch vụ",
+		},
+	})
+	common.RegisterError(domainerrors.ErrTierNotAssigned, common.ErrorDefinition{
+		Code:   "TIER_NOT_ASSIGNED",
+		Status: fiber.StatusNotFound,
+		Messages: common.Messages{
+			"en": "The user has no current tier",
+			"vi": "Người dùng chưa có gói dịch vụ hiện hành",
+		},
+	})
+	common.RegisterError(domainerrors.ErrInvalidTierPeriod, common.ErrorDefinition{
+		Code:   "TIER_INVALID_PERIOD",
+		Status: fiber.StatusUnprocessableEntity,
+		Messages: common.Messages{
+			"en": "The tier assignment must end after it starts",
+			"vi": "Thời điểm kết thúc gói dịch vụ phải sau thời điểm bắt đầu",
+		},
+	})
+
+	// Settings
+	common.RegisterError(domainerrors.ErrUnknownSetting, common.ErrorDefinition{
+		Code:   "SETTINGS_UNKNOWN_KEY",
+		Status: fiber.StatusUnprocessableEntity,
+		Messages: common.Messages{
+			"en": "Unknown setting",
+			"vi": "Cài đặt không tồn tại",
+		},
+	})
+	common.RegisterError(domainerrors.ErrInvalidSetting, common.ErrorDefinition{
+		Code:   "SETTINGS_INVALID_VALUE",
+		Status: fiber.StatusUnprocessableEntity,
+		Messages: common.Messages{
+			"en": "Invalid setting value",
+			"vi": "Giá trị cài đặt không hợp lệ",
+		},
+	})
+
+	// Organizations
+	common.RegisterError(domainerrors.ErrOrganizationNotFound, common.ErrorDefinition{
+		Code:   "ORG_NOT_FOUND",
+		Status: fiber.StatusNotFound,
+		Messages: common.Messages{
+			"en": "Organization not found",
+			"vi": "Không tìm thấy tổ chức",
+		},
+	})
+	common.RegisterError(domainerrors.ErrDuplicateOrganizationSlug, common.ErrorDefinition{
+		Code:   "ORG_DUPLICATE_SLUG",
+		Status: fiber.StatusConflict,
+		Messages: common.Messages{
+			"en": "Organization slug already exists",
+			"vi": "Định danh tổ chức đã tồn tại",
+		},
+	})
+	common.RegisterError(domainerrors.ErrOrgMemberNotFound, common.ErrorDefinition{
+		Code:   "ORG_MEMBER_NOT_FOUND",
+		Status: fiber.StatusNotFound,
+		Messages: common.Messages{
+			"en": "The user is not a member of the organization",
+			"vi": "Người dùng không phải thành viên của tổ chức",
+		},
+	})
+	common.RegisterError(domainerrors.ErrOrgMemberExists, common.ErrorDefinition{
+		Code:   "ORG_MEMBER_EXISTS",
+		Status: fiber.StatusConflict,
+		Messages: common.Messages{
+			"en": "The user is already a member of the organization",
+			"vi": "Người dùng đã là thành viên của tổ chức",
+		},
+	})
+	common.RegisterError(domainerrors.ErrNoActiveOrganization, common.ErrorDefinition{
+		Code:   "ORG_NOT_SELECTED",
+		Status: fiber.StatusForbidden,
+		Messages: common.Messages{
+			"en": "Switch to an organization first",
+			"vi": "Vui lòng chọn tổ chức trước",
+		},
+	})
+	common.RegisterError(domainerrors.ErrInsufficientOrgRole, common.ErrorDefinition{
+		Code:   "ORG_FORBIDDEN",
+		Status: fiber.StatusForbidden,
+		Messages: common.Messages{
+			"en": "Your organization role does not allow this action",
+			"vi": "Vai trò của bạn trong tổ chức không cho phép thao tác này",
+		},
+	})
+	common.RegisterError(domainerrors.ErrLastOrgOwner, common.ErrorDefinition{
+		Code:   "ORG_LAST_OWNER",
+		Status: fiber.StatusConflict,
+		Messages: common.Messages{
+			"en": "The organization must keep at least one owner",
+			"vi": "Tổ chức phải có ít nhất một chủ sở hữu",
+		},
+	})
+	common.RegisterError(domainerrors.ErrInvitationNotFound, common.ErrorDefinition{
+		Code:   "ORG_INVITATION_NOT_FOUND",
+		Status: fiber.StatusNotFound,
+		Messages: common.Messages{
+			"en": "Invitation not found or expired",
+			"vi": "Lời mời không tồn tại hoặc đã hết hạn",
+		},
+	})
+	common.RegisterError(domainerrors.ErrInvitationEmailMismatch, common.ErrorDefinition{
+		Code:   "ORG_INVITATION_EMAIL_MISMATCH",
+		Status: fiber.StatusForbidden,
+		Messages: common.Messages{
+			"en": "The invitation was sent to another email address",
+			"vi": "Lời mời được gửi tới địa chỉ email khác",
+		},
+	})
+
+	// Tenants
+	common.RegisterError(domainerrors.ErrTenantNotFound, common.ErrorDefinition{
+		Code:   "TENANT_NOT_FOUND",
+		Status: fiber.StatusNotFound,
+		Messages: common.Messages{
+			"en": "Tenant not found",
+			"vi": "Không tìm thấy khách hàng thuê",
+		},
+	})
+
+	// User imports
+	common.RegisterError(domainerrors.ErrImportNotFound, common.ErrorDefinition{
+		Code:   "IMPORT_NOT_FOUND",
+		Status: fiber.StatusNotFound,
+		Messages: common.Messages{
+			"en": "User import not found",
+			"vi": "Không tìm thấy lượt nhập người dùng",
+		},
+	})
+	common.RegisterError(domainerrors.ErrImportNotResumable, common.ErrorDefinition{
+		Code:   "IMPORT_NOT_RESUMABLE",
+		Status: fiber.StatusConflict,
+		Messages: common.Messages{
+			"en": "The import has completed or is still running",
+			"vi": "Lượt nhập đã hoàn tất hoặc vẫn đang chạy",
+		},
+	})
+	common.RegisterError(domainerrors.ErrUnsupportedImportFormat, common.ErrorDefinition{
+		Code:   "IMPORT_UNSUPPORTED_FORMAT",
+		Status: fiber.StatusBadRequest,
+		Messages: common.Messages{
+			"en": "Unsupported import format, use csv or ndjson",
+			"vi": "Định dạng tệp nhập không được hỗ trợ, hãy dùng csv hoặc ndjson",
+		},
+	})
+
+	// Persistence
+	common.RegisterError(domainerrors.ErrConflict, common.ErrorDefinition{
+		Code:   "RESOURCE_CONFLICT",
+		Status: fiber.StatusConflict,
+		Messages: common.Messages{
+			"en": "Resource already exists",
+			"vi": "Tài nguyên đã tồn tại",
+		},
+	})
+	common.RegisterError(domainerrors.ErrConcurrentModification, common.ErrorDefinition{
+		Code:   "RESOURCE_CONCURRENT_MODIFICATION",
+		Status: fiber.StatusConflict,
+		Messages: common.Messages{
+			"en": "Resource was modified concurrently, please retry",
+			"vi": "Tài nguyên đã bị thay đổi đồng thời, vui lòng thử lại",
+		},
+	})
+	common.RegisterError(domainerrors.ErrVersionMismatch, common.ErrorDefinition{
+		Code:   "RESOURCE_VERSION_MISMATCH",
+		Status: fiber.StatusPreconditionFailed,
+		Messages: common.Messages{
+			"en": "Resource was modified since you loaded it, reload and try again",
+			"vi": "Tài nguyên đã bị thay đổi kể từ khi bạn tải, vui lòng tải lại và thử lại",
+		},
+	})
+	common.RegisterError(domainerrors.ErrInvalidReference, common.ErrorDefinition{
+		Code:   "RESOURCE_INVALID_REFERENCE",
+		Status: fiber.StatusUnprocessableEntity,
+		Messages: common.Messages{
+			"en": "Referenced resource does not exist",
+			"vi": "Tài nguyên được tham chiếu không tồn tại",
+		},
+	})
+	common.RegisterError(domainerrors.ErrConstraintViolation, common.ErrorDefinition{
+		Code:   "RESOURCE_CONSTRAINT_VIOLATION",
+		Status: fiber.StatusUnprocessableEntity,
+		Messages: common.Messages{
+			"en": "Data violates an integrity constraint",
+			"vi": "Dữ liệu vi phạm ràng buộc toàn vẹn",
+		},
+	})
+	common.RegisterError(domainerrors.ErrOperationCanceled, common.ErrorDefinition{
+		Code:   "OPERATION_CANCELED",
+		Status: fiber.StatusServiceUnavailable,
+		Messages: common.Messages{
+			"en": "Operation was canceled or timed out, please retry",
+			"vi": "Thao tác đã bị hủy hoặc quá thời gian, vui lòng thử lại",
+		},
+	})
+
+	// Validation
+	common.RegisterError(validator.ErrInvalidEmail, common.ErrorDefinition{
+		Code:   "VALIDATION_INVALID_EMAIL",
+		Status: fiber.StatusUnprocessableEntity,
+		Messages: common.Messages{
+			"en": "Invalid email format",
+			"vi": "Email không đúng định dạng",
+		},
+	})
+	common.RegisterError(validator.ErrInvalidUsername, common.ErrorDefinition{
+		Code:   "VALIDATION_INVALID_USERNAME",
+		Status: fiber.StatusUnprocessableEntity,
+		Messages: common.Messages{
+			"en": "Invalid username format",
+			"vi": "Tên đăng nhập không đúng định dạng",
+		},
+	})
+	common.RegisterError(validator.ErrWeakPassword, common.ErrorDefinition{
+		Code:   "VALIDATION_WEAK_PASSWORD",
+		Status: fiber.StatusUnprocessableEntity,
+		Messages: common.Messages{
+			"en": "Password does not meet security requirements",
+			"vi": "Mật khẩu không đáp ứng yêu cầu bảo mật",
+		},
+	})
+	common.RegisterError(validator.ErrEmptyField, common.ErrorDefinition{
+		Code:   "VALIDATION_EMPTY_FIELD",
+		Status: fiber.StatusUnprocessableEntity,
+		Messages: common.Messages{
+			"en": "Field cannot be empty",
+			"vi": "Trường không được để trống",
+		},
+	})
+	common.RegisterError(validator.ErrMalformedBody, common.ErrorDefinition{
+		Code:   "VALIDATION_MALFORMED_BODY",
+		Status: fiber.StatusBadRequest,
+		Messages: common.Messages{
+			"en": "Request body is malformed",
+			"vi": "Nội dung yêu cầu không đúng định dạng",
+		},
+	})
+}
@@ -0,0 +1,118 @@
+package mapper
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"base-service/internal/common"
+	domainerrors "base-service/internal/domain/errors"
+	_ "base-service/internal/middleware" // registers the middleware errors
+)
+
+// codePattern is the shape of every catalog code.
+var codePattern = regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`)
+
+func TestErrorCatalogDefinitions(t *testing.T) {
+	defs := common.ErrorCatalog()
+	if len(defs) == 0 {
+		t.Fatal("empty error catalog")
+	}
+	for _, def := range defs {
+		if !codePattern.MatchString(def.Code) {
+			t.Errorf("%s: code is not UPPER_SNAKE_CASE", def.Code)
+		}
+		if def.Status < http.StatusBadRequest || http.StatusText(def.Status) == "" {
+			t.Errorf("%s: status %d is not an error status", def.Code, def.Status)
+		}
+		for _, lang := range []string{"en", "vi"} {
+			if strings.TrimSpace(def.Messages[lang]) == "" {
+				t.Errorf("%s: no %s message", def.Code, lang)
+			}
+		}
+	}
+}
+
+// domainErrors lists every domain error by name.
+var domainErrors = map[string]error{
+	"ErrUserNotFound":              domainerrors.ErrUserNotFound,
+	"ErrDuplicateEmail":            domainerrors.ErrDuplicateEmail,
+	"ErrDuplicateUsername":         domainerrors.ErrDuplicateUsername,
+	"ErrInvalidCredentials":        domainerrors.ErrInvalidCredentials,
+	"ErrInvalidPassword":           domainerrors.ErrInvalidPassword,
+	"ErrUserDeleted":               domainerrors.ErrUserDeleted,
+	"ErrUserNotDeleted":            domainerrors.ErrUserNotDeleted,
+	"ErrInvalidRole":               domainerrors.ErrInvalidRole,
+	"ErrTOTPNotEnabled":            domainerrors.ErrTOTPNotEnabled,
+	"ErrImageTooLarge":             domainerrors.ErrImageTooLarge,
+	"ErrUnsupportedImageType":      domainerrors.ErrUnsupportedImageType,
+	"ErrInvalidImage":              domainerrors.ErrInvalidImage,
+	"ErrExportInProgress":          domainerrors.ErrExportInProgress,
+	"ErrUserPending":               domainerrors.ErrUserPending,
+	"ErrUserSuspended":             domainerrors.ErrUserSuspended,
+	"ErrUserBanned":                domainerrors.ErrUserBanned,
+	"ErrInvalidStatusTransition":   domainerrors.ErrInvalidStatusTransition,
+	"ErrOwnStatusChange":           domainerrors.ErrOwnStatusChange,
+	"ErrUsernameUnchanged":         domainerrors.ErrUsernameUnchanged,
+	"ErrUsernameChangeTooSoon":     domainerrors.ErrUsernameChangeTooSoon,
+	"ErrUsernameReserved":          domainerrors.ErrUsernameReserved,
+	"ErrTierNotFound":              domainerrors.ErrTierNotFound,
+	"ErrTierNotAssigned":           domainerrors.ErrTierNotAssigned,
+	"ErrInvalidTierPeriod":         domainerrors.ErrInvalidTierPeriod,
+	"ErrUnknownSetting":            domainerrors.ErrUnknownSetting,
+	"ErrInvalidSetting":            domainerrors.ErrInvalidSetting,
+	"ErrOrganizationNotFound":      domainerrors.ErrOrganizationNotFound,
+	"ErrDuplicateOrganizationSlug": domainerrors.ErrDuplicateOrganizationSlug,
+	"ErrOrgMemberNotFound":         domainerrors.ErrOrgMemberNotFound,
+	"ErrOrgMemberExists":           domainerrors.ErrOrgMemberExists,
+	"ErrNoActiveOrganization":      domainerrors.ErrNoActiveOrganization,
+	"ErrInsufficientOrgRole":       domainerrors.ErrInsufficientOrgRole,
+	"ErrLastOrgOwner":              domainerrors.ErrLastOrgOwner,
+	"ErrInvitationNotFound":        domainerrors.ErrInvitationNotFound,
+	"ErrInvitationEmailMismatch":   domainerrors.ErrInvitationEmailMismatch,
+	"ErrTenantNotFound":            domainerrors.ErrTenantNotFound,
+	"ErrImportNotFound":            domainerrors.ErrImportNotFound,
+	"ErrImportNotResumable":        domainerrors.ErrImportNotResumable,
+	"ErrUnsupportedImportFormat":   domainerrors.ErrUnsupportedImportFormat,
+	"ErrConflict":                  domainerrors.ErrConflict,
+	"ErrInvalidReference":          domainerrors.ErrInvalidReference,
+	"ErrConstraintViolation":       domainerrors.ErrConstraintViolation,
+	"ErrConcurrentModification":    domainerrors.ErrConcurrentModification,
+	"ErrVersionMismatch":           domainerrors.ErrVersionMismatch,
+	"ErrOperationCanceled":         domainerrors.ErrOperationCanceled,
+}
+
+// TestDomainErrorsAreCataloged parses the domain errors package, so a domain
+// error added without a catalog entry, and answered with a masked 500, fails.
+func TestDomainErrorsAreCataloged(t *testing.T) {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), "../../../domain/errors", nil, 0)
+	if err != nil {
+		t.Fatalf("parse domain errors: %v", err)
+	}
+	var declared int
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for name, obj := range file.Scope.Objects {
+				if obj.Kind != ast.Var || !strings.HasPrefix(name, "Err") {
+					continue
+				}
+				declared++
+				err, ok := domainErrors[name]
+				if !ok {
+					t.Errorf("%s is not listed in domainErrors", name)
+					continue
+				}
+				if _, ok := common.LookupError(err); !ok {
+					t.Errorf("%s is not in the error catalog", name)
+				}
+			}
+		}
+	}
+	if declared != len(domainErrors) {
+		t.Errorf("%d domain errors declared, %d listed", declared, len(domainErrors))
+	}
+}
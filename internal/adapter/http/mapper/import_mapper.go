@@ -0,0 +1,53 @@
+package mapper
+
+import (
+	"base-service/internal/adapter/http/dto/response"
+	"base-service/internal/domain/entity"
+)
+
+// UserImportToUserImportResponse converts a user import to a response DTO.
+func UserImportToUserImportResponse(imp *entity.UserImport) *response.UserImportResponse {
+	if imp == nil {
+		return nil
+	}
+
+	resp := &response.UserImportResponse{
+		Id:            imp.ID,
+		Format:        imp.Format,
+		DryRun:        imp.DryRun,
+		Status:        imp.Status,
+		RowsProcessed: imp.RowsProcessed,
+		RowsImported:  imp.RowsImported,
+		RowsFailed:    imp.RowsFailed,
+		Error:         imp.Error,
+		CreatedBy:     imp.CreatedBy,
+		CreatedAt:     imp.CreatedAt.UnixMilli(),
+		UpdatedAt:     imp.UpdatedAt.UnixMilli(),
+	}
+	if imp.FinishedAt != nil {
+		resp.FinishedAt = imp.FinishedAt.UnixMilli()
+	}
+	return resp
+}
+
+// UserImportsToUserImportResponses converts user imports to response DTOs.
+func UserImportsToUserImportResponses(imports []*entity.UserImport) []*response.UserImportResponse {
+	resp := make([]*response.UserImportResponse, 0, len(imports))
+	for _, imp := range imports {
+		resp = append(resp, UserImportToUserImportResponse(imp))
+	}
+	return resp
+}
+
+// UserImportErrorsToResponses converts rejected rows to response DTOs.
+func UserImportErrorsToResponses(rowErrors []*entity.UserImportError) []*response.UserImportErrorResponse {
+	resp := make([]*response.UserImportErrorResponse, 0, len(rowErrors))
+	for _, rowError := range rowErrors {
+		resp = append(resp, &response.UserImportErrorResponse{
+			Row:     rowError.Row,
+			Field:   rowError.Field,
+			Message: rowError.Message,
+		})
+	}
+	return resp
+}
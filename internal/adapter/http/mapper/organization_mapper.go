@@ -0,0 +1,84 @@
+package mapper
+
+import (
+	"base-service/internal/adapter/http/dto/response"
+	"base-service/internal/domain/entity"
+)
+
+// OrganizationToResponse converts a domain organization to a response DTO.
+func OrganizationToResponse(org *entity.Organization) *response.OrganizationResponse {
+	if org == nil {
+		return nil
+	}
+	return &response.OrganizationResponse{
+		Id:        org.ID,
+		Slug:      org.Slug,
+		Name:      org.Name,
+		CreatedAt: org.CreatedAt.UnixMilli(),
+	}
+}
+
+// MembershipsToResponses converts a user's memberships to response DTOs,
+// marking the membership of activeOrgID as active.
+func MembershipsToResponses(memberships []*entity.OrganizationMember, activeOrgID int64) []*response.MembershipResponse {
+	resp := make([]*response.MembershipResponse, 0, len(memberships))
+	for _, membership := range memberships {
+		if membership.Organization == nil {
+			continue
+		}
+		resp = append(resp, &response.MembershipResponse{
+			Organization: *OrganizationToResponse(membership.Organization),
+			Role:         membership.Role,
+			Active:       membership.OrganizationID == activeOrgID,
+			JoinedAt:     membership.CreatedAt.UnixMilli(),
+		})
+	}
+	return resp
+}
+
+// OrgMemberToResponse converts a domain membership to a member response DTO.
+func OrgMemberToResponse(member *entity.OrganizationMember) *response.OrgMemberResponse {
+	if member == nil {
+		return nil
+	}
+	return &response.OrgMemberResponse{
+		UserId:   member.UserID,
+		Username: member.Username,
+		Email:    member.Email,
+		Role:     member.Role,
+		JoinedAt: member.CreatedAt.UnixMilli(),
+	}
+}
+
+// OrgMembersToResponses converts domain memberships to member response DTOs.
+func OrgMembersToResponses(members []*entity.OrganizationMember) []*response.OrgMemberResponse {
+	resp := make([]*response.OrgMemberResponse, 0, len(members))
+	for _, member := range members {
+		resp = append(resp, OrgMemberToResponse(member))
+	}
+	return resp
+}
+
+// InvitationToResponse converts a domain invitation to a response DTO.
+func InvitationToResponse(invitation *entity.OrganizationInvitation) *response.InvitationResponse {
+	if invitation == nil {
+		return nil
+	}
+	return &response.InvitationResponse{
+		Id:        invitation.ID,
+		Email:     invitation.Email,
+		Role:      invitation.Role,
+		InvitedBy: invitation.InvitedBy,
+		ExpiresAt: invitation.ExpiresAt.UnixMilli(),
+		CreatedAt: invitation.CreatedAt.UnixMilli(),
+	}
+}
+
+// InvitationsToResponses converts domain invitations to response DTOs.
+func InvitationsToResponses(invitations []*entity.OrganizationInvitation) []*response.InvitationResponse {
+	resp := make([]*response.InvitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		resp = append(resp, InvitationToResponse(invitation))
+	}
+	return resp
+}
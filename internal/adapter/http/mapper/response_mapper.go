@@ -3,6 +3,7 @@ package mapper
 import (
 	"base-service/internal/adapter/http/dto/response"
 	"base-service/internal/domain/entity"
+	"base-service/internal/usecase/port"
 )
 
 // UserToProfileResponse converts a domain user entity to a profile response DTO.
@@ -16,6 +17,9 @@ func UserToProfileResponse(user *entity.User) *response.ProfileResponse {
 		Email:       user.Email,
 		Active:      !user.IsDeleted(),
 		DisplayName: user.FullName(),
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		Phone:       user.PhoneNumber,
 		Avatar:      user.Avatar,
 		Username:    user.Username,
 		CreatedAt:   user.CreatedAt.UnixMilli(),
@@ -23,6 +27,36 @@ func UserToProfileResponse(user *entity.User) *response.ProfileResponse {
 	}
 }
 
+// UserToPublicProfileResponse converts a domain user entity to a public
+// profile response DTO.
+func UserToPublicProfileResponse(user *entity.User) *response.PublicProfileResponse {
+	if user == nil {
+		return nil
+	}
+
+	return &response.PublicProfileResponse{
+		Id:          user.ID,
+		Username:    user.Username,
+		DisplayName: user.FullName(),
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		Avatar:      user.Avatar,
+	}
+}
+
+// UsernameChangesToResponses converts username changes to response DTOs.
+func UsernameChangesToResponses(changes []*entity.UsernameChange) []*response.UsernameChangeResponse {
+	resp := make([]*response.UsernameChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		resp = append(resp, &response.UsernameChangeResponse{
+			Username:      change.Username,
+			ChangedAt:     change.ChangedAt.UnixMilli(),
+			ReservedUntil: change.ReservedUntil.UnixMilli(),
+		})
+	}
+	return resp
+}
+
 // UserToUserResponse converts a domain user entity to a user response DTO.
 func UserToUserResponse(user *entity.User) *response.UserResponse {
 	if user == nil {
@@ -41,3 +75,55 @@ func UserToUserResponse(user *entity.User) *response.UserResponse {
 		UpdatedAt: user.UpdatedAt.UnixMilli(),
 	}
 }
+
+// UserToAdminUserResponse converts a domain user entity to an admin user response DTO.
+func UserToAdminUserResponse(user *entity.User) *response.AdminUserResponse {
+	if user == nil {
+		return nil
+	}
+
+	resp := &response.AdminUserResponse{
+		Id:           user.ID,
+		Username:     user.Username,
+		Email:        user.Email,
+		Phone:        user.PhoneNumber,
+		FirstName:    user.FirstName,
+		LastName:     user.LastName,
+		Avatar:       user.Avatar,
+		Role:         user.Role,
+		Active:       !user.IsDeleted(),
+		Status:       user.Status,
+		StatusReason: user.StatusReason,
+		CreatedAt:    user.CreatedAt.UnixMilli(),
+		UpdatedAt:    user.UpdatedAt.UnixMilli(),
+	}
+	if user.StatusChangedAt != nil {
+		resp.StatusChangedAt = user.StatusChangedAt.UnixMilli()
+	}
+	if user.DeletedAt != nil {
+		resp.DeletedAt = user.DeletedAt.UnixMilli()
+	}
+	return resp
+}
+
+// UsersToAdminUserResponses converts domain user entities to admin user response DTOs.
+func UsersToAdminUserResponses(users []*entity.User) []*response.AdminUserResponse {
+	resp := make([]*response.AdminUserResponse, 0, len(users))
+	for _, user := range users {
+		resp = append(resp, UserToAdminUserResponse(user))
+	}
+	return resp
+}
+
+// SearchResultsToAdminUserSearchResponses converts user search results to admin search response DTOs.
+func SearchResultsToAdminUserSearchResponses(results []*port.UserSearchResult) []*response.AdminUserSearchResponse {
+	resp := make([]*response.AdminUserSearchResponse, 0, len(results))
+	for _, result := range results {
+		resp = append(resp, &response.AdminUserSearchResponse{
+			AdminUserResponse: *UserToAdminUserResponse(result.User),
+			Rank:              result.Rank,
+			Highlight:         result.Highlight,
+		})
+	}
+	return resp
+}
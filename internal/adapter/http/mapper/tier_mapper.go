@@ -0,0 +1,75 @@
+package mapper
+
+import (
+	"base-service/internal/adapter/http/dto/response"
+	"base-service/internal/domain/entity"
+)
+
+// UserTierToProfileTierResponse converts a current tier assignment to a
+// profile tier response DTO.
+func UserTierToProfileTierResponse(userTier *entity.UserTier) *response.ProfileTierResponse {
+	if userTier == nil || userTier.Tier == nil {
+		return nil
+	}
+
+	resp := &response.ProfileTierResponse{
+		Id:        userTier.Tier.ID,
+		Code:      userTier.Tier.Code,
+		Name:      userTier.Tier.Name,
+		Features:  userTier.Tier.Features,
+		ValidFrom: userTier.ValidFrom.UnixMilli(),
+		CreatedAt: userTier.CreatedAt.UnixMilli(),
+	}
+	if userTier.ValidTo != nil {
+		resp.ValidTo = userTier.ValidTo.UnixMilli()
+	}
+	return resp
+}
+
+// TiersToTierResponses converts domain tiers to tier response DTOs.
+func TiersToTierResponses(tiers []*entity.Tier) []*response.TierResponse {
+	resp := make([]*response.TierResponse, 0, len(tiers))
+	for _, tier := range tiers {
+		resp = append(resp, &response.TierResponse{
+			Id:        tier.ID,
+			Code:      tier.Code,
+			Name:      tier.Name,
+			RateLimit: tier.RateLimit,
+			Features:  tier.Features,
+			CreatedAt: tier.CreatedAt.UnixMilli(),
+			UpdatedAt: tier.UpdatedAt.UnixMilli(),
+		})
+	}
+	return resp
+}
+
+// UserTierToUserTierResponse converts a tier assignment to a user tier response DTO.
+func UserTierToUserTierResponse(userTier *entity.UserTier) *response.UserTierResponse {
+	if userTier == nil {
+		return nil
+	}
+
+	resp := &response.UserTierResponse{
+		Id:         userTier.ID,
+		UserId:     userTier.UserID,
+		ValidFrom:  userTier.ValidFrom.UnixMilli(),
+		AssignedBy: userTier.AssignedBy,
+		CreatedAt:  userTier.CreatedAt.UnixMilli(),
+	}
+	if userTier.Tier != nil {
+		resp.Tier = userTier.Tier.Code
+	}
+	if userTier.ValidTo != nil {
+		resp.ValidTo = userTier.ValidTo.UnixMilli()
+	}
+	return resp
+}
+
+// UserTiersToUserTierResponses converts tier assignments to user tier response DTOs.
+func UserTiersToUserTierResponses(userTiers []*entity.UserTier) []*response.UserTierResponse {
+	resp := make([]*response.UserTierResponse, 0, len(userTiers))
+	for _, userTier := range userTiers {
+		resp = append(resp, UserTierToUserTierResponse(userTier))
+	}
+	return resp
+}
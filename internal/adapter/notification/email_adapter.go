@@ -0,0 +1,29 @@
+package notification
+
+import (
+	"context"
+
+	"base-service/internal/infra"
+)
+
+// EmailAdapter wraps an infra.NotificationSender to implement use case mailer
+// interfaces.
+type EmailAdapter struct {
+	sender infra.NotificationSender
+}
+
+// NewEmailAdapter creates a new email adapter.
+func NewEmailAdapter(sender infra.NotificationSender) *EmailAdapter {
+	return &EmailAdapter{
+		sender: sender,
+	}
+}
+
+// SendEmail implements export.Mailer.
+func (a *EmailAdapter) SendEmail(ctx context.Context, to, subject, body string) error {
+	return a.sender.SendEmail(ctx, infra.EmailRequest{
+		To:      []string{to},
+		Subject: subject,
+		Body:    body,
+	})
+}
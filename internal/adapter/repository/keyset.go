@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"base-service/internal/common"
+)
+
+// =============================================================================
+// Keyset Pagination
+// clean-arch: Converts signed cursors to and from typed seek values
+// =============================================================================
+
+// KeyKind is the type of a keyset column value.
+type KeyKind int
+
+const (
+	KeyInt KeyKind = iota
+	KeyText
+	KeyTime
+)
+
+// KeysetColumn is one ordering column of a keyset.
+type KeysetColumn struct {
+	Name string
+	Kind KeyKind
+}
+
+// Keyset is a total ordering used for seeks, e.g. created_at DESC, id DESC.
+// The last column must be unique (normally the primary key) so no two rows
+// share a position. Queries seek with a row comparison on the same columns:
+//
+//	WHERE (created_at, id) < ($1, $2) ORDER BY created_at DESC, id DESC
+type Keyset struct {
+	Columns []KeysetColumn
+	Desc    bool
+}
+
+// Name identifies the ordering; cursors are only accepted for the keyset
+// that issued them.
+func (k Keyset) Name() string {
+	names := make([]string, 0, len(k.Columns))
+	for _, column := range k.Columns {
+		names = append(names, column.Name)
+	}
+	direction := "asc"
+	if k.Desc {
+		direction = "desc"
+	}
+	return strings.Join(names, ",") + ":" + direction
+}
+
+// Encode returns the opaque cursor positioned after a row with the given
+// column values, in column order.
+func (k Keyset) Encode(values ...any) (string, error) {
+	if len(values) != len(k.Columns) {
+		return "", fmt.Errorf("keyset %s: got %d values for %d columns", k.Name(), len(values), len(k.Columns))
+	}
+
+	encoded := make([]string, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case int64:
+			encoded[i] = strconv.FormatInt(v, 10)
+		case string:
+			encoded[i] = v
+		case time.Time:
+			encoded[i] = v.UTC().Format(time.RFC3339Nano)
+		default:
+			return "", fmt.Errorf("keyset %s: unsupported value type %T", k.Name(), value)
+		}
+	}
+	return common.EncodeCursor(common.Cursor{Sort: k.Name(), Values: encoded})
+}
+
+// Decode verifies a cursor and returns its typed values (int64, string or
+// time.Time per column kind). It returns common.ErrInvalidCursor for cursors
+// of another keyset or with malformed values.
+func (k Keyset) Decode(token string) ([]any, error) {
+	cursor, err := common.DecodeCursor(token, k.Name())
+	if err != nil {
+		return nil, err
+	}
+	if len(cursor.Values) != len(k.Columns) {
+		return nil, common.ErrInvalidCursor
+	}
+
+	values := make([]any, len(k.Columns))
+	for i, column := range k.Columns {
+		raw := cursor.Values[i]
+		switch column.Kind {
+		case KeyInt:
+			v, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil {
+				return nil, common.ErrInvalidCursor
+			}
+			values[i] = v
+		case KeyText:
+			values[i] = raw
+		case KeyTime:
+			v, err := time.Parse(time.RFC3339Nano, raw)
+			if err != nil {
+				return nil, common.ErrInvalidCursor
+			}
+			values[i] = v
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,87 @@
+package mapper
+
+import (
+	"time"
+
+	"base-service/internal/database/user"
+	"base-service/internal/domain/entity"
+	"base-service/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// OrganizationDBToEntity converts a database organization to a domain entity.
+func OrganizationDBToEntity(dbOrg *user.Organization) *entity.Organization {
+	if dbOrg == nil {
+		return nil
+	}
+	return &entity.Organization{
+		ID:        dbOrg.ID,
+		Slug:      dbOrg.Slug,
+		Name:      dbOrg.Name,
+		CreatedBy: dbOrg.CreatedBy.Int64,
+		CreatedAt: dbOrg.CreatedAt.Time,
+		UpdatedAt: dbOrg.UpdatedAt.Time,
+	}
+}
+
+// OrganizationMemberDBToEntity converts a database membership and its
+// organization, if known, to a domain entity.
+func OrganizationMemberDBToEntity(dbMember *user.OrganizationMember, dbOrg *user.Organization) *entity.OrganizationMember {
+	if dbMember == nil {
+		return nil
+	}
+
+	var selectedAt *time.Time
+	if dbMember.SelectedAt.Valid {
+		t := dbMember.SelectedAt.Time
+		selectedAt = &t
+	}
+
+	return &entity.OrganizationMember{
+		OrganizationID: dbMember.OrganizationID,
+		UserID:         dbMember.UserID,
+		Role:           dbMember.Role,
+		SelectedAt:     selectedAt,
+		CreatedAt:      dbMember.CreatedAt.Time,
+		Organization:   OrganizationDBToEntity(dbOrg),
+	}
+}
+
+// OrganizationInvitationDBToEntity converts a database invitation to a domain
+// entity. The token hash is not exposed.
+func OrganizationInvitationDBToEntity(dbInvitation *user.OrganizationInvitation) *entity.OrganizationInvitation {
+	if dbInvitation == nil {
+		return nil
+	}
+	return &entity.OrganizationInvitation{
+		ID:             dbInvitation.ID,
+		OrganizationID: dbInvitation.OrganizationID,
+		Email:          dbInvitation.Email,
+		Role:           dbInvitation.Role,
+		InvitedBy:      dbInvitation.InvitedBy.Int64,
+		ExpiresAt:      dbInvitation.ExpiresAt.Time,
+		CreatedAt:      dbInvitation.CreatedAt.Time,
+	}
+}
+
+// OrganizationToCreateParams converts an organization to create params.
+func OrganizationToCreateParams(org *entity.Organization) *user.CreateOrganizationParams {
+	return &user.CreateOrganizationParams{
+		Slug:      org.Slug,
+		Name:      org.Name,
+		CreatedBy: pgtype.Int8{Int64: org.CreatedBy, Valid: org.CreatedBy != 0},
+	}
+}
+
+// InvitationCreationToParams converts an invitation to create params.
+func InvitationCreationToParams(invitation repository.InvitationCreation) *user.CreateOrganizationInvitationParams {
+	return &user.CreateOrganizationInvitationParams{
+		OrganizationID: invitation.OrganizationID,
+		Email:          invitation.Email,
+		Role:           invitation.Role,
+		TokenHash:      invitation.TokenHash,
+		InvitedBy:      pgtype.Int8{Int64: invitation.InvitedBy, Valid: invitation.InvitedBy != 0},
+		ExpiresAt:      pgtype.Timestamptz{Time: invitation.ExpiresAt, Valid: true},
+	}
+}
@@ -0,0 +1,19 @@
+package mapper
+
+import (
+	"base-service/internal/database/user"
+	"base-service/internal/domain/entity"
+)
+
+// TenantDBToEntity converts a database tenant to a domain entity.
+func TenantDBToEntity(dbTenant *user.Tenant) *entity.Tenant {
+	if dbTenant == nil {
+		return nil
+	}
+	return &entity.Tenant{
+		ID:        dbTenant.ID,
+		Slug:      dbTenant.Slug,
+		Name:      dbTenant.Name,
+		CreatedAt: dbTenant.CreatedAt.Time,
+	}
+}
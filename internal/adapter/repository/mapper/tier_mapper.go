@@ -0,0 +1,62 @@
+package mapper
+
+import (
+	"time"
+
+	"base-service/internal/database/user"
+	"base-service/internal/domain/entity"
+	"base-service/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// TierDBToEntity converts a database tier to a domain entity.
+func TierDBToEntity(dbTier *user.Tier) *entity.Tier {
+	if dbTier == nil {
+		return nil
+	}
+	return &entity.Tier{
+		ID:        dbTier.ID,
+		Code:      dbTier.Code,
+		Name:      dbTier.Name,
+		RateLimit: int(dbTier.RateLimit),
+		Features:  dbTier.Features,
+		CreatedAt: dbTier.CreatedAt.Time,
+		UpdatedAt: dbTier.UpdatedAt.Time,
+	}
+}
+
+// UserTierDBToEntity converts a database tier assignment and its tier to a
+// domain entity.
+func UserTierDBToEntity(dbUserTier *user.UserTier, dbTier *user.Tier) *entity.UserTier {
+	if dbUserTier == nil {
+		return nil
+	}
+
+	var validTo *time.Time
+	if dbUserTier.ValidTo.Valid {
+		t := dbUserTier.ValidTo.Time
+		validTo = &t
+	}
+
+	return &entity.UserTier{
+		ID:         dbUserTier.ID,
+		UserID:     dbUserTier.UserID,
+		Tier:       TierDBToEntity(dbTier),
+		ValidFrom:  dbUserTier.ValidFrom.Time,
+		ValidTo:    validTo,
+		AssignedBy: dbUserTier.AssignedBy.Int64,
+		CreatedAt:  dbUserTier.CreatedAt.Time,
+	}
+}
+
+// TierAssignmentToParams converts a tier assignment to create params.
+func TierAssignmentToParams(assignment repository.TierAssignment) *user.CreateUserTierParams {
+	return &user.CreateUserTierParams{
+		UserID:     assignment.UserID,
+		TierID:     assignment.TierID,
+		ValidFrom:  pgtype.Timestamptz{Time: assignment.ValidFrom, Valid: true},
+		ValidTo:    optionalTime(assignment.ValidTo),
+		AssignedBy: pgtype.Int8{Int64: assignment.AssignedBy, Valid: assignment.AssignedBy != 0},
+	}
+}
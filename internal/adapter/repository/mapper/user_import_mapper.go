@@ -0,0 +1,119 @@
+package mapper
+
+import (
+	"time"
+
+	"base-service/internal/database/user"
+	"base-service/internal/domain/entity"
+	"base-service/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// UserImportDBToEntity converts a database user import to a domain entity.
+func UserImportDBToEntity(dbImport *user.UserImport) *entity.UserImport {
+	if dbImport == nil {
+		return nil
+	}
+
+	var finishedAt *time.Time
+	if dbImport.FinishedAt.Valid {
+		t := dbImport.FinishedAt.Time
+		finishedAt = &t
+	}
+
+	return &entity.UserImport{
+		ID:            dbImport.ID,
+		TenantID:      dbImport.TenantID,
+		Format:        dbImport.Format,
+		Source:        dbImport.Source,
+		DryRun:        dbImport.DryRun,
+		Status:        dbImport.Status,
+		RowsProcessed: dbImport.RowsProcessed,
+		RowsImported:  dbImport.RowsImported,
+		RowsFailed:    dbImport.RowsFailed,
+		Error:         dbImport.Error.String,
+		CreatedBy:     dbImport.CreatedBy.Int64,
+		CreatedAt:     dbImport.CreatedAt.Time,
+		UpdatedAt:     dbImport.UpdatedAt.Time,
+		FinishedAt:    finishedAt,
+	}
+}
+
+// UserImportErrorDBToEntity converts a database import error to a domain entity.
+func UserImportErrorDBToEntity(dbError *user.UserImportError) *entity.UserImportError {
+	if dbError == nil {
+		return nil
+	}
+	return &entity.UserImportError{
+		ImportID: dbError.ImportID,
+		Row:      dbError.RowNumber,
+		Field:    dbError.Field.String,
+		Message:  dbError.Message,
+	}
+}
+
+// UserImportToCreateParams converts an import to create params.
+func UserImportToCreateParams(imp *entity.UserImport) *user.CreateUserImportParams {
+	return &user.CreateUserImportParams{
+		Format:    imp.Format,
+		Source:    imp.Source,
+		DryRun:    imp.DryRun,
+		CreatedBy: pgtype.Int8{Int64: imp.CreatedBy, Valid: imp.CreatedBy != 0},
+	}
+}
+
+// ImportedUsersToParams converts the users of an import batch to the
+// column arrays of a bulk insert.
+func ImportedUsersToParams(users []repository.ImportedUser) *user.ImportUsersParams {
+	params := &user.ImportUsersParams{
+		Usernames:     make([]string, len(users)),
+		Emails:        make([]string, len(users)),
+		PhoneNumbers:  make([]string, len(users)),
+		FirstNames:    make([]string, len(users)),
+		LastNames:     make([]string, len(users)),
+		HashPasswords: make([]string, len(users)),
+		CreatedAts:    make([]pgtype.Timestamptz, len(users)),
+	}
+	for i, imported := range users {
+		u := imported.User
+		params.Usernames[i] = u.Username
+		params.Emails[i] = u.Email
+		params.PhoneNumbers[i] = u.PhoneNumber
+		params.FirstNames[i] = u.FirstName
+		params.LastNames[i] = u.LastName
+		params.HashPasswords[i] = u.HashPassword
+		params.CreatedAts[i] = pgtype.Timestamptz{Time: u.CreatedAt, Valid: !u.CreatedAt.IsZero()}
+	}
+	return params
+}
+
+// ImportedUsersToConflictParams converts the users of an import batch to the
+// username and email arrays of a conflict lookup.
+func ImportedUsersToConflictParams(users []repository.ImportedUser) *user.ListImportConflictsParams {
+	params := &user.ListImportConflictsParams{
+		Usernames: make([]string, len(users)),
+		Emails:    make([]string, len(users)),
+	}
+	for i, imported := range users {
+		params.Usernames[i] = imported.User.Username
+		params.Emails[i] = imported.User.Email
+	}
+	return params
+}
+
+// UserImportErrorsToParams converts rejected rows to batch insert params.
+func UserImportErrorsToParams(importID int64, rowErrors []*entity.UserImportError) *user.CreateUserImportErrorsParams {
+	params := &user.CreateUserImportErrorsParams{
+		ImportID:   importID,
+		RowNumbers: make([]int64, len(rowErrors)),
+		Fields:     make([]string, len(rowErrors)),
+		Messages:   make([]string, len(rowErrors)),
+	}
+	for i, rowError := range rowErrors {
+		params.RowNumbers[i] = rowError.Row
+		params.Fields[i] = rowError.Field
+		params.Messages[i] = rowError.Message
+	}
+	return params
+}
@@ -1,10 +1,15 @@
 package mapper
 
 import (
+	"strings"
 	"time"
+	"unicode"
 
 	"base-service/internal/database/user"
 	"base-service/internal/domain/entity"
+	"base-service/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // UserDBToEntity converts a database user model to a domain entity.
@@ -24,18 +29,37 @@ func UserDBToEntity(dbUser *user.User) *entity.User {
 		deletedAt = &t
 	}
 
+	var purgeAt *time.Time
+	if dbUser.PurgeAt.Valid {
+		t := dbUser.PurgeAt.Time
+		purgeAt = &t
+	}
+
+	var statusChangedAt *time.Time
+	if dbUser.StatusChangedAt.Valid {
+		t := dbUser.StatusChangedAt.Time
+		statusChangedAt = &t
+	}
+
 	return &entity.User{
-		ID:           dbUser.ID,
-		Username:     dbUser.Username,
-		Email:        dbUser.Email,
-		PhoneNumber:  dbUser.PhoneNumber,
-		FirstName:    dbUser.FirstName,
-		LastName:     dbUser.LastName,
-		HashPassword: dbUser.HashPassword,
-		Avatar:       avatar,
-		CreatedAt:    dbUser.CreatedAt.Time,
-		UpdatedAt:    dbUser.UpdatedAt.Time,
-		DeletedAt:    deletedAt,
+		ID:              dbUser.ID,
+		Username:        dbUser.Username,
+		Email:           dbUser.Email,
+		PhoneNumber:     dbUser.PhoneNumber,
+		FirstName:       dbUser.FirstName,
+		LastName:        dbUser.LastName,
+		HashPassword:    dbUser.HashPassword,
+		Avatar:          avatar,
+		Role:            dbUser.Role,
+		Version:         dbUser.Version,
+		CreatedAt:       dbUser.CreatedAt.Time,
+		UpdatedAt:       dbUser.UpdatedAt.Time,
+		DeletedAt:       deletedAt,
+		PurgeAt:         purgeAt,
+		SelfDeleted:     dbUser.SelfDeleted,
+		Status:          dbUser.Status,
+		StatusReason:    dbUser.StatusReason.String,
+		StatusChangedAt: statusChangedAt,
 	}
 }
 
@@ -54,3 +78,171 @@ func UserEntityToCreateParams(entity *entity.User) *user.CreateUserParams {
 		HashPassword: entity.HashPassword,
 	}
 }
+
+// UserEntityToUpdateParams converts a domain entity to database update params.
+// Empty fields become NULL so the query leaves the stored value unchanged.
+func UserEntityToUpdateParams(entity *entity.User) *user.UpdateUserParams {
+	if entity == nil {
+		return nil
+	}
+
+	return &user.UpdateUserParams{
+		ID:           entity.ID,
+		Email:        optionalText(entity.Email),
+		PhoneNumber:  optionalText(entity.PhoneNumber),
+		FirstName:    optionalText(entity.FirstName),
+		LastName:     optionalText(entity.LastName),
+		Avatar:       optionalText(entity.Avatar),
+		HashPassword: optionalText(entity.HashPassword),
+		Role:         optionalText(entity.Role),
+	}
+}
+
+// ProfilePatchToParams converts a domain profile patch to database patch params.
+func ProfilePatchToParams(patch repository.ProfilePatch) *user.PatchUserProfileParams {
+	params := &user.PatchUserProfileParams{
+		ID:          patch.UserID,
+		FirstName:   patchText(patch.FirstName),
+		LastName:    patchText(patch.LastName),
+		PhoneNumber: patchText(patch.PhoneNumber),
+	}
+	if patch.Avatar != nil {
+		params.ClearAvatar = *patch.Avatar == ""
+		params.Avatar = optionalText(*patch.Avatar)
+	}
+	if patch.ExpectedVersion > 0 {
+		params.ExpectedVersion = pgtype.Int8{Int64: patch.ExpectedVersion, Valid: true}
+	}
+	return params
+}
+
+// UserDeletionToParams converts a user deletion to database soft delete params.
+func UserDeletionToParams(deletion repository.UserDeletion) *user.SoftDeleteUserParams {
+	return &user.SoftDeleteUserParams{
+		PurgeAt:     optionalTime(deletion.PurgeAt),
+		SelfDeleted: deletion.SelfService,
+		ID:          deletion.UserID,
+	}
+}
+
+// StatusChangeToParams converts a status change to database status update params.
+func StatusChangeToParams(change repository.StatusChange) *user.UpdateUserStatusParams {
+	return &user.UpdateUserStatusParams{
+		Status:       change.To,
+		StatusReason: optionalText(change.Reason),
+		ID:           change.UserID,
+		FromStatus:   change.From,
+	}
+}
+
+// UserFilterToParams converts a domain user filter to database filter params.
+func UserFilterToParams(filter repository.UserFilter) (*user.FilterUsersParams, *user.CountFilteredUsersParams) {
+	count := &user.CountFilteredUsersParams{
+		Deleted:     filter.Deleted,
+		CreatedFrom: optionalTime(filter.CreatedFrom),
+		CreatedTo:   optionalTime(filter.CreatedTo),
+		EmailDomain: optionalText(filter.EmailDomain),
+		Role:        optionalText(filter.Role),
+		Status:      optionalText(filter.Status),
+	}
+	if count.Deleted == "" {
+		count.Deleted = repository.UserDeletedExclude
+	}
+
+	sortBy := filter.SortBy
+	if sortBy == "" {
+		sortBy = repository.UserSortID
+	}
+
+	return &user.FilterUsersParams{
+		Deleted:     count.Deleted,
+		CreatedFrom: count.CreatedFrom,
+		CreatedTo:   count.CreatedTo,
+		EmailDomain: count.EmailDomain,
+		Role:        count.Role,
+		Status:      count.Status,
+		SortBy:      sortBy,
+		SortDesc:    filter.SortDesc,
+		LimitCount:  int32(filter.Limit),
+		OffsetCount: int32(filter.Offset),
+	}, count
+}
+
+// UserSearchToParams converts a domain user search to database search params.
+// The query is matched as term prefixes (full-text), as a substring and fuzzily.
+func UserSearchToParams(search repository.UserSearch) (*user.SearchUsersParams, *user.CountSearchUsersParams) {
+	term := strings.ToLower(strings.TrimSpace(search.Query))
+	count := &user.CountSearchUsersParams{
+		IncludeDeleted: search.IncludeDeleted,
+		TsQuery:        prefixTsQuery(term),
+		Pattern:        "%" + likeEscaper.Replace(term) + "%",
+		Term:           term,
+	}
+	return &user.SearchUsersParams{
+		TsQuery:        count.TsQuery,
+		Term:           count.Term,
+		IncludeDeleted: count.IncludeDeleted,
+		Pattern:        count.Pattern,
+		LimitCount:     int32(search.Limit),
+		OffsetCount:    int32(search.Offset),
+	}, count
+}
+
+// SearchUserDBToHit converts a database search row to a domain search hit.
+func SearchUserDBToHit(row *user.SearchUsersRow) *repository.UserSearchHit {
+	if row == nil {
+		return nil
+	}
+	return &repository.UserSearchHit{
+		User:      UserDBToEntity(&row.User),
+		Rank:      float64(row.Rank),
+		Highlight: row.Headline,
+	}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// prefixTsQuery builds a to_tsquery expression matching every word of term as
+// a prefix, e.g. "jo smi" becomes "jo:* & smi:*". Characters with a meaning in
+// tsquery syntax are dropped, so the result is always well-formed.
+func prefixTsQuery(term string) string {
+	words := strings.FieldsFunc(term, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '@' && r != '.' && r != '_'
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+func optionalTime(value *time.Time) pgtype.Timestamptz {
+	if value == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *value, Valid: true}
+}
+
+func optionalText(value string) pgtype.Text {
+	return pgtype.Text{String: value, Valid: value != ""}
+}
+
+// patchText is optionalText for pointers: nil is NULL, an empty string is a value.
+func patchText(value *string) pgtype.Text {
+	if value == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *value, Valid: true}
+}
+
+// TOTPSecretDBToEntity converts a database TOTP secret to a domain entity.
+func TOTPSecretDBToEntity(dbSecret *user.UserTotpSecret) *entity.TOTPSecret {
+	if dbSecret == nil {
+		return nil
+	}
+	return &entity.TOTPSecret{
+		UserID:       dbSecret.UserID,
+		Secret:       dbSecret.Secret,
+		Enabled:      dbSecret.Enabled,
+		LastUsedStep: dbSecret.LastUsedStep,
+	}
+}
@@ -0,0 +1,69 @@
+package mapper
+
+import (
+	"testing"
+
+	"base-service/internal/domain/repository"
+)
+
+func TestPrefixTsQuery(t *testing.T) {
+	tests := []struct {
+		term string
+		want string
+	}{
+		{"", ""},
+		{"jo", "jo:*"},
+		{"jo smi", "jo:* & smi:*"},
+		{"  jo   smi  ", "jo:* & smi:*"},
+		{"jane.doe@example.com", "jane.doe@example.com:*"},
+		{"user_42", "user_42:*"},
+		{"nguyễn văn", "nguyễn:* & văn:*"},
+		{"jo & !smi | (x):*", "jo:* & smi:* & x:*"},
+		{`o'brien\`, "o:* & brien:*"},
+		{"+84 912", "84:* & 912:*"},
+		{"&|!():*'", ""},
+	}
+	for _, tt := range tests {
+		if got := prefixTsQuery(tt.term); got != tt.want {
+			t.Errorf("prefixTsQuery(%q) = %q, want %q", tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestUserSearchToParams(t *testing.T) {
+	params, count := UserSearchToParams(repository.UserSearch{Query: "  50%_Off\\ ", Limit: 20, Offset: 40})
+
+	if want := `%50\%\_off\\%`; count.Pattern != want {
+		t.Errorf("Pattern = %q, want %q", count.Pattern, want)
+	}
+	if want := `50%_off\`; count.Term != want {
+		t.Errorf("Term = %q, want %q", count.Term, want)
+	}
+	if want := "50:* & _off:*"; count.TsQuery != want {
+		t.Errorf("TsQuery = %q, want %q", count.TsQuery, want)
+	}
+	if params.TsQuery != count.TsQuery || params.Pattern != count.Pattern || params.Term != count.Term {
+		t.Errorf("search params %+v differ from count params %+v", params, count)
+	}
+	if params.LimitCount != 20 || params.OffsetCount != 40 {
+		t.Errorf("limit, offset = %d, %d, want 20, 40", params.LimitCount, params.OffsetCount)
+	}
+}
+
+func TestUserFilterToParams(t *testing.T) {
+	params, count := UserFilterToParams(repository.UserFilter{Limit: 20})
+	if count.Deleted != repository.UserDeletedExclude || params.Deleted != count.Deleted {
+		t.Errorf("deleted = %q, %q; want soft-deleted users excluded by default", params.Deleted, count.Deleted)
+	}
+	if count.Status.Valid || params.Status.Valid {
+		t.Error("empty status filters by status")
+	}
+
+	params, count = UserFilterToParams(repository.UserFilter{Deleted: repository.UserDeletedOnly, Status: "suspended", Limit: 20})
+	if params.Deleted != repository.UserDeletedOnly || count.Deleted != repository.UserDeletedOnly {
+		t.Errorf("deleted = %q, %q, want %q", params.Deleted, count.Deleted, repository.UserDeletedOnly)
+	}
+	if params.Status.String != "suspended" || count.Status != params.Status {
+		t.Errorf("status = %+v, %+v, want suspended", params.Status, count.Status)
+	}
+}
@@ -0,0 +1,34 @@
+package mapper
+
+import (
+	"base-service/internal/database/user"
+	"base-service/internal/domain/entity"
+	"base-service/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// UsernameChangeDBToEntity converts a database username history row to a
+// domain entity.
+func UsernameChangeDBToEntity(dbChange *user.UsernameHistory) *entity.UsernameChange {
+	if dbChange == nil {
+		return nil
+	}
+	return &entity.UsernameChange{
+		ID:            dbChange.ID,
+		UserID:        dbChange.UserID,
+		Username:      dbChange.Username,
+		ChangedAt:     dbChange.ChangedAt.Time,
+		ReservedUntil: dbChange.ReservedUntil.Time,
+	}
+}
+
+// UsernameRenameToParams converts a username rename to change params.
+func UsernameRenameToParams(rename repository.UsernameRename) *user.ChangeUsernameParams {
+	return &user.ChangeUsernameParams{
+		Username:      rename.To,
+		ID:            rename.UserID,
+		OldUsername:   rename.From,
+		ReservedUntil: pgtype.Timestamptz{Time: rename.ReservedUntil, Valid: true},
+	}
+}
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"base-service/internal/adapter/repository/mapper"
+	"base-service/internal/database/user"
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	// organizationConstraints maps organizations, organization_members and
+	// organization_invitations constraints to domain errors.
+	organizationConstraints = map[string]error{
+		"organizations_slug_key":                        domainerrors.ErrDuplicateOrganizationSlug,
+		"organizations_created_by_fkey":                 domainerrors.ErrUserNotFound,
+		"organization_members_pkey":                     domainerrors.ErrOrgMemberExists,
+		"organization_members_user_id_fkey":             domainerrors.ErrUserNotFound,
+		"organization_members_organization_id_fkey":     domainerrors.ErrOrganizationNotFound,
+		"organization_invitations_organization_id_fkey": domainerrors.ErrOrganizationNotFound,
+	}
+	// organizationErrors translates organization errors into domain errors.
+	organizationErrors = NewPgErrorTranslator(domainerrors.ErrOrganizationNotFound, organizationConstraints)
+	// memberErrors reports missing memberships.
+	memberErrors = NewPgErrorTranslator(domainerrors.ErrOrgMemberNotFound, organizationConstraints)
+	// activeOrganizationErrors reports users without an active organization.
+	activeOrganizationErrors = NewPgErrorTranslator(domainerrors.ErrNoActiveOrganization, nil)
+	// invitationErrors reports missing or expired invitations.
+	invitationErrors = NewPgErrorTranslator(domainerrors.ErrInvitationNotFound, organizationConstraints)
+)
+
+// organizationRepository implements the domain.OrganizationRepository interface.
+type organizationRepository struct {
+	queries *user.Queries
+}
+
+// NewOrganizationRepository creates a new organization repository adapter.
+func NewOrganizationRepository(pool *pgxpool.Pool) repository.OrganizationRepository {
+	return &organizationRepository{
+		queries: user.New(pool),
+	}
+}
+
+// Create creates an organization with its creator as the first owner.
+func (r *organizationRepository) Create(ctx context.Context, org *entity.Organization) (*entity.Organization, error) {
+	dbOrg, err := r.queries.CreateOrganization(ctx, mapper.OrganizationToCreateParams(org))
+	if err != nil {
+		return nil, organizationErrors.Translate(err)
+	}
+	return mapper.OrganizationDBToEntity(dbOrg), nil
+}
+
+// FindByID finds an organization by ID.
+func (r *organizationRepository) FindByID(ctx context.Context, id int64) (*entity.Organization, error) {
+	dbOrg, err := r.queries.GetOrganization(ctx, id)
+	if err != nil {
+		return nil, organizationErrors.Translate(err)
+	}
+	return mapper.OrganizationDBToEntity(dbOrg), nil
+}
+
+// ListByUser returns the memberships of a user with their organization.
+func (r *organizationRepository) ListByUser(ctx context.Context, userID int64) ([]*entity.OrganizationMember, error) {
+	rows, err := r.queries.ListUserOrganizations(ctx, userID)
+	if err != nil {
+		return nil, organizationErrors.Translate(err)
+	}
+	members := make([]*entity.OrganizationMember, 0, len(rows))
+	for _, row := range rows {
+		members = append(members, mapper.OrganizationMemberDBToEntity(&row.OrganizationMember, &row.Organization))
+	}
+	return members, nil
+}
+
+// FindActive finds the membership the user switched to last.
+func (r *organizationRepository) FindActive(ctx context.Context, userID int64) (*entity.OrganizationMember, error) {
+	row, err := r.queries.GetActiveOrganization(ctx, userID)
+	if err != nil {
+		return nil, activeOrganizationErrors.Translate(err)
+	}
+	return mapper.OrganizationMemberDBToEntity(&row.OrganizationMember, &row.Organization), nil
+}
+
+// Select makes the organization the user's active one.
+func (r *organizationRepository) Select(ctx context.Context, orgID, userID int64) error {
+	rows, err := r.queries.SelectOrganization(ctx, &user.SelectOrganizationParams{
+		OrganizationID: orgID,
+		UserID:         userID,
+	})
+	if err != nil {
+		return memberErrors.Translate(err)
+	}
+	if rows == 0 {
+		return domainerrors.ErrOrgMemberNotFound
+	}
+	return nil
+}
+
+// FindMember finds a membership.
+func (r *organizationRepository) FindMember(ctx context.Context, orgID, userID int64) (*entity.OrganizationMember, error) {
+	dbMember, err := r.queries.GetOrganizationMember(ctx, &user.GetOrganizationMemberParams{
+		OrganizationID: orgID,
+		UserID:         userID,
+	})
+	if err != nil {
+		return nil, memberErrors.Translate(err)
+	}
+	return mapper.OrganizationMemberDBToEntity(dbMember, nil), nil
+}
+
+// ListMembers returns the members of an organization, oldest first.
+func (r *organizationRepository) ListMembers(ctx context.Context, orgID int64) ([]*entity.OrganizationMember, error) {
+	rows, err := r.queries.ListOrganizationMembers(ctx, orgID)
+	if err != nil {
+		return nil, memberErrors.Translate(err)
+	}
+	members := make([]*entity.OrganizationMember, 0, len(rows))
+	for _, row := range rows {
+		member := mapper.OrganizationMemberDBToEntity(&row.OrganizationMember, nil)
+		member.Username = row.Username
+		member.Email = row.Email
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+// UpdateMemberRole changes a member's role, refusing to demote the last
+// owner.
+func (r *organizationRepository) UpdateMemberRole(ctx context.Context, orgID, userID int64, role string) (*entity.OrganizationMember, error) {
+	dbMember, err := r.queries.UpdateOrganizationMemberRole(ctx, &user.UpdateOrganizationMemberRoleParams{
+		OrganizationID: orgID,
+		UserID:         userID,
+		Role:           role,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, r.unchangedMember(ctx, orgID, userID)
+	}
+	if err != nil {
+		return nil, memberErrors.Translate(err)
+	}
+	return mapper.OrganizationMemberDBToEntity(dbMember, nil), nil
+}
+
+// RemoveMember removes a member from an organization, refusing to remove
+// the last owner.
+func (r *organizationRepository) RemoveMember(ctx context.Context, orgID, userID int64) error {
+	rows, err := r.queries.DeleteOrganizationMember(ctx, &user.DeleteOrganizationMemberParams{
+		OrganizationID: orgID,
+		UserID:         userID,
+	})
+	if err != nil {
+		return memberErrors.Translate(err)
+	}
+	if rows == 0 {
+		return r.unchangedMember(ctx, orgID, userID)
+	}
+	return nil
+}
+
+// unchangedMember explains why a guarded member update matched no row: the
+// membership is missing, or it is the last owner.
+func (r *organizationRepository) unchangedMember(ctx context.Context, orgID, userID int64) error {
+	if _, err := r.FindMember(ctx, orgID, userID); err != nil {
+		return err
+	}
+	return domainerrors.ErrLastOrgOwner
+}
+
+// CreateInvitation records an invitation, replacing a pending one for the
+// same email.
+func (r *organizationRepository) CreateInvitation(ctx context.Context, invitation repository.InvitationCreation) (*entity.OrganizationInvitation, error) {
+	dbInvitation, err := r.queries.CreateOrganizationInvitation(ctx, mapper.InvitationCreationToParams(invitation))
+	if err != nil {
+		return nil, invitationErrors.Translate(err)
+	}
+	return mapper.OrganizationInvitationDBToEntity(dbInvitation), nil
+}
+
+// FindInvitationByToken finds an unexpired invitation by its token hash.
+func (r *organizationRepository) FindInvitationByToken(ctx context.Context, tokenHash string) (*entity.OrganizationInvitation, error) {
+	dbInvitation, err := r.queries.GetOrganizationInvitationByToken(ctx, tokenHash)
+	if err != nil {
+		return nil, invitationErrors.Translate(err)
+	}
+	return mapper.OrganizationInvitationDBToEntity(dbInvitation), nil
+}
+
+// ListInvitations returns the unexpired invitations of an organization.
+func (r *organizationRepository) ListInvitations(ctx context.Context, orgID int64) ([]*entity.OrganizationInvitation, error) {
+	dbInvitations, err := r.queries.ListOrganizationInvitations(ctx, orgID)
+	if err != nil {
+		return nil, invitationErrors.Translate(err)
+	}
+	invitations := make([]*entity.OrganizationInvitation, 0, len(dbInvitations))
+	for _, dbInvitation := range dbInvitations {
+		invitations = append(invitations, mapper.OrganizationInvitationDBToEntity(dbInvitation))
+	}
+	return invitations, nil
+}
+
+// DeleteInvitation deletes an invitation of an organization.
+func (r *organizationRepository) DeleteInvitation(ctx context.Context, orgID, invitationID int64) error {
+	rows, err := r.queries.DeleteOrganizationInvitation(ctx, &user.DeleteOrganizationInvitationParams{
+		ID:             invitationID,
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		return invitationErrors.Translate(err)
+	}
+	if rows == 0 {
+		return domainerrors.ErrInvitationNotFound
+	}
+	return nil
+}
+
+// AcceptInvitation consumes an invitation and adds the user as a member.
+// An invitation consumed concurrently is reported as not found.
+func (r *organizationRepository) AcceptInvitation(ctx context.Context, invitationID, userID int64) (*entity.OrganizationMember, error) {
+	dbMember, err := r.queries.AcceptOrganizationInvitation(ctx, &user.AcceptOrganizationInvitationParams{
+		ID:     invitationID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, invitationErrors.Translate(err)
+	}
+	return mapper.OrganizationMemberDBToEntity(dbMember, nil), nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+)
+
+func TestOrganizationKeepsAnOwner(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := newTestTenant(t, db)
+	pool := db.GetPool()
+	users := NewUserRepository(pool)
+	repo := NewOrganizationRepository(pool)
+
+	newUser := func(username string) *entity.User {
+		u, err := users.Create(ctx, &entity.User{
+			Username:     username,
+			Email:        username + "@example.com",
+			PhoneNumber:  "0900000000",
+			FirstName:    "Jane",
+			LastName:     "Doe",
+			HashPassword: "hash",
+		})
+		if err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+		return u
+	}
+	jane, john := newUser("jane"), newUser("john")
+
+	org, err := repo.Create(ctx, &entity.Organization{Slug: "acme", Name: "Acme", CreatedBy: jane.ID})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	t.Cleanup(func() { // before the tenant's users are deleted
+		if _, err := pool.Exec(ctx, "DELETE FROM organizations WHERE id = $1", org.ID); err != nil {
+			t.Errorf("cleanup: %v", err)
+		}
+	})
+	if _, err := pool.Exec(ctx, "INSERT INTO organization_members (organization_id, user_id, role) VALUES ($1, $2, 'owner')", org.ID, john.ID); err != nil {
+		t.Fatalf("add owner: %v", err)
+	}
+
+	// Each change alone leaves an owner, both together would not
+	start := make(chan struct{})
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		<-start
+		_, errs[0] = repo.UpdateMemberRole(ctx, org.ID, jane.ID, entity.OrgRoleAdmin)
+	})
+	wg.Go(func() {
+		<-start
+		errs[1] = repo.RemoveMember(ctx, org.ID, john.ID)
+	})
+	close(start)
+	wg.Wait()
+
+	var refused int
+	for _, err := range errs {
+		switch {
+		case errors.Is(err, domainerrors.ErrLastOrgOwner):
+			refused++
+		case err != nil:
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if refused != 1 {
+		t.Errorf("errors = %v, want exactly one ErrLastOrgOwner", errs)
+	}
+
+	members, err := repo.ListMembers(ctx, org.ID)
+	if err != nil {
+		t.Fatalf("ListMembers: %v", err)
+	}
+	var owners int
+	for _, m := range members {
+		if m.Role == entity.OrgRoleOwner {
+			owners++
+		}
+	}
+	if owners != 1 {
+		t.Errorf("owners = %d, want 1", owners)
+	}
+
+	if err := repo.RemoveMember(ctx, org.ID, john.ID+jane.ID); !errors.Is(err, domainerrors.ErrOrgMemberNotFound) {
+		t.Errorf("RemoveMember of a non-member err = %v, want %v", err, domainerrors.ErrOrgMemberNotFound)
+	}
+}
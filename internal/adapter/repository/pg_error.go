@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"errors"
+	"log/slog"
+
+	domainerrors "base-service/internal/domain/errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// =============================================================================
+// Postgres Error Translation
+// clean-arch: Keeps driver errors (and constraint names) out of the domain
+// =============================================================================
+
+// Postgres SQLSTATE codes translated by PgErrorTranslator.
+const (
+	pgUniqueViolation      = "23505"
+	pgForeignKeyViolation  = "23503"
+	pgCheckViolation       = "23514"
+	pgSerializationFailure = "40001"
+	pgQueryCanceled        = "57014"
+)
+
+// PgErrorTranslator maps pgx errors to domain errors. Each repository creates
+// one with its not-found error and the domain errors of its constraints.
+type PgErrorTranslator struct {
+	notFound    error
+	constraints map[string]error
+}
+
+// NewPgErrorTranslator creates a translator. constraints maps Postgres
+// constraint names to domain errors; unmapped constraints fall back to the
+// generic error for the SQLSTATE.
+func NewPgErrorTranslator(notFound error, constraints map[string]error) *PgErrorTranslator {
+	return &PgErrorTranslator{
+		notFound:    notFound,
+		constraints: constraints,
+	}
+}
+
+// Translate returns the domain error for err. The original error stays in the
+// chain for errors.As, but its message is not exposed.
+func (t *PgErrorTranslator) Translate(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return t.notFound
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	var domainErr error
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		domainErr = t.constraintError(pgErr, domainerrors.ErrConflict)
+	case pgForeignKeyViolation:
+		domainErr = t.constraintError(pgErr, domainerrors.ErrInvalidReference)
+	case pgCheckViolation:
+		domainErr = t.constraintError(pgErr, domainerrors.ErrConstraintViolation)
+	case pgSerializationFailure:
+		domainErr = domainerrors.ErrConcurrentModification
+	case pgQueryCanceled:
+		domainErr = domainerrors.ErrOperationCanceled
+	default:
+		return err
+	}
+
+	slog.Debug("Translated database error",
+		"sqlstate", pgErr.Code,
+		"constraint", pgErr.ConstraintName,
+		"table", pgErr.TableName,
+		"domain_error", domainErr,
+	)
+	return &translatedError{domain: domainErr, cause: err}
+}
+
+func (t *PgErrorTranslator) constraintError(pgErr *pgconn.PgError, fallback error) error {
+	if domainErr, ok := t.constraints[pgErr.ConstraintName]; ok {
+		return domainErr
+	}
+	return fallback
+}
+
+// translatedError reports the domain error's message and unwraps to both the
+// domain error and the driver error.
+type translatedError struct {
+	domain error
+	cause  error
+}
+
+func (e *translatedError) Error() string {
+	return e.domain.Error()
+}
+
+func (e *translatedError) Unwrap() []error {
+	return []error{e.domain, e.cause}
+}
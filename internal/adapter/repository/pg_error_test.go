@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	domainerrors "base-service/internal/domain/errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestPgErrorTranslator(t *testing.T) {
+	otherErr := errors.New("connection refused")
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "no error", err: nil, want: nil},
+		{name: "no rows", err: pgx.ErrNoRows, want: domainerrors.ErrUserNotFound},
+		{name: "wrapped no rows", err: fmt.Errorf("get user: %w", pgx.ErrNoRows), want: domainerrors.ErrUserNotFound},
+		{name: "mapped unique constraint", err: &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "users_email_key"}, want: domainerrors.ErrDuplicateEmail},
+		{name: "other mapped unique constraint", err: &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "users_username_key"}, want: domainerrors.ErrDuplicateUsername},
+		{name: "unmapped unique constraint", err: &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "users_phone_key"}, want: domainerrors.ErrConflict},
+		{name: "foreign key", err: &pgconn.PgError{Code: pgForeignKeyViolation, ConstraintName: "users_tier_id_fkey"}, want: domainerrors.ErrInvalidReference},
+		{name: "check", err: &pgconn.PgError{Code: pgCheckViolation, ConstraintName: "users_status_check"}, want: domainerrors.ErrConstraintViolation},
+		{name: "serialization failure", err: &pgconn.PgError{Code: pgSerializationFailure}, want: domainerrors.ErrConcurrentModification},
+		{name: "query canceled", err: &pgconn.PgError{Code: pgQueryCanceled}, want: domainerrors.ErrOperationCanceled},
+		{name: "unknown SQLSTATE", err: &pgconn.PgError{Code: "42P01"}, want: nil},
+		{name: "not a database error", err: otherErr, want: otherErr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := userErrors.Translate(tt.err)
+			if tt.err == nil {
+				if got != nil {
+					t.Errorf("Translate(nil) = %v", got)
+				}
+				return
+			}
+			if tt.want == nil {
+				// Untranslated errors are returned as they are
+				if got != tt.err {
+					t.Errorf("Translate() = %v, want %v", got, tt.err)
+				}
+				return
+			}
+			if !errors.Is(got, tt.want) {
+				t.Errorf("Translate() = %v, want %v", got, tt.want)
+			}
+
+			var pgErr *pgconn.PgError
+			if errors.As(tt.err, &pgErr) {
+				// The driver error stays in the chain, its message does not leak
+				if !errors.As(got, &pgErr) {
+					t.Errorf("Translate() lost the driver error")
+				}
+				if got.Error() != tt.want.Error() {
+					t.Errorf("message = %q, want %q", got.Error(), tt.want.Error())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"base-service/internal/domain/entity"
+	"base-service/internal/domain/repository"
+	"base-service/internal/infra"
+)
+
+const (
+	settingsCacheKey        = "settings:user:%d"
+	defaultSettingsCacheTTL = 10 * time.Minute
+)
+
+// cachedSettingsRepository caches the settings of a SettingsRepository in a
+// CacheStore: reads go through the cache and writes refresh it with the
+// stored result. Cache failures are logged and fall back to the database.
+type cachedSettingsRepository struct {
+	repo  repository.SettingsRepository
+	cache infra.CacheStore
+	ttl   time.Duration
+}
+
+// NewCachedSettingsRepository wraps repo with a read-through cache. Without a
+// cache, repo is returned as is.
+func NewCachedSettingsRepository(repo repository.SettingsRepository, cache infra.CacheStore, ttl time.Duration) repository.SettingsRepository {
+	if cache == nil {
+		return repo
+	}
+	if ttl <= 0 {
+		ttl = defaultSettingsCacheTTL
+	}
+	return &cachedSettingsRepository{
+		repo:  repo,
+		cache: cache,
+		ttl:   ttl,
+	}
+}
+
+// Find returns the cached settings, loading them on a miss.
+func (r *cachedSettingsRepository) Find(ctx context.Context, userID int64) (entity.Settings, error) {
+	key := fmt.Sprintf(settingsCacheKey, userID)
+	raw, err := r.cache.Get(ctx, key)
+	if err != nil {
+		slog.Error("Failed to read cached settings", "error", err, "user_id", userID)
+	}
+	if raw != nil {
+		settings := entity.Settings{}
+		if err := json.Unmarshal(raw, &settings); err == nil {
+			return settings, nil
+		}
+	}
+
+	settings, err := r.repo.Find(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	r.store(ctx, userID, settings)
+	return settings, nil
+}
+
+// Replace stores the settings and refreshes the cache.
+func (r *cachedSettingsRepository) Replace(ctx context.Context, userID int64, values entity.Settings) (entity.Settings, error) {
+	settings, err := r.repo.Replace(ctx, userID, values)
+	if err != nil {
+		return nil, err
+	}
+	r.store(ctx, userID, settings)
+	return settings, nil
+}
+
+// Merge changes the settings and refreshes the cache.
+func (r *cachedSettingsRepository) Merge(ctx context.Context, userID int64, values entity.Settings, reset []string) (entity.Settings, error) {
+	settings, err := r.repo.Merge(ctx, userID, values, reset)
+	if err != nil {
+		return nil, err
+	}
+	r.store(ctx, userID, settings)
+	return settings, nil
+}
+
+// store caches settings. On failure the stale entry is dropped so reads do
+// not serve it until it expires.
+func (r *cachedSettingsRepository) store(ctx context.Context, userID int64, settings entity.Settings) {
+	key := fmt.Sprintf(settingsCacheKey, userID)
+	raw, err := json.Marshal(settings)
+	if err == nil {
+		err = r.cache.Set(ctx, key, raw, r.ttl)
+	}
+	if err == nil {
+		return
+	}
+	slog.Error("Failed to cache settings", "error", err, "user_id", userID)
+	if err := r.cache.Delete(ctx, key); err != nil {
+		slog.Error("Failed to drop cached settings", "error", err, "user_id", userID)
+	}
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"base-service/internal/database/user"
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// settingsErrors translates user_settings table errors into domain errors.
+var settingsErrors = NewPgErrorTranslator(domainerrors.ErrUserNotFound, map[string]error{
+	"user_settings_user_id_fkey": domainerrors.ErrUserNotFound,
+})
+
+// settingsRepository implements the domain.SettingsRepository interface.
+type settingsRepository struct {
+	queries *user.Queries
+}
+
+// NewSettingsRepository creates a new settings repository adapter.
+func NewSettingsRepository(pool *pgxpool.Pool) repository.SettingsRepository {
+	return &settingsRepository{
+		queries: user.New(pool),
+	}
+}
+
+// Find returns the values set by the user, empty if none.
+func (r *settingsRepository) Find(ctx context.Context, userID int64) (entity.Settings, error) {
+	dbSettings, err := r.queries.GetUserSettings(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Settings{}, nil // nothing set yet
+	}
+	if err != nil {
+		return nil, settingsErrors.Translate(err)
+	}
+	return decodeSettings(dbSettings.Settings)
+}
+
+// Replace stores values as the user's settings.
+func (r *settingsRepository) Replace(ctx context.Context, userID int64, values entity.Settings) (entity.Settings, error) {
+	raw, err := encodeSettings(values)
+	if err != nil {
+		return nil, err
+	}
+	dbSettings, err := r.queries.ReplaceUserSettings(ctx, &user.ReplaceUserSettingsParams{
+		UserID:   userID,
+		Settings: raw,
+	})
+	if err != nil {
+		return nil, settingsErrors.Translate(err)
+	}
+	return decodeSettings(dbSettings.Settings)
+}
+
+// Merge sets values and removes the reset keys in one statement.
+func (r *settingsRepository) Merge(ctx context.Context, userID int64, values entity.Settings, reset []string) (entity.Settings, error) {
+	raw, err := encodeSettings(values)
+	if err != nil {
+		return nil, err
+	}
+	if reset == nil {
+		reset = []string{} // text[] must not be NULL, or the result is NULL
+	}
+	dbSettings, err := r.queries.MergeUserSettings(ctx, &user.MergeUserSettingsParams{
+		UserID:    userID,
+		SetValues: raw,
+		ResetKeys: reset,
+	})
+	if err != nil {
+		return nil, settingsErrors.Translate(err)
+	}
+	return decodeSettings(dbSettings.Settings)
+}
+
+func encodeSettings(values entity.Settings) ([]byte, error) {
+	if values == nil {
+		values = entity.Settings{}
+	}
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode settings: %w", err)
+	}
+	return raw, nil
+}
+
+func decodeSettings(raw []byte) (entity.Settings, error) {
+	settings := entity.Settings{}
+	if err := json.Unmarshal(raw, &settings); err != nil {
+		return nil, fmt.Errorf("failed to decode settings: %w", err)
+	}
+	return settings, nil
+}
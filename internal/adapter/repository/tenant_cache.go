@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"base-service/internal/domain/entity"
+	"base-service/internal/domain/repository"
+	"base-service/internal/infra"
+)
+
+const (
+	tenantIDCacheKey      = "tenant:id:%d"
+	tenantSlugCacheKey    = "tenant:slug:%s"
+	defaultTenantCacheTTL = 5 * time.Minute
+)
+
+// cachedTenantRepository caches the tenants of a TenantRepository in a
+// CacheStore, since every request looks its tenant up. Tenants are changed
+// in the database only, so entries are refreshed when they expire. Cache
+// failures are logged and fall back to the database.
+type cachedTenantRepository struct {
+	repo  repository.TenantRepository
+	cache infra.CacheStore
+	ttl   time.Duration
+}
+
+// NewCachedTenantRepository wraps repo with a read-through cache. Without a
+// cache, repo is returned as is.
+func NewCachedTenantRepository(repo repository.TenantRepository, cache infra.CacheStore, ttl time.Duration) repository.TenantRepository {
+	if cache == nil {
+		return repo
+	}
+	if ttl <= 0 {
+		ttl = defaultTenantCacheTTL
+	}
+	return &cachedTenantRepository{
+		repo:  repo,
+		cache: cache,
+		ttl:   ttl,
+	}
+}
+
+// FindByID returns the cached tenant, loading it on a miss.
+func (r *cachedTenantRepository) FindByID(ctx context.Context, id int64) (*entity.Tenant, error) {
+	return r.find(ctx, fmt.Sprintf(tenantIDCacheKey, id), func() (*entity.Tenant, error) {
+		return r.repo.FindByID(ctx, id)
+	})
+}
+
+// FindBySlug returns the cached tenant, loading it on a miss.
+func (r *cachedTenantRepository) FindBySlug(ctx context.Context, slug string) (*entity.Tenant, error) {
+	return r.find(ctx, fmt.Sprintf(tenantSlugCacheKey, slug), func() (*entity.Tenant, error) {
+		return r.repo.FindBySlug(ctx, slug)
+	})
+}
+
+// find reads key from the cache, calling load and caching its result on a miss.
+func (r *cachedTenantRepository) find(ctx context.Context, key string, load func() (*entity.Tenant, error)) (*entity.Tenant, error) {
+	raw, err := r.cache.Get(ctx, key)
+	if err != nil {
+		slog.Error("Failed to read cached tenant", "error", err, "key", key)
+	}
+	if raw != nil {
+		tenant := &entity.Tenant{}
+		if err := json.Unmarshal(raw, tenant); err == nil {
+			return tenant, nil
+		}
+	}
+
+	tenant, err := load()
+	if err != nil {
+		return nil, err
+	}
+	raw, err = json.Marshal(tenant)
+	if err == nil {
+		err = r.cache.Set(ctx, key, raw, r.ttl)
+	}
+	if err != nil {
+		slog.Error("Failed to cache tenant", "error", err, "key", key)
+	}
+	return tenant, nil
+}
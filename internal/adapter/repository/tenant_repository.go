@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+
+	"base-service/internal/adapter/repository/mapper"
+	"base-service/internal/database/user"
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	// tenantErrors translates tenants table errors into domain errors.
+	tenantErrors = NewPgErrorTranslator(domainerrors.ErrTenantNotFound, nil)
+)
+
+// tenantRepository implements the domain.TenantRepository interface.
+type tenantRepository struct {
+	queries *user.Queries
+}
+
+// NewTenantRepository creates a new tenant repository adapter.
+func NewTenantRepository(pool *pgxpool.Pool) repository.TenantRepository {
+	return &tenantRepository{
+		queries: user.New(pool),
+	}
+}
+
+// FindByID finds a tenant by ID.
+func (r *tenantRepository) FindByID(ctx context.Context, id int64) (*entity.Tenant, error) {
+	dbTenant, err := r.queries.GetTenant(ctx, id)
+	if err != nil {
+		return nil, tenantErrors.Translate(err)
+	}
+	return mapper.TenantDBToEntity(dbTenant), nil
+}
+
+// FindBySlug finds a tenant by its slug.
+func (r *tenantRepository) FindBySlug(ctx context.Context, slug string) (*entity.Tenant, error) {
+	dbTenant, err := r.queries.GetTenantBySlug(ctx, slug)
+	if err != nil {
+		return nil, tenantErrors.Translate(err)
+	}
+	return mapper.TenantDBToEntity(dbTenant), nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+
+	"base-service/internal/adapter/repository/mapper"
+	"base-service/internal/database/user"
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	// tierErrors translates tiers and user_tiers table errors into domain errors.
+	tierErrors = NewPgErrorTranslator(domainerrors.ErrTierNotFound, map[string]error{
+		"user_tiers_user_id_fkey": domainerrors.ErrUserNotFound,
+		"user_tiers_tier_id_fkey": domainerrors.ErrTierNotFound,
+		"user_tiers_valid_period": domainerrors.ErrInvalidTierPeriod,
+	})
+	// currentTierErrors reports users without a current tier.
+	currentTierErrors = NewPgErrorTranslator(domainerrors.ErrTierNotAssigned, nil)
+)
+
+// tierRepository implements the domain.TierRepository interface.
+type tierRepository struct {
+	queries *user.Queries
+}
+
+// NewTierRepository creates a new tier repository adapter.
+func NewTierRepository(pool *pgxpool.Pool) repository.TierRepository {
+	return &tierRepository{
+		queries: user.New(pool),
+	}
+}
+
+// List returns every tier.
+func (r *tierRepository) List(ctx context.Context) ([]*entity.Tier, error) {
+	dbTiers, err := r.queries.ListTiers(ctx)
+	if err != nil {
+		return nil, tierErrors.Translate(err)
+	}
+	tiers := make([]*entity.Tier, 0, len(dbTiers))
+	for _, dbTier := range dbTiers {
+		tiers = append(tiers, mapper.TierDBToEntity(dbTier))
+	}
+	return tiers, nil
+}
+
+// FindByCode finds a tier by its code.
+func (r *tierRepository) FindByCode(ctx context.Context, code string) (*entity.Tier, error) {
+	dbTier, err := r.queries.GetTierByCode(ctx, code)
+	if err != nil {
+		return nil, tierErrors.Translate(err)
+	}
+	return mapper.TierDBToEntity(dbTier), nil
+}
+
+// FindCurrent finds the user's current tier assignment.
+func (r *tierRepository) FindCurrent(ctx context.Context, userID int64) (*entity.UserTier, error) {
+	row, err := r.queries.GetCurrentUserTier(ctx, userID)
+	if err != nil {
+		return nil, currentTierErrors.Translate(err)
+	}
+	return mapper.UserTierDBToEntity(&row.UserTier, &row.Tier), nil
+}
+
+// ListAssignments returns every tier assignment of a user, newest first.
+func (r *tierRepository) ListAssignments(ctx context.Context, userID int64) ([]*entity.UserTier, error) {
+	rows, err := r.queries.ListUserTiers(ctx, userID)
+	if err != nil {
+		return nil, tierErrors.Translate(err)
+	}
+	assignments := make([]*entity.UserTier, 0, len(rows))
+	for _, row := range rows {
+		assignments = append(assignments, mapper.UserTierDBToEntity(&row.UserTier, &row.Tier))
+	}
+	return assignments, nil
+}
+
+// Assign records a tier assignment.
+func (r *tierRepository) Assign(ctx context.Context, assignment repository.TierAssignment) (*entity.UserTier, error) {
+	dbUserTier, err := r.queries.CreateUserTier(ctx, mapper.TierAssignmentToParams(assignment))
+	if err != nil {
+		return nil, tierErrors.Translate(err)
+	}
+	return mapper.UserTierDBToEntity(dbUserTier, nil), nil // the caller knows the tier
+}
+
+// EndCurrent ends the user's assignments valid now.
+func (r *tierRepository) EndCurrent(ctx context.Context, userID int64) (int64, error) {
+	rows, err := r.queries.EndUserTiers(ctx, userID)
+	if err != nil {
+		return 0, tierErrors.Translate(err)
+	}
+	return rows, nil
+}
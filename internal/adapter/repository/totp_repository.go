@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"base-service/internal/adapter/repository/mapper"
+	"base-service/internal/database/user"
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// totpErrors translates user_totp_secrets table errors into domain errors.
+var totpErrors = NewPgErrorTranslator(domainerrors.ErrTOTPNotEnabled, map[string]error{
+	"user_totp_secrets_user_id_fkey": domainerrors.ErrUserNotFound,
+})
+
+// totpRepository implements the domain.TOTPRepository interface.
+type totpRepository struct {
+	queries *user.Queries
+}
+
+// NewTOTPRepository creates a new TOTP repository adapter.
+func NewTOTPRepository(pool *pgxpool.Pool) repository.TOTPRepository {
+	return &totpRepository{
+		queries: user.New(pool),
+	}
+}
+
+// FindByUserID finds the TOTP enrollment of a user.
+func (r *totpRepository) FindByUserID(ctx context.Context, userID int64) (*entity.TOTPSecret, error) {
+	dbSecret, err := r.queries.GetUserTOTPSecret(ctx, userID)
+	if err != nil {
+		return nil, totpErrors.Translate(err)
+	}
+	return mapper.TOTPSecretDBToEntity(dbSecret), nil
+}
+
+// ConsumeStep records step as used, returning false on replay.
+func (r *totpRepository) ConsumeStep(ctx context.Context, userID int64, step int64) (bool, error) {
+	rows, err := r.queries.ConsumeUserTOTPStep(ctx, &user.ConsumeUserTOTPStepParams{
+		UserID:       userID,
+		LastUsedStep: step,
+	})
+	if err != nil {
+		return false, totpErrors.Translate(err)
+	}
+	return rows == 1, nil
+}
+
+// Delete removes the TOTP enrollment of a user, if any.
+func (r *totpRepository) Delete(ctx context.Context, userID int64) error {
+	if err := r.queries.DeleteUserTOTPSecret(ctx, userID); err != nil {
+		return totpErrors.Translate(err)
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"base-service/internal/adapter/repository/mapper"
+	"base-service/internal/database/user"
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+	"base-service/internal/infra"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Messages recorded for rows skipped because their username or email is
+// taken, or their username is reserved. rejectedTakenMessage is for rows
+// whose conflict was gone by the time it was looked up.
+const (
+	rejectedUsernameTakenMessage    = "username is already taken"
+	rejectedUsernameReservedMessage = "username is reserved"
+	rejectedEmailTakenMessage       = "email is already taken"
+	rejectedTakenMessage            = "username or email is already taken"
+)
+
+var (
+	// userImportConstraints maps user_imports and user_import_errors
+	// constraints to domain errors.
+	userImportConstraints = map[string]error{
+		"user_imports_format_check":         domainerrors.ErrUnsupportedImportFormat,
+		"user_imports_created_by_fkey":      domainerrors.ErrUserNotFound,
+		"user_import_errors_import_id_fkey": domainerrors.ErrImportNotFound,
+	}
+	// userImportErrors translates user import errors into domain errors.
+	userImportErrors = NewPgErrorTranslator(domainerrors.ErrImportNotFound, userImportConstraints)
+	// claimErrors reports imports that cannot be started.
+	claimErrors = NewPgErrorTranslator(domainerrors.ErrImportNotResumable, userImportConstraints)
+)
+
+// userImportRepository implements the domain.UserImportRepository interface.
+type userImportRepository struct {
+	db      *infra.DatabaseClient
+	queries *user.Queries
+}
+
+// NewUserImportRepository creates a new user import repository adapter.
+// Batches run in transactions of db, scoped to the tenant of their context.
+func NewUserImportRepository(db *infra.DatabaseClient) repository.UserImportRepository {
+	return &userImportRepository{
+		db:      db,
+		queries: user.New(db.GetPool()),
+	}
+}
+
+// Create creates a pending import.
+func (r *userImportRepository) Create(ctx context.Context, imp *entity.UserImport) (*entity.UserImport, error) {
+	dbImport, err := r.queries.CreateUserImport(ctx, mapper.UserImportToCreateParams(imp))
+	if err != nil {
+		return nil, userImportErrors.Translate(err)
+	}
+	return mapper.UserImportDBToEntity(dbImport), nil
+}
+
+// FindByID finds an import by ID.
+func (r *userImportRepository) FindByID(ctx context.Context, id int64) (*entity.UserImport, error) {
+	dbImport, err := r.queries.GetUserImport(ctx, id)
+	if err != nil {
+		return nil, userImportErrors.Translate(err)
+	}
+	return mapper.UserImportDBToEntity(dbImport), nil
+}
+
+// List returns the most recent imports, newest first.
+func (r *userImportRepository) List(ctx context.Context, limit int) ([]*entity.UserImport, error) {
+	dbImports, err := r.queries.ListUserImports(ctx, int32(limit))
+	if err != nil {
+		return nil, userImportErrors.Translate(err)
+	}
+	imports := make([]*entity.UserImport, 0, len(dbImports))
+	for _, dbImport := range dbImports {
+		imports = append(imports, mapper.UserImportDBToEntity(dbImport))
+	}
+	return imports, nil
+}
+
+// Claim marks an import running if no other caller is running it.
+func (r *userImportRepository) Claim(ctx context.Context, id int64, staleBefore time.Time) (*entity.UserImport, error) {
+	dbImport, err := r.queries.ClaimUserImport(ctx, &user.ClaimUserImportParams{
+		ID:          id,
+		StaleBefore: pgtype.Timestamptz{Time: staleBefore, Valid: true},
+	})
+	if err != nil {
+		return nil, claimErrors.Translate(err)
+	}
+	return mapper.UserImportDBToEntity(dbImport), nil
+}
+
+// ImportBatch stores a batch and advances the checkpoint atomically, so a
+// resumed import neither skips nor repeats rows.
+func (r *userImportRepository) ImportBatch(ctx context.Context, batch repository.ImportBatch) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, userImportErrors.Translate(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // no-op once committed
+
+	queries := r.queries.WithTx(tx)
+	rowErrors := batch.Errors
+	var imported int64
+	if len(batch.Users) > 0 {
+		inserted, err := r.insertUsers(ctx, tx, batch.Users, batch.DryRun)
+		if err != nil {
+			return 0, err
+		}
+		var skipped []repository.ImportedUser
+		for _, u := range batch.Users {
+			if _, ok := inserted[u.User.Username]; !ok {
+				skipped = append(skipped, u)
+			}
+		}
+		if len(skipped) > 0 {
+			conflicts, err := queries.ListImportConflicts(ctx, mapper.ImportedUsersToConflictParams(skipped))
+			if err != nil {
+				return 0, userImportErrors.Translate(err)
+			}
+			rowErrors = append(rowErrors, conflictErrors(skipped, conflicts)...)
+		}
+		imported = int64(len(inserted))
+	}
+
+	if len(rowErrors) > 0 {
+		if _, err := queries.CreateUserImportErrors(ctx, mapper.UserImportErrorsToParams(batch.ImportID, rowErrors)); err != nil {
+			return 0, userImportErrors.Translate(err)
+		}
+	}
+
+	advanced, err := queries.AdvanceUserImport(ctx, &user.AdvanceUserImportParams{
+		RowsProcessed: batch.ToRow,
+		RowsImported:  imported,
+		RowsFailed:    countRows(rowErrors),
+		ID:            batch.ImportID,
+		FromRow:       batch.FromRow,
+	})
+	if err != nil {
+		return 0, userImportErrors.Translate(err)
+	}
+	if advanced == 0 {
+		return 0, domainerrors.ErrImportNotResumable // taken over by another caller
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, userImportErrors.Translate(err)
+	}
+	return imported, nil
+}
+
+// insertUsers inserts users in a savepoint of tx and returns the usernames
+// inserted. In a dry run the savepoint is rolled back.
+func (r *userImportRepository) insertUsers(ctx context.Context, tx pgx.Tx, users []repository.ImportedUser, dryRun bool) (map[string]struct{}, error) {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return nil, userImportErrors.Translate(err)
+	}
+
+	usernames, err := r.queries.WithTx(savepoint).ImportUsers(ctx, mapper.ImportedUsersToParams(users))
+	if err != nil {
+		_ = savepoint.Rollback(ctx)
+		return nil, fmt.Errorf("insert users: %w", userErrors.Translate(err))
+	}
+
+	if dryRun {
+		err = savepoint.Rollback(ctx)
+	} else {
+		err = savepoint.Commit(ctx)
+	}
+	if err != nil {
+		return nil, userImportErrors.Translate(err)
+	}
+
+	inserted := make(map[string]struct{}, len(usernames))
+	for _, username := range usernames {
+		inserted[username] = struct{}{}
+	}
+	return inserted, nil
+}
+
+// conflictErrors returns why the skipped users were not inserted, one error
+// per conflicting column.
+func conflictErrors(skipped []repository.ImportedUser, conflicts []*user.ListImportConflictsRow) []*entity.UserImportError {
+	byUsername := make(map[string]*user.ListImportConflictsRow, len(conflicts))
+	for _, conflict := range conflicts {
+		byUsername[conflict.Username] = conflict
+	}
+
+	var rowErrors []*entity.UserImportError
+	for _, u := range skipped {
+		reject := func(field, message string) {
+			rowErrors = append(rowErrors, &entity.UserImportError{Row: u.Row, Field: field, Message: message})
+		}
+		conflict, ok := byUsername[u.User.Username]
+		switch {
+		case !ok:
+			reject("", rejectedTakenMessage)
+		case conflict.UsernameTaken:
+			reject("username", rejectedUsernameTakenMessage)
+		case conflict.UsernameReserved:
+			reject("username", rejectedUsernameReservedMessage)
+		case !conflict.EmailTaken:
+			reject("", rejectedTakenMessage)
+		}
+		if ok && conflict.EmailTaken {
+			reject("email", rejectedEmailTakenMessage)
+		}
+	}
+	return rowErrors
+}
+
+// countRows returns the number of distinct rows in rowErrors; a row may be
+// rejected for several fields.
+func countRows(rowErrors []*entity.UserImportError) int64 {
+	rows := make(map[int64]struct{}, len(rowErrors))
+	for _, rowError := range rowErrors {
+		rows[rowError.Row] = struct{}{}
+	}
+	return int64(len(rows))
+}
+
+// Finish marks a running import completed or failed.
+func (r *userImportRepository) Finish(ctx context.Context, id int64, status string, reason string) (*entity.UserImport, error) {
+	dbImport, err := r.queries.FinishUserImport(ctx, &user.FinishUserImportParams{
+		ID:     id,
+		Status: status,
+		Error:  pgtype.Text{String: reason, Valid: reason != ""},
+	})
+	if err != nil {
+		return nil, claimErrors.Translate(err)
+	}
+	return mapper.UserImportDBToEntity(dbImport), nil
+}
+
+// ListErrors returns a page of the rejected rows of an import.
+func (r *userImportRepository) ListErrors(ctx context.Context, importID int64, limit, offset int) ([]*entity.UserImportError, error) {
+	dbErrors, err := r.queries.ListUserImportErrors(ctx, &user.ListUserImportErrorsParams{
+		ImportID: importID,
+		Limit:    int32(limit),
+		Offset:   int32(offset),
+	})
+	if err != nil {
+		return nil, userImportErrors.Translate(err)
+	}
+	rowErrors := make([]*entity.UserImportError, 0, len(dbErrors))
+	for _, dbError := range dbErrors {
+		rowErrors = append(rowErrors, mapper.UserImportErrorDBToEntity(dbError))
+	}
+	return rowErrors, nil
+}
+
+// CountErrors returns the number of rejected rows of an import.
+func (r *userImportRepository) CountErrors(ctx context.Context, importID int64) (int64, error) {
+	count, err := r.queries.CountUserImportErrors(ctx, importID)
+	if err != nil {
+		return 0, userImportErrors.Translate(err)
+	}
+	return count, nil
+}
@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"base-service/config"
+	"base-service/internal/database/user"
+	"base-service/internal/domain/entity"
+	"base-service/internal/domain/repository"
+	"base-service/internal/infra"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// testDatabaseURLEnv names the database the Postgres tests run against. It
+// must be a scratch database and a role that is neither superuser nor
+// BYPASSRLS, since those skip row-level security. The migrations are applied
+// on first use.
+const testDatabaseURLEnv = "TEST_DATABASE_URL"
+
+// newTestDatabase connects to the test database with tenancy enabled, or
+// skips the test when none is configured.
+func newTestDatabase(t *testing.T) *infra.DatabaseClient {
+	t.Helper()
+	dsn := os.Getenv(testDatabaseURLEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDatabaseURLEnv)
+	}
+	conn, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		t.Fatalf("parse %s: %v", testDatabaseURLEnv, err)
+	}
+	sslMode := "disable"
+	if conn.TLSConfig != nil {
+		sslMode = "require"
+	}
+	db, err := infra.NewDatabaseClient(&config.DatabaseConfig{
+		Host:               conn.Host,
+		Port:               int(conn.Port),
+		UserName:           conn.User,
+		Password:           conn.Password,
+		DBName:             conn.Database,
+		SSLMode:            sslMode,
+		MaxOpenConnections: 4,
+	}, config.TenancyConfig{Enabled: true})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	ctx := context.Background()
+	pool := db.GetPool()
+	var bypassesRLS bool
+	if err := pool.QueryRow(ctx, "SELECT rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user").Scan(&bypassesRLS); err != nil {
+		t.Fatalf("check role: %v", err)
+	}
+	if bypassesRLS {
+		t.Skipf("the role of %s bypasses row-level security", testDatabaseURLEnv)
+	}
+
+	var exists bool
+	if err := pool.QueryRow(ctx, "SELECT to_regclass('user_import_errors') IS NOT NULL").Scan(&exists); err != nil {
+		t.Fatalf("check schema: %v", err)
+	}
+	if !exists {
+		migrations, err := filepath.Glob("../../database/migrations/*.up.sql")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, migration := range migrations { // Glob sorts them by version
+			sql, err := os.ReadFile(migration)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := pool.Exec(ctx, string(sql), pgx.QueryExecModeSimpleProtocol); err != nil {
+				t.Fatalf("apply %s: %v", filepath.Base(migration), err)
+			}
+		}
+	}
+	return db
+}
+
+// newTestTenant creates a tenant and returns a context scoped to it. Its
+// imports and users are deleted when the test ends.
+func newTestTenant(t *testing.T, db *infra.DatabaseClient) context.Context {
+	t.Helper()
+	ctx := context.Background()
+	slug := fmt.Sprintf("test-%d", time.Now().UnixNano())
+	var id int64
+	if err := db.GetPool().QueryRow(ctx, "INSERT INTO tenants (slug, name) VALUES ($1, $1) RETURNING id", slug).Scan(&id); err != nil {
+		t.Fatalf("create tenant: %v", err)
+	}
+	tenantCtx := entity.ContextWithTenant(ctx, &entity.Tenant{ID: id, Slug: slug})
+
+	t.Cleanup(func() {
+		tx, err := db.Begin(tenantCtx)
+		if err != nil {
+			t.Errorf("cleanup: %v", err)
+			return
+		}
+		defer func() { _ = tx.Rollback(ctx) }()
+		for _, stmt := range []string{"DELETE FROM user_imports", "DELETE FROM users"} {
+			if _, err := tx.Exec(ctx, stmt); err != nil {
+				t.Errorf("cleanup: %v", err)
+				return
+			}
+		}
+		if err := tx.Commit(ctx); err != nil {
+			t.Errorf("cleanup: %v", err)
+			return
+		}
+		if _, err := db.GetPool().Exec(ctx, "DELETE FROM tenants WHERE id = $1", id); err != nil {
+			t.Errorf("cleanup: %v", err)
+		}
+	})
+	return tenantCtx
+}
+
+func TestConflictErrors(t *testing.T) {
+	imported := func(row int64, username string) repository.ImportedUser {
+		return repository.ImportedUser{Row: row, User: &entity.User{Username: username, Email: username + "@example.com"}}
+	}
+
+	tests := []struct {
+		name      string
+		conflicts []*user.ListImportConflictsRow
+		want      []entity.UserImportError
+	}{
+		{
+			name:      "taken username",
+			conflicts: []*user.ListImportConflictsRow{{Username: "jane", UsernameTaken: true}},
+			want:      []entity.UserImportError{{Row: 7, Field: "username", Message: rejectedUsernameTakenMessage}},
+		},
+		{
+			name:      "reserved username",
+			conflicts: []*user.ListImportConflictsRow{{Username: "jane", UsernameReserved: true}},
+			want:      []entity.UserImportError{{Row: 7, Field: "username", Message: rejectedUsernameReservedMessage}},
+		},
+		{
+			name:      "taken email",
+			conflicts: []*user.ListImportConflictsRow{{Username: "jane", EmailTaken: true}},
+			want:      []entity.UserImportError{{Row: 7, Field: "email", Message: rejectedEmailTakenMessage}},
+		},
+		{
+			name:      "taken username and email",
+			conflicts: []*user.ListImportConflictsRow{{Username: "jane", UsernameTaken: true, UsernameReserved: true, EmailTaken: true}},
+			want: []entity.UserImportError{
+				{Row: 7, Field: "username", Message: rejectedUsernameTakenMessage},
+				{Row: 7, Field: "email", Message: rejectedEmailTakenMessage},
+			},
+		},
+		{
+			name:      "conflict gone",
+			conflicts: []*user.ListImportConflictsRow{{Username: "jane"}},
+			want:      []entity.UserImportError{{Row: 7, Message: rejectedTakenMessage}},
+		},
+		{
+			name: "conflict not found",
+			want: []entity.UserImportError{{Row: 7, Message: rejectedTakenMessage}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := conflictErrors([]repository.ImportedUser{imported(7, "jane")}, tt.conflicts)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d errors, want %d", len(got), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if *got[i] != want {
+					t.Errorf("error %d = %+v, want %+v", i, *got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestImportBatchRecordsErrors(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := newTestTenant(t, db)
+	otherCtx := newTestTenant(t, db)
+	repo := NewUserImportRepository(db)
+
+	imp, err := repo.Create(ctx, &entity.UserImport{Format: entity.ImportFormatCSV, Source: "imports/users.csv"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := repo.Claim(ctx, imp.ID, time.Now()); err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+
+	newUser := func(row int64, username string) repository.ImportedUser {
+		return repository.ImportedUser{Row: row, User: &entity.User{
+			Username:     username,
+			Email:        username + "@example.com",
+			PhoneNumber:  "0900000000",
+			FirstName:    "Jane",
+			LastName:     "Doe",
+			HashPassword: "hash",
+		}}
+	}
+
+	imported, err := repo.ImportBatch(ctx, repository.ImportBatch{
+		ImportID: imp.ID,
+		FromRow:  0,
+		ToRow:    3,
+		Users:    []repository.ImportedUser{newUser(1, "jane"), newUser(2, "jane")},
+		Errors:   []*entity.UserImportError{{Row: 3, Message: "invalid row"}},
+	})
+	if err != nil {
+		t.Fatalf("ImportBatch: %v", err)
+	}
+	if imported != 1 {
+		t.Errorf("imported = %d, want 1", imported)
+	}
+
+	got, err := repo.ListErrors(ctx, imp.ID, 10, 0)
+	if err != nil {
+		t.Fatalf("ListErrors: %v", err)
+	}
+	want := []entity.UserImportError{
+		{ImportID: imp.ID, Row: 2, Field: "username", Message: rejectedUsernameTakenMessage},
+		{ImportID: imp.ID, Row: 2, Field: "email", Message: rejectedEmailTakenMessage},
+		{ImportID: imp.ID, Row: 3, Message: "invalid row"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("errors = %d, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if *got[i] != want[i] {
+			t.Errorf("errors[%d] = %+v, want %+v", i, *got[i], want[i])
+		}
+	}
+
+	failed, err := repo.FindByID(ctx, imp.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if failed.RowsProcessed != 3 || failed.RowsImported != 1 || failed.RowsFailed != 2 {
+		t.Errorf("processed, imported, failed = %d, %d, %d, want 3, 1, 2", failed.RowsProcessed, failed.RowsImported, failed.RowsFailed)
+	}
+
+	// Row-level security hides the errors from other tenants
+	hidden, err := repo.ListErrors(otherCtx, imp.ID, 10, 0)
+	if err != nil {
+		t.Fatalf("ListErrors of another tenant: %v", err)
+	}
+	if len(hidden) != 0 {
+		t.Errorf("another tenant sees %d errors", len(hidden))
+	}
+}
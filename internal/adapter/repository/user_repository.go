@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"base-service/internal/adapter/repository/mapper"
 	"base-service/internal/database/user"
@@ -9,9 +11,17 @@ import (
 	domainerrors "base-service/internal/domain/errors"
 	"base-service/internal/domain/repository"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// userErrors translates users table errors into domain errors.
+var userErrors = NewPgErrorTranslator(domainerrors.ErrUserNotFound, map[string]error{
+	"users_email_key":    domainerrors.ErrDuplicateEmail,
+	"users_username_key": domainerrors.ErrDuplicateUsername,
+})
+
 // userRepository implements the domain.UserRepository interface.
 type userRepository struct {
 	pool    *pgxpool.Pool
@@ -31,54 +41,285 @@ func (r *userRepository) Create(ctx context.Context, u *entity.User) (*entity.Us
 	params := mapper.UserEntityToCreateParams(u)
 	dbUser, err := r.queries.CreateUser(ctx, params)
 	if err != nil {
-		// TODO: Check for unique constraint violations and return appropriate domain errors
-		return nil, err
+		return nil, userErrors.Translate(err)
 	}
 	return mapper.UserDBToEntity(dbUser), nil
 }
 
 // FindByID finds a user by their ID.
 func (r *userRepository) FindByID(ctx context.Context, id int64) (*entity.User, error) {
-	// Note: This would require adding a GetUserByID query to sqlc
-	// For now, we'll return ErrUserNotFound as a placeholder
-	return nil, domainerrors.ErrUserNotFound
+	dbUser, err := r.queries.GetUser(ctx, id)
+	if err != nil {
+		return nil, userErrors.Translate(err)
+	}
+	return mapper.UserDBToEntity(dbUser), nil
+}
+
+// FindByIDWithDeleted finds a user by their ID, including soft-deleted users.
+func (r *userRepository) FindByIDWithDeleted(ctx context.Context, id int64) (*entity.User, error) {
+	dbUser, err := r.queries.GetUserWithDeleted(ctx, id)
+	if err != nil {
+		return nil, userErrors.Translate(err)
+	}
+	return mapper.UserDBToEntity(dbUser), nil
+}
+
+// List returns one page of the users matching filter and the total number of matches.
+// One extra row is fetched to tell whether a next page exists.
+func (r *userRepository) List(ctx context.Context, filter repository.UserFilter) (*repository.UserPage, error) {
+	params, countParams := mapper.UserFilterToParams(filter)
+	keyset := userKeyset(params.SortBy, params.SortDesc)
+	if filter.Cursor != "" {
+		if err := seekUsers(params, keyset, filter.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	total, err := r.queries.CountFilteredUsers(ctx, countParams)
+	if err != nil {
+		return nil, userErrors.Translate(err)
+	}
+	if total == 0 {
+		return &repository.UserPage{Users: []*entity.User{}}, nil
+	}
+
+	params.LimitCount++
+	dbUsers, err := r.queries.FilterUsers(ctx, params)
+	if err != nil {
+		return nil, userErrors.Translate(err)
+	}
+
+	page := &repository.UserPage{Total: total}
+	hasMore := len(dbUsers) > filter.Limit
+	if hasMore {
+		dbUsers = dbUsers[:filter.Limit]
+	}
+	page.Users = make([]*entity.User, 0, len(dbUsers))
+	for _, dbUser := range dbUsers {
+		page.Users = append(page.Users, mapper.UserDBToEntity(dbUser))
+	}
+
+	if hasMore && len(page.Users) > 0 {
+		last := page.Users[len(page.Users)-1]
+		page.NextCursor, err = keyset.Encode(userSeekValues(last, params.SortBy)...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// Search returns one page of the users matching search, best matches first.
+func (r *userRepository) Search(ctx context.Context, search repository.UserSearch) ([]*repository.UserSearchHit, int64, error) {
+	params, countParams := mapper.UserSearchToParams(search)
+
+	total, err := r.queries.CountSearchUsers(ctx, countParams)
+	if err != nil {
+		return nil, 0, userErrors.Translate(err)
+	}
+	if total == 0 {
+		return []*repository.UserSearchHit{}, 0, nil
+	}
+
+	rows, err := r.queries.SearchUsers(ctx, params)
+	if err != nil {
+		return nil, 0, userErrors.Translate(err)
+	}
+
+	hits := make([]*repository.UserSearchHit, 0, len(rows))
+	for _, row := range rows {
+		hits = append(hits, mapper.SearchUserDBToHit(row))
+	}
+	return hits, total, nil
+}
+
+// userKeyset returns the keyset matching the ORDER BY of FilterUsers.
+func userKeyset(sortBy string, desc bool) Keyset {
+	id := KeysetColumn{Name: repository.UserSortID, Kind: KeyInt}
+	switch sortBy {
+	case repository.UserSortCreatedAt, repository.UserSortUpdatedAt:
+		return Keyset{Columns: []KeysetColumn{{Name: sortBy, Kind: KeyTime}, id}, Desc: desc}
+	case repository.UserSortUsername, repository.UserSortEmail:
+		return Keyset{Columns: []KeysetColumn{{Name: sortBy, Kind: KeyText}, id}, Desc: desc}
+	default:
+		return Keyset{Columns: []KeysetColumn{id}, Desc: desc}
+	}
+}
+
+// seekUsers positions params after the row of cursor. Offsets are ignored
+// when seeking.
+func seekUsers(params *user.FilterUsersParams, keyset Keyset, cursor string) error {
+	values, err := keyset.Decode(cursor)
+	if err != nil {
+		return err
+	}
+
+	params.AfterID = pgtype.Int8{Int64: values[len(values)-1].(int64), Valid: true}
+	if len(values) > 1 {
+		switch v := values[0].(type) {
+		case time.Time:
+			params.AfterTime = pgtype.Timestamptz{Time: v, Valid: true}
+		case string:
+			params.AfterText = pgtype.Text{String: v, Valid: true}
+		}
+	}
+	params.OffsetCount = 0
+	return nil
+}
+
+// userSeekValues returns the keyset values of u for sortBy, in column order.
+func userSeekValues(u *entity.User, sortBy string) []any {
+	switch sortBy {
+	case repository.UserSortCreatedAt:
+		return []any{u.CreatedAt, u.ID}
+	case repository.UserSortUpdatedAt:
+		return []any{u.UpdatedAt, u.ID}
+	case repository.UserSortUsername:
+		return []any{u.Username, u.ID}
+	case repository.UserSortEmail:
+		return []any{u.Email, u.ID}
+	default:
+		return []any{u.ID}
+	}
 }
 
 // FindByUsername finds a user by their username.
 func (r *userRepository) FindByUsername(ctx context.Context, username string) (*entity.User, error) {
 	dbUser, err := r.queries.GetUserByUserName(ctx, username)
 	if err != nil {
-		return nil, domainerrors.ErrUserNotFound
+		return nil, userErrors.Translate(err)
 	}
 	return mapper.UserDBToEntity(dbUser), nil
 }
 
 // FindByEmail finds a user by their email.
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
-	// Note: This would require adding a GetUserByEmail query to sqlc
-	// For now, we'll return ErrUserNotFound as a placeholder
-	return nil, domainerrors.ErrUserNotFound
+	dbUser, err := r.queries.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, userErrors.Translate(err)
+	}
+	return mapper.UserDBToEntity(dbUser), nil
 }
 
 // FindByUsernameOrEmail finds a user by username or email.
 func (r *userRepository) FindByUsernameOrEmail(ctx context.Context, usernameOrEmail string) (*entity.User, error) {
 	dbUser, err := r.queries.GetUserByUsernameOrEmail(ctx, usernameOrEmail)
 	if err != nil {
-		return nil, domainerrors.ErrUserNotFound
+		return nil, userErrors.Translate(err)
+	}
+	return mapper.UserDBToEntity(dbUser), nil
+}
+
+// FindByUsernameOrEmailWithDeleted finds a user by username or email,
+// including soft-deleted users.
+func (r *userRepository) FindByUsernameOrEmailWithDeleted(ctx context.Context, usernameOrEmail string) (*entity.User, error) {
+	dbUser, err := r.queries.GetUserByUsernameOrEmailWithDeleted(ctx, usernameOrEmail)
+	if err != nil {
+		return nil, userErrors.Translate(err)
 	}
 	return mapper.UserDBToEntity(dbUser), nil
 }
 
-// Update updates an existing user.
+// Update applies the non-empty fields of u and refreshes updated_at.
 func (r *userRepository) Update(ctx context.Context, u *entity.User) (*entity.User, error) {
-	// Note: This would require adding an UpdateUser query to sqlc
-	// For now, we'll return ErrUserNotFound as a placeholder
-	return nil, domainerrors.ErrUserNotFound
+	params := mapper.UserEntityToUpdateParams(u)
+	dbUser, err := r.queries.UpdateUser(ctx, params)
+	if err != nil {
+		return nil, userErrors.Translate(err)
+	}
+	return mapper.UserDBToEntity(dbUser), nil
+}
+
+// PatchProfile applies a profile patch. When the patch expects a version and
+// matches no row, a still existing user means the version changed.
+func (r *userRepository) PatchProfile(ctx context.Context, patch repository.ProfilePatch) (*entity.User, error) {
+	dbUser, err := r.queries.PatchUserProfile(ctx, mapper.ProfilePatchToParams(patch))
+	if errors.Is(err, pgx.ErrNoRows) && patch.ExpectedVersion > 0 {
+		if _, findErr := r.queries.GetUser(ctx, patch.UserID); findErr == nil {
+			return nil, domainerrors.ErrVersionMismatch
+		}
+	}
+	if err != nil {
+		return nil, userErrors.Translate(err)
+	}
+	return mapper.UserDBToEntity(dbUser), nil
+}
+
+// ChangeStatus updates the status of a user still in change.From. When no row
+// matches, a still existing user means the status changed concurrently.
+func (r *userRepository) ChangeStatus(ctx context.Context, change repository.StatusChange) (*entity.User, error) {
+	dbUser, err := r.queries.UpdateUserStatus(ctx, mapper.StatusChangeToParams(change))
+	if errors.Is(err, pgx.ErrNoRows) {
+		if _, findErr := r.queries.GetUser(ctx, change.UserID); findErr == nil {
+			return nil, domainerrors.ErrInvalidStatusTransition
+		}
+	}
+	if err != nil {
+		return nil, userErrors.Translate(err)
+	}
+	return mapper.UserDBToEntity(dbUser), nil
 }
 
-// Delete soft-deletes a user by their ID.
-func (r *userRepository) Delete(ctx context.Context, id int64) error {
-	// Note: This would require adding a DeleteUser (soft delete) query to sqlc
-	// For now, we'll return ErrUserNotFound as a placeholder
-	return domainerrors.ErrUserNotFound
+// Delete soft-deletes a user by setting deleted_at and schedules its purge.
+func (r *userRepository) Delete(ctx context.Context, deletion repository.UserDeletion) error {
+	rows, err := r.queries.SoftDeleteUser(ctx, mapper.UserDeletionToParams(deletion))
+	if err != nil {
+		return userErrors.Translate(err)
+	}
+	if rows == 0 {
+		return domainerrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// Restore clears deleted_at and the purge schedule of a soft-deleted user.
+func (r *userRepository) Restore(ctx context.Context, id int64) error {
+	rows, err := r.queries.RestoreUser(ctx, id)
+	if err != nil {
+		return userErrors.Translate(err)
+	}
+	if rows == 0 {
+		return domainerrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// ListDueForPurge returns the deleted users whose purge time has passed.
+func (r *userRepository) ListDueForPurge(ctx context.Context, limit int) ([]repository.DueAccount, error) {
+	rows, err := r.queries.ListUsersDueForPurge(ctx, int32(limit))
+	if err != nil {
+		return nil, userErrors.Translate(err)
+	}
+	accounts := make([]repository.DueAccount, 0, len(rows))
+	for _, row := range rows {
+		accounts = append(accounts, repository.DueAccount{UserID: row.ID, TenantID: row.TenantID})
+	}
+	return accounts, nil
+}
+
+// DeferPurge moves the purge time of a user due for purge to until.
+func (r *userRepository) DeferPurge(ctx context.Context, id int64, until time.Time) error {
+	rows, err := r.queries.DeferUserPurge(ctx, &user.DeferUserPurgeParams{
+		ID:      id,
+		PurgeAt: pgtype.Timestamptz{Time: until, Valid: true},
+	})
+	if err != nil {
+		return userErrors.Translate(err)
+	}
+	if rows == 0 {
+		return domainerrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// Purge deletes the row of a user due for purge; dependent rows cascade.
+func (r *userRepository) Purge(ctx context.Context, id int64) error {
+	rows, err := r.queries.PurgeUser(ctx, id)
+	if err != nil {
+		return userErrors.Translate(err)
+	}
+	if rows == 0 {
+		return domainerrors.ErrUserNotFound
+	}
+	return nil
 }
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+)
+
+func TestUserRepositoryLifecycle(t *testing.T) {
+	db := newTestDatabase(t)
+	ctx := newTestTenant(t, db)
+	repo := NewUserRepository(db.GetPool())
+
+	newUser := func(username string) *entity.User {
+		u, err := repo.Create(ctx, &entity.User{
+			Username:     username,
+			Email:        username + "@example.com",
+			PhoneNumber:  "0900000000",
+			FirstName:    "Jane",
+			LastName:     "Doe",
+			HashPassword: "hash",
+		})
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		return u
+	}
+	jane, john := newUser("jane"), newUser("john")
+
+	found, err := repo.FindByID(ctx, jane.ID)
+	if err != nil || found.Username != "jane" {
+		t.Fatalf("FindByID = %+v, %v", found, err)
+	}
+	found, err = repo.FindByEmail(ctx, "jane@example.com")
+	if err != nil || found.ID != jane.ID {
+		t.Fatalf("FindByEmail = %+v, %v", found, err)
+	}
+	if _, err := repo.FindByEmail(ctx, "nobody@example.com"); !errors.Is(err, domainerrors.ErrUserNotFound) {
+		t.Errorf("FindByEmail of an unknown email err = %v, want %v", err, domainerrors.ErrUserNotFound)
+	}
+
+	// Update only applies the fields that are set
+	updated, err := repo.Update(ctx, &entity.User{ID: jane.ID, FirstName: "Janet"})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated.FirstName != "Janet" || updated.LastName != "Doe" || updated.Email != "jane@example.com" || updated.HashPassword != "hash" {
+		t.Errorf("Update = %+v, want only the first name changed", updated)
+	}
+	if updated.Version != jane.Version+1 {
+		t.Errorf("version = %d, want %d", updated.Version, jane.Version+1)
+	}
+	if _, err := repo.Update(ctx, &entity.User{ID: jane.ID, Email: john.Email}); !errors.Is(err, domainerrors.ErrDuplicateEmail) {
+		t.Errorf("Update to a taken email err = %v, want %v", err, domainerrors.ErrDuplicateEmail)
+	}
+
+	// Soft-deleted users are kept but no longer found
+	if err := repo.Delete(ctx, repository.UserDeletion{UserID: jane.ID}); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, jane.ID); !errors.Is(err, domainerrors.ErrUserNotFound) {
+		t.Errorf("FindByID after Delete err = %v, want %v", err, domainerrors.ErrUserNotFound)
+	}
+	if _, err := repo.FindByEmail(ctx, "jane@example.com"); !errors.Is(err, domainerrors.ErrUserNotFound) {
+		t.Errorf("FindByEmail after Delete err = %v, want %v", err, domainerrors.ErrUserNotFound)
+	}
+	if _, err := repo.Update(ctx, &entity.User{ID: jane.ID, FirstName: "Jan"}); !errors.Is(err, domainerrors.ErrUserNotFound) {
+		t.Errorf("Update after Delete err = %v, want %v", err, domainerrors.ErrUserNotFound)
+	}
+	deleted, err := repo.FindByIDWithDeleted(ctx, jane.ID)
+	if err != nil || !deleted.IsDeleted() {
+		t.Errorf("FindByIDWithDeleted = %+v, %v; want the deleted user", deleted, err)
+	}
+	if err := repo.Delete(ctx, repository.UserDeletion{UserID: jane.ID}); !errors.Is(err, domainerrors.ErrUserNotFound) {
+		t.Errorf("second Delete err = %v, want %v", err, domainerrors.ErrUserNotFound)
+	}
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"base-service/internal/adapter/repository/mapper"
+	"base-service/internal/database/user"
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// usernameHistoryErrors translates username_history table errors into domain errors.
+var usernameHistoryErrors = NewPgErrorTranslator(domainerrors.ErrUserNotFound, map[string]error{
+	"username_history_user_id_fkey": domainerrors.ErrUserNotFound,
+})
+
+// usernameRepository implements the domain.UsernameRepository interface.
+type usernameRepository struct {
+	queries *user.Queries
+}
+
+// NewUsernameRepository creates a new username repository adapter.
+func NewUsernameRepository(pool *pgxpool.Pool) repository.UsernameRepository {
+	return &usernameRepository{
+		queries: user.New(pool),
+	}
+}
+
+// Rename changes a user's username and records the old one.
+func (r *usernameRepository) Rename(ctx context.Context, rename repository.UsernameRename) (*entity.User, error) {
+	dbUser, err := r.queries.ChangeUsername(ctx, mapper.UsernameRenameToParams(rename))
+	if err != nil {
+		return nil, userErrors.Translate(err)
+	}
+	return mapper.UserDBToEntity(dbUser), nil
+}
+
+// FindLatest returns the user's most recent username change, nil if none.
+func (r *usernameRepository) FindLatest(ctx context.Context, userID int64) (*entity.UsernameChange, error) {
+	dbChange, err := r.queries.GetLatestUsernameChange(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil // never changed
+	}
+	if err != nil {
+		return nil, usernameHistoryErrors.Translate(err)
+	}
+	return mapper.UsernameChangeDBToEntity(dbChange), nil
+}
+
+// FindReservation returns the change that still reserves username, nil if none.
+func (r *usernameRepository) FindReservation(ctx context.Context, username string) (*entity.UsernameChange, error) {
+	dbChange, err := r.queries.GetUsernameReservation(ctx, username)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil // not reserved
+	}
+	if err != nil {
+		return nil, usernameHistoryErrors.Translate(err)
+	}
+	return mapper.UsernameChangeDBToEntity(dbChange), nil
+}
+
+// ListByUser returns the user's username changes, newest first.
+func (r *usernameRepository) ListByUser(ctx context.Context, userID int64) ([]*entity.UsernameChange, error) {
+	dbChanges, err := r.queries.ListUsernameHistory(ctx, userID)
+	if err != nil {
+		return nil, usernameHistoryErrors.Translate(err)
+	}
+	changes := make([]*entity.UsernameChange, 0, len(dbChanges))
+	for _, dbChange := range dbChanges {
+		changes = append(changes, mapper.UsernameChangeDBToEntity(dbChange))
+	}
+	return changes, nil
+}
@@ -39,10 +39,11 @@ type Pagination struct {
 }
 
 type Response struct {
-	Code       string      `json:"code,omitempty"`
-	Msg        string      `json:"msg,omitempty"`
-	Data       any         `json:"data,omitempty"`
-	Pagination *Pagination `json:"pagination,omitempty"`
+	Code       string            `json:"code,omitempty"`
+	Msg        string            `json:"msg,omitempty"`
+	Data       any               `json:"data,omitempty"`
+	Pagination *Pagination       `json:"pagination,omitempty"`
+	Cursor     *CursorPagination `json:"cursor,omitempty"`
 }
 
 func GetLanguage(c *fiber.Ctx) string {
@@ -71,13 +72,33 @@ func ApiResponse(data any, pagination *Pagination, err error) Response {
 	return resp
 }
 
+// ResponseApi writes data in the Response envelope, or err as problem+json
+// with the status registered for it.
 func ResponseApi(c *fiber.Ctx, data any, err error) error {
-	apiResponse := ApiResponse(data, nil, err)
+	if err != nil {
+		return ResponseProblem(c, err)
+	}
+	apiResponse := ApiResponse(data, nil, nil)
 	return c.Status(fiber.StatusOK).JSON(apiResponse)
 }
 
+// ResponseApiPagination is ResponseApi with pagination metadata.
 func ResponseApiPagination(c *fiber.Ctx, data any, pagin *Pagination, err error) error {
-	apiResponse := ApiResponse(data, pagin, err)
+	if err != nil {
+		return ResponseProblem(c, err)
+	}
+	apiResponse := ApiResponse(data, pagin, nil)
+	return c.Status(fiber.StatusOK).JSON(apiResponse)
+}
+
+// ResponseApiCursor is ResponseApi with keyset pagination metadata; pagination
+// may additionally carry totals.
+func ResponseApiCursor(c *fiber.Ctx, data any, pagin *Pagination, cursor *CursorPagination, err error) error {
+	if err != nil {
+		return ResponseProblem(c, err)
+	}
+	apiResponse := ApiResponse(data, pagin, nil)
+	apiResponse.Cursor = cursor
 	return c.Status(fiber.StatusOK).JSON(apiResponse)
 }
 
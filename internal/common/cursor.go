@@ -0,0 +1,130 @@
+package common
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// =============================================================================
+// Keyset Cursors
+// clean-arch: Opaque, signed positions in keyset-ordered lists
+// =============================================================================
+
+// InvalidCursorCode is the error catalog code of ErrInvalidCursor.
+const InvalidCursorCode = "REQUEST_INVALID_CURSOR"
+
+// ErrInvalidCursor is returned for cursors that were tampered with, expired
+// with a key rotation, or were issued for another sort order.
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// Cursor is the position after the last row of a page: the values of the
+// ordering columns of that row, in order. Sort identifies the ordering the
+// cursor was issued for so it cannot be replayed against another one.
+type Cursor struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+}
+
+// CursorPagination is the pagination block of keyset-paginated responses.
+type CursorPagination struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+	Limit      int    `json:"limit"`
+}
+
+var (
+	cursorKeyMu sync.RWMutex
+	cursorKey   []byte
+)
+
+// SetCursorSecret sets the HMAC key cursors are signed with. All instances
+// must share it; an empty secret falls back to a random per-process key.
+func SetCursorSecret(secret []byte) {
+	cursorKeyMu.Lock()
+	defer cursorKeyMu.Unlock()
+	if len(secret) == 0 {
+		slog.Warn("No cursor secret configured, pagination cursors are only valid on this instance until restart")
+		secret = randomCursorKey()
+	}
+	cursorKey = secret
+}
+
+// EncodeCursor returns the opaque form of c: base64url(payload).base64url(hmac).
+func EncodeCursor(c Cursor) (string, error) {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(signCursor(payload)), nil
+}
+
+// DecodeCursor verifies and decodes an opaque cursor issued for sort.
+func DecodeCursor(token, sort string) (Cursor, error) {
+	encodedPayload, encodedMAC, ok := strings.Cut(token, ".")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil || !hmac.Equal(mac, signCursor(payload)) {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(payload, &c); err != nil || c.Sort != sort {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+func signCursor(payload []byte) []byte {
+	mac := hmac.New(sha256.New, currentCursorKey())
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// currentCursorKey returns the signing key, generating one if none was set.
+func currentCursorKey() []byte {
+	cursorKeyMu.RLock()
+	key := cursorKey
+	cursorKeyMu.RUnlock()
+	if key != nil {
+		return key
+	}
+
+	cursorKeyMu.Lock()
+	defer cursorKeyMu.Unlock()
+	if cursorKey == nil {
+		cursorKey = randomCursorKey()
+	}
+	return cursorKey
+}
+
+func randomCursorKey() []byte {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+}
+
+func init() {
+	RegisterError(ErrInvalidCursor, ErrorDefinition{
+		Code:   InvalidCursorCode,
+		Status: http.StatusBadRequest,
+		Messages: Messages{
+			"en": "The pagination cursor is invalid, restart from the first page",
+			"vi": "Con trỏ phân trang không hợp lệ, vui lòng bắt đầu lại từ trang đầu",
+		},
+	})
+}
@@ -0,0 +1,68 @@
+package common
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	SetCursorSecret([]byte("cursor-secret"))
+	issued := Cursor{Sort: "created_at:desc", Values: []string{"2024-05-01T10:00:00Z", "42"}}
+	token, err := EncodeCursor(issued)
+	if err != nil {
+		t.Fatalf("EncodeCursor: %v", err)
+	}
+	payload, mac, _ := strings.Cut(token, ".")
+
+	// forged carries another position, signed with another key
+	SetCursorSecret([]byte("other-secret"))
+	forged, err := EncodeCursor(Cursor{Sort: issued.Sort, Values: []string{"2024-05-01T10:00:00Z", "1"}})
+	if err != nil {
+		t.Fatalf("EncodeCursor: %v", err)
+	}
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+	SetCursorSecret([]byte("cursor-secret"))
+
+	tests := []struct {
+		name    string
+		token   string
+		sort    string
+		wantErr bool
+	}{
+		{name: "issued cursor", token: token, sort: issued.Sort},
+		{name: "other sort order", token: token, sort: "username:asc", wantErr: true},
+		{name: "signed with another key", token: forged, sort: issued.Sort, wantErr: true},
+		{name: "payload swapped", token: forgedPayload + "." + mac, sort: issued.Sort, wantErr: true},
+		{name: "signature truncated", token: payload + "." + mac[:len(mac)-2], sort: issued.Sort, wantErr: true},
+		{name: "unsigned", token: payload, sort: issued.Sort, wantErr: true},
+		{name: "not base64", token: "!!!." + mac, sort: issued.Sort, wantErr: true},
+		{name: "empty", token: "", sort: issued.Sort, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCursor(tt.token, tt.sort)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCursor) {
+					t.Errorf("err = %v, want %v", err, ErrInvalidCursor)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodeCursor: %v", err)
+			}
+			if got.Sort != issued.Sort || !slices.Equal(got.Values, issued.Values) {
+				t.Errorf("cursor = %+v, want %+v", got, issued)
+			}
+		})
+	}
+
+	t.Run("key rotation", func(t *testing.T) {
+		SetCursorSecret([]byte("rotated-secret"))
+		defer SetCursorSecret([]byte("cursor-secret"))
+		if _, err := DecodeCursor(token, issued.Sort); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("err = %v, want %v", err, ErrInvalidCursor)
+		}
+	})
+}
@@ -0,0 +1,211 @@
+package common
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// =============================================================================
+// Error Catalog
+// clean-arch: Stable error codes and localized messages shared with clients
+// =============================================================================
+
+// Codes of errors that are not tied to a registered sentinel error.
+const (
+	InternalErrorCode        = "INTERNAL_ERROR"
+	ValidationFailedCode     = "VALIDATION_FAILED"
+	RequestInvalidCode       = "REQUEST_INVALID"
+	ResourceNotFoundCode     = "NOT_FOUND"
+	MethodNotAllowedCode     = "METHOD_NOT_ALLOWED"
+	RequestTooLargeCode      = "REQUEST_TOO_LARGE"
+	RequestUnprocessableCode = "REQUEST_UNPROCESSABLE"
+	ServiceUnavailableCode   = "SERVICE_UNAVAILABLE"
+)
+
+// Messages maps a language (e.g. "en", "vi") to a message.
+type Messages map[string]string
+
+// ErrorDefinition describes a cataloged error.
+type ErrorDefinition struct {
+	Code     string   `json:"code"`
+	Status   int      `json:"status"`
+	Messages Messages `json:"messages"`
+}
+
+// Message returns the message for lang, falling back to the default language.
+func (d ErrorDefinition) Message(lang string) string {
+	if msg, ok := d.Messages[normalizeLanguage(lang)]; ok {
+		return msg
+	}
+	if msg, ok := d.Messages[GetDefaultLanguage()]; ok {
+		return msg
+	}
+	return http.StatusText(d.Status)
+}
+
+// ErrorCoder is implemented by error types (rather than sentinel values) that
+// know their catalog code, e.g. validation errors.
+type ErrorCoder interface {
+	error
+	ErrorCode() string
+}
+
+type catalogEntry struct {
+	err error
+	def ErrorDefinition
+}
+
+var (
+	catalogMu sync.RWMutex
+	catalog   []catalogEntry
+	byCode    = make(map[string]ErrorDefinition)
+	byStatus  = make(map[int]ErrorDefinition)
+)
+
+// RegisterError adds err to the catalog; it is matched with errors.Is.
+// Pass a nil err to register a code used through ErrorCoder only.
+// Codes are part of the API contract: never change or reuse them.
+func RegisterError(err error, def ErrorDefinition) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	if err != nil {
+		catalog = append(catalog, catalogEntry{err: err, def: def})
+	}
+	byCode[def.Code] = def
+}
+
+// registerStatusError registers the fallback definition for a status.
+func registerStatusError(def ErrorDefinition) {
+	RegisterError(nil, def)
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	byStatus[def.Status] = def
+}
+
+// LookupError returns the catalog definition for err.
+func LookupError(err error) (ErrorDefinition, bool) {
+	if err == nil {
+		return ErrorDefinition{}, false
+	}
+
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+
+	var coder ErrorCoder
+	if errors.As(err, &coder) {
+		if def, ok := byCode[coder.ErrorCode()]; ok {
+			return def, true
+		}
+	}
+	for _, entry := range catalog {
+		if errors.Is(err, entry.err) {
+			return entry.def, true
+		}
+	}
+	return ErrorDefinition{}, false
+}
+
+// lookupStatusError returns the fallback definition for status.
+func lookupStatusError(status int) ErrorDefinition {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	if def, ok := byStatus[status]; ok {
+		return def
+	}
+	if status >= http.StatusInternalServerError {
+		return byStatus[http.StatusInternalServerError]
+	}
+	return byStatus[http.StatusBadRequest]
+}
+
+// ErrorCatalog returns every cataloged error, sorted by code.
+func ErrorCatalog() []ErrorDefinition {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+
+	defs := make([]ErrorDefinition, 0, len(byCode))
+	for _, def := range byCode {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Code < defs[j].Code })
+	return defs
+}
+
+// normalizeLanguage reduces an X-Language value such as "vi-VN" to "vi".
+func normalizeLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_,;"); i > 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
+
+func init() {
+	registerStatusError(ErrorDefinition{
+		Code:   RequestInvalidCode,
+		Status: http.StatusBadRequest,
+		Messages: Messages{
+			"en": "The request is invalid",
+			"vi": "Yêu cầu không hợp lệ",
+		},
+	})
+	registerStatusError(ErrorDefinition{
+		Code:   ResourceNotFoundCode,
+		Status: http.StatusNotFound,
+		Messages: Messages{
+			"en": "The requested resource was not found",
+			"vi": "Không tìm thấy tài nguyên được yêu cầu",
+		},
+	})
+	registerStatusError(ErrorDefinition{
+		Code:   MethodNotAllowedCode,
+		Status: http.StatusMethodNotAllowed,
+		Messages: Messages{
+			"en": "The method is not allowed for this resource",
+			"vi": "Phương thức không được hỗ trợ cho tài nguyên này",
+		},
+	})
+	registerStatusError(ErrorDefinition{
+		Code:   RequestTooLargeCode,
+		Status: http.StatusRequestEntityTooLarge,
+		Messages: Messages{
+			"en": "The request body is too large",
+			"vi": "Nội dung yêu cầu quá lớn",
+		},
+	})
+	registerStatusError(ErrorDefinition{
+		Code:   RequestUnprocessableCode,
+		Status: http.StatusUnprocessableEntity,
+		Messages: Messages{
+			"en": "The request body could not be processed",
+			"vi": "Không thể xử lý nội dung yêu cầu",
+		},
+	})
+	registerStatusError(ErrorDefinition{
+		Code:   InternalErrorCode,
+		Status: http.StatusInternalServerError,
+		Messages: Messages{
+			"en": "Internal server error",
+			"vi": "Lỗi máy chủ nội bộ",
+		},
+	})
+	registerStatusError(ErrorDefinition{
+		Code:   ServiceUnavailableCode,
+		Status: http.StatusServiceUnavailable,
+		Messages: Messages{
+			"en": "The service is temporarily unavailable, please retry",
+			"vi": "Dịch vụ tạm thời không khả dụng, vui lòng thử lại",
+		},
+	})
+	RegisterError(nil, ErrorDefinition{
+		Code:   ValidationFailedCode,
+		Status: http.StatusUnprocessableEntity,
+		Messages: Messages{
+			"en": "One or more fields are invalid",
+			"vi": "Một hoặc nhiều trường không hợp lệ",
+		},
+	})
+}
@@ -0,0 +1,88 @@
+package common
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// =============================================================================
+// Entity Tags
+// clean-arch: ETag / If-Match for optimistic concurrency on versioned resources
+// =============================================================================
+
+const (
+	// PreconditionRequiredCode is the error catalog code of ErrPreconditionRequired.
+	PreconditionRequiredCode = "PRECONDITION_REQUIRED"
+	// PreconditionFailedCode is the error catalog code of ErrPreconditionFailed.
+	PreconditionFailedCode = "PRECONDITION_FAILED"
+)
+
+var (
+	// ErrPreconditionRequired is returned when a write needs If-Match but has none.
+	ErrPreconditionRequired = errors.New("if-match header is required")
+	// ErrPreconditionFailed is returned when If-Match names no current version.
+	ErrPreconditionFailed = errors.New("if-match precondition failed")
+)
+
+// VersionETag returns the strong entity tag of a resource version.
+func VersionETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// SetVersionETag sets the ETag response header for a resource version.
+func SetVersionETag(c *fiber.Ctx, version int64) {
+	c.Set(fiber.HeaderETag, VersionETag(version))
+}
+
+// IfMatchVersions parses the If-Match header into the versions it accepts.
+// It returns nil for "*" (any version), ErrPreconditionRequired when the header
+// is missing and ErrPreconditionFailed when no tag is a version ETag. Weak tags
+// never match: If-Match uses strong comparison (RFC 9110 13.1.1).
+func IfMatchVersions(c *fiber.Ctx) ([]int64, error) {
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if header == "" {
+		return nil, ErrPreconditionRequired
+	}
+	if header == "*" {
+		return nil, nil
+	}
+
+	var versions []int64
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+		if err == nil && version > 0 {
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) == 0 {
+		return nil, ErrPreconditionFailed
+	}
+	return versions, nil
+}
+
+func init() {
+	RegisterError(ErrPreconditionRequired, ErrorDefinition{
+		Code:   PreconditionRequiredCode,
+		Status: http.StatusPreconditionRequired,
+		Messages: Messages{
+			"en": "This request must be conditional, send If-Match with the ETag you loaded",
+			"vi": "Yêu cầu này phải có điều kiện, hãy gửi If-Match với ETag đã tải",
+		},
+	})
+	RegisterError(ErrPreconditionFailed, ErrorDefinition{
+		Code:   PreconditionFailedCode,
+		Status: http.StatusPreconditionFailed,
+		Messages: Messages{
+			"en": "The If-Match header does not match the current version",
+			"vi": "Header If-Match không khớp với phiên bản hiện tại",
+		},
+	})
+}
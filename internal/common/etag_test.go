@@ -0,0 +1,58 @@
+package common
+
+import (
+	"errors"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestIfMatchVersions(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    []int64
+		wantErr error
+	}{
+		{name: "missing", header: "", wantErr: ErrPreconditionRequired},
+		{name: "blank", header: "  ", wantErr: ErrPreconditionRequired},
+		{name: "any version", header: "*", want: nil},
+		{name: "one version", header: `"3"`, want: []int64{3}},
+		{name: "several versions", header: `"3", "4" ,"5"`, want: []int64{3, 4, 5}},
+		{name: "weak tag", header: `W/"3"`, wantErr: ErrPreconditionFailed},
+		{name: "weak tags are skipped", header: `W/"3", "4"`, want: []int64{4}},
+		{name: "unquoted", header: `3`, wantErr: ErrPreconditionFailed},
+		{name: "not a version", header: `"abc"`, wantErr: ErrPreconditionFailed},
+		{name: "zero", header: `"0"`, wantErr: ErrPreconditionFailed},
+		{name: "negative", header: `"-1"`, wantErr: ErrPreconditionFailed},
+		{name: "empty tag", header: `""`, wantErr: ErrPreconditionFailed},
+		{name: "lone quote", header: `"`, wantErr: ErrPreconditionFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int64
+			var err error
+			app := fiber.New()
+			app.Put("/", func(c *fiber.Ctx) error {
+				got, err = IfMatchVersions(c)
+				return nil
+			})
+			req := httptest.NewRequest(fiber.MethodPut, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(fiber.HeaderIfMatch, tt.header)
+			}
+			if _, testErr := app.Test(req); testErr != nil {
+				t.Fatalf("request failed: %v", testErr)
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("versions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
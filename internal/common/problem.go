@@ -0,0 +1,159 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// =============================================================================
+// Problem Details (RFC 7807)
+// clean-arch: Error-to-status mapping shared by handlers and middleware
+// =============================================================================
+
+const (
+	// ProblemContentType is the media type of error responses.
+	ProblemContentType = "application/problem+json"
+	// DefaultProblemType is used when a problem has no more specific type URI.
+	DefaultProblemType = "about:blank"
+
+	requestIDHeader   = "X-Request-ID"
+	requestIDLocalKey = "request_id"
+)
+
+// Problem is an RFC 7807 problem details object. Extensions are written as
+// additional top-level members.
+type Problem struct {
+	Type       string         `json:"type"`
+	Title      string         `json:"title"`
+	Status     int            `json:"status"`
+	Detail     string         `json:"detail,omitempty"`
+	Instance   string         `json:"instance,omitempty"`
+	RequestID  string         `json:"request_id,omitempty"`
+	Code       string         `json:"code,omitempty"`
+	Extensions map[string]any `json:"-"`
+}
+
+// MarshalJSON flattens Extensions into the problem object.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+	base, err := json.Marshal(problem(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return base, err
+	}
+
+	members := make(map[string]any, len(p.Extensions)+7)
+	for key, value := range p.Extensions {
+		members[key] = value
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(base, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range fields {
+		members[key] = value // standard members win over extensions
+	}
+	return json.Marshal(members)
+}
+
+// HTTPStatusError is implemented by errors that carry their own HTTP status.
+type HTTPStatusError interface {
+	error
+	HTTPStatus() int
+}
+
+// ProblemExtender is implemented by errors that add members to their problem,
+// e.g. per-field validation errors.
+type ProblemExtender interface {
+	error
+	ProblemExtensions() map[string]any
+}
+
+// StatusFromError returns the HTTP status for err. Unknown errors are 500.
+func StatusFromError(err error) int {
+	if err == nil {
+		return fiber.StatusOK
+	}
+
+	if def, ok := LookupError(err); ok {
+		return def.Status
+	}
+
+	var statusErr HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.HTTPStatus()
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+
+	return fiber.StatusInternalServerError
+}
+
+// =============================================================================
+// Problem Responses
+// =============================================================================
+
+// NewProblem builds the problem for err. The code and detail come from the
+// error catalog in the caller's X-Language, so raw error messages never reach
+// clients; 5xx errors are logged with the request ID.
+func NewProblem(c *fiber.Ctx, err error) Problem {
+	status := StatusFromError(err)
+	def, ok := LookupError(err)
+	if !ok {
+		def = lookupStatusError(status)
+	}
+
+	problem := Problem{
+		Type:      DefaultProblemType,
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    def.Message(GetLanguage(c)),
+		Instance:  c.OriginalURL(),
+		RequestID: requestID(c),
+		Code:      def.Code,
+	}
+
+	if status >= fiber.StatusInternalServerError {
+		LogError(c.UserContext(), err, "Request failed",
+			"status", status,
+			"code", problem.Code,
+			"path", c.Path(),
+			"request_id", problem.RequestID,
+		)
+		return problem
+	}
+
+	var extender ProblemExtender
+	if errors.As(err, &extender) {
+		problem.Extensions = extender.ProblemExtensions()
+	}
+	return problem
+}
+
+// WriteProblem writes problem as an application/problem+json response.
+func WriteProblem(c *fiber.Ctx, problem Problem) error {
+	return c.Status(problem.Status).JSON(problem, ProblemContentType)
+}
+
+// ResponseProblem writes the problem for err.
+func ResponseProblem(c *fiber.Ctx, err error) error {
+	return WriteProblem(c, NewProblem(c, err))
+}
+
+// ProblemErrorHandler is a fiber.ErrorHandler that answers unhandled errors
+// (including unknown routes) with problem+json.
+func ProblemErrorHandler(c *fiber.Ctx, err error) error {
+	return ResponseProblem(c, err)
+}
+
+func requestID(c *fiber.Ctx) string {
+	if id, ok := c.Locals(requestIDLocalKey).(string); ok && id != "" {
+		return id
+	}
+	return c.GetRespHeader(requestIDHeader)
+}
@@ -0,0 +1,190 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var errTestConflict = errors.New("test conflict")
+
+func init() {
+	RegisterError(errTestConflict, ErrorDefinition{
+		Code:   "TEST_CONFLICT",
+		Status: http.StatusConflict,
+		Messages: Messages{
+			"en": "The test resource already exists",
+			"vi": "Tài nguyên thử nghiệm đã tồn tại",
+		},
+	})
+}
+
+// statusError carries its own HTTP status.
+type statusError struct{ status int }
+
+func (e statusError) Error() string   { return "status error" }
+func (e statusError) HTTPStatus() int { return e.status }
+
+// fieldsError adds a fields member to its problem.
+type fieldsError struct{ status int }
+
+func (e fieldsError) Error() string   { return "invalid fields" }
+func (e fieldsError) HTTPStatus() int { return e.status }
+
+func (fieldsError) ProblemExtensions() map[string]any {
+	return map[string]any{"fields": []string{"email"}, "status": 200}
+}
+
+func TestStatusFromError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "no error", err: nil, want: http.StatusOK},
+		{name: "cataloged error", err: errTestConflict, want: http.StatusConflict},
+		{name: "wrapped cataloged error", err: fmt.Errorf("create: %w", errTestConflict), want: http.StatusConflict},
+		{name: "error with a status", err: statusError{status: http.StatusTooManyRequests}, want: http.StatusTooManyRequests},
+		{name: "fiber error", err: fiber.ErrMethodNotAllowed, want: http.StatusMethodNotAllowed},
+		{name: "unknown error", err: errors.New("boom"), want: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StatusFromError(tt.err); got != tt.want {
+				t.Errorf("StatusFromError() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResponseProblem(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		language string
+		want     map[string]any
+	}{
+		{
+			name: "cataloged error",
+			err:  fmt.Errorf("create: %w", errTestConflict),
+			want: map[string]any{"status": 409.0, "title": "Conflict", "code": "TEST_CONFLICT", "detail": "The test resource already exists"},
+		},
+		{
+			name:     "localized detail",
+			err:      errTestConflict,
+			language: "vi-VN",
+			want:     map[string]any{"code": "TEST_CONFLICT", "detail": "Tài nguyên thử nghiệm đã tồn tại"},
+		},
+		{
+			name:     "unknown language falls back to the default",
+			err:      errTestConflict,
+			language: "fr",
+			want:     map[string]any{"detail": "The test resource already exists"},
+		},
+		{
+			name: "uncataloged status uses the status fallback",
+			err:  fiber.ErrMethodNotAllowed,
+			want: map[string]any{"status": 405.0, "code": MethodNotAllowedCode},
+		},
+		{
+			name: "internal errors hide their message",
+			err:  errors.New("pq: password authentication failed"),
+			want: map[string]any{"status": 500.0, "code": InternalErrorCode, "detail": "Internal server error"},
+		},
+		{
+			name: "error with its own status",
+			err:  statusError{status: http.StatusBadRequest},
+			want: map[string]any{"status": 400.0, "code": RequestInvalidCode},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/things", func(c *fiber.Ctx) error {
+				return ResponseProblem(c, tt.err)
+			})
+			req := httptest.NewRequest(http.MethodGet, "/things?page=2", nil)
+			if tt.language != "" {
+				req.Header.Set(XHeaderLanguage, tt.language)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request: %v", err)
+			}
+
+			if got := resp.Header.Get(fiber.HeaderContentType); got != ProblemContentType {
+				t.Errorf("Content-Type = %q, want %q", got, ProblemContentType)
+			}
+			problem := decodeProblem(t, resp.Body)
+			if int(problem["status"].(float64)) != resp.StatusCode {
+				t.Errorf("status member %v differs from the response status %d", problem["status"], resp.StatusCode)
+			}
+			if problem["type"] != DefaultProblemType || problem["instance"] != "/things?page=2" {
+				t.Errorf("type, instance = %v, %v", problem["type"], problem["instance"])
+			}
+			for key, value := range tt.want {
+				if problem[key] != value {
+					t.Errorf("%s = %v, want %v", key, problem[key], value)
+				}
+			}
+		})
+	}
+}
+
+func TestProblemExtensions(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		wantFields bool
+	}{
+		{name: "client errors carry extensions", status: http.StatusUnprocessableEntity, wantFields: true},
+		{name: "server errors drop them", status: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				return ResponseProblem(c, fieldsError{status: tt.status})
+			})
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+			if err != nil {
+				t.Fatalf("request: %v", err)
+			}
+			problem := decodeProblem(t, resp.Body)
+
+			if resp.StatusCode != tt.status || problem["status"] != float64(tt.status) {
+				t.Errorf("status %d, member %v; want %d, standard members win over extensions", resp.StatusCode, problem["status"], tt.status)
+			}
+			if _, ok := problem["fields"]; ok != tt.wantFields {
+				t.Errorf("fields present = %v, want %v", ok, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestProblemErrorHandler(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ProblemErrorHandler})
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	problem := decodeProblem(t, resp.Body)
+	if resp.StatusCode != http.StatusNotFound || problem["code"] != ResourceNotFoundCode {
+		t.Errorf("status %d, code %v; want 404 %s", resp.StatusCode, problem["code"], ResourceNotFoundCode)
+	}
+}
+
+func decodeProblem(t *testing.T, body io.Reader) map[string]any {
+	t.Helper()
+	var problem map[string]any
+	if err := json.NewDecoder(body).Decode(&problem); err != nil {
+		t.Fatalf("decode problem: %v", err)
+	}
+	return problem
+}
@@ -0,0 +1,4 @@
+-- Rollback: Remove TOTP secrets
+-- Description: Drops the table added in migration 002
+
+DROP TABLE IF EXISTS user_totp_secrets;
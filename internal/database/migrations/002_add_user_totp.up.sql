@@ -0,0 +1,17 @@
+-- Migration: Add TOTP secrets for step-up authentication
+-- Description: Stores per-user RFC 6238 secrets used by the re-auth endpoint
+-- Date: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS user_totp_secrets (
+    user_id        BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret         VARCHAR(128) NOT NULL,
+    enabled        BOOLEAN NOT NULL DEFAULT FALSE,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Comments for documentation
+COMMENT ON TABLE user_totp_secrets IS 'Time-based one-time password secrets (RFC 6238)';
+COMMENT ON COLUMN user_totp_secrets.secret IS 'Base32 encoded shared secret';
+COMMENT ON COLUMN user_totp_secrets.last_used_step IS 'Last accepted time step, rejects code replay';
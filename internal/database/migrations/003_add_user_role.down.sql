@@ -0,0 +1,5 @@
+-- Rollback: Remove user roles
+-- Description: Drops the column and index added in migration 003
+
+DROP INDEX IF EXISTS idx_users_email_domain;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
@@ -0,0 +1,11 @@
+-- Migration: Add user roles for the admin API
+-- Description: Role used by RBAC checks; existing users become regular users
+-- Date: 2026-10-18
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'user';
+
+-- Index for the admin user list email domain filter
+CREATE INDEX IF NOT EXISTS idx_users_email_domain ON users (lower(split_part(email, '@', 2)));
+
+-- Comments for documentation
+COMMENT ON COLUMN users.role IS 'Authorization role: user or admin';
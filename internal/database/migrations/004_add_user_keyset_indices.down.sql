@@ -0,0 +1,8 @@
+-- Rollback: Remove keyset pagination indices
+-- Description: Restores the single-column timestamp indices of migration 001
+
+CREATE INDEX IF NOT EXISTS idx_users_created_at ON users(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_users_updated_at ON users(updated_at DESC);
+
+DROP INDEX IF EXISTS idx_users_created_at_id;
+DROP INDEX IF EXISTS idx_users_updated_at_id;
@@ -0,0 +1,13 @@
+-- Migration: Add keyset pagination indices to users table
+-- Description: Composite (column, id) indices so cursor seeks are index range scans
+-- Date: 2026-10-18
+
+-- Replaces the single-column timestamp indices: the composite ones serve
+-- the same range filters and also break ties on id
+CREATE INDEX IF NOT EXISTS idx_users_created_at_id ON users(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_users_updated_at_id ON users(updated_at DESC, id DESC);
+
+DROP INDEX IF EXISTS idx_users_created_at;
+DROP INDEX IF EXISTS idx_users_updated_at;
+
+ANALYZE users;
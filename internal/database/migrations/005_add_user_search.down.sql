@@ -0,0 +1,6 @@
+-- Rollback: Remove user search indices
+-- Description: Drops the indices added in migration 005. The pg_trgm extension
+-- is left installed since other schemas may use it.
+
+DROP INDEX IF EXISTS idx_users_search_trgm;
+DROP INDEX IF EXISTS idx_users_search_fts;
@@ -0,0 +1,16 @@
+-- Migration: Add user search indices
+-- Description: Full-text and trigram indices for the admin user search
+-- Date: 2026-10-18
+
+-- Trigram matching (similarity, word_similarity, LIKE '%...%')
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Full-text index; the expression must match the SearchUsers query
+CREATE INDEX IF NOT EXISTS idx_users_search_fts ON users
+    USING GIN (to_tsvector('simple', username || ' ' || first_name || ' ' || last_name || ' ' || email));
+
+-- Trigram index for partial and fuzzy matches, including phone numbers
+CREATE INDEX IF NOT EXISTS idx_users_search_trgm ON users
+    USING GIN (lower(username || ' ' || first_name || ' ' || last_name || ' ' || email || ' ' || phone_number) gin_trgm_ops);
+
+ANALYZE users;
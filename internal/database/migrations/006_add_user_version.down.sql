@@ -0,0 +1,4 @@
+-- Rollback: Remove user row versions
+-- Description: Drops the column added in migration 006
+
+ALTER TABLE users DROP COLUMN IF EXISTS version;
@@ -0,0 +1,8 @@
+-- Migration: Add user row versions
+-- Description: Version counter bumped on every write, used for ETag / If-Match
+-- Date: 2026-10-18
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+-- Comments for documentation
+COMMENT ON COLUMN users.version IS 'Row version for optimistic concurrency, incremented on every update';
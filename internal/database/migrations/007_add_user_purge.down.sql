@@ -0,0 +1,6 @@
+-- Rollback: Remove account deletion scheduling
+-- Description: Drops the index and columns added in migration 007
+
+DROP INDEX IF EXISTS idx_users_purge_at;
+ALTER TABLE users DROP COLUMN IF EXISTS self_deleted;
+ALTER TABLE users DROP COLUMN IF EXISTS purge_at;
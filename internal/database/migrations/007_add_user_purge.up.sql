@@ -0,0 +1,13 @@
+-- Migration: Add account deletion scheduling
+-- Description: Purge time of soft-deleted users and whether they deleted their own account
+-- Date: 2026-10-18
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS purge_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS self_deleted BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Partial index for the purge job; only soft-deleted users with a purge time are indexed
+CREATE INDEX IF NOT EXISTS idx_users_purge_at ON users(purge_at) WHERE purge_at IS NOT NULL;
+
+-- Comments for documentation
+COMMENT ON COLUMN users.purge_at IS 'When a soft-deleted user is permanently deleted; NULL keeps the row until restored';
+COMMENT ON COLUMN users.self_deleted IS 'The user deleted their own account and may restore it by logging in before purge_at';
@@ -0,0 +1,6 @@
+-- Rollback: Remove user lifecycle status
+-- Description: Drops the columns added in migration 008
+
+ALTER TABLE users DROP COLUMN IF EXISTS status_changed_at;
+ALTER TABLE users DROP COLUMN IF EXISTS status_reason;
+ALTER TABLE users DROP COLUMN IF EXISTS status;
@@ -0,0 +1,12 @@
+-- Migration: Add user lifecycle status
+-- Description: pending, active, suspended or banned, with the reason and time of the last change
+-- Date: 2026-10-18
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status_reason VARCHAR(500);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMPTZ;
+
+-- Comments for documentation
+COMMENT ON COLUMN users.status IS 'Lifecycle status: pending, active, suspended or banned; only active users can sign in';
+COMMENT ON COLUMN users.status_reason IS 'Reason given for the last status change';
+COMMENT ON COLUMN users.status_changed_at IS 'Time of the last status change; NULL if never changed';
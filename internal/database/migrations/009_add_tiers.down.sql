@@ -0,0 +1,5 @@
+-- Rollback: Remove subscription tiers
+-- Description: Drops the tables added in migration 009
+
+DROP TABLE IF EXISTS user_tiers;
+DROP TABLE IF EXISTS tiers;
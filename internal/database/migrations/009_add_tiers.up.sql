@@ -0,0 +1,39 @@
+-- Migration: Add subscription tiers
+-- Description: Tier definitions with their limits, and the tiers assigned to users over time
+-- Date: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS tiers (
+    id         BIGSERIAL PRIMARY KEY,
+    code       VARCHAR(50) NOT NULL UNIQUE,
+    name       VARCHAR(100) NOT NULL,
+    rate_limit INTEGER NOT NULL DEFAULT 0,
+    features   TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS user_tiers (
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tier_id     BIGINT NOT NULL REFERENCES tiers(id),
+    valid_from  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    valid_to    TIMESTAMPTZ,
+    assigned_by BIGINT,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT user_tiers_valid_period CHECK (valid_to IS NULL OR valid_to > valid_from)
+);
+
+-- Current tier lookups, newest assignment first
+CREATE INDEX IF NOT EXISTS idx_user_tiers_user_id ON user_tiers(user_id, valid_from DESC);
+
+-- Default tiers; edit their limits and features to match the product
+INSERT INTO tiers (code, name) VALUES ('free', 'Free'), ('pro', 'Pro')
+ON CONFLICT (code) DO NOTHING;
+
+-- Comments for documentation
+COMMENT ON TABLE tiers IS 'Subscription tiers and their limits';
+COMMENT ON COLUMN tiers.rate_limit IS 'Requests per rate limit window per user; 0 applies only the general limit';
+COMMENT ON COLUMN tiers.features IS 'Feature flags enabled for the tier';
+COMMENT ON TABLE user_tiers IS 'Tiers assigned to users; the latest assignment valid now is current';
+COMMENT ON COLUMN user_tiers.valid_to IS 'End of the assignment (exclusive); NULL never ends';
+COMMENT ON COLUMN user_tiers.assigned_by IS 'Administrator who assigned the tier';
@@ -0,0 +1,4 @@
+-- Rollback: Remove user settings
+-- Description: Drops the table added in migration 010
+
+DROP TABLE IF EXISTS user_settings;
@@ -0,0 +1,13 @@
+-- Migration: Add user settings
+-- Description: Per-user preferences as a JSONB document of the values changed from their defaults
+-- Date: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS user_settings (
+    user_id    BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    settings   JSONB NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Comments for documentation
+COMMENT ON TABLE user_settings IS 'Per-user preferences synced across devices';
+COMMENT ON COLUMN user_settings.settings IS 'Values set by the user by key; absent keys use the defaults declared in code';
@@ -0,0 +1,6 @@
+-- Rollback: Remove organizations
+-- Description: Drops the tables added in migration 011
+
+DROP TABLE IF EXISTS organization_invitations;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,48 @@
+-- Migration: Add organizations
+-- Description: Organizations, their members with org-scoped roles, and pending invitations
+-- Date: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id         BIGSERIAL PRIMARY KEY,
+    slug       VARCHAR(50) NOT NULL,
+    name       VARCHAR(100) NOT NULL,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT organizations_slug_key UNIQUE (slug)
+);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id         BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role            VARCHAR(20) NOT NULL DEFAULT 'member',
+    selected_at     TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT organization_members_pkey PRIMARY KEY (organization_id, user_id),
+    CONSTRAINT organization_members_role_check CHECK (role IN ('owner', 'admin', 'member'))
+);
+
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    id              BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email           VARCHAR(255) NOT NULL,
+    role            VARCHAR(20) NOT NULL DEFAULT 'member',
+    token_hash      VARCHAR(64) NOT NULL,
+    invited_by      BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    expires_at      TIMESTAMPTZ NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT organization_invitations_token_hash_key UNIQUE (token_hash),
+    CONSTRAINT organization_invitations_email_key UNIQUE (organization_id, email),
+    CONSTRAINT organization_invitations_role_check CHECK (role IN ('admin', 'member'))
+);
+
+-- Organizations of a user, and their active one (latest selected_at)
+CREATE INDEX IF NOT EXISTS idx_organization_members_user_id ON organization_members(user_id, selected_at DESC);
+
+-- Comments for documentation
+COMMENT ON TABLE organizations IS 'Organizations (teams) users belong to';
+COMMENT ON TABLE organization_members IS 'Members of organizations and their org-scoped role';
+COMMENT ON COLUMN organization_members.selected_at IS 'When the user last switched to the organization; the latest is their active one';
+COMMENT ON TABLE organization_invitations IS 'Pending invitations to join an organization, deleted once accepted or declined';
+COMMENT ON COLUMN organization_invitations.email IS 'Invited email address, lower case';
+COMMENT ON COLUMN organization_invitations.token_hash IS 'SHA-256 (hex) of the token sent by email';
@@ -0,0 +1,46 @@
+-- Rollback: Remove tenants
+-- Description: Drops the row-level security, tenant columns and tables added in migration 012.
+-- Restoring the global unique constraints fails if two tenants share an email, username or slug.
+
+DROP POLICY IF EXISTS tenant_isolation ON organization_invitations;
+ALTER TABLE organization_invitations NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE organization_invitations DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON organization_members;
+ALTER TABLE organization_members NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE organization_members DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON user_settings;
+ALTER TABLE user_settings NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE user_settings DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON user_tiers;
+ALTER TABLE user_tiers NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE user_tiers DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON user_totp_secrets;
+ALTER TABLE user_totp_secrets NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE user_totp_secrets DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON organizations;
+ALTER TABLE organizations NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE organizations DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation ON users;
+ALTER TABLE users NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE users DISABLE ROW LEVEL SECURITY;
+
+DROP INDEX IF EXISTS idx_users_tenant_id;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_key;
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
+ALTER TABLE organizations DROP CONSTRAINT IF EXISTS organizations_slug_key;
+ALTER TABLE organizations ADD CONSTRAINT organizations_slug_key UNIQUE (slug);
+
+ALTER TABLE organizations DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
+
+DROP FUNCTION IF EXISTS app_tenant_id();
+DROP TABLE IF EXISTS tenants;
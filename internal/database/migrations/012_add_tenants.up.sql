@@ -0,0 +1,91 @@
+-- Migration: Add tenants
+-- Description: Tenants, the tenant of users and organizations, and row-level security isolating them
+-- Date: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS tenants (
+    id         BIGSERIAL PRIMARY KEY,
+    slug       VARCHAR(50) NOT NULL,
+    name       VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT tenants_slug_key UNIQUE (slug),
+    CONSTRAINT tenants_slug_check CHECK (slug ~ '^[a-z][a-z0-9-]*$')
+);
+
+-- Existing users and organizations belong to the default tenant
+INSERT INTO tenants (id, slug, name) VALUES (1, 'default', 'Default') ON CONFLICT (id) DO NOTHING;
+SELECT setval(pg_get_serial_sequence('tenants', 'id'), (SELECT MAX(id) FROM tenants));
+
+-- Tenant of the current transaction (SET LOCAL app.tenant_id); NULL when unset, which
+-- matches no row
+CREATE OR REPLACE FUNCTION app_tenant_id() RETURNS BIGINT
+    LANGUAGE sql STABLE
+    AS $$ SELECT NULLIF(current_setting('app.tenant_id', true), '')::BIGINT $$;
+
+-- New rows get the tenant of the transaction
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tenant_id BIGINT NOT NULL DEFAULT COALESCE(app_tenant_id(), 1) REFERENCES tenants(id);
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS tenant_id BIGINT NOT NULL DEFAULT COALESCE(app_tenant_id(), 1) REFERENCES tenants(id);
+
+-- Emails, usernames and organization slugs are unique per tenant
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (tenant_id, email);
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_key;
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (tenant_id, username);
+ALTER TABLE organizations DROP CONSTRAINT IF EXISTS organizations_slug_key;
+ALTER TABLE organizations ADD CONSTRAINT organizations_slug_key UNIQUE (tenant_id, slug);
+
+CREATE INDEX IF NOT EXISTS idx_users_tenant_id ON users(tenant_id);
+
+-- Row-level security: only rows of the tenant in app.tenant_id are visible and writable;
+-- without app.tenant_id none are. FORCE applies the policies to the table owner too;
+-- superusers and BYPASSRLS roles still bypass them, so the service must not connect as
+-- one except for jobs working across tenants (database.systemUserName).
+ALTER TABLE users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE users FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON users;
+CREATE POLICY tenant_isolation ON users
+    USING (tenant_id = app_tenant_id())
+    WITH CHECK (tenant_id = app_tenant_id());
+
+ALTER TABLE organizations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE organizations FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON organizations;
+CREATE POLICY tenant_isolation ON organizations
+    USING (tenant_id = app_tenant_id())
+    WITH CHECK (tenant_id = app_tenant_id());
+
+ALTER TABLE user_totp_secrets ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_totp_secrets FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON user_totp_secrets;
+CREATE POLICY tenant_isolation ON user_totp_secrets
+    USING (EXISTS (SELECT 1 FROM users WHERE users.id = user_totp_secrets.user_id));
+
+ALTER TABLE user_tiers ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_tiers FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON user_tiers;
+CREATE POLICY tenant_isolation ON user_tiers
+    USING (EXISTS (SELECT 1 FROM users WHERE users.id = user_tiers.user_id));
+
+ALTER TABLE user_settings ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_settings FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON user_settings;
+CREATE POLICY tenant_isolation ON user_settings
+    USING (EXISTS (SELECT 1 FROM users WHERE users.id = user_settings.user_id));
+
+ALTER TABLE organization_members ENABLE ROW LEVEL SECURITY;
+ALTER TABLE organization_members FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON organization_members;
+CREATE POLICY tenant_isolation ON organization_members
+    USING (EXISTS (SELECT 1 FROM organizations WHERE organizations.id = organization_members.organization_id)
+       AND EXISTS (SELECT 1 FROM users WHERE users.id = organization_members.user_id));
+
+ALTER TABLE organization_invitations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE organization_invitations FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON organization_invitations;
+CREATE POLICY tenant_isolation ON organization_invitations
+    USING (EXISTS (SELECT 1 FROM organizations WHERE organizations.id = organization_invitations.organization_id));
+
+-- Comments for documentation
+COMMENT ON TABLE tenants IS 'Customers hosted by the deployment; users and organizations belong to one';
+COMMENT ON FUNCTION app_tenant_id() IS 'Tenant of the current transaction from app.tenant_id, NULL when unset (no rows visible)';
+COMMENT ON COLUMN users.tenant_id IS 'Tenant of the user, defaults to app.tenant_id';
+COMMENT ON COLUMN organizations.tenant_id IS 'Tenant of the organization, defaults to app.tenant_id';
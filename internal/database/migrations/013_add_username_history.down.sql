@@ -0,0 +1,4 @@
+-- Rollback: Remove username history
+-- Description: Drops the table added in migration 013
+
+DROP TABLE IF EXISTS username_history;
@@ -0,0 +1,25 @@
+-- Migration: Add username history
+-- Description: Previous usernames of users, reserved for a period after a change so they redirect to the new one
+-- Date: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS username_history (
+    id             BIGSERIAL PRIMARY KEY,
+    user_id        BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    username       VARCHAR(50) NOT NULL,
+    changed_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reserved_until TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_username_history_username ON username_history(username, reserved_until DESC);
+CREATE INDEX IF NOT EXISTS idx_username_history_user_id ON username_history(user_id, changed_at DESC);
+
+-- Row-level security: history of users of other tenants is invisible
+ALTER TABLE username_history ENABLE ROW LEVEL SECURITY;
+ALTER TABLE username_history FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON username_history
+    USING (EXISTS (SELECT 1 FROM users WHERE users.id = username_history.user_id));
+
+-- Comments for documentation
+COMMENT ON TABLE username_history IS 'Usernames users changed away from';
+COMMENT ON COLUMN username_history.username IS 'The previous username';
+COMMENT ON COLUMN username_history.reserved_until IS 'Until then only the same user can take the username back and lookups of it redirect';
@@ -0,0 +1,5 @@
+-- Rollback: Remove user imports
+-- Description: Drops the tables added in migration 014
+
+DROP TABLE IF EXISTS user_import_errors;
+DROP TABLE IF EXISTS user_imports;
@@ -0,0 +1,51 @@
+-- Migration: Add user imports
+-- Description: Resumable bulk user imports from CSV or NDJSON files and their per-row error reports
+-- Date: 2026-10-18
+
+CREATE TABLE IF NOT EXISTS user_imports (
+    id             BIGSERIAL PRIMARY KEY,
+    format         VARCHAR(10) NOT NULL,
+    source         VARCHAR(255) NOT NULL,
+    dry_run        BOOLEAN NOT NULL DEFAULT FALSE,
+    status         VARCHAR(20) NOT NULL DEFAULT 'pending',
+    rows_processed BIGINT NOT NULL DEFAULT 0,
+    rows_imported  BIGINT NOT NULL DEFAULT 0,
+    rows_failed    BIGINT NOT NULL DEFAULT 0,
+    error          TEXT,
+    created_by     BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at    TIMESTAMPTZ,
+    tenant_id      BIGINT NOT NULL DEFAULT COALESCE(app_tenant_id(), 1) REFERENCES tenants(id),
+    CONSTRAINT user_imports_format_check CHECK (format IN ('csv', 'ndjson')),
+    CONSTRAINT user_imports_status_check CHECK (status IN ('pending', 'running', 'completed', 'failed'))
+);
+
+CREATE TABLE IF NOT EXISTS user_import_errors (
+    id         BIGSERIAL PRIMARY KEY,
+    import_id  BIGINT NOT NULL REFERENCES user_imports(id) ON DELETE CASCADE,
+    row_number BIGINT NOT NULL,
+    field      VARCHAR(50),
+    message    TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_imports_created_at ON user_imports(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_user_import_errors_import_id ON user_import_errors(import_id, row_number);
+
+-- Row-level security: imports of other tenants are invisible
+ALTER TABLE user_imports ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_imports FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON user_imports
+    USING (tenant_id = app_tenant_id())
+    WITH CHECK (tenant_id = app_tenant_id());
+
+ALTER TABLE user_import_errors ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_import_errors FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON user_import_errors
+    USING (EXISTS (SELECT 1 FROM user_imports WHERE user_imports.id = user_import_errors.import_id));
+
+-- Comments for documentation
+COMMENT ON TABLE user_imports IS 'Bulk user imports; rows_processed is the checkpoint a resumed import continues after';
+COMMENT ON COLUMN user_imports.source IS 'Object key of the uploaded file';
+COMMENT ON COLUMN user_imports.dry_run IS 'Rows are validated and checked against existing users but not inserted';
+COMMENT ON TABLE user_import_errors IS 'Rows of an import that were rejected and why';
@@ -0,0 +1,4 @@
+-- Rollback: Remove user lifecycle status check
+-- Description: Drops the constraint added in migration 015
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
@@ -0,0 +1,6 @@
+-- Migration: Check user lifecycle status
+-- Description: Restricts users.status to the lifecycle statuses added in migration 008
+-- Date: 2026-10-18
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check CHECK (status IN ('pending', 'active', 'suspended', 'banned'));
@@ -1,8 +1,90 @@
 -- name: GetUser :one
 SELECT * FROM users WHERE id = $1 AND deleted_at IS NULL;
 
+-- name: GetUserWithDeleted :one
+SELECT * FROM users WHERE id = $1;
+
 -- name: ListUsers :many
-SELECT * FROM users WHERE deleted_at IS NULL ORDER BY id LIMIT $1 OFFSET $2;
+-- Keyset pagination on (created_at DESC, id DESC); NULL cursor values start from the first page
+SELECT * FROM users
+WHERE deleted_at IS NULL
+  AND (sqlc.narg('after_created_at')::timestamptz IS NULL
+       OR (created_at, id) < (sqlc.narg('after_created_at')::timestamptz, sqlc.narg('after_id')::bigint))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit_count');
+
+-- name: FilterUsers :many
+-- NULL filters are skipped and the sort column is picked with CASE, so the statement is static.
+-- after_* is the keyset position of the previous page: (sort column, id) past it in sort order
+SELECT * FROM users
+WHERE (sqlc.arg('deleted')::text = 'include'
+       OR (sqlc.arg('deleted')::text = 'exclude' AND deleted_at IS NULL)
+       OR (sqlc.arg('deleted')::text = 'only' AND deleted_at IS NOT NULL))
+  AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from')::timestamptz)
+  AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to')::timestamptz)
+  AND (sqlc.narg('email_domain')::text IS NULL OR lower(split_part(email, '@', 2)) = lower(sqlc.narg('email_domain')::text))
+  AND (sqlc.narg('role')::text IS NULL OR role = sqlc.narg('role')::text)
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status')::text)
+  AND (sqlc.narg('after_id')::bigint IS NULL OR CASE
+        WHEN sqlc.arg('sort_by')::text = 'created_at' AND sqlc.arg('sort_desc')::boolean THEN (created_at, id) < (sqlc.narg('after_time')::timestamptz, sqlc.narg('after_id')::bigint)
+        WHEN sqlc.arg('sort_by')::text = 'created_at' THEN (created_at, id) > (sqlc.narg('after_time')::timestamptz, sqlc.narg('after_id')::bigint)
+        WHEN sqlc.arg('sort_by')::text = 'updated_at' AND sqlc.arg('sort_desc')::boolean THEN (updated_at, id) < (sqlc.narg('after_time')::timestamptz, sqlc.narg('after_id')::bigint)
+        WHEN sqlc.arg('sort_by')::text = 'updated_at' THEN (updated_at, id) > (sqlc.narg('after_time')::timestamptz, sqlc.narg('after_id')::bigint)
+        WHEN sqlc.arg('sort_by')::text = 'username' AND sqlc.arg('sort_desc')::boolean THEN (username, id) < (sqlc.narg('after_text')::text, sqlc.narg('after_id')::bigint)
+        WHEN sqlc.arg('sort_by')::text = 'username' THEN (username, id) > (sqlc.narg('after_text')::text, sqlc.narg('after_id')::bigint)
+        WHEN sqlc.arg('sort_by')::text = 'email' AND sqlc.arg('sort_desc')::boolean THEN (email, id) < (sqlc.narg('after_text')::text, sqlc.narg('after_id')::bigint)
+        WHEN sqlc.arg('sort_by')::text = 'email' THEN (email, id) > (sqlc.narg('after_text')::text, sqlc.narg('after_id')::bigint)
+        WHEN sqlc.arg('sort_desc')::boolean THEN id < sqlc.narg('after_id')::bigint
+        ELSE id > sqlc.narg('after_id')::bigint
+      END)
+ORDER BY
+    CASE WHEN sqlc.arg('sort_by')::text = 'created_at' AND NOT sqlc.arg('sort_desc')::boolean THEN created_at END ASC,
+    CASE WHEN sqlc.arg('sort_by')::text = 'created_at' AND sqlc.arg('sort_desc')::boolean THEN created_at END DESC,
+    CASE WHEN sqlc.arg('sort_by')::text = 'updated_at' AND NOT sqlc.arg('sort_desc')::boolean THEN updated_at END ASC,
+    CASE WHEN sqlc.arg('sort_by')::text = 'updated_at' AND sqlc.arg('sort_desc')::boolean THEN updated_at END DESC,
+    CASE WHEN sqlc.arg('sort_by')::text = 'username' AND NOT sqlc.arg('sort_desc')::boolean THEN username END ASC,
+    CASE WHEN sqlc.arg('sort_by')::text = 'username' AND sqlc.arg('sort_desc')::boolean THEN username END DESC,
+    CASE WHEN sqlc.arg('sort_by')::text = 'email' AND NOT sqlc.arg('sort_desc')::boolean THEN email END ASC,
+    CASE WHEN sqlc.arg('sort_by')::text = 'email' AND sqlc.arg('sort_desc')::boolean THEN email END DESC,
+    CASE WHEN sqlc.arg('sort_desc')::boolean THEN id END DESC,
+    id ASC
+LIMIT sqlc.arg('limit_count') OFFSET sqlc.arg('offset_count');
+
+-- name: CountFilteredUsers :one
+SELECT COUNT(*) FROM users
+WHERE (sqlc.arg('deleted')::text = 'include'
+       OR (sqlc.arg('deleted')::text = 'exclude' AND deleted_at IS NULL)
+       OR (sqlc.arg('deleted')::text = 'only' AND deleted_at IS NOT NULL))
+  AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from')::timestamptz)
+  AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to')::timestamptz)
+  AND (sqlc.narg('email_domain')::text IS NULL OR lower(split_part(email, '@', 2)) = lower(sqlc.narg('email_domain')::text))
+  AND (sqlc.narg('role')::text IS NULL OR role = sqlc.narg('role')::text)
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status')::text);
+
+-- name: SearchUsers :many
+-- Full-text prefix matches rank above fuzzy trigram matches. The search expressions
+-- must match idx_users_search_fts / idx_users_search_trgm to use the indexes.
+SELECT sqlc.embed(users),
+       (ts_rank(to_tsvector('simple', username || ' ' || first_name || ' ' || last_name || ' ' || email),
+                to_tsquery('simple', sqlc.arg('ts_query')::text)) * 2
+        + word_similarity(sqlc.arg('term')::text, lower(username || ' ' || first_name || ' ' || last_name || ' ' || email || ' ' || phone_number)))::real AS rank,
+       ts_headline('simple', first_name || ' ' || last_name || ' ' || username || ' ' || email,
+                   to_tsquery('simple', sqlc.arg('ts_query')::text),
+                   'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')::text AS headline
+FROM users
+WHERE (sqlc.arg('include_deleted')::boolean OR deleted_at IS NULL)
+  AND (to_tsvector('simple', username || ' ' || first_name || ' ' || last_name || ' ' || email) @@ to_tsquery('simple', sqlc.arg('ts_query')::text)
+       OR lower(username || ' ' || first_name || ' ' || last_name || ' ' || email || ' ' || phone_number) LIKE sqlc.arg('pattern')::text
+       OR sqlc.arg('term')::text <% lower(username || ' ' || first_name || ' ' || last_name || ' ' || email || ' ' || phone_number))
+ORDER BY rank DESC, id ASC
+LIMIT sqlc.arg('limit_count') OFFSET sqlc.arg('offset_count');
+
+-- name: CountSearchUsers :one
+SELECT COUNT(*) FROM users
+WHERE (sqlc.arg('include_deleted')::boolean OR deleted_at IS NULL)
+  AND (to_tsvector('simple', username || ' ' || first_name || ' ' || last_name || ' ' || email) @@ to_tsquery('simple', sqlc.arg('ts_query')::text)
+       OR lower(username || ' ' || first_name || ' ' || last_name || ' ' || email || ' ' || phone_number) LIKE sqlc.arg('pattern')::text
+       OR sqlc.arg('term')::text <% lower(username || ' ' || first_name || ' ' || last_name || ' ' || email || ' ' || phone_number));
 
 -- name: CreateUser :one
 INSERT INTO users (username, email, phone_number, first_name, last_name, hash_password) VALUES ($1, $2, $3, $4, $5, $6) RETURNING *;
@@ -10,9 +92,352 @@ INSERT INTO users (username, email, phone_number, first_name, last_name, hash_pa
 -- name: GetUserByUserName :one
 SELECT * FROM users WHERE username = $1 AND deleted_at IS NULL;
 
+-- name: GetUserByEmail :one
+SELECT * FROM users WHERE email = $1 AND deleted_at IS NULL;
+
+-- name: UpdateUser :one
+-- Only non-NULL parameters are applied
+UPDATE users SET
+    email         = COALESCE(sqlc.narg('email'), email),
+    phone_number  = COALESCE(sqlc.narg('phone_number'), phone_number),
+    first_name    = COALESCE(sqlc.narg('first_name'), first_name),
+    last_name     = COALESCE(sqlc.narg('last_name'), last_name),
+    avatar        = COALESCE(sqlc.narg('avatar'), avatar),
+    hash_password = COALESCE(sqlc.narg('hash_password'), hash_password),
+    role          = COALESCE(sqlc.narg('role'), role),
+    version       = version + 1,
+    updated_at    = NOW()
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
+
+-- name: UpdateUserStatus :one
+-- Conditional on the current status, so concurrent changes cannot skip a transition check
+UPDATE users SET
+    status            = sqlc.arg('status'),
+    status_reason     = sqlc.narg('status_reason'),
+    status_changed_at = NOW(),
+    version           = version + 1,
+    updated_at        = NOW()
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL AND status = sqlc.arg('from_status')
+RETURNING *;
+
+-- name: PatchUserProfile :one
+-- Only non-NULL parameters are applied; clear_avatar removes the avatar.
+-- A non-NULL expected_version makes the update conditional (optimistic concurrency)
+UPDATE users SET
+    first_name   = COALESCE(sqlc.narg('first_name'), first_name),
+    last_name    = COALESCE(sqlc.narg('last_name'), last_name),
+    phone_number = COALESCE(sqlc.narg('phone_number'), phone_number),
+    avatar       = CASE WHEN sqlc.arg('clear_avatar')::boolean THEN NULL
+                        ELSE COALESCE(sqlc.narg('avatar'), avatar) END,
+    version      = version + 1,
+    updated_at   = NOW()
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+  AND (sqlc.narg('expected_version')::bigint IS NULL OR version = sqlc.narg('expected_version')::bigint)
+RETURNING *;
+
+-- name: SoftDeleteUser :execrows
+-- A NULL purge_at keeps the row until it is restored
+UPDATE users SET
+    deleted_at   = NOW(),
+    purge_at     = sqlc.narg('purge_at'),
+    self_deleted = sqlc.arg('self_deleted'),
+    version      = version + 1,
+    updated_at   = NOW()
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL;
+
+-- name: RestoreUser :execrows
+UPDATE users SET deleted_at = NULL, purge_at = NULL, self_deleted = FALSE, version = version + 1, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: ListUsersDueForPurge :many
+SELECT id, tenant_id FROM users WHERE deleted_at IS NOT NULL AND purge_at <= NOW() ORDER BY purge_at LIMIT $1;
+
+-- name: DeferUserPurge :execrows
+-- Moves a failed purge back so the next batches list the accounts behind it
+UPDATE users SET purge_at = $2 WHERE id = $1 AND deleted_at IS NOT NULL AND purge_at <= NOW();
+
+-- name: PurgeUser :execrows
+-- Rows restored in the meantime are left alone; dependent rows cascade
+DELETE FROM users WHERE id = $1 AND deleted_at IS NOT NULL AND purge_at <= NOW();
+
 -- name: GetUserByUsernameOrEmail :one
 SELECT * FROM users WHERE (username = $1 OR email = $1) AND deleted_at IS NULL;
 
+-- name: GetUserByUsernameOrEmailWithDeleted :one
+SELECT * FROM users WHERE username = $1 OR email = $1;
+
 -- name: ValidateUserPasswordByUserName :one
 -- DEPRECATED: This query has a SQL injection vulnerability. Use GetUserByUsernameOrEmail instead.
 SELECT * FROM users WHERE (username = $1 OR email = $1) AND hash_password = $2 AND deleted_at IS NULL;
+
+-- name: GetUserTOTPSecret :one
+SELECT * FROM user_totp_secrets WHERE user_id = $1;
+
+-- name: ConsumeUserTOTPStep :execrows
+-- Only advances forward so each code is accepted at most once
+UPDATE user_totp_secrets SET last_used_step = $2, updated_at = NOW() WHERE user_id = $1 AND last_used_step < $2;
+
+-- name: DeleteUserTOTPSecret :exec
+DELETE FROM user_totp_secrets WHERE user_id = $1;
+
+-- name: ListTiers :many
+SELECT * FROM tiers ORDER BY id;
+
+-- name: GetTierByCode :one
+SELECT * FROM tiers WHERE code = $1;
+
+-- name: GetCurrentUserTier :one
+-- The latest assignment valid now wins, so a temporary assignment overrides an open-ended one
+SELECT sqlc.embed(user_tiers), sqlc.embed(tiers)
+FROM user_tiers JOIN tiers ON tiers.id = user_tiers.tier_id
+WHERE user_tiers.user_id = $1
+  AND user_tiers.valid_from <= NOW()
+  AND (user_tiers.valid_to IS NULL OR user_tiers.valid_to > NOW())
+ORDER BY user_tiers.valid_from DESC, user_tiers.id DESC
+LIMIT 1;
+
+-- name: ListUserTiers :many
+SELECT sqlc.embed(user_tiers), sqlc.embed(tiers)
+FROM user_tiers JOIN tiers ON tiers.id = user_tiers.tier_id
+WHERE user_tiers.user_id = $1
+ORDER BY user_tiers.valid_from DESC, user_tiers.id DESC;
+
+-- name: CreateUserTier :one
+INSERT INTO user_tiers (user_id, tier_id, valid_from, valid_to, assigned_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: EndUserTiers :execrows
+-- Ends the assignments valid now; scheduled ones are kept
+UPDATE user_tiers SET valid_to = NOW()
+WHERE user_id = $1
+  AND valid_from <= NOW()
+  AND (valid_to IS NULL OR valid_to > NOW());
+
+-- name: GetUserSettings :one
+SELECT * FROM user_settings WHERE user_id = $1;
+
+-- name: ReplaceUserSettings :one
+INSERT INTO user_settings (user_id, settings) VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET settings = EXCLUDED.settings, updated_at = NOW()
+RETURNING *;
+
+-- name: MergeUserSettings :one
+-- Applied in one statement so concurrent changes of different keys are all kept
+INSERT INTO user_settings (user_id, settings) VALUES (@user_id, @set_values::jsonb - @reset_keys::text[])
+ON CONFLICT (user_id) DO UPDATE SET
+    settings   = (user_settings.settings || @set_values::jsonb) - @reset_keys::text[],
+    updated_at = NOW()
+RETURNING *;
+
+-- name: CreateOrganization :one
+-- The creator becomes the first owner in the same statement
+WITH organization AS (
+    INSERT INTO organizations (slug, name, created_by) VALUES ($1, $2, $3)
+    RETURNING *
+), owner AS (
+    INSERT INTO organization_members (organization_id, user_id, role)
+    SELECT id, created_by, 'owner' FROM organization
+)
+SELECT * FROM organization;
+
+-- name: GetOrganization :one
+SELECT * FROM organizations WHERE id = $1;
+
+-- name: ListUserOrganizations :many
+SELECT sqlc.embed(organization_members), sqlc.embed(organizations)
+FROM organization_members JOIN organizations ON organizations.id = organization_members.organization_id
+WHERE organization_members.user_id = $1
+ORDER BY organizations.name, organizations.id;
+
+-- name: GetActiveOrganization :one
+SELECT sqlc.embed(organization_members), sqlc.embed(organizations)
+FROM organization_members JOIN organizations ON organizations.id = organization_members.organization_id
+WHERE organization_members.user_id = $1 AND organization_members.selected_at IS NOT NULL
+ORDER BY organization_members.selected_at DESC
+LIMIT 1;
+
+-- name: SelectOrganization :execrows
+UPDATE organization_members SET selected_at = NOW()
+WHERE organization_id = $1 AND user_id = $2;
+
+-- name: GetOrganizationMember :one
+SELECT * FROM organization_members WHERE organization_id = $1 AND user_id = $2;
+
+-- name: ListOrganizationMembers :many
+SELECT sqlc.embed(organization_members), users.username, users.email
+FROM organization_members JOIN users ON users.id = organization_members.user_id
+WHERE organization_members.organization_id = $1
+ORDER BY organization_members.created_at, organization_members.user_id;
+
+-- name: UpdateOrganizationMemberRole :one
+-- Locks the owners before demoting one, so concurrent demotions cannot leave
+-- the organization without an owner; the last owner is not updated
+WITH owners AS (
+    SELECT user_id FROM organization_members
+    WHERE organization_id = $1 AND role = 'owner'
+    FOR UPDATE
+)
+UPDATE organization_members SET role = $3
+WHERE organization_id = $1 AND user_id = $2
+  AND (role <> 'owner' OR $3 = 'owner' OR (SELECT COUNT(*) FROM owners) > 1)
+RETURNING *;
+
+-- name: DeleteOrganizationMember :execrows
+-- Locks the owners like UpdateOrganizationMemberRole; the last owner is not
+-- deleted
+WITH owners AS (
+    SELECT user_id FROM organization_members
+    WHERE organization_id = $1 AND role = 'owner'
+    FOR UPDATE
+)
+DELETE FROM organization_members
+WHERE organization_id = $1 AND user_id = $2
+  AND (role <> 'owner' OR (SELECT COUNT(*) FROM owners) > 1);
+
+-- name: CreateOrganizationInvitation :one
+-- Inviting an address again replaces the pending invitation and its token
+INSERT INTO organization_invitations (organization_id, email, role, token_hash, invited_by, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (organization_id, email) DO UPDATE SET
+    role       = EXCLUDED.role,
+    token_hash = EXCLUDED.token_hash,
+    invited_by = EXCLUDED.invited_by,
+    expires_at = EXCLUDED.expires_at,
+    created_at = NOW()
+RETURNING *;
+
+-- name: GetOrganizationInvitationByToken :one
+SELECT * FROM organization_invitations WHERE token_hash = $1 AND expires_at > NOW();
+
+-- name: ListOrganizationInvitations :many
+SELECT * FROM organization_invitations
+WHERE organization_id = $1 AND expires_at > NOW()
+ORDER BY created_at DESC, id DESC;
+
+-- name: DeleteOrganizationInvitation :execrows
+DELETE FROM organization_invitations WHERE id = $1 AND organization_id = $2;
+
+-- name: AcceptOrganizationInvitation :one
+-- Consumes the invitation and adds the member in one statement; a member
+-- conflict keeps the invitation
+WITH invitation AS (
+    DELETE FROM organization_invitations WHERE id = $1
+    RETURNING organization_id, role
+)
+INSERT INTO organization_members (organization_id, user_id, role)
+SELECT organization_id, $2, role FROM invitation
+RETURNING *;
+
+-- name: GetTenant :one
+SELECT * FROM tenants WHERE id = $1;
+
+-- name: GetTenantBySlug :one
+SELECT * FROM tenants WHERE slug = $1;
+
+-- name: ChangeUsername :one
+-- Records the old username in the same statement. Conditional on the current
+-- username, so of two concurrent changes only one applies
+WITH renamed AS (
+    UPDATE users SET
+        username   = sqlc.arg('username'),
+        version    = version + 1,
+        updated_at = NOW()
+    WHERE id = sqlc.arg('id') AND deleted_at IS NULL AND username = sqlc.arg('old_username')
+    RETURNING *
+), history AS (
+    INSERT INTO username_history (user_id, username, reserved_until)
+    SELECT id, sqlc.arg('old_username'), sqlc.arg('reserved_until') FROM renamed
+)
+SELECT * FROM renamed;
+
+-- name: GetLatestUsernameChange :one
+SELECT * FROM username_history WHERE user_id = $1 ORDER BY changed_at DESC, id DESC LIMIT 1;
+
+-- name: GetUsernameReservation :one
+SELECT * FROM username_history
+WHERE username = $1 AND reserved_until > NOW()
+ORDER BY changed_at DESC, id DESC
+LIMIT 1;
+
+-- name: ListUsernameHistory :many
+SELECT * FROM username_history WHERE user_id = $1 ORDER BY changed_at DESC, id DESC;
+
+-- name: ImportUsers :many
+-- Inserts a batch in one statement. Rows whose username or email is taken,
+-- or whose username is reserved, are skipped; the usernames of the inserted
+-- rows are returned
+INSERT INTO users (username, email, phone_number, first_name, last_name, hash_password, created_at)
+SELECT r.username, r.email, r.phone_number, r.first_name, r.last_name, r.hash_password, COALESCE(r.created_at, NOW())
+FROM unnest(
+    @usernames::varchar[], @emails::varchar[], @phone_numbers::varchar[],
+    @first_names::varchar[], @last_names::varchar[], @hash_passwords::varchar[],
+    @created_ats::timestamptz[]
+) AS r(username, email, phone_number, first_name, last_name, hash_password, created_at)
+WHERE NOT EXISTS (
+    SELECT 1 FROM username_history
+    WHERE username_history.username = r.username AND username_history.reserved_until > NOW()
+)
+ON CONFLICT DO NOTHING
+RETURNING username;
+
+-- name: ListImportConflicts :many
+-- Why ImportUsers skipped rows: for each username and email pair, whether
+-- the username or email is taken and whether the username is reserved
+SELECT r.username::varchar AS username,
+    EXISTS (SELECT 1 FROM users WHERE users.username = r.username) AS username_taken,
+    EXISTS (SELECT 1 FROM users WHERE users.email = r.email) AS email_taken,
+    EXISTS (
+        SELECT 1 FROM username_history
+        WHERE username_history.username = r.username AND username_history.reserved_until > NOW()
+    ) AS username_reserved
+FROM unnest(@usernames::varchar[], @emails::varchar[]) AS r(username, email);
+
+-- name: CreateUserImport :one
+INSERT INTO user_imports (format, source, dry_run, created_by) VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetUserImport :one
+SELECT * FROM user_imports WHERE id = $1;
+
+-- name: ListUserImports :many
+SELECT * FROM user_imports ORDER BY created_at DESC, id DESC LIMIT $1;
+
+-- name: ClaimUserImport :one
+-- Pending and failed imports, and running ones without progress since
+-- stale_before (their process stopped), are started by one caller only
+UPDATE user_imports SET status = 'running', error = NULL, finished_at = NULL, updated_at = NOW()
+WHERE id = sqlc.arg('id')
+  AND (status IN ('pending', 'failed') OR (status = 'running' AND updated_at < sqlc.arg('stale_before')))
+RETURNING *;
+
+-- name: AdvanceUserImport :execrows
+-- Checkpoint of a processed batch. Conditional on the checkpoint the batch
+-- started from, so a batch processed twice is only counted once
+UPDATE user_imports SET
+    rows_processed = sqlc.arg('rows_processed'),
+    rows_imported  = rows_imported + sqlc.arg('rows_imported')::bigint,
+    rows_failed    = rows_failed + sqlc.arg('rows_failed')::bigint,
+    updated_at     = NOW()
+WHERE id = sqlc.arg('id') AND status = 'running' AND rows_processed = sqlc.arg('from_row');
+
+-- name: FinishUserImport :one
+UPDATE user_imports SET status = $2, error = $3, finished_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'running'
+RETURNING *;
+
+-- name: CreateUserImportErrors :execrows
+-- Inserts a batch in one statement: COPY is refused on tables with row-level
+-- security. An empty field is stored as NULL
+INSERT INTO user_import_errors (import_id, row_number, field, message)
+SELECT @import_id::bigint, r.row_number, NULLIF(r.field, ''), r.message
+FROM unnest(@row_numbers::bigint[], @fields::varchar[], @messages::text[]) AS r(row_number, field, message);
+
+-- name: ListUserImportErrors :many
+SELECT * FROM user_import_errors
+WHERE import_id = $1
+ORDER BY row_number, id
+LIMIT $2 OFFSET $3;
+
+-- name: CountUserImportErrors :one
+SELECT COUNT(*) FROM user_import_errors WHERE import_id = $1;
@@ -1,3 +1,18 @@
+CREATE TABLE IF NOT EXISTS tenants (
+    id         BIGSERIAL PRIMARY KEY,
+    slug       VARCHAR(50) NOT NULL,
+    name       VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT tenants_slug_key UNIQUE (slug),
+    CONSTRAINT tenants_slug_check CHECK (slug ~ '^[a-z][a-z0-9-]*$')
+);
+
+-- Tenant of the current transaction (SET LOCAL app.tenant_id); NULL when unset, which
+-- matches no row
+CREATE OR REPLACE FUNCTION app_tenant_id() RETURNS BIGINT
+    LANGUAGE sql STABLE
+    AS $$ SELECT NULLIF(current_setting('app.tenant_id', true), '')::BIGINT $$;
+
 CREATE TABLE IF NOT EXISTS users (
     id            BIGSERIAL PRIMARY KEY,
     email         VARCHAR(255) NOT NULL,
@@ -9,14 +24,23 @@ CREATE TABLE IF NOT EXISTS users (
     hash_password VARCHAR(255) NOT NULL,
     created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-    deleted_at    TIMESTAMPTZ
+    deleted_at    TIMESTAMPTZ,
+    role          VARCHAR(20) NOT NULL DEFAULT 'user',
+    version       BIGINT NOT NULL DEFAULT 1,
+    purge_at      TIMESTAMPTZ,
+    self_deleted  BOOLEAN NOT NULL DEFAULT FALSE,
+    status        VARCHAR(20) NOT NULL DEFAULT 'active',
+    status_reason VARCHAR(500),
+    status_changed_at TIMESTAMPTZ,
+    tenant_id     BIGINT NOT NULL DEFAULT COALESCE(app_tenant_id(), 1) REFERENCES tenants(id),
+    CONSTRAINT users_status_check CHECK (status IN ('pending', 'active', 'suspended', 'banned'))
 );
 -- Performance indices for common query patterns
--- Index on created_at for sorting and date range queries
-CREATE INDEX IF NOT EXISTS idx_users_created_at ON users(created_at DESC);
+-- Index on (created_at, id) for sorting, date range queries and keyset seeks
+CREATE INDEX IF NOT EXISTS idx_users_created_at_id ON users(created_at DESC, id DESC);
 
--- Index on updated_at for change tracking queries
-CREATE INDEX IF NOT EXISTS idx_users_updated_at ON users(updated_at DESC);
+-- Index on (updated_at, id) for change tracking queries and keyset seeks
+CREATE INDEX IF NOT EXISTS idx_users_updated_at_id ON users(updated_at DESC, id DESC);
 
 -- Partial index for active user lookups (example for future use)
 CREATE INDEX IF NOT EXISTS idx_users_username ON users(username);
@@ -26,3 +50,189 @@ CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
 
 -- Partial index for phone number lookups (example for future use)
 CREATE INDEX IF NOT EXISTS idx_users_phone_number ON users(phone_number);
+
+-- Expression index for the admin email domain filter
+CREATE INDEX IF NOT EXISTS idx_users_email_domain ON users (lower(split_part(email, '@', 2)));
+
+-- Admin user search: full-text (prefix) and trigram (partial, fuzzy) matching
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS idx_users_search_fts ON users
+    USING GIN (to_tsvector('simple', username || ' ' || first_name || ' ' || last_name || ' ' || email));
+CREATE INDEX IF NOT EXISTS idx_users_search_trgm ON users
+    USING GIN (lower(username || ' ' || first_name || ' ' || last_name || ' ' || email || ' ' || phone_number) gin_trgm_ops);
+
+-- Partial index for the purge job of deleted accounts
+CREATE INDEX IF NOT EXISTS idx_users_purge_at ON users(purge_at) WHERE purge_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS user_totp_secrets (
+    user_id        BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret         VARCHAR(128) NOT NULL,
+    enabled        BOOLEAN NOT NULL DEFAULT FALSE,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS tiers (
+    id         BIGSERIAL PRIMARY KEY,
+    code       VARCHAR(50) NOT NULL UNIQUE,
+    name       VARCHAR(100) NOT NULL,
+    rate_limit INTEGER NOT NULL DEFAULT 0,
+    features   TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS user_tiers (
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tier_id     BIGINT NOT NULL REFERENCES tiers(id),
+    valid_from  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    valid_to    TIMESTAMPTZ,
+    assigned_by BIGINT,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT user_tiers_valid_period CHECK (valid_to IS NULL OR valid_to > valid_from)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_tiers_user_id ON user_tiers(user_id, valid_from DESC);
+
+CREATE TABLE IF NOT EXISTS user_settings (
+    user_id    BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    settings   JSONB NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id         BIGSERIAL PRIMARY KEY,
+    slug       VARCHAR(50) NOT NULL,
+    name       VARCHAR(100) NOT NULL,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    tenant_id  BIGINT NOT NULL DEFAULT COALESCE(app_tenant_id(), 1) REFERENCES tenants(id),
+    CONSTRAINT organizations_slug_key UNIQUE (tenant_id, slug)
+);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id         BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role            VARCHAR(20) NOT NULL DEFAULT 'member',
+    selected_at     TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT organization_members_pkey PRIMARY KEY (organization_id, user_id),
+    CONSTRAINT organization_members_role_check CHECK (role IN ('owner', 'admin', 'member'))
+);
+
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    id              BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email           VARCHAR(255) NOT NULL,
+    role            VARCHAR(20) NOT NULL DEFAULT 'member',
+    token_hash      VARCHAR(64) NOT NULL,
+    invited_by      BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    expires_at      TIMESTAMPTZ NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT organization_invitations_token_hash_key UNIQUE (token_hash),
+    CONSTRAINT organization_invitations_email_key UNIQUE (organization_id, email),
+    CONSTRAINT organization_invitations_role_check CHECK (role IN ('admin', 'member'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_members_user_id ON organization_members(user_id, selected_at DESC);
+
+CREATE TABLE IF NOT EXISTS username_history (
+    id             BIGSERIAL PRIMARY KEY,
+    user_id        BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    username       VARCHAR(50) NOT NULL,
+    changed_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reserved_until TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_username_history_username ON username_history(username, reserved_until DESC);
+CREATE INDEX IF NOT EXISTS idx_username_history_user_id ON username_history(user_id, changed_at DESC);
+
+CREATE TABLE IF NOT EXISTS user_imports (
+    id             BIGSERIAL PRIMARY KEY,
+    format         VARCHAR(10) NOT NULL,
+    source         VARCHAR(255) NOT NULL,
+    dry_run        BOOLEAN NOT NULL DEFAULT FALSE,
+    status         VARCHAR(20) NOT NULL DEFAULT 'pending',
+    rows_processed BIGINT NOT NULL DEFAULT 0,
+    rows_imported  BIGINT NOT NULL DEFAULT 0,
+    rows_failed    BIGINT NOT NULL DEFAULT 0,
+    error          TEXT,
+    created_by     BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at    TIMESTAMPTZ,
+    tenant_id      BIGINT NOT NULL DEFAULT COALESCE(app_tenant_id(), 1) REFERENCES tenants(id),
+    CONSTRAINT user_imports_format_check CHECK (format IN ('csv', 'ndjson')),
+    CONSTRAINT user_imports_status_check CHECK (status IN ('pending', 'running', 'completed', 'failed'))
+);
+
+CREATE TABLE IF NOT EXISTS user_import_errors (
+    id         BIGSERIAL PRIMARY KEY,
+    import_id  BIGINT NOT NULL REFERENCES user_imports(id) ON DELETE CASCADE,
+    row_number BIGINT NOT NULL,
+    field      VARCHAR(50),
+    message    TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_imports_created_at ON user_imports(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_user_import_errors_import_id ON user_import_errors(import_id, row_number);
+
+CREATE INDEX IF NOT EXISTS idx_users_tenant_id ON users(tenant_id);
+
+-- Row-level security: only rows of the tenant in app.tenant_id are visible; without it none are
+ALTER TABLE users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE users FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON users
+    USING (tenant_id = app_tenant_id())
+    WITH CHECK (tenant_id = app_tenant_id());
+
+ALTER TABLE organizations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE organizations FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON organizations
+    USING (tenant_id = app_tenant_id())
+    WITH CHECK (tenant_id = app_tenant_id());
+
+ALTER TABLE user_totp_secrets ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_totp_secrets FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON user_totp_secrets
+    USING (EXISTS (SELECT 1 FROM users WHERE users.id = user_totp_secrets.user_id));
+
+ALTER TABLE user_tiers ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_tiers FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON user_tiers
+    USING (EXISTS (SELECT 1 FROM users WHERE users.id = user_tiers.user_id));
+
+ALTER TABLE user_settings ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_settings FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON user_settings
+    USING (EXISTS (SELECT 1 FROM users WHERE users.id = user_settings.user_id));
+
+ALTER TABLE organization_members ENABLE ROW LEVEL SECURITY;
+ALTER TABLE organization_members FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON organization_members
+    USING (EXISTS (SELECT 1 FROM organizations WHERE organizations.id = organization_members.organization_id)
+       AND EXISTS (SELECT 1 FROM users WHERE users.id = organization_members.user_id));
+
+ALTER TABLE organization_invitations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE organization_invitations FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON organization_invitations
+    USING (EXISTS (SELECT 1 FROM organizations WHERE organizations.id = organization_invitations.organization_id));
+
+ALTER TABLE username_history ENABLE ROW LEVEL SECURITY;
+ALTER TABLE username_history FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON username_history
+    USING (EXISTS (SELECT 1 FROM users WHERE users.id = username_history.user_id));
+
+ALTER TABLE user_imports ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_imports FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON user_imports
+    USING (tenant_id = app_tenant_id())
+    WITH CHECK (tenant_id = app_tenant_id());
+
+ALTER TABLE user_import_errors ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_import_errors FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON user_import_errors
+    USING (EXISTS (SELECT 1 FROM user_imports WHERE user_imports.id = user_import_errors.import_id));
@@ -8,16 +8,128 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Organization struct {
+	ID        int64              `json:"id"`
+	Slug      string             `json:"slug"`
+	Name      string             `json:"name"`
+	CreatedBy pgtype.Int8        `json:"created_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	TenantID  int64              `json:"tenant_id"`
+}
+
+type OrganizationInvitation struct {
+	ID             int64              `json:"id"`
+	OrganizationID int64              `json:"organization_id"`
+	Email          string             `json:"email"`
+	Role           string             `json:"role"`
+	TokenHash      string             `json:"token_hash"`
+	InvitedBy      pgtype.Int8        `json:"invited_by"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type OrganizationMember struct {
+	OrganizationID int64              `json:"organization_id"`
+	UserID         int64              `json:"user_id"`
+	Role           string             `json:"role"`
+	SelectedAt     pgtype.Timestamptz `json:"selected_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type Tenant struct {
+	ID        int64              `json:"id"`
+	Slug      string             `json:"slug"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Tier struct {
+	ID        int64              `json:"id"`
+	Code      string             `json:"code"`
+	Name      string             `json:"name"`
+	RateLimit int32              `json:"rate_limit"`
+	Features  []string           `json:"features"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
 type User struct {
-	ID           int64              `json:"id"`
-	Email        string             `json:"email"`
-	Avatar       pgtype.Text        `json:"avatar"`
-	PhoneNumber  string             `json:"phone_number"`
-	Username     string             `json:"username"`
-	FirstName    string             `json:"first_name"`
-	LastName     string             `json:"last_name"`
-	HashPassword string             `json:"hash_password"`
+	ID              int64              `json:"id"`
+	Email           string             `json:"email"`
+	Avatar          pgtype.Text        `json:"avatar"`
+	PhoneNumber     string             `json:"phone_number"`
+	Username        string             `json:"username"`
+	FirstName       string             `json:"first_name"`
+	LastName        string             `json:"last_name"`
+	HashPassword    string             `json:"hash_password"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt       pgtype.Timestamptz `json:"deleted_at"`
+	Role            string             `json:"role"`
+	Version         int64              `json:"version"`
+	PurgeAt         pgtype.Timestamptz `json:"purge_at"`
+	SelfDeleted     bool               `json:"self_deleted"`
+	Status          string             `json:"status"`
+	StatusReason    pgtype.Text        `json:"status_reason"`
+	StatusChangedAt pgtype.Timestamptz `json:"status_changed_at"`
+	TenantID        int64              `json:"tenant_id"`
+}
+
+type UserImport struct {
+	ID            int64              `json:"id"`
+	Format        string             `json:"format"`
+	Source        string             `json:"source"`
+	DryRun        bool               `json:"dry_run"`
+	Status        string             `json:"status"`
+	RowsProcessed int64              `json:"rows_processed"`
+	RowsImported  int64              `json:"rows_imported"`
+	RowsFailed    int64              `json:"rows_failed"`
+	Error         pgtype.Text        `json:"error"`
+	CreatedBy     pgtype.Int8        `json:"created_by"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	FinishedAt    pgtype.Timestamptz `json:"finished_at"`
+	TenantID      int64              `json:"tenant_id"`
+}
+
+type UserImportError struct {
+	ID        int64       `json:"id"`
+	ImportID  int64       `json:"import_id"`
+	RowNumber int64       `json:"row_number"`
+	Field     pgtype.Text `json:"field"`
+	Message   string      `json:"message"`
+}
+
+type UserSetting struct {
+	UserID    int64              `json:"user_id"`
+	Settings  []byte             `json:"settings"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type UserTier struct {
+	ID         int64              `json:"id"`
+	UserID     int64              `json:"user_id"`
+	TierID     int64              `json:"tier_id"`
+	ValidFrom  pgtype.Timestamptz `json:"valid_from"`
+	ValidTo    pgtype.Timestamptz `json:"valid_to"`
+	AssignedBy pgtype.Int8        `json:"assigned_by"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type UserTotpSecret struct {
+	UserID       int64              `json:"user_id"`
+	Secret       string             `json:"secret"`
+	Enabled      bool               `json:"enabled"`
+	LastUsedStep int64              `json:"last_used_step"`
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt    pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type UsernameHistory struct {
+	ID            int64              `json:"id"`
+	UserID        int64              `json:"user_id"`
+	Username      string             `json:"username"`
+	ChangedAt     pgtype.Timestamptz `json:"changed_at"`
+	ReservedUntil pgtype.Timestamptz `json:"reserved_until"`
 }
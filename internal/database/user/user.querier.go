@@ -9,11 +9,106 @@ import (
 )
 
 type Querier interface {
+	// Consumes the invitation and adds the member in one statement; a member
+	// conflict keeps the invitation
+	AcceptOrganizationInvitation(ctx context.Context, arg *AcceptOrganizationInvitationParams) (*OrganizationMember, error)
+	// Checkpoint of a processed batch. Conditional on the checkpoint the batch
+	// started from, so a batch processed twice is only counted once
+	AdvanceUserImport(ctx context.Context, arg *AdvanceUserImportParams) (int64, error)
+	// Records the old username in the same statement. Conditional on the current
+	// username, so of two concurrent changes only one applies
+	ChangeUsername(ctx context.Context, arg *ChangeUsernameParams) (*User, error)
+	// Pending and failed imports, and running ones without progress since
+	// stale_before (their process stopped), are started by one caller only
+	ClaimUserImport(ctx context.Context, arg *ClaimUserImportParams) (*UserImport, error)
+	// Only advances forward so each code is accepted at most once
+	ConsumeUserTOTPStep(ctx context.Context, arg *ConsumeUserTOTPStepParams) (int64, error)
+	CountFilteredUsers(ctx context.Context, arg *CountFilteredUsersParams) (int64, error)
+	CountSearchUsers(ctx context.Context, arg *CountSearchUsersParams) (int64, error)
+	// The creator becomes the first owner in the same statement
+	CountUserImportErrors(ctx context.Context, importID int64) (int64, error)
+	CreateOrganization(ctx context.Context, arg *CreateOrganizationParams) (*Organization, error)
+	// Inviting an address again replaces the pending invitation and its token
+	CreateOrganizationInvitation(ctx context.Context, arg *CreateOrganizationInvitationParams) (*OrganizationInvitation, error)
 	CreateUser(ctx context.Context, arg *CreateUserParams) (*User, error)
+	CreateUserImport(ctx context.Context, arg *CreateUserImportParams) (*UserImport, error)
+	// Inserts a batch in one statement: COPY is refused on tables with row-level
+	// security. An empty field is stored as NULL
+	CreateUserImportErrors(ctx context.Context, arg *CreateUserImportErrorsParams) (int64, error)
+	CreateUserTier(ctx context.Context, arg *CreateUserTierParams) (*UserTier, error)
+	// Moves a failed purge back so the next batches list the accounts behind it
+	DeferUserPurge(ctx context.Context, arg *DeferUserPurgeParams) (int64, error)
+	DeleteOrganizationInvitation(ctx context.Context, arg *DeleteOrganizationInvitationParams) (int64, error)
+	// Locks the owners like UpdateOrganizationMemberRole; the last owner is not
+	// deleted
+	DeleteOrganizationMember(ctx context.Context, arg *DeleteOrganizationMemberParams) (int64, error)
+	DeleteUserTOTPSecret(ctx context.Context, userID int64) error
+	// Ends the assignments valid now; scheduled ones are kept
+	EndUserTiers(ctx context.Context, userID int64) (int64, error)
+	// NULL filters are skipped and the sort column is picked with CASE, so the statement is static.
+	// after_* is the keyset position of the previous page: (sort column, id) past it in sort order
+	FilterUsers(ctx context.Context, arg *FilterUsersParams) ([]*User, error)
+	FinishUserImport(ctx context.Context, arg *FinishUserImportParams) (*UserImport, error)
+	GetActiveOrganization(ctx context.Context, userID int64) (*GetActiveOrganizationRow, error)
+	// The latest assignment valid now wins, so a temporary assignment overrides an open-ended one
+	GetCurrentUserTier(ctx context.Context, userID int64) (*GetCurrentUserTierRow, error)
+	GetLatestUsernameChange(ctx context.Context, userID int64) (*UsernameHistory, error)
+	GetOrganization(ctx context.Context, id int64) (*Organization, error)
+	GetOrganizationInvitationByToken(ctx context.Context, tokenHash string) (*OrganizationInvitation, error)
+	GetOrganizationMember(ctx context.Context, arg *GetOrganizationMemberParams) (*OrganizationMember, error)
+	GetTenant(ctx context.Context, id int64) (*Tenant, error)
+	GetTenantBySlug(ctx context.Context, slug string) (*Tenant, error)
+	GetTierByCode(ctx context.Context, code string) (*Tier, error)
 	GetUser(ctx context.Context, id int64) (*User, error)
+	GetUserByEmail(ctx context.Context, email string) (*User, error)
 	GetUserByUserName(ctx context.Context, username string) (*User, error)
 	GetUserByUsernameOrEmail(ctx context.Context, username string) (*User, error)
+	GetUserByUsernameOrEmailWithDeleted(ctx context.Context, username string) (*User, error)
+	GetUserImport(ctx context.Context, id int64) (*UserImport, error)
+	GetUserSettings(ctx context.Context, userID int64) (*UserSetting, error)
+	GetUserTOTPSecret(ctx context.Context, userID int64) (*UserTotpSecret, error)
+	GetUserWithDeleted(ctx context.Context, id int64) (*User, error)
+	GetUsernameReservation(ctx context.Context, username string) (*UsernameHistory, error)
+	// Inserts a batch in one statement. Rows whose username or email is taken,
+	// or whose username is reserved, are skipped; the usernames of the inserted
+	// rows are returned
+	ImportUsers(ctx context.Context, arg *ImportUsersParams) ([]string, error)
+	// Why ImportUsers skipped rows: for each username and email pair, whether
+	// the username or email is taken and whether the username is reserved
+	ListImportConflicts(ctx context.Context, arg *ListImportConflictsParams) ([]*ListImportConflictsRow, error)
+	ListOrganizationInvitations(ctx context.Context, organizationID int64) ([]*OrganizationInvitation, error)
+	ListOrganizationMembers(ctx context.Context, organizationID int64) ([]*ListOrganizationMembersRow, error)
+	ListTiers(ctx context.Context) ([]*Tier, error)
+	ListUserImportErrors(ctx context.Context, arg *ListUserImportErrorsParams) ([]*UserImportError, error)
+	ListUserImports(ctx context.Context, limit int32) ([]*UserImport, error)
+	ListUserOrganizations(ctx context.Context, userID int64) ([]*ListUserOrganizationsRow, error)
+	ListUserTiers(ctx context.Context, userID int64) ([]*ListUserTiersRow, error)
+	ListUsernameHistory(ctx context.Context, userID int64) ([]*UsernameHistory, error)
+	// Keyset pagination on (created_at DESC, id DESC); NULL cursor values start from the first page
 	ListUsers(ctx context.Context, arg *ListUsersParams) ([]*User, error)
+	ListUsersDueForPurge(ctx context.Context, limit int32) ([]*ListUsersDueForPurgeRow, error)
+	// Applied in one statement so concurrent changes of different keys are all kept
+	MergeUserSettings(ctx context.Context, arg *MergeUserSettingsParams) (*UserSetting, error)
+	// Only non-NULL parameters are applied; clear_avatar removes the avatar.
+	// A non-NULL expected_version makes the update conditional (optimistic concurrency)
+	PatchUserProfile(ctx context.Context, arg *PatchUserProfileParams) (*User, error)
+	// Rows restored in the meantime are left alone; dependent rows cascade
+	PurgeUser(ctx context.Context, id int64) (int64, error)
+	ReplaceUserSettings(ctx context.Context, arg *ReplaceUserSettingsParams) (*UserSetting, error)
+	RestoreUser(ctx context.Context, id int64) (int64, error)
+	// Full-text prefix matches rank above fuzzy trigram matches. The search expressions
+	// must match idx_users_search_fts / idx_users_search_trgm to use the indexes.
+	SearchUsers(ctx context.Context, arg *SearchUsersParams) ([]*SearchUsersRow, error)
+	SelectOrganization(ctx context.Context, arg *SelectOrganizationParams) (int64, error)
+	// A NULL purge_at keeps the row until it is restored
+	SoftDeleteUser(ctx context.Context, arg *SoftDeleteUserParams) (int64, error)
+	// Locks the owners before demoting one, so concurrent demotions cannot leave
+	// the organization without an owner; the last owner is not updated
+	UpdateOrganizationMemberRole(ctx context.Context, arg *UpdateOrganizationMemberRoleParams) (*OrganizationMember, error)
+	// Only non-NULL parameters are applied
+	UpdateUser(ctx context.Context, arg *UpdateUserParams) (*User, error)
+	// Conditional on the current status, so concurrent changes cannot skip a transition check
+	UpdateUserStatus(ctx context.Context, arg *UpdateUserStatusParams) (*User, error)
 	// DEPRECATED: This query has a SQL injection vulnerability. Use GetUserByUsernameOrEmail instead.
 	ValidateUserPasswordByUserName(ctx context.Context, arg *ValidateUserPasswordByUserNameParams) (*User, error)
 }
@@ -7,29 +7,103 @@ package user
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const CreateUser = `-- name: CreateUser :one
-INSERT INTO users (username, email, phone_number, first_name, last_name, hash_password) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at
+const AcceptOrganizationInvitation = `-- name: AcceptOrganizationInvitation :one
+WITH invitation AS (
+    DELETE FROM organization_invitations WHERE id = $1
+    RETURNING organization_id, role
+)
+INSERT INTO organization_members (organization_id, user_id, role)
+SELECT organization_id, $2, role FROM invitation
+RETURNING organization_id, user_id, role, selected_at, created_at
 `
 
-type CreateUserParams struct {
-	Username     string `json:"username"`
-	Email        string `json:"email"`
-	PhoneNumber  string `json:"phone_number"`
-	FirstName    string `json:"first_name"`
-	LastName     string `json:"last_name"`
-	HashPassword string `json:"hash_password"`
+type AcceptOrganizationInvitationParams struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
 }
 
-func (q *Queries) CreateUser(ctx context.Context, arg *CreateUserParams) (*User, error) {
-	row := q.db.QueryRow(ctx, CreateUser,
+// Consumes the invitation and adds the member in one statement; a member
+// conflict keeps the invitation
+func (q *Queries) AcceptOrganizationInvitation(ctx context.Context, arg *AcceptOrganizationInvitationParams) (*OrganizationMember, error) {
+	row := q.db.QueryRow(ctx, AcceptOrganizationInvitation, arg.ID, arg.UserID)
+	var i OrganizationMember
+	err := row.Scan(
+		&i.OrganizationID,
+		&i.UserID,
+		&i.Role,
+		&i.SelectedAt,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const AdvanceUserImport = `-- name: AdvanceUserImport :execrows
+UPDATE user_imports SET
+    rows_processed = $1,
+    rows_imported  = rows_imported + $2::bigint,
+    rows_failed    = rows_failed + $3::bigint,
+    updated_at     = NOW()
+WHERE id = $4 AND status = 'running' AND rows_processed = $5
+`
+
+type AdvanceUserImportParams struct {
+	RowsProcessed int64 `json:"rows_processed"`
+	RowsImported  int64 `json:"rows_imported"`
+	RowsFailed    int64 `json:"rows_failed"`
+	ID            int64 `json:"id"`
+	FromRow       int64 `json:"from_row"`
+}
+
+// Checkpoint of a processed batch. Conditional on the checkpoint the batch
+// started from, so a batch processed twice is only counted once
+func (q *Queries) AdvanceUserImport(ctx context.Context, arg *AdvanceUserImportParams) (int64, error) {
+	result, err := q.db.Exec(ctx, AdvanceUserImport,
+		arg.RowsProcessed,
+		arg.RowsImported,
+		arg.RowsFailed,
+		arg.ID,
+		arg.FromRow,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const ChangeUsername = `-- name: ChangeUsername :one
+WITH renamed AS (
+    UPDATE users SET
+        username   = $1,
+        version    = version + 1,
+        updated_at = NOW()
+    WHERE id = $2 AND deleted_at IS NULL AND username = $3
+    RETURNING id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at, role, version, purge_at, self_deleted, status, status_reason, status_changed_at, tenant_id
+), history AS (
+    INSERT INTO username_history (user_id, username, reserved_until)
+    SELECT id, $3, $4 FROM renamed
+)
+SELECT id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at, role, version, purge_at, self_deleted, status, status_reason, status_changed_at, tenant_id FROM renamed
+`
+
+type ChangeUsernameParams struct {
+	Username      string             `json:"username"`
+	ID            int64              `json:"id"`
+	OldUsername   string             `json:"old_username"`
+	ReservedUntil pgtype.Timestamptz `json:"reserved_until"`
+}
+
+// Records the old username in the same statement. Conditional on the current
+// username, so of two concurrent changes only one applies
+func (q *Queries) ChangeUsername(ctx context.Context, arg *ChangeUsernameParams) (*User, error) {
+	row := q.db.QueryRow(ctx, ChangeUsername,
 		arg.Username,
-		arg.Email,
-		arg.PhoneNumber,
-		arg.FirstName,
-		arg.LastName,
-		arg.HashPassword,
+		arg.ID,
+		arg.OldUsername,
+		arg.ReservedUntil,
 	)
 	var i User
 	err := row.Scan(
@@ -44,62 +118,245 @@ func (q *Queries) CreateUser(ctx context.Context, arg *CreateUserParams) (*User,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Role,
+		&i.Version,
+		&i.PurgeAt,
+		&i.SelfDeleted,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusChangedAt,
+		&i.TenantID,
 	)
 	return &i, err
 }
 
-const GetUser = `-- name: GetUser :one
-SELECT id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at FROM users WHERE id = $1 AND deleted_at IS NULL
+const ClaimUserImport = `-- name: ClaimUserImport :one
+UPDATE user_imports SET status = 'running', error = NULL, finished_at = NULL, updated_at = NOW()
+WHERE id = $1
+  AND (status IN ('pending', 'failed') OR (status = 'running' AND updated_at < $2))
+RETURNING id, format, source, dry_run, status, rows_processed, rows_imported, rows_failed, error, created_by, created_at, updated_at, finished_at, tenant_id
 `
 
-func (q *Queries) GetUser(ctx context.Context, id int64) (*User, error) {
-	row := q.db.QueryRow(ctx, GetUser, id)
-	var i User
+type ClaimUserImportParams struct {
+	ID          int64              `json:"id"`
+	StaleBefore pgtype.Timestamptz `json:"stale_before"`
+}
+
+// Pending and failed imports, and running ones without progress since
+// stale_before (their process stopped), are started by one caller only
+func (q *Queries) ClaimUserImport(ctx context.Context, arg *ClaimUserImportParams) (*UserImport, error) {
+	row := q.db.QueryRow(ctx, ClaimUserImport, arg.ID, arg.StaleBefore)
+	var i UserImport
 	err := row.Scan(
 		&i.ID,
-		&i.Email,
-		&i.Avatar,
-		&i.PhoneNumber,
-		&i.Username,
-		&i.FirstName,
-		&i.LastName,
-		&i.HashPassword,
+		&i.Format,
+		&i.Source,
+		&i.DryRun,
+		&i.Status,
+		&i.RowsProcessed,
+		&i.RowsImported,
+		&i.RowsFailed,
+		&i.Error,
+		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
-		&i.DeletedAt,
+		&i.FinishedAt,
+		&i.TenantID,
 	)
 	return &i, err
 }
 
-const GetUserByUserName = `-- name: GetUserByUserName :one
-SELECT id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at FROM users WHERE username = $1 AND deleted_at IS NULL
+const ConsumeUserTOTPStep = `-- name: ConsumeUserTOTPStep :execrows
+UPDATE user_totp_secrets SET last_used_step = $2, updated_at = NOW() WHERE user_id = $1 AND last_used_step < $2
 `
 
-func (q *Queries) GetUserByUserName(ctx context.Context, username string) (*User, error) {
-	row := q.db.QueryRow(ctx, GetUserByUserName, username)
-	var i User
+type ConsumeUserTOTPStepParams struct {
+	UserID       int64 `json:"user_id"`
+	LastUsedStep int64 `json:"last_used_step"`
+}
+
+// Only advances forward so each code is accepted at most once
+func (q *Queries) ConsumeUserTOTPStep(ctx context.Context, arg *ConsumeUserTOTPStepParams) (int64, error) {
+	result, err := q.db.Exec(ctx, ConsumeUserTOTPStep, arg.UserID, arg.LastUsedStep)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const CountFilteredUsers = `-- name: CountFilteredUsers :one
+SELECT COUNT(*) FROM users
+WHERE ($1::text = 'include'
+       OR ($1::text = 'exclude' AND deleted_at IS NULL)
+       OR ($1::text = 'only' AND deleted_at IS NOT NULL))
+  AND ($2::timestamptz IS NULL OR created_at >= $2::timestamptz)
+  AND ($3::timestamptz IS NULL OR created_at < $3::timestamptz)
+  AND ($4::text IS NULL OR lower(split_part(email, '@', 2)) = lower($4::text))
+  AND ($5::text IS NULL OR role = $5::text)
+  AND ($6::text IS NULL OR status = $6::text)
+`
+
+type CountFilteredUsersParams struct {
+	Deleted     string             `json:"deleted"`
+	CreatedFrom pgtype.Timestamptz `json:"created_from"`
+	CreatedTo   pgtype.Timestamptz `json:"created_to"`
+	EmailDomain pgtype.Text        `json:"email_domain"`
+	Role        pgtype.Text        `json:"role"`
+	Status      pgtype.Text        `json:"status"`
+}
+
+func (q *Queries) CountFilteredUsers(ctx context.Context, arg *CountFilteredUsersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, CountFilteredUsers,
+		arg.Deleted,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.EmailDomain,
+		arg.Role,
+		arg.Status,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const CountSearchUsers = `-- name: CountSearchUsers :one
+SELECT COUNT(*) FROM users
+WHERE ($1::boolean OR deleted_at IS NULL)
+  AND (to_tsvector('simple', username || ' ' || first_name || ' ' || last_name || ' ' || email) @@ to_tsquery('simple', $2::text)
+       OR lower(username || ' ' || first_name || ' ' || last_name || ' ' || email || ' ' || phone_number) LIKE $3::text
+       OR $4::text <% lower(username || ' ' || first_name || ' ' || last_name || ' ' || email || ' ' || phone_number))
+`
+
+type CountSearchUsersParams struct {
+	IncludeDeleted bool   `json:"include_deleted"`
+	TsQuery        string `json:"ts_query"`
+	Pattern        string `json:"pattern"`
+	Term           string `json:"term"`
+}
+
+func (q *Queries) CountSearchUsers(ctx context.Context, arg *CountSearchUsersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, CountSearchUsers,
+		arg.IncludeDeleted,
+		arg.TsQuery,
+		arg.Pattern,
+		arg.Term,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const CountUserImportErrors = `-- name: CountUserImportErrors :one
+SELECT COUNT(*) FROM user_import_errors WHERE import_id = $1
+`
+
+func (q *Queries) CountUserImportErrors(ctx context.Context, importID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, CountUserImportErrors, importID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const CreateOrganization = `-- name: CreateOrganization :one
+WITH organization AS (
+    INSERT INTO organizations (slug, name, created_by) VALUES ($1, $2, $3)
+    RETURNING id, slug, name, created_by, created_at, updated_at, tenant_id
+), owner AS (
+    INSERT INTO organization_members (organization_id, user_id, role)
+    SELECT id, created_by, 'owner' FROM organization
+)
+SELECT id, slug, name, created_by, created_at, updated_at, tenant_id FROM organization
+`
+
+type CreateOrganizationParams struct {
+	Slug      string      `json:"slug"`
+	Name      string      `json:"name"`
+	CreatedBy pgtype.Int8 `json:"created_by"`
+}
+
+// The creator becomes the first owner in the same statement
+func (q *Queries) CreateOrganization(ctx context.Context, arg *CreateOrganizationParams) (*Organization, error) {
+	row := q.db.QueryRow(ctx, CreateOrganization, arg.Slug, arg.Name, arg.CreatedBy)
+	var i Organization
 	err := row.Scan(
 		&i.ID,
-		&i.Email,
-		&i.Avatar,
-		&i.PhoneNumber,
-		&i.Username,
-		&i.FirstName,
-		&i.LastName,
-		&i.HashPassword,
+		&i.Slug,
+		&i.Name,
+		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
-		&i.DeletedAt,
+		&i.TenantID,
 	)
 	return &i, err
 }
 
-const GetUserByUsernameOrEmail = `-- name: GetUserByUsernameOrEmail :one
-SELECT id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at FROM users WHERE (username = $1 OR email = $1) AND deleted_at IS NULL
+const CreateOrganizationInvitation = `-- name: CreateOrganizationInvitation :one
+INSERT INTO organization_invitations (organization_id, email, role, token_hash, invited_by, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (organization_id, email) DO UPDATE SET
+    role       = EXCLUDED.role,
+    token_hash = EXCLUDED.token_hash,
+    invited_by = EXCLUDED.invited_by,
+    expires_at = EXCLUDED.expires_at,
+    created_at = NOW()
+RETURNING id, organization_id, email, role, token_hash, invited_by, expires_at, created_at
 `
 
-func (q *Queries) GetUserByUsernameOrEmail(ctx context.Context, username string) (*User, error) {
-	row := q.db.QueryRow(ctx, GetUserByUsernameOrEmail, username)
+type CreateOrganizationInvitationParams struct {
+	OrganizationID int64              `json:"organization_id"`
+	Email          string             `json:"email"`
+	Role           string             `json:"role"`
+	TokenHash      string             `json:"token_hash"`
+	InvitedBy      pgtype.Int8        `json:"invited_by"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+}
+
+// Inviting an address again replaces the pending invitation and its token
+func (q *Queries) CreateOrganizationInvitation(ctx context.Context, arg *CreateOrganizationInvitationParams) (*OrganizationInvitation, error) {
+	row := q.db.QueryRow(ctx, CreateOrganizationInvitation,
+		arg.OrganizationID,
+		arg.Email,
+		arg.Role,
+		arg.TokenHash,
+		arg.InvitedBy,
+		arg.ExpiresAt,
+	)
+	var i OrganizationInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.Email,
+		&i.Role,
+		&i.TokenHash,
+		&i.InvitedBy,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const CreateUser = `-- name: CreateUser :one
+INSERT INTO users (username, email, phone_number, first_name, last_name, hash_password) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at, role, version, purge_at, self_deleted, status, status_reason, status_changed_at, tenant_id
+`
+
+type CreateUserParams struct {
+	Username     string `json:"username"`
+	Email        string `json:"email"`
+	PhoneNumber  string `json:"phone_number"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	HashPassword string `json:"hash_password"`
+}
+
+func (q *Queries) CreateUser(ctx context.Context, arg *CreateUserParams) (*User, error) {
+	row := q.db.QueryRow(ctx, CreateUser,
+		arg.Username,
+		arg.Email,
+		arg.PhoneNumber,
+		arg.FirstName,
+		arg.LastName,
+		arg.HashPassword,
+	)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -113,21 +370,274 @@ func (q *Queries) GetUserByUsernameOrEmail(ctx context.Context, username string)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Role,
+		&i.Version,
+		&i.PurgeAt,
+		&i.SelfDeleted,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusChangedAt,
+		&i.TenantID,
 	)
 	return &i, err
 }
 
-const ListUsers = `-- name: ListUsers :many
-SELECT id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at FROM users WHERE deleted_at IS NULL ORDER BY id LIMIT $1 OFFSET $2
+const CreateUserImport = `-- name: CreateUserImport :one
+INSERT INTO user_imports (format, source, dry_run, created_by) VALUES ($1, $2, $3, $4)
+RETURNING id, format, source, dry_run, status, rows_processed, rows_imported, rows_failed, error, created_by, created_at, updated_at, finished_at, tenant_id
 `
 
-type ListUsersParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+type CreateUserImportParams struct {
+	Format    string      `json:"format"`
+	Source    string      `json:"source"`
+	DryRun    bool        `json:"dry_run"`
+	CreatedBy pgtype.Int8 `json:"created_by"`
 }
 
-func (q *Queries) ListUsers(ctx context.Context, arg *ListUsersParams) ([]*User, error) {
-	rows, err := q.db.Query(ctx, ListUsers, arg.Limit, arg.Offset)
+func (q *Queries) CreateUserImport(ctx context.Context, arg *CreateUserImportParams) (*UserImport, error) {
+	row := q.db.QueryRow(ctx, CreateUserImport,
+		arg.Format,
+		arg.Source,
+		arg.DryRun,
+		arg.CreatedBy,
+	)
+	var i UserImport
+	err := row.Scan(
+		&i.ID,
+		&i.Format,
+		&i.Source,
+		&i.DryRun,
+		&i.Status,
+		&i.RowsProcessed,
+		&i.RowsImported,
+		&i.RowsFailed,
+		&i.Error,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.FinishedAt,
+		&i.TenantID,
+	)
+	return &i, err
+}
+
+const CreateUserImportErrors = `-- name: CreateUserImportErrors :execrows
+INSERT INTO user_import_errors (import_id, row_number, field, message)
+SELECT $1::bigint, r.row_number, NULLIF(r.field, ''), r.message
+FROM unnest($2::bigint[], $3::varchar[], $4::text[]) AS r(row_number, field, message)
+`
+
+type CreateUserImportErrorsParams struct {
+	ImportID   int64    `json:"import_id"`
+	RowNumbers []int64  `json:"row_numbers"`
+	Fields     []string `json:"fields"`
+	Messages   []string `json:"messages"`
+}
+
+// Inserts a batch in one statement: COPY is refused on tables with row-level
+// security. An empty field is stored as NULL
+func (q *Queries) CreateUserImportErrors(ctx context.Context, arg *CreateUserImportErrorsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, CreateUserImportErrors,
+		arg.ImportID,
+		arg.RowNumbers,
+		arg.Fields,
+		arg.Messages,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const CreateUserTier = `-- name: CreateUserTier :one
+INSERT INTO user_tiers (user_id, tier_id, valid_from, valid_to, assigned_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, user_id, tier_id, valid_from, valid_to, assigned_by, created_at
+`
+
+type CreateUserTierParams struct {
+	UserID     int64              `json:"user_id"`
+	TierID     int64              `json:"tier_id"`
+	ValidFrom  pgtype.Timestamptz `json:"valid_from"`
+	ValidTo    pgtype.Timestamptz `json:"valid_to"`
+	AssignedBy pgtype.Int8        `json:"assigned_by"`
+}
+
+func (q *Queries) CreateUserTier(ctx context.Context, arg *CreateUserTierParams) (*UserTier, error) {
+	row := q.db.QueryRow(ctx, CreateUserTier,
+		arg.UserID,
+		arg.TierID,
+		arg.ValidFrom,
+		arg.ValidTo,
+		arg.AssignedBy,
+	)
+	var i UserTier
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TierID,
+		&i.ValidFrom,
+		&i.ValidTo,
+		&i.AssignedBy,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const DeferUserPurge = `-- name: DeferUserPurge :execrows
+UPDATE users SET purge_at = $2 WHERE id = $1 AND deleted_at IS NOT NULL AND purge_at <= NOW()
+`
+
+type DeferUserPurgeParams struct {
+	ID      int64              `json:"id"`
+	PurgeAt pgtype.Timestamptz `json:"purge_at"`
+}
+
+// Moves a failed purge back so the next batches list the accounts behind it
+func (q *Queries) DeferUserPurge(ctx context.Context, arg *DeferUserPurgeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, DeferUserPurge, arg.ID, arg.PurgeAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const DeleteOrganizationInvitation = `-- name: DeleteOrganizationInvitation :execrows
+DELETE FROM organization_invitations WHERE id = $1 AND organization_id = $2
+`
+
+type DeleteOrganizationInvitationParams struct {
+	ID             int64 `json:"id"`
+	OrganizationID int64 `json:"organization_id"`
+}
+
+func (q *Queries) DeleteOrganizationInvitation(ctx context.Context, arg *DeleteOrganizationInvitationParams) (int64, error) {
+	result, err := q.db.Exec(ctx, DeleteOrganizationInvitation, arg.ID, arg.OrganizationID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const DeleteOrganizationMember = `-- name: DeleteOrganizationMember :execrows
+WITH owners AS (
+    SELECT user_id FROM organization_members
+    WHERE organization_id = $1 AND role = 'owner'
+    FOR UPDATE
+)
+DELETE FROM organization_members
+WHERE organization_id = $1 AND user_id = $2
+  AND (role <> 'owner' OR (SELECT COUNT(*) FROM owners) > 1)
+`
+
+type DeleteOrganizationMemberParams struct {
+	OrganizationID int64 `json:"organization_id"`
+	UserID         int64 `json:"user_id"`
+}
+
+func (q *Queries) DeleteOrganizationMember(ctx context.Context, arg *DeleteOrganizationMemberParams) (int64, error) {
+	result, err := q.db.Exec(ctx, DeleteOrganizationMember, arg.OrganizationID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const DeleteUserTOTPSecret = `-- name: DeleteUserTOTPSecret :exec
+DELETE FROM user_totp_secrets WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserTOTPSecret(ctx context.Context, userID int64) error {
+	_, err := q.db.Exec(ctx, DeleteUserTOTPSecret, userID)
+	return err
+}
+
+const EndUserTiers = `-- name: EndUserTiers :execrows
+UPDATE user_tiers SET valid_to = NOW()
+WHERE user_id = $1
+  AND valid_from <= NOW()
+  AND (valid_to IS NULL OR valid_to > NOW())
+`
+
+// Ends the assignments valid now; scheduled ones are kept
+func (q *Queries) EndUserTiers(ctx context.Context, userID int64) (int64, error) {
+	result, err := q.db.Exec(ctx, EndUserTiers, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const FilterUsers = `-- name: FilterUsers :many
+SELECT id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at, role, version, purge_at, self_deleted, status, status_reason, status_changed_at, tenant_id FROM users
+WHERE ($1::text = 'include'
+       OR ($1::text = 'exclude' AND deleted_at IS NULL)
+       OR ($1::text = 'only' AND deleted_at IS NOT NULL))
+  AND ($2::timestamptz IS NULL OR created_at >= $2::timestamptz)
+  AND ($3::timestamptz IS NULL OR created_at < $3::timestamptz)
+  AND ($4::text IS NULL OR lower(split_part(email, '@', 2)) = lower($4::text))
+  AND ($5::text IS NULL OR role = $5::text)
+  AND ($6::text IS NULL OR status = $6::text)
+  AND ($7::bigint IS NULL OR CASE
+        WHEN $8::text = 'created_at' AND $9::boolean THEN (created_at, id) < ($10::timestamptz, $7::bigint)
+        WHEN $8::text = 'created_at' THEN (created_at, id) > ($10::timestamptz, $7::bigint)
+        WHEN $8::text = 'updated_at' AND $9::boolean THEN (updated_at, id) < ($10::timestamptz, $7::bigint)
+        WHEN $8::text = 'updated_at' THEN (updated_at, id) > ($10::timestamptz, $7::bigint)
+        WHEN $8::text = 'username' AND $9::boolean THEN (username, id) < ($11::text, $7::bigint)
+        WHEN $8::text = 'username' THEN (username, id) > ($11::text, $7::bigint)
+        WHEN $8::text = 'email' AND $9::boolean THEN (email, id) < ($11::text, $7::bigint)
+        WHEN $8::text = 'email' THEN (email, id) > ($11::text, $7::bigint)
+        WHEN $9::boolean THEN id < $7::bigint
+        ELSE id > $7::bigint
+      END)
+ORDER BY
+    CASE WHEN $8::text = 'created_at' AND NOT $9::boolean THEN created_at END ASC,
+    CASE WHEN $8::text = 'created_at' AND $9::boolean THEN created_at END DESC,
+    CASE WHEN $8::text = 'updated_at' AND NOT $9::boolean THEN updated_at END ASC,
+    CASE WHEN $8::text = 'updated_at' AND $9::boolean THEN updated_at END DESC,
+    CASE WHEN $8::text = 'username' AND NOT $9::boolean THEN username END ASC,
+    CASE WHEN $8::text = 'username' AND $9::boolean THEN username END DESC,
+    CASE WHEN $8::text = 'email' AND NOT $9::boolean THEN email END ASC,
+    CASE WHEN $8::text = 'email' AND $9::boolean THEN email END DESC,
+    CASE WHEN $9::boolean THEN id END DESC,
+    id ASC
+LIMIT $12 OFFSET $13
+`
+
+type FilterUsersParams struct {
+	Deleted     string             `json:"deleted"`
+	CreatedFrom pgtype.Timestamptz `json:"created_from"`
+	CreatedTo   pgtype.Timestamptz `json:"created_to"`
+	EmailDomain pgtype.Text        `json:"email_domain"`
+	Role        pgtype.Text        `json:"role"`
+	Status      pgtype.Text        `json:"status"`
+	AfterID     pgtype.Int8        `json:"after_id"`
+	SortBy      string             `json:"sort_by"`
+	SortDesc    bool               `json:"sort_desc"`
+	AfterTime   pgtype.Timestamptz `json:"after_time"`
+	AfterText   pgtype.Text        `json:"after_text"`
+	LimitCount  int32              `json:"limit_count"`
+	OffsetCount int32              `json:"offset_count"`
+}
+
+// NULL filters are skipped and the sort column is picked with CASE, so the statement is static.
+// after_* is the keyset position of the previous page: (sort column, id) past it in sort order
+func (q *Queries) FilterUsers(ctx context.Context, arg *FilterUsersParams) ([]*User, error) {
+	rows, err := q.db.Query(ctx, FilterUsers,
+		arg.Deleted,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.EmailDomain,
+		arg.Role,
+		arg.Status,
+		arg.AfterID,
+		arg.SortBy,
+		arg.SortDesc,
+		arg.AfterTime,
+		arg.AfterText,
+		arg.LimitCount,
+		arg.OffsetCount,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -147,6 +657,14 @@ func (q *Queries) ListUsers(ctx context.Context, arg *ListUsersParams) ([]*User,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Role,
+			&i.Version,
+			&i.PurgeAt,
+			&i.SelfDeleted,
+			&i.Status,
+			&i.StatusReason,
+			&i.StatusChangedAt,
+			&i.TenantID,
 		); err != nil {
 			return nil, err
 		}
@@ -158,8 +676,1412 @@ func (q *Queries) ListUsers(ctx context.Context, arg *ListUsersParams) ([]*User,
 	return items, nil
 }
 
+const FinishUserImport = `-- name: FinishUserImport :one
+UPDATE user_imports SET status = $2, error = $3, finished_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'running'
+RETURNING id, format, source, dry_run, status, rows_processed, rows_imported, rows_failed, error, created_by, created_at, updated_at, finished_at, tenant_id
+`
+
+type FinishUserImportParams struct {
+	ID     int64       `json:"id"`
+	Status string      `json:"status"`
+	Error  pgtype.Text `json:"error"`
+}
+
+func (q *Queries) FinishUserImport(ctx context.Context, arg *FinishUserImportParams) (*UserImport, error) {
+	row := q.db.QueryRow(ctx, FinishUserImport, arg.ID, arg.Status, arg.Error)
+	var i UserImport
+	err := row.Scan(
+		&i.ID,
+		&i.Format,
+		&i.Source,
+		&i.DryRun,
+		&i.Status,
+		&i.RowsProcessed,
+		&i.RowsImported,
+		&i.RowsFailed,
+		&i.Error,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.FinishedAt,
+		&i.TenantID,
+	)
+	return &i, err
+}
+
+const GetActiveOrganization = `-- name: GetActiveOrganization :one
+SELECT organization_members.organization_id, organization_members.user_id, organization_members.role, organization_members.selected_at, organization_members.created_at, organizations.id, organizations.slug, organizations.name, organizations.created_by, organizations.created_at, organizations.updated_at, organizations.tenant_id
+FROM organization_members JOIN organizations ON organizations.id = organization_members.organization_id
+WHERE organization_members.user_id = $1 AND organization_members.selected_at IS NOT NULL
+ORDER BY organization_members.selected_at DESC
+LIMIT 1
+`
+
+type GetActiveOrganizationRow struct {
+	OrganizationMember OrganizationMember `json:"organization_member"`
+	Organization       Organization       `json:"organization"`
+}
+
+func (q *Queries) GetActiveOrganization(ctx context.Context, userID int64) (*GetActiveOrganizationRow, error) {
+	row := q.db.QueryRow(ctx, GetActiveOrganization, userID)
+	var i GetActiveOrganizationRow
+	err := row.Scan(
+		&i.OrganizationMember.OrganizationID,
+		&i.OrganizationMember.UserID,
+		&i.OrganizationMember.Role,
+		&i.OrganizationMember.SelectedAt,
+		&i.OrganizationMember.CreatedAt,
+		&i.Organization.ID,
+		&i.Organization.Slug,
+		&i.Organization.Name,
+		&i.Organization.CreatedBy,
+		&i.Organization.CreatedAt,
+		&i.Organization.UpdatedAt,
+		&i.Organization.TenantID,
+	)
+	return &i, err
+}
+
+const GetCurrentUserTier = `-- name: GetCurrentUserTier :one
+SELECT user_tiers.id, user_tiers.user_id, user_tiers.tier_id, user_tiers.valid_from, user_tiers.valid_to, user_tiers.assigned_by, user_tiers.created_at, tiers.id, tiers.code, tiers.name, tiers.rate_limit, tiers.features, tiers.created_at, tiers.updated_at
+FROM user_tiers JOIN tiers ON tiers.id = user_tiers.tier_id
+WHERE user_tiers.user_id = $1
+  AND user_tiers.valid_from <= NOW()
+  AND (user_tiers.valid_to IS NULL OR user_tiers.valid_to > NOW())
+ORDER BY user_tiers.valid_from DESC, user_tiers.id DESC
+LIMIT 1
+`
+
+type GetCurrentUserTierRow struct {
+	UserTier UserTier `json:"user_tier"`
+	Tier     Tier     `json:"tier"`
+}
+
+// The latest assignment valid now wins, so a temporary assignment overrides an open-ended one
+func (q *Queries) GetCurrentUserTier(ctx context.Context, userID int64) (*GetCurrentUserTierRow, error) {
+	row := q.db.QueryRow(ctx, GetCurrentUserTier, userID)
+	var i GetCurrentUserTierRow
+	err := row.Scan(
+		&i.UserTier.ID,
+		&i.UserTier.UserID,
+		&i.UserTier.TierID,
+		&i.UserTier.ValidFrom,
+		&i.UserTier.ValidTo,
+		&i.UserTier.AssignedBy,
+		&i.UserTier.CreatedAt,
+		&i.Tier.ID,
+		&i.Tier.Code,
+		&i.Tier.Name,
+		&i.Tier.RateLimit,
+		&i.Tier.Features,
+		&i.Tier.CreatedAt,
+		&i.Tier.UpdatedAt,
+	)
+	return &i, err
+}
+
+const GetLatestUsernameChange = `-- name: GetLatestUsernameChange :one
+SELECT id, user_id, username, changed_at, reserved_until FROM username_history WHERE user_id = $1 ORDER BY changed_at DESC, id DESC LIMIT 1
+`
+
+func (q *Queries) GetLatestUsernameChange(ctx context.Context, userID int64) (*UsernameHistory, error) {
+	row := q.db.QueryRow(ctx, GetLatestUsernameChange, userID)
+	var i UsernameHistory
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Username,
+		&i.ChangedAt,
+		&i.ReservedUntil,
+	)
+	return &i, err
+}
+
+const GetOrganization = `-- name: GetOrganization :one
+SELECT id, slug, name, created_by, created_at, updated_at, tenant_id FROM organizations WHERE id = $1
+`
+
+func (q *Queries) GetOrganization(ctx context.Context, id int64) (*Organization, error) {
+	row := q.db.QueryRow(ctx, GetOrganization, id)
+	var i Organization
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.Name,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.TenantID,
+	)
+	return &i, err
+}
+
+const GetOrganizationInvitationByToken = `-- name: GetOrganizationInvitationByToken :one
+SELECT id, organization_id, email, role, token_hash, invited_by, expires_at, created_at FROM organization_invitations WHERE token_hash = $1 AND expires_at > NOW()
+`
+
+func (q *Queries) GetOrganizationInvitationByToken(ctx context.Context, tokenHash string) (*OrganizationInvitation, error) {
+	row := q.db.QueryRow(ctx, GetOrganizationInvitationByToken, tokenHash)
+	var i OrganizationInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.Email,
+		&i.Role,
+		&i.TokenHash,
+		&i.InvitedBy,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const GetOrganizationMember = `-- name: GetOrganizationMember :one
+SELECT organization_id, user_id, role, selected_at, created_at FROM organization_members WHERE organization_id = $1 AND user_id = $2
+`
+
+type GetOrganizationMemberParams struct {
+	OrganizationID int64 `json:"organization_id"`
+	UserID         int64 `json:"user_id"`
+}
+
+func (q *Queries) GetOrganizationMember(ctx context.Context, arg *GetOrganizationMemberParams) (*OrganizationMember, error) {
+	row := q.db.QueryRow(ctx, GetOrganizationMember, arg.OrganizationID, arg.UserID)
+	var i OrganizationMember
+	err := row.Scan(
+		&i.OrganizationID,
+		&i.UserID,
+		&i.Role,
+		&i.SelectedAt,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const GetTenant = `-- name: GetTenant :one
+SELECT id, slug, name, created_at FROM tenants WHERE id = $1
+`
+
+func (q *Queries) GetTenant(ctx context.Context, id int64) (*Tenant, error) {
+	row := q.db.QueryRow(ctx, GetTenant, id)
+	var i Tenant
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.Name,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const GetTenantBySlug = `-- name: GetTenantBySlug :one
+SELECT id, slug, name, created_at FROM tenants WHERE slug = $1
+`
+
+func (q *Queries) GetTenantBySlug(ctx context.Context, slug string) (*Tenant, error) {
+	row := q.db.QueryRow(ctx, GetTenantBySlug, slug)
+	var i Tenant
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.Name,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const GetTierByCode = `-- name: GetTierByCode :one
+SELECT id, code, name, rate_limit, features, created_at, updated_at FROM tiers WHERE code = $1
+`
+
+func (q *Queries) GetTierByCode(ctx context.Context, code string) (*Tier, error) {
+	row := q.db.QueryRow(ctx, GetTierByCode, code)
+	var i Tier
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Name,
+		&i.RateLimit,
+		&i.Features,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const GetUser = `-- name: GetUser :one
+SELECT id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at, role, version, purge_at, self_deleted, status, status_reason, status_changed_at, tenant_id FROM users WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetUser(ctx context.Context, id int64) (*User, error) {
+	row := q.db.QueryRow(ctx, GetUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Avatar,
+		&i.PhoneNumber,
+		&i.Username,
+		&i.FirstName,
+		&i.LastName,
+		&i.HashPassword,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Role,
+		&i.Version,
+		&i.PurgeAt,
+		&i.SelfDeleted,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusChangedAt,
+		&i.TenantID,
+	)
+	return &i, err
+}
+
+const GetUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at, role, version, purge_at, self_deleted, status, status_reason, status_changed_at, tenant_id FROM users WHERE email = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (*User, error) {
+	row := q.db.QueryRow(ctx, GetUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Avatar,
+		&i.PhoneNumber,
+		&i.Username,
+		&i.FirstName,
+		&i.LastName,
+		&i.HashPassword,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Role,
+		&i.Version,
+		&i.PurgeAt,
+		&i.SelfDeleted,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusChangedAt,
+		&i.TenantID,
+	)
+	return &i, err
+}
+
+const GetUserByUserName = `-- name: GetUserByUserName :one
+SELECT id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at, role, version, purge_at, self_deleted, status, status_reason, status_changed_at, tenant_id FROM users WHERE username = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetUserByUserName(ctx context.Context, username string) (*User, error) {
+	row := q.db.QueryRow(ctx, GetUserByUserName, username)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Avatar,
+		&i.PhoneNumber,
+		&i.Username,
+		&i.FirstName,
+		&i.LastName,
+		&i.HashPassword,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Role,
+		&i.Version,
+		&i.PurgeAt,
+		&i.SelfDeleted,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusChangedAt,
+		&i.TenantID,
+	)
+	return &i, err
+}
+
+const GetUserByUsernameOrEmail = `-- name: GetUserByUsernameOrEmail :one
+SELECT id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at, role, version, purge_at, self_deleted, status, status_reason, status_changed_at, tenant_id FROM users WHERE (username = $1 OR email = $1) AND deleted_at IS NULL
+`
+
+func (q *Queries) GetUserByUsernameOrEmail(ctx context.Context, username string) (*User, error) {
+	row := q.db.QueryRow(ctx, GetUserByUsernameOrEmail, username)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Avatar,
+		&i.PhoneNumber,
+		&i.Username,
+		&i.FirstName,
+		&i.LastName,
+		&i.HashPassword,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Role,
+		&i.Version,
+		&i.PurgeAt,
+		&i.SelfDeleted,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusChangedAt,
+		&i.TenantID,
+	)
+	return &i, err
+}
+
+const GetUserByUsernameOrEmailWithDeleted = `-- name: GetUserByUsernameOrEmailWithDeleted :one
+SELECT id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at, role, version, purge_at, self_deleted, status, status_reason, status_changed_at, tenant_id FROM users WHERE username = $1 OR email = $1
+`
+
+func (q *Queries) GetUserByUsernameOrEmailWithDeleted(ctx context.Context, username string) (*User, error) {
+	row := q.db.QueryRow(ctx, GetUserByUsernameOrEmailWithDeleted, username)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Avatar,
+		&i.PhoneNumber,
+		&i.Username,
+		&i.FirstName,
+		&i.LastName,
+		&i.HashPassword,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Role,
+		&i.Version,
+		&i.PurgeAt,
+		&i.SelfDeleted,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusChangedAt,
+		&i.TenantID,
+	)
+	return &i, err
+}
+
+const GetUserImport = `-- name: GetUserImport :one
+SELECT id, format, source, dry_run, status, rows_processed, rows_imported, rows_failed, error, created_by, created_at, updated_at, finished_at, tenant_id FROM user_imports WHERE id = $1
+`
+
+func (q *Queries) GetUserImport(ctx context.Context, id int64) (*UserImport, error) {
+	row := q.db.QueryRow(ctx, GetUserImport, id)
+	var i UserImport
+	err := row.Scan(
+		&i.ID,
+		&i.Format,
+		&i.Source,
+		&i.DryRun,
+		&i.Status,
+		&i.RowsProcessed,
+		&i.RowsImported,
+		&i.RowsFailed,
+		&i.Error,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.FinishedAt,
+		&i.TenantID,
+	)
+	return &i, err
+}
+
+const GetUserSettings = `-- name: GetUserSettings :one
+SELECT user_id, settings, updated_at FROM user_settings WHERE user_id = $1
+`
+
+func (q *Queries) GetUserSettings(ctx context.Context, userID int64) (*UserSetting, error) {
+	row := q.db.QueryRow(ctx, GetUserSettings, userID)
+	var i UserSetting
+	err := row.Scan(
+		&i.UserID,
+		&i.Settings,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const GetUserTOTPSecret = `-- name: GetUserTOTPSecret :one
+SELECT user_id, secret, enabled, last_used_step, created_at, updated_at FROM user_totp_secrets WHERE user_id = $1
+`
+
+func (q *Queries) GetUserTOTPSecret(ctx context.Context, userID int64) (*UserTotpSecret, error) {
+	row := q.db.QueryRow(ctx, GetUserTOTPSecret, userID)
+	var i UserTotpSecret
+	err := row.Scan(
+		&i.UserID,
+		&i.Secret,
+		&i.Enabled,
+		&i.LastUsedStep,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const GetUserWithDeleted = `-- name: GetUserWithDeleted :one
+SELECT id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at, role, version, purge_at, self_deleted, status, status_reason, status_changed_at, tenant_id FROM users WHERE id = $1
+`
+
+func (q *Queries) GetUserWithDeleted(ctx context.Context, id int64) (*User, error) {
+	row := q.db.QueryRow(ctx, GetUserWithDeleted, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Avatar,
+		&i.PhoneNumber,
+		&i.Username,
+		&i.FirstName,
+		&i.LastName,
+		&i.HashPassword,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Role,
+		&i.Version,
+		&i.PurgeAt,
+		&i.SelfDeleted,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusChangedAt,
+		&i.TenantID,
+	)
+	return &i, err
+}
+
+const GetUsernameReservation = `-- name: GetUsernameReservation :one
+SELECT id, user_id, username, changed_at, reserved_until FROM username_history
+WHERE username = $1 AND reserved_until > NOW()
+ORDER BY changed_at DESC, id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetUsernameReservation(ctx context.Context, username string) (*UsernameHistory, error) {
+	row := q.db.QueryRow(ctx, GetUsernameReservation, username)
+	var i UsernameHistory
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Username,
+		&i.ChangedAt,
+		&i.ReservedUntil,
+	)
+	return &i, err
+}
+
+const ImportUsers = `-- name: ImportUsers :many
+INSERT INTO users (username, email, phone_number, first_name, last_name, hash_password, created_at)
+SELECT r.username, r.email, r.phone_number, r.first_name, r.last_name, r.hash_password, COALESCE(r.created_at, NOW())
+FROM unnest(
+    $1::varchar[], $2::varchar[], $3::varchar[],
+    $4::varchar[], $5::varchar[], $6::varchar[],
+    $7::timestamptz[]
+) AS r(username, email, phone_number, first_name, last_name, hash_password, created_at)
+WHERE NOT EXISTS (
+    SELECT 1 FROM username_history
+    WHERE username_history.username = r.username AND username_history.reserved_until > NOW()
+)
+ON CONFLICT DO NOTHING
+RETURNING username
+`
+
+type ImportUsersParams struct {
+	Usernames     []string             `json:"usernames"`
+	Emails        []string             `json:"emails"`
+	PhoneNumbers  []string             `json:"phone_numbers"`
+	FirstNames    []string             `json:"first_names"`
+	LastNames     []string             `json:"last_names"`
+	HashPasswords []string             `json:"hash_passwords"`
+	CreatedAts    []pgtype.Timestamptz `json:"created_ats"`
+}
+
+// Inserts a batch in one statement. Rows whose username or email is taken,
+// or whose username is reserved, are skipped; the usernames of the inserted
+// rows are returned
+func (q *Queries) ImportUsers(ctx context.Context, arg *ImportUsersParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, ImportUsers,
+		arg.Usernames,
+		arg.Emails,
+		arg.PhoneNumbers,
+		arg.FirstNames,
+		arg.LastNames,
+		arg.HashPasswords,
+		arg.CreatedAts,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var username string
+		if err := rows.Scan(&username); err != nil {
+			return nil, err
+		}
+		items = append(items, username)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListImportConflicts = `-- name: ListImportConflicts :many
+SELECT r.username::varchar AS username,
+    EXISTS (SELECT 1 FROM users WHERE users.username = r.username) AS username_taken,
+    EXISTS (SELECT 1 FROM users WHERE users.email = r.email) AS email_taken,
+    EXISTS (
+        SELECT 1 FROM username_history
+        WHERE username_history.username = r.username AND username_history.reserved_until > NOW()
+    ) AS username_reserved
+FROM unnest($1::varchar[], $2::varchar[]) AS r(username, email)
+`
+
+type ListImportConflictsParams struct {
+	Usernames []string `json:"usernames"`
+	Emails    []string `json:"emails"`
+}
+
+type ListImportConflictsRow struct {
+	Username         string `json:"username"`
+	UsernameTaken    bool   `json:"username_taken"`
+	EmailTaken       bool   `json:"email_taken"`
+	UsernameReserved bool   `json:"username_reserved"`
+}
+
+// Why ImportUsers skipped rows: for each username and email pair, whether
+// the username or email is taken and whether the username is reserved
+func (q *Queries) ListImportConflicts(ctx context.Context, arg *ListImportConflictsParams) ([]*ListImportConflictsRow, error) {
+	rows, err := q.db.Query(ctx, ListImportConflicts, arg.Usernames, arg.Emails)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ListImportConflictsRow
+	for rows.Next() {
+		var i ListImportConflictsRow
+		if err := rows.Scan(
+			&i.Username,
+			&i.UsernameTaken,
+			&i.EmailTaken,
+			&i.UsernameReserved,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListOrganizationInvitations = `-- name: ListOrganizationInvitations :many
+SELECT id, organization_id, email, role, token_hash, invited_by, expires_at, created_at FROM organization_invitations
+WHERE organization_id = $1 AND expires_at > NOW()
+ORDER BY created_at DESC, id DESC
+`
+
+func (q *Queries) ListOrganizationInvitations(ctx context.Context, organizationID int64) ([]*OrganizationInvitation, error) {
+	rows, err := q.db.Query(ctx, ListOrganizationInvitations, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*OrganizationInvitation
+	for rows.Next() {
+		var i OrganizationInvitation
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.Email,
+			&i.Role,
+			&i.TokenHash,
+			&i.InvitedBy,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListOrganizationMembers = `-- name: ListOrganizationMembers :many
+SELECT organization_members.organization_id, organization_members.user_id, organization_members.role, organization_members.selected_at, organization_members.created_at, users.username, users.email
+FROM organization_members JOIN users ON users.id = organization_members.user_id
+WHERE organization_members.organization_id = $1
+ORDER BY organization_members.created_at, organization_members.user_id
+`
+
+type ListOrganizationMembersRow struct {
+	OrganizationMember OrganizationMember `json:"organization_member"`
+	Username           string             `json:"username"`
+	Email              string             `json:"email"`
+}
+
+func (q *Queries) ListOrganizationMembers(ctx context.Context, organizationID int64) ([]*ListOrganizationMembersRow, error) {
+	rows, err := q.db.Query(ctx, ListOrganizationMembers, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ListOrganizationMembersRow
+	for rows.Next() {
+		var i ListOrganizationMembersRow
+		if err := rows.Scan(
+			&i.OrganizationMember.OrganizationID,
+			&i.OrganizationMember.UserID,
+			&i.OrganizationMember.Role,
+			&i.OrganizationMember.SelectedAt,
+			&i.OrganizationMember.CreatedAt,
+			&i.Username,
+			&i.Email,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListTiers = `-- name: ListTiers :many
+SELECT id, code, name, rate_limit, features, created_at, updated_at FROM tiers ORDER BY id
+`
+
+func (q *Queries) ListTiers(ctx context.Context) ([]*Tier, error) {
+	rows, err := q.db.Query(ctx, ListTiers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Tier
+	for rows.Next() {
+		var i Tier
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Name,
+			&i.RateLimit,
+			&i.Features,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListUserImportErrors = `-- name: ListUserImportErrors :many
+SELECT id, import_id, row_number, field, message FROM user_import_errors
+WHERE import_id = $1
+ORDER BY row_number, id
+LIMIT $2 OFFSET $3
+`
+
+type ListUserImportErrorsParams struct {
+	ImportID int64 `json:"import_id"`
+	Limit    int32 `json:"limit"`
+	Offset   int32 `json:"offset"`
+}
+
+func (q *Queries) ListUserImportErrors(ctx context.Context, arg *ListUserImportErrorsParams) ([]*UserImportError, error) {
+	rows, err := q.db.Query(ctx, ListUserImportErrors, arg.ImportID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*UserImportError
+	for rows.Next() {
+		var i UserImportError
+		if err := rows.Scan(
+			&i.ID,
+			&i.ImportID,
+			&i.RowNumber,
+			&i.Field,
+			&i.Message,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListUserImports = `-- name: ListUserImports :many
+SELECT id, format, source, dry_run, status, rows_processed, rows_imported, rows_failed, error, created_by, created_at, updated_at, finished_at, tenant_id FROM user_imports ORDER BY created_at DESC, id DESC LIMIT $1
+`
+
+func (q *Queries) ListUserImports(ctx context.Context, limit int32) ([]*UserImport, error) {
+	rows, err := q.db.Query(ctx, ListUserImports, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*UserImport
+	for rows.Next() {
+		var i UserImport
+		if err := rows.Scan(
+			&i.ID,
+			&i.Format,
+			&i.Source,
+			&i.DryRun,
+			&i.Status,
+			&i.RowsProcessed,
+			&i.RowsImported,
+			&i.RowsFailed,
+			&i.Error,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FinishedAt,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListUserOrganizations = `-- name: ListUserOrganizations :many
+SELECT organization_members.organization_id, organization_members.user_id, organization_members.role, organization_members.selected_at, organization_members.created_at, organizations.id, organizations.slug, organizations.name, organizations.created_by, organizations.created_at, organizations.updated_at, organizations.tenant_id
+FROM organization_members JOIN organizations ON organizations.id = organization_members.organization_id
+WHERE organization_members.user_id = $1
+ORDER BY organizations.name, organizations.id
+`
+
+type ListUserOrganizationsRow struct {
+	OrganizationMember OrganizationMember `json:"organization_member"`
+	Organization       Organization       `json:"organization"`
+}
+
+func (q *Queries) ListUserOrganizations(ctx context.Context, userID int64) ([]*ListUserOrganizationsRow, error) {
+	rows, err := q.db.Query(ctx, ListUserOrganizations, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ListUserOrganizationsRow
+	for rows.Next() {
+		var i ListUserOrganizationsRow
+		if err := rows.Scan(
+			&i.OrganizationMember.OrganizationID,
+			&i.OrganizationMember.UserID,
+			&i.OrganizationMember.Role,
+			&i.OrganizationMember.SelectedAt,
+			&i.OrganizationMember.CreatedAt,
+			&i.Organization.ID,
+			&i.Organization.Slug,
+			&i.Organization.Name,
+			&i.Organization.CreatedBy,
+			&i.Organization.CreatedAt,
+			&i.Organization.UpdatedAt,
+			&i.Organization.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListUserTiers = `-- name: ListUserTiers :many
+SELECT user_tiers.id, user_tiers.user_id, user_tiers.tier_id, user_tiers.valid_from, user_tiers.valid_to, user_tiers.assigned_by, user_tiers.created_at, tiers.id, tiers.code, tiers.name, tiers.rate_limit, tiers.features, tiers.created_at, tiers.updated_at
+FROM user_tiers JOIN tiers ON tiers.id = user_tiers.tier_id
+WHERE user_tiers.user_id = $1
+ORDER BY user_tiers.valid_from DESC, user_tiers.id DESC
+`
+
+type ListUserTiersRow struct {
+	UserTier UserTier `json:"user_tier"`
+	Tier     Tier     `json:"tier"`
+}
+
+func (q *Queries) ListUserTiers(ctx context.Context, userID int64) ([]*ListUserTiersRow, error) {
+	rows, err := q.db.Query(ctx, ListUserTiers, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ListUserTiersRow
+	for rows.Next() {
+		var i ListUserTiersRow
+		if err := rows.Scan(
+			&i.UserTier.ID,
+			&i.UserTier.UserID,
+			&i.UserTier.TierID,
+			&i.UserTier.ValidFrom,
+			&i.UserTier.ValidTo,
+			&i.UserTier.AssignedBy,
+			&i.UserTier.CreatedAt,
+			&i.Tier.ID,
+			&i.Tier.Code,
+			&i.Tier.Name,
+			&i.Tier.RateLimit,
+			&i.Tier.Features,
+			&i.Tier.CreatedAt,
+			&i.Tier.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListUsernameHistory = `-- name: ListUsernameHistory :many
+SELECT id, user_id, username, changed_at, reserved_until FROM username_history WHERE user_id = $1 ORDER BY changed_at DESC, id DESC
+`
+
+func (q *Queries) ListUsernameHistory(ctx context.Context, userID int64) ([]*UsernameHistory, error) {
+	rows, err := q.db.Query(ctx, ListUsernameHistory, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*UsernameHistory
+	for rows.Next() {
+		var i UsernameHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Username,
+			&i.ChangedAt,
+			&i.ReservedUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListUsers = `-- name: ListUsers :many
+SELECT id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at, role, version, purge_at, self_deleted, status, status_reason, status_changed_at, tenant_id FROM users
+WHERE deleted_at IS NULL
+  AND ($1::timestamptz IS NULL
+       OR (created_at, id) < ($1::timestamptz, $2::bigint))
+ORDER BY created_at DESC, id DESC
+LIMIT $3
+`
+
+type ListUsersParams struct {
+	AfterCreatedAt pgtype.Timestamptz `json:"after_created_at"`
+	AfterID        pgtype.Int8        `json:"after_id"`
+	LimitCount     int32              `json:"limit_count"`
+}
+
+// Keyset pagination on (created_at DESC, id DESC); NULL cursor values start from the first page
+func (q *Queries) ListUsers(ctx context.Context, arg *ListUsersParams) ([]*User, error) {
+	rows, err := q.db.Query(ctx, ListUsers, arg.AfterCreatedAt, arg.AfterID, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.Avatar,
+			&i.PhoneNumber,
+			&i.Username,
+			&i.FirstName,
+			&i.LastName,
+			&i.HashPassword,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Role,
+			&i.Version,
+			&i.PurgeAt,
+			&i.SelfDeleted,
+			&i.Status,
+			&i.StatusReason,
+			&i.StatusChangedAt,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListUsersDueForPurge = `-- name: ListUsersDueForPurge :many
+SELECT id, tenant_id FROM users WHERE deleted_at IS NOT NULL AND purge_at <= NOW() ORDER BY purge_at LIMIT $1
+`
+
+type ListUsersDueForPurgeRow struct {
+	ID       int64 `json:"id"`
+	TenantID int64 `json:"tenant_id"`
+}
+
+func (q *Queries) ListUsersDueForPurge(ctx context.Context, limit int32) ([]*ListUsersDueForPurgeRow, error) {
+	rows, err := q.db.Query(ctx, ListUsersDueForPurge, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ListUsersDueForPurgeRow
+	for rows.Next() {
+		var i ListUsersDueForPurgeRow
+		if err := rows.Scan(&i.ID, &i.TenantID); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const MergeUserSettings = `-- name: MergeUserSettings :one
+INSERT INTO user_settings (user_id, settings) VALUES ($1, $2::jsonb - $3::text[])
+ON CONFLICT (user_id) DO UPDATE SET
+    settings   = (user_settings.settings || $2::jsonb) - $3::text[],
+    updated_at = NOW()
+RETURNING user_id, settings, updated_at
+`
+
+type MergeUserSettingsParams struct {
+	UserID    int64    `json:"user_id"`
+	SetValues []byte   `json:"set_values"`
+	ResetKeys []string `json:"reset_keys"`
+}
+
+// Applied in one statement so concurrent changes of different keys are all kept
+func (q *Queries) MergeUserSettings(ctx context.Context, arg *MergeUserSettingsParams) (*UserSetting, error) {
+	row := q.db.QueryRow(ctx, MergeUserSettings, arg.UserID, arg.SetValues, arg.ResetKeys)
+	var i UserSetting
+	err := row.Scan(
+		&i.UserID,
+		&i.Settings,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const PatchUserProfile = `-- name: PatchUserProfile :one
+UPDATE users SET
+    first_name   = COALESCE($1, first_name),
+    last_name    = COALESCE($2, last_name),
+    phone_number = COALESCE($3, phone_number),
+    avatar       = CASE WHEN $4::boolean THEN NULL
+                        ELSE COALESCE($5, avatar) END,
+    version      = version + 1,
+    updated_at   = NOW()
+WHERE id = $6 AND deleted_at IS NULL
+  AND ($7::bigint IS NULL OR version = $7::bigint)
+RETURNING id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at, role, version, purge_at, self_deleted, status, status_reason, status_changed_at, tenant_id
+`
+
+type PatchUserProfileParams struct {
+	FirstName       pgtype.Text `json:"first_name"`
+	LastName        pgtype.Text `json:"last_name"`
+	PhoneNumber     pgtype.Text `json:"phone_number"`
+	ClearAvatar     bool        `json:"clear_avatar"`
+	Avatar          pgtype.Text `json:"avatar"`
+	ID              int64       `json:"id"`
+	ExpectedVersion pgtype.Int8 `json:"expected_version"`
+}
+
+// Only non-NULL parameters are applied; clear_avatar removes the avatar.
+// A non-NULL expected_version makes the update conditional (optimistic concurrency)
+func (q *Queries) PatchUserProfile(ctx context.Context, arg *PatchUserProfileParams) (*User, error) {
+	row := q.db.QueryRow(ctx, PatchUserProfile,
+		arg.FirstName,
+		arg.LastName,
+		arg.PhoneNumber,
+		arg.ClearAvatar,
+		arg.Avatar,
+		arg.ID,
+		arg.ExpectedVersion,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Avatar,
+		&i.PhoneNumber,
+		&i.Username,
+		&i.FirstName,
+		&i.LastName,
+		&i.HashPassword,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Role,
+		&i.Version,
+		&i.PurgeAt,
+		&i.SelfDeleted,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusChangedAt,
+		&i.TenantID,
+	)
+	return &i, err
+}
+
+const PurgeUser = `-- name: PurgeUser :execrows
+DELETE FROM users WHERE id = $1 AND deleted_at IS NOT NULL AND purge_at <= NOW()
+`
+
+// Rows restored in the meantime are left alone; dependent rows cascade
+func (q *Queries) PurgeUser(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, PurgeUser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const ReplaceUserSettings = `-- name: ReplaceUserSettings :one
+INSERT INTO user_settings (user_id, settings) VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET settings = EXCLUDED.settings, updated_at = NOW()
+RETURNING user_id, settings, updated_at
+`
+
+type ReplaceUserSettingsParams struct {
+	UserID   int64  `json:"user_id"`
+	Settings []byte `json:"settings"`
+}
+
+func (q *Queries) ReplaceUserSettings(ctx context.Context, arg *ReplaceUserSettingsParams) (*UserSetting, error) {
+	row := q.db.QueryRow(ctx, ReplaceUserSettings, arg.UserID, arg.Settings)
+	var i UserSetting
+	err := row.Scan(
+		&i.UserID,
+		&i.Settings,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const RestoreUser = `-- name: RestoreUser :execrows
+UPDATE users SET deleted_at = NULL, purge_at = NULL, self_deleted = FALSE, version = version + 1, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) RestoreUser(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, RestoreUser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const SearchUsers = `-- name: SearchUsers :many
+SELECT users.id, users.email, users.avatar, users.phone_number, users.username, users.first_name, users.last_name, users.hash_password, users.created_at, users.updated_at, users.deleted_at, users.role, users.version, users.purge_at, users.self_deleted, users.status, users.status_reason, users.status_changed_at, users.tenant_id,
+       (ts_rank(to_tsvector('simple', username || ' ' || first_name || ' ' || last_name || ' ' || email),
+                to_tsquery('simple', $1::text)) * 2
+        + word_similarity($2::text, lower(username || ' ' || first_name || ' ' || last_name || ' ' || email || ' ' || phone_number)))::real AS rank,
+       ts_headline('simple', first_name || ' ' || last_name || ' ' || username || ' ' || email,
+                   to_tsquery('simple', $1::text),
+                   'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')::text AS headline
+FROM users
+WHERE ($3::boolean OR deleted_at IS NULL)
+  AND (to_tsvector('simple', username || ' ' || first_name || ' ' || last_name || ' ' || email) @@ to_tsquery('simple', $1::text)
+       OR lower(username || ' ' || first_name || ' ' || last_name || ' ' || email || ' ' || phone_number) LIKE $4::text
+       OR $2::text <% lower(username || ' ' || first_name || ' ' || last_name || ' ' || email || ' ' || phone_number))
+ORDER BY rank DESC, id ASC
+LIMIT $5 OFFSET $6
+`
+
+type SearchUsersParams struct {
+	TsQuery        string `json:"ts_query"`
+	Term           string `json:"term"`
+	IncludeDeleted bool   `json:"include_deleted"`
+	Pattern        string `json:"pattern"`
+	LimitCount     int32  `json:"limit_count"`
+	OffsetCount    int32  `json:"offset_count"`
+}
+
+type SearchUsersRow struct {
+	User     User    `json:"user"`
+	Rank     float32 `json:"rank"`
+	Headline string  `json:"headline"`
+}
+
+// Full-text prefix matches rank above fuzzy trigram matches. The search expressions
+// must match idx_users_search_fts / idx_users_search_trgm to use the indexes.
+func (q *Queries) SearchUsers(ctx context.Context, arg *SearchUsersParams) ([]*SearchUsersRow, error) {
+	rows, err := q.db.Query(ctx, SearchUsers,
+		arg.TsQuery,
+		arg.Term,
+		arg.IncludeDeleted,
+		arg.Pattern,
+		arg.LimitCount,
+		arg.OffsetCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*SearchUsersRow
+	for rows.Next() {
+		var i SearchUsersRow
+		if err := rows.Scan(
+			&i.User.ID,
+			&i.User.Email,
+			&i.User.Avatar,
+			&i.User.PhoneNumber,
+			&i.User.Username,
+			&i.User.FirstName,
+			&i.User.LastName,
+			&i.User.HashPassword,
+			&i.User.CreatedAt,
+			&i.User.UpdatedAt,
+			&i.User.DeletedAt,
+			&i.User.Role,
+			&i.User.Version,
+			&i.User.PurgeAt,
+			&i.User.SelfDeleted,
+			&i.User.Status,
+			&i.User.StatusReason,
+			&i.User.StatusChangedAt,
+			&i.User.TenantID,
+			&i.Rank,
+			&i.Headline,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const SelectOrganization = `-- name: SelectOrganization :execrows
+UPDATE organization_members SET selected_at = NOW()
+WHERE organization_id = $1 AND user_id = $2
+`
+
+type SelectOrganizationParams struct {
+	OrganizationID int64 `json:"organization_id"`
+	UserID         int64 `json:"user_id"`
+}
+
+func (q *Queries) SelectOrganization(ctx context.Context, arg *SelectOrganizationParams) (int64, error) {
+	result, err := q.db.Exec(ctx, SelectOrganization, arg.OrganizationID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const SoftDeleteUser = `-- name: SoftDeleteUser :execrows
+UPDATE users SET
+    deleted_at   = NOW(),
+    purge_at     = $1,
+    self_deleted = $2,
+    version      = version + 1,
+    updated_at   = NOW()
+WHERE id = $3 AND deleted_at IS NULL
+`
+
+type SoftDeleteUserParams struct {
+	PurgeAt     pgtype.Timestamptz `json:"purge_at"`
+	SelfDeleted bool               `json:"self_deleted"`
+	ID          int64              `json:"id"`
+}
+
+// A NULL purge_at keeps the row until it is restored
+func (q *Queries) SoftDeleteUser(ctx context.Context, arg *SoftDeleteUserParams) (int64, error) {
+	result, err := q.db.Exec(ctx, SoftDeleteUser, arg.PurgeAt, arg.SelfDeleted, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const UpdateOrganizationMemberRole = `-- name: UpdateOrganizationMemberRole :one
+WITH owners AS (
+    SELECT user_id FROM organization_members
+    WHERE organization_id = $1 AND role = 'owner'
+    FOR UPDATE
+)
+UPDATE organization_members SET role = $3
+WHERE organization_id = $1 AND user_id = $2
+  AND (role <> 'owner' OR $3 = 'owner' OR (SELECT COUNT(*) FROM owners) > 1)
+RETURNING organization_id, user_id, role, selected_at, created_at
+`
+
+type UpdateOrganizationMemberRoleParams struct {
+	OrganizationID int64  `json:"organization_id"`
+	UserID         int64  `json:"user_id"`
+	Role           string `json:"role"`
+}
+
+func (q *Queries) UpdateOrganizationMemberRole(ctx context.Context, arg *UpdateOrganizationMemberRoleParams) (*OrganizationMember, error) {
+	row := q.db.QueryRow(ctx, UpdateOrganizationMemberRole, arg.OrganizationID, arg.UserID, arg.Role)
+	var i OrganizationMember
+	err := row.Scan(
+		&i.OrganizationID,
+		&i.UserID,
+		&i.Role,
+		&i.SelectedAt,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const UpdateUser = `-- name: UpdateUser :one
+UPDATE users SET
+    email         = COALESCE($1, email),
+    phone_number  = COALESCE($2, phone_number),
+    first_name    = COALESCE($3, first_name),
+    last_name     = COALESCE($4, last_name),
+    avatar        = COALESCE($5, avatar),
+    hash_password = COALESCE($6, hash_password),
+    role          = COALESCE($7, role),
+    version       = version + 1,
+    updated_at    = NOW()
+WHERE id = $8 AND deleted_at IS NULL
+RETURNING id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at, role, version, purge_at, self_deleted, status, status_reason, status_changed_at, tenant_id
+`
+
+type UpdateUserParams struct {
+	Email        pgtype.Text `json:"email"`
+	PhoneNumber  pgtype.Text `json:"phone_number"`
+	FirstName    pgtype.Text `json:"first_name"`
+	LastName     pgtype.Text `json:"last_name"`
+	Avatar       pgtype.Text `json:"avatar"`
+	HashPassword pgtype.Text `json:"hash_password"`
+	Role         pgtype.Text `json:"role"`
+	ID           int64       `json:"id"`
+}
+
+// Only non-NULL parameters are applied
+func (q *Queries) UpdateUser(ctx context.Context, arg *UpdateUserParams) (*User, error) {
+	row := q.db.QueryRow(ctx, UpdateUser,
+		arg.Email,
+		arg.PhoneNumber,
+		arg.FirstName,
+		arg.LastName,
+		arg.Avatar,
+		arg.HashPassword,
+		arg.Role,
+		arg.ID,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Avatar,
+		&i.PhoneNumber,
+		&i.Username,
+		&i.FirstName,
+		&i.LastName,
+		&i.HashPassword,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Role,
+		&i.Version,
+		&i.PurgeAt,
+		&i.SelfDeleted,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusChangedAt,
+		&i.TenantID,
+	)
+	return &i, err
+}
+
+const UpdateUserStatus = `-- name: UpdateUserStatus :one
+UPDATE users SET
+    status            = $1,
+    status_reason     = $2,
+    status_changed_at = NOW(),
+    version           = version + 1,
+    updated_at        = NOW()
+WHERE id = $3 AND deleted_at IS NULL AND status = $4
+RETURNING id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at, role, version, purge_at, self_deleted, status, status_reason, status_changed_at, tenant_id
+`
+
+type UpdateUserStatusParams struct {
+	Status       string      `json:"status"`
+	StatusReason pgtype.Text `json:"status_reason"`
+	ID           int64       `json:"id"`
+	FromStatus   string      `json:"from_status"`
+}
+
+// Conditional on the current status, so concurrent changes cannot skip a transition check
+func (q *Queries) UpdateUserStatus(ctx context.Context, arg *UpdateUserStatusParams) (*User, error) {
+	row := q.db.QueryRow(ctx, UpdateUserStatus,
+		arg.Status,
+		arg.StatusReason,
+		arg.ID,
+		arg.FromStatus,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Avatar,
+		&i.PhoneNumber,
+		&i.Username,
+		&i.FirstName,
+		&i.LastName,
+		&i.HashPassword,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Role,
+		&i.Version,
+		&i.PurgeAt,
+		&i.SelfDeleted,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusChangedAt,
+		&i.TenantID,
+	)
+	return &i, err
+}
+
 const ValidateUserPasswordByUserName = `-- name: ValidateUserPasswordByUserName :one
-SELECT id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at FROM users WHERE (username = $1 OR email = $1) AND hash_password = $2 AND deleted_at IS NULL
+SELECT id, email, avatar, phone_number, username, first_name, last_name, hash_password, created_at, updated_at, deleted_at, role, version, purge_at, self_deleted, status, status_reason, status_changed_at, tenant_id FROM users WHERE (username = $1 OR email = $1) AND hash_password = $2 AND deleted_at IS NULL
 `
 
 type ValidateUserPasswordByUserNameParams struct {
@@ -183,6 +2105,14 @@ func (q *Queries) ValidateUserPasswordByUserName(ctx context.Context, arg *Valid
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Role,
+		&i.Version,
+		&i.PurgeAt,
+		&i.SelfDeleted,
+		&i.Status,
+		&i.StatusReason,
+		&i.StatusChangedAt,
+		&i.TenantID,
 	)
 	return &i, err
 }
@@ -0,0 +1,67 @@
+package entity
+
+import "time"
+
+// Organization roles, from most to least privileged.
+const (
+	OrgRoleOwner  = "owner"
+	OrgRoleAdmin  = "admin"
+	OrgRoleMember = "member"
+)
+
+// orgRoleRanks orders the organization roles by privilege.
+var orgRoleRanks = map[string]int{
+	OrgRoleMember: 1,
+	OrgRoleAdmin:  2,
+	OrgRoleOwner:  3,
+}
+
+// IsValidOrgRole checks if role is an organization role.
+func IsValidOrgRole(role string) bool {
+	_, ok := orgRoleRanks[role]
+	return ok
+}
+
+// OrgRoleAtLeast checks if role grants at least the privileges of min.
+func OrgRoleAtLeast(role, min string) bool {
+	return IsValidOrgRole(role) && orgRoleRanks[role] >= orgRoleRanks[min]
+}
+
+// Organization represents an organization (team) users belong to.
+type Organization struct {
+	ID        int64
+	Slug      string
+	Name      string
+	CreatedBy int64 // 0 once the creator is purged
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// OrganizationMember represents a user's membership of an organization.
+type OrganizationMember struct {
+	OrganizationID int64
+	UserID         int64
+	Role           string
+	SelectedAt     *time.Time    // when the user last switched to the organization
+	CreatedAt      time.Time     // when the user joined
+	Organization   *Organization // set when listing a user's organizations
+	Username       string        // set when listing an organization's members
+	Email          string        // set when listing an organization's members
+}
+
+// OrganizationInvitation represents a pending invitation to join an
+// organization. Only a hash of its token is kept.
+type OrganizationInvitation struct {
+	ID             int64
+	OrganizationID int64
+	Email          string
+	Role           string
+	InvitedBy      int64 // 0 once the inviter is purged
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+}
+
+// IsExpired checks if the invitation can no longer be accepted at now.
+func (i *OrganizationInvitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
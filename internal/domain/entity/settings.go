@@ -0,0 +1,5 @@
+package entity
+
+// Settings holds user setting values by key, as decoded from JSON: strings,
+// bools and float64 numbers.
+type Settings map[string]any
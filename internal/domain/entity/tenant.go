@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+// Tenant represents a customer hosted by the deployment. Users and
+// organizations belong to exactly one tenant.
+type Tenant struct {
+	ID        int64
+	Slug      string
+	Name      string
+	CreatedAt time.Time
+}
+
+// DefaultTenantID is the tenant created by the tenants migration. Existing
+// data belongs to it, and with tenancy disabled so does everything.
+const DefaultTenantID int64 = 1
+
+// tenantContextKey is the context key of the request's tenant.
+type tenantContextKey struct{}
+
+// TenantContextKey stores the request's tenant. Besides ContextWithTenant,
+// it is the Fiber Locals key the tenant middleware uses, so contexts taken
+// from c.Context() carry the tenant as well.
+var TenantContextKey = tenantContextKey{}
+
+// ContextWithTenant returns a copy of ctx carrying tenant.
+func ContextWithTenant(ctx context.Context, tenant *Tenant) context.Context {
+	return context.WithValue(ctx, TenantContextKey, tenant)
+}
+
+// TenantFromContext returns the tenant carried by ctx, if any.
+func TenantFromContext(ctx context.Context) (*Tenant, bool) {
+	tenant, ok := ctx.Value(TenantContextKey).(*Tenant)
+	return tenant, ok && tenant != nil
+}
+
+// DetachTenant returns a background context carrying the tenant of ctx, for
+// work that outlives the request of ctx: request contexts are recycled once
+// the request completes, so they cannot be kept.
+func DetachTenant(ctx context.Context) context.Context {
+	detached := context.Background()
+	if tenant, ok := TenantFromContext(ctx); ok {
+		detached = ContextWithTenant(detached, tenant)
+	}
+	return detached
+}
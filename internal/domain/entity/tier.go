@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+// Tier represents a subscription tier and its limits.
+type Tier struct {
+	ID        int64
+	Code      string
+	Name      string
+	RateLimit int // requests per rate limit window per user; 0 applies only the general limit
+	Features  []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// HasFeature checks if the tier enables feature.
+func (t *Tier) HasFeature(feature string) bool {
+	return slices.Contains(t.Features, feature)
+}
+
+// UserTier represents a tier assigned to a user for a period.
+type UserTier struct {
+	ID         int64
+	UserID     int64
+	Tier       *Tier
+	ValidFrom  time.Time
+	ValidTo    *time.Time // nil if the assignment never ends
+	AssignedBy int64      // the administrator who assigned it, 0 if unknown
+	CreatedAt  time.Time
+}
+
+// IsCurrent checks if the assignment is valid at now.
+func (ut *UserTier) IsCurrent(now time.Time) bool {
+	return !now.Before(ut.ValidFrom) && (ut.ValidTo == nil || now.Before(*ut.ValidTo))
+}
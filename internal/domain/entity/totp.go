@@ -0,0 +1,9 @@
+package entity
+
+// TOTPSecret represents a user's time-based one-time password enrollment.
+type TOTPSecret struct {
+	UserID       int64
+	Secret       string
+	Enabled      bool
+	LastUsedStep int64
+}
@@ -1,21 +1,53 @@
 package entity
 
-import "time"
+import (
+	"slices"
+	"time"
+)
+
+// Roles used for authorization.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Lifecycle statuses. Only active users can sign in.
+const (
+	StatusPending   = "pending"
+	StatusActive    = "active"
+	StatusSuspended = "suspended"
+	StatusBanned    = "banned"
+)
+
+// statusTransitions lists the statuses each status may change to.
+var statusTransitions = map[string][]string{
+	StatusPending:   {StatusActive, StatusSuspended, StatusBanned},
+	StatusActive:    {StatusSuspended, StatusBanned},
+	StatusSuspended: {StatusActive, StatusBanned},
+	StatusBanned:    {StatusActive},
+}
 
 // User represents the domain entity for a user.
 // This is a pure domain model without any framework or database dependencies.
 type User struct {
-	ID           int64
-	Username     string
-	Email        string
-	PhoneNumber  string
-	FirstName    string
-	LastName     string
-	HashPassword string
-	Avatar       string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	DeletedAt    *time.Time
+	ID              int64
+	Username        string
+	Email           string
+	PhoneNumber     string
+	FirstName       string
+	LastName        string
+	HashPassword    string
+	Avatar          string
+	Role            string
+	Version         int64 // incremented on every write, for optimistic concurrency
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       *time.Time
+	PurgeAt         *time.Time // when a deleted user is permanently removed; nil keeps the row
+	SelfDeleted     bool       // the user deleted their own account
+	Status          string
+	StatusReason    string
+	StatusChangedAt *time.Time // nil if the status never changed
 }
 
 // FullName returns the user's full name.
@@ -27,3 +59,29 @@ func (u *User) FullName() string {
 func (u *User) IsDeleted() bool {
 	return u.DeletedAt != nil
 }
+
+// IsRestorableByLogin checks if the user deleted their own account and is
+// still within the grace period, in which logging in restores the account.
+func (u *User) IsRestorableByLogin(now time.Time) bool {
+	return u.IsDeleted() && u.SelfDeleted && u.PurgeAt != nil && now.Before(*u.PurgeAt)
+}
+
+// IsActive checks if the user's lifecycle status allows signing in.
+func (u *User) IsActive() bool {
+	return u.Status == StatusActive
+}
+
+// CanChangeStatusTo checks if the user's status may change to status.
+func (u *User) CanChangeStatusTo(status string) bool {
+	return slices.Contains(statusTransitions[u.Status], status)
+}
+
+// IsAdmin checks if the user has the admin role.
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+// IsValidRole checks if role is a known role.
+func IsValidRole(role string) bool {
+	return role == RoleUser || role == RoleAdmin
+}
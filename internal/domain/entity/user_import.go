@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// Formats of user import files.
+const (
+	ImportFormatCSV    = "csv"
+	ImportFormatNDJSON = "ndjson"
+)
+
+// Statuses of a user import. Pending and failed imports can be (re)started.
+const (
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+// UserImport represents a bulk import of users from an uploaded file.
+type UserImport struct {
+	ID            int64
+	TenantID      int64
+	Format        string
+	Source        string // object key of the uploaded file
+	DryRun        bool   // rows are validated and checked against existing users but not inserted
+	Status        string
+	RowsProcessed int64 // rows read so far; a resumed import continues after them
+	RowsImported  int64 // in a dry run, rows that would have been imported
+	RowsFailed    int64
+	Error         string // why the import failed, empty otherwise
+	CreatedBy     int64  // the administrator who started it, 0 if unknown
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	FinishedAt    *time.Time
+}
+
+// IsFinished checks if the import has completed or failed.
+func (i *UserImport) IsFinished() bool {
+	return i.Status == ImportStatusCompleted || i.Status == ImportStatusFailed
+}
+
+// UserImportError records a row of an import that was rejected.
+type UserImportError struct {
+	ImportID int64
+	Row      int64  // the CSV record after the header, or the NDJSON line
+	Field    string // the offending column, empty if the row as a whole was rejected
+	Message  string
+}
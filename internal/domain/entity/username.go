@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// UsernameChange records a username a user changed away from. Until
+// ReservedUntil no other user can take it, and lookups of it resolve to the
+// user who had it.
+type UsernameChange struct {
+	ID            int64
+	UserID        int64
+	Username      string // the previous username
+	ChangedAt     time.Time
+	ReservedUntil time.Time
+}
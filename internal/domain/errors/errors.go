@@ -1,6 +1,10 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+
+	"base-service/internal/domain/entity"
+)
 
 // Domain-specific errors for the user domain.
 var (
@@ -21,8 +25,144 @@ var (
 
 	// ErrUserDeleted is returned when attempting to access a soft-deleted user.
 	ErrUserDeleted = errors.New("user has been deleted")
+
+	// ErrUserNotDeleted is returned when restoring a user that is not soft-deleted.
+	ErrUserNotDeleted = errors.New("user is not deleted")
+
+	// ErrInvalidRole is returned when assigning an unknown role.
+	ErrInvalidRole = errors.New("invalid role")
+
+	// ErrTOTPNotEnabled is returned when a one-time code is given but the user has no TOTP enrollment.
+	ErrTOTPNotEnabled = errors.New("one-time password authentication is not enabled")
+
+	// ErrImageTooLarge is returned when an uploaded image exceeds the size limit.
+	ErrImageTooLarge = errors.New("image is too large")
+
+	// ErrUnsupportedImageType is returned when an upload is not a supported image format.
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+
+	// ErrInvalidImage is returned when an uploaded image is corrupt or its dimensions are too large.
+	ErrInvalidImage = errors.New("image is invalid")
+
+	// ErrExportInProgress is returned when a data export is requested while the previous one is still running.
+	ErrExportInProgress = errors.New("data export is already in progress")
+
+	// ErrUserPending is returned when a user whose account is not activated yet signs in.
+	ErrUserPending = errors.New("user account is pending activation")
+
+	// ErrUserSuspended is returned when a suspended user signs in or uses a token.
+	ErrUserSuspended = errors.New("user account is suspended")
+
+	// ErrUserBanned is returned when a banned user signs in or uses a token.
+	ErrUserBanned = errors.New("user account is banned")
+
+	// ErrInvalidStatusTransition is returned when a user's status cannot change to the requested status.
+	ErrInvalidStatusTransition = errors.New("user status cannot change to the requested status")
+
+	// ErrOwnStatusChange is returned when administrators change their own status.
+	ErrOwnStatusChange = errors.New("administrators cannot change their own status")
+
+	// ErrUsernameUnchanged is returned when changing a username to the current one.
+	ErrUsernameUnchanged = errors.New("username is unchanged")
+
+	// ErrUsernameChangeTooSoon is returned when a user changes their username again within the cooldown.
+	ErrUsernameChangeTooSoon = errors.New("username was changed too recently")
+
+	// ErrUsernameReserved is returned when taking a username another user changed away from recently.
+	ErrUsernameReserved = errors.New("username is reserved")
+
+	// ErrTierNotFound is returned when a tier does not exist.
+	ErrTierNotFound = errors.New("tier not found")
+
+	// ErrTierNotAssigned is returned when a user has no current tier.
+	ErrTierNotAssigned = errors.New("user has no current tier")
+
+	// ErrInvalidTierPeriod is returned when a tier assignment ends before it starts.
+	ErrInvalidTierPeriod = errors.New("tier assignment ends before it starts")
+
+	// ErrUnknownSetting is returned when a settings change names an undeclared setting.
+	ErrUnknownSetting = errors.New("unknown setting")
+
+	// ErrInvalidSetting is returned when a setting value does not match its declaration.
+	ErrInvalidSetting = errors.New("invalid setting value")
+
+	// ErrOrganizationNotFound is returned when an organization does not exist.
+	ErrOrganizationNotFound = errors.New("organization not found")
+
+	// ErrDuplicateOrganizationSlug is returned when creating an organization with a taken slug.
+	ErrDuplicateOrganizationSlug = errors.New("organization slug already exists")
+
+	// ErrOrgMemberNotFound is returned when a user is not a member of the organization.
+	ErrOrgMemberNotFound = errors.New("organization member not found")
+
+	// ErrOrgMemberExists is returned when adding a user who is already a member.
+	ErrOrgMemberExists = errors.New("user is already a member of the organization")
+
+	// ErrNoActiveOrganization is returned when the user has not switched to an organization.
+	ErrNoActiveOrganization = errors.New("no active organization")
+
+	// ErrInsufficientOrgRole is returned when the caller's organization role does not allow the action.
+	ErrInsufficientOrgRole = errors.New("insufficient organization role")
+
+	// ErrLastOrgOwner is returned when removing or demoting the only owner of an organization.
+	ErrLastOrgOwner = errors.New("organization must keep at least one owner")
+
+	// ErrInvitationNotFound is returned when an invitation does not exist or has expired.
+	ErrInvitationNotFound = errors.New("invitation not found or expired")
+
+	// ErrInvitationEmailMismatch is returned when accepting an invitation sent to another email.
+	ErrInvitationEmailMismatch = errors.New("invitation was sent to another email address")
+
+	// ErrTenantNotFound is returned when a request names an unknown tenant.
+	ErrTenantNotFound = errors.New("tenant not found")
+
+	// ErrImportNotFound is returned when a user import does not exist.
+	ErrImportNotFound = errors.New("user import not found")
+
+	// ErrImportNotResumable is returned when resuming an import that has completed or is still running.
+	ErrImportNotResumable = errors.New("user import is completed or still running")
+
+	// ErrUnsupportedImportFormat is returned when an import file is neither CSV nor NDJSON.
+	ErrUnsupportedImportFormat = errors.New("unsupported import format")
 )
 
+// Generic persistence errors, used when no entity-specific error applies.
+var (
+	// ErrConflict is returned when a write violates a uniqueness rule.
+	ErrConflict = errors.New("resource already exists")
+
+	// ErrInvalidReference is returned when a write references a resource that does not exist.
+	ErrInvalidReference = errors.New("referenced resource does not exist")
+
+	// ErrConstraintViolation is returned when a write violates a data integrity rule.
+	ErrConstraintViolation = errors.New("data violates an integrity constraint")
+
+	// ErrConcurrentModification is returned when a write conflicts with a concurrent transaction; it may be retried.
+	ErrConcurrentModification = errors.New("resource was modified concurrently, please retry")
+
+	// ErrVersionMismatch is returned when a conditional write finds the resource
+	// changed since the version the caller read.
+	ErrVersionMismatch = errors.New("resource was modified since it was read")
+
+	// ErrOperationCanceled is returned when a query was canceled or timed out.
+	ErrOperationCanceled = errors.New("operation was canceled or timed out")
+)
+
+// UserStatusError returns the error refusing a user with the given lifecycle
+// status, or nil if the status allows signing in.
+func UserStatusError(status string) error {
+	switch status {
+	case entity.StatusPending:
+		return ErrUserPending
+	case entity.StatusSuspended:
+		return ErrUserSuspended
+	case entity.StatusBanned:
+		return ErrUserBanned
+	default:
+		return nil
+	}
+}
+
 // IsDomainError checks if the error is a domain-specific error.
 func IsDomainError(err error) bool {
 	return errors.Is(err, ErrUserNotFound) ||
@@ -30,5 +170,44 @@ func IsDomainError(err error) bool {
 		errors.Is(err, ErrDuplicateUsername) ||
 		errors.Is(err, ErrInvalidCredentials) ||
 		errors.Is(err, ErrInvalidPassword) ||
-		errors.Is(err, ErrUserDeleted)
+		errors.Is(err, ErrUserDeleted) ||
+		errors.Is(err, ErrUserNotDeleted) ||
+		errors.Is(err, ErrInvalidRole) ||
+		errors.Is(err, ErrTOTPNotEnabled) ||
+		errors.Is(err, ErrImageTooLarge) ||
+		errors.Is(err, ErrUnsupportedImageType) ||
+		errors.Is(err, ErrInvalidImage) ||
+		errors.Is(err, ErrExportInProgress) ||
+		errors.Is(err, ErrUserPending) ||
+		errors.Is(err, ErrUserSuspended) ||
+		errors.Is(err, ErrUserBanned) ||
+		errors.Is(err, ErrInvalidStatusTransition) ||
+		errors.Is(err, ErrOwnStatusChange) ||
+		errors.Is(err, ErrUsernameUnchanged) ||
+		errors.Is(err, ErrUsernameChangeTooSoon) ||
+		errors.Is(err, ErrUsernameReserved) ||
+		errors.Is(err, ErrTierNotFound) ||
+		errors.Is(err, ErrTierNotAssigned) ||
+		errors.Is(err, ErrInvalidTierPeriod) ||
+		errors.Is(err, ErrUnknownSetting) ||
+		errors.Is(err, ErrInvalidSetting) ||
+		errors.Is(err, ErrOrganizationNotFound) ||
+		errors.Is(err, ErrDuplicateOrganizationSlug) ||
+		errors.Is(err, ErrOrgMemberNotFound) ||
+		errors.Is(err, ErrOrgMemberExists) ||
+		errors.Is(err, ErrNoActiveOrganization) ||
+		errors.Is(err, ErrInsufficientOrgRole) ||
+		errors.Is(err, ErrLastOrgOwner) ||
+		errors.Is(err, ErrInvitationNotFound) ||
+		errors.Is(err, ErrInvitationEmailMismatch) ||
+		errors.Is(err, ErrTenantNotFound) ||
+		errors.Is(err, ErrImportNotFound) ||
+		errors.Is(err, ErrImportNotResumable) ||
+		errors.Is(err, ErrUnsupportedImportFormat) ||
+		errors.Is(err, ErrConflict) ||
+		errors.Is(err, ErrInvalidReference) ||
+		errors.Is(err, ErrConstraintViolation) ||
+		errors.Is(err, ErrConcurrentModification) ||
+		errors.Is(err, ErrVersionMismatch) ||
+		errors.Is(err, ErrOperationCanceled)
 }
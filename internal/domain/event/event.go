@@ -0,0 +1,57 @@
+package event
+
+import "time"
+
+// Event is a fact about a change in the domain. Events are published after
+// the change is persisted.
+type Event interface {
+	// EventName identifies the kind of event, e.g. "user.status_changed".
+	EventName() string
+}
+
+// Event names.
+const (
+	UserStatusChangedName   = "user.status_changed"
+	UserSettingsChangedName = "user.settings_changed"
+	UsernameChangedName     = "user.username_changed"
+)
+
+// UserStatusChanged is published when a user's lifecycle status changes.
+type UserStatusChanged struct {
+	UserID     int64
+	From       string
+	To         string
+	Reason     string
+	ActorID    int64 // the administrator who made the change
+	OccurredAt time.Time
+}
+
+// EventName identifies the event.
+func (e UserStatusChanged) EventName() string {
+	return UserStatusChangedName
+}
+
+// UserSettingsChanged is published when a user changes their settings.
+type UserSettingsChanged struct {
+	UserID     int64
+	Keys       []string // the settings set or reset
+	OccurredAt time.Time
+}
+
+// EventName identifies the event.
+func (e UserSettingsChanged) EventName() string {
+	return UserSettingsChangedName
+}
+
+// UsernameChanged is published when a user changes their username.
+type UsernameChanged struct {
+	UserID     int64
+	From       string
+	To         string
+	OccurredAt time.Time
+}
+
+// EventName identifies the event.
+func (e UsernameChanged) EventName() string {
+	return UsernameChangedName
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"base-service/internal/domain/entity"
+)
+
+// InvitationCreation invites an email address to join an organization.
+type InvitationCreation struct {
+	OrganizationID int64
+	Email          string
+	Role           string
+	TokenHash      string
+	InvitedBy      int64
+	ExpiresAt      time.Time
+}
+
+// OrganizationRepository defines the interface for organization, membership
+// and invitation persistence operations.
+type OrganizationRepository interface {
+	// Create creates an organization with its creator as the first owner.
+	Create(ctx context.Context, org *entity.Organization) (*entity.Organization, error)
+
+	// FindByID finds an organization by ID.
+	FindByID(ctx context.Context, id int64) (*entity.Organization, error)
+
+	// ListByUser returns the memberships of a user with their organization,
+	// by organization name.
+	ListByUser(ctx context.Context, userID int64) ([]*entity.OrganizationMember, error)
+
+	// FindActive finds the membership the user switched to last, with its
+	// organization. It fails with ErrNoActiveOrganization if there is none.
+	FindActive(ctx context.Context, userID int64) (*entity.OrganizationMember, error)
+
+	// Select makes the organization the user's active one.
+	Select(ctx context.Context, orgID, userID int64) error
+
+	// FindMember finds a membership.
+	FindMember(ctx context.Context, orgID, userID int64) (*entity.OrganizationMember, error)
+
+	// ListMembers returns the members of an organization with their username
+	// and email, oldest first.
+	ListMembers(ctx context.Context, orgID int64) ([]*entity.OrganizationMember, error)
+
+	// UpdateMemberRole changes a member's role. Demoting the last owner
+	// returns ErrLastOrgOwner, checked atomically with the update.
+	UpdateMemberRole(ctx context.Context, orgID, userID int64, role string) (*entity.OrganizationMember, error)
+
+	// RemoveMember removes a member from an organization. Removing the last
+	// owner returns ErrLastOrgOwner, checked atomically with the removal.
+	RemoveMember(ctx context.Context, orgID, userID int64) error
+
+	// CreateInvitation records an invitation, replacing a pending one for the
+	// same email.
+	CreateInvitation(ctx context.Context, invitation InvitationCreation) (*entity.OrganizationInvitation, error)
+
+	// FindInvitationByToken finds an unexpired invitation by its token hash.
+	FindInvitationByToken(ctx context.Context, tokenHash string) (*entity.OrganizationInvitation, error)
+
+	// ListInvitations returns the unexpired invitations of an organization,
+	// newest first.
+	ListInvitations(ctx context.Context, orgID int64) ([]*entity.OrganizationInvitation, error)
+
+	// DeleteInvitation deletes an invitation of an organization.
+	DeleteInvitation(ctx context.Context, orgID, invitationID int64) error
+
+	// AcceptInvitation consumes an invitation and adds the user as a member
+	// with the invited role, atomically.
+	AcceptInvitation(ctx context.Context, invitationID, userID int64) (*entity.OrganizationMember, error)
+}
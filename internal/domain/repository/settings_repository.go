@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"base-service/internal/domain/entity"
+)
+
+// SettingsRepository defines the interface for user settings persistence
+// operations. Only the values set by the user are stored; the defaults are
+// declared in code.
+type SettingsRepository interface {
+	// Find returns the values set by the user, empty if none.
+	Find(ctx context.Context, userID int64) (entity.Settings, error)
+
+	// Replace stores values as the user's settings and returns them.
+	Replace(ctx context.Context, userID int64, values entity.Settings) (entity.Settings, error)
+
+	// Merge sets values and removes the reset keys in one atomic change and
+	// returns the resulting settings.
+	Merge(ctx context.Context, userID int64, values entity.Settings, reset []string) (entity.Settings, error)
+}
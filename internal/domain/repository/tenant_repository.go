@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"base-service/internal/domain/entity"
+)
+
+// TenantRepository defines the interface for tenant persistence operations.
+// Tenants are managed in the database; the service only looks them up.
+type TenantRepository interface {
+	// FindByID finds a tenant by ID.
+	FindByID(ctx context.Context, id int64) (*entity.Tenant, error)
+
+	// FindBySlug finds a tenant by its slug.
+	FindBySlug(ctx context.Context, slug string) (*entity.Tenant, error)
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"base-service/internal/domain/entity"
+)
+
+// TierAssignment assigns a tier to a user for a period.
+type TierAssignment struct {
+	UserID     int64
+	TierID     int64
+	ValidFrom  time.Time
+	ValidTo    *time.Time // nil never ends
+	AssignedBy int64
+}
+
+// TierRepository defines the interface for tier persistence operations.
+type TierRepository interface {
+	// List returns every tier.
+	List(ctx context.Context) ([]*entity.Tier, error)
+
+	// FindByCode finds a tier by its code.
+	FindByCode(ctx context.Context, code string) (*entity.Tier, error)
+
+	// FindCurrent finds the user's current tier assignment: the latest one
+	// valid now. It fails with ErrTierNotAssigned if there is none.
+	FindCurrent(ctx context.Context, userID int64) (*entity.UserTier, error)
+
+	// ListAssignments returns every tier assignment of a user, newest first.
+	ListAssignments(ctx context.Context, userID int64) ([]*entity.UserTier, error)
+
+	// Assign records a tier assignment.
+	Assign(ctx context.Context, assignment TierAssignment) (*entity.UserTier, error)
+
+	// EndCurrent ends the user's assignments valid now and returns how many
+	// were ended. Scheduled assignments are kept.
+	EndCurrent(ctx context.Context, userID int64) (int64, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"base-service/internal/domain/entity"
+)
+
+// TOTPRepository defines the interface for TOTP secret persistence operations.
+type TOTPRepository interface {
+	// FindByUserID finds the TOTP enrollment of a user.
+	FindByUserID(ctx context.Context, userID int64) (*entity.TOTPSecret, error)
+
+	// ConsumeStep records step as used. It returns false if the step (or a
+	// later one) was already used, which means the code is being replayed.
+	ConsumeStep(ctx context.Context, userID int64, step int64) (bool, error)
+
+	// Delete removes the TOTP enrollment of a user, if any.
+	Delete(ctx context.Context, userID int64) error
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"base-service/internal/domain/entity"
+)
+
+// ImportedUser is a validated row of an import.
+type ImportedUser struct {
+	Row  int64
+	User *entity.User
+}
+
+// ImportBatch is a batch of consecutive rows of an import.
+type ImportBatch struct {
+	ImportID int64
+	FromRow  int64 // the checkpoint the batch continues after
+	ToRow    int64 // the checkpoint once the batch is stored
+	Users    []ImportedUser
+	Errors   []*entity.UserImportError // rows rejected before inserting
+	DryRun   bool
+}
+
+// UserImportRepository defines the interface for bulk user imports.
+type UserImportRepository interface {
+	// Create creates a pending import.
+	Create(ctx context.Context, imp *entity.UserImport) (*entity.UserImport, error)
+
+	// FindByID finds an import by ID.
+	FindByID(ctx context.Context, id int64) (*entity.UserImport, error)
+
+	// List returns the most recent imports, newest first.
+	List(ctx context.Context, limit int) ([]*entity.UserImport, error)
+
+	// Claim marks a pending or failed import, or a running one without
+	// progress since staleBefore, as running. Any other import fails with
+	// ErrImportNotResumable, so only one caller runs an import at a time.
+	Claim(ctx context.Context, id int64, staleBefore time.Time) (*entity.UserImport, error)
+
+	// ImportBatch inserts the users of batch, records its rejected rows and
+	// advances the checkpoint to batch.ToRow in one transaction. Users whose
+	// username or email is taken, or whose username is reserved, are recorded
+	// as rejected. In a dry run the users are checked but not kept. Returns the
+	// number of users imported; fails with ErrImportNotResumable if the
+	// checkpoint is no longer batch.FromRow.
+	ImportBatch(ctx context.Context, batch ImportBatch) (int64, error)
+
+	// Finish marks a running import completed or failed.
+	Finish(ctx context.Context, id int64, status string, reason string) (*entity.UserImport, error)
+
+	// ListErrors returns a page of the rejected rows of an import, by row.
+	ListErrors(ctx context.Context, importID int64, limit, offset int) ([]*entity.UserImportError, error)
+
+	// CountErrors returns the number of rejected rows of an import.
+	CountErrors(ctx context.Context, importID int64) (int64, error)
+}
@@ -2,10 +2,100 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"base-service/internal/domain/entity"
 )
 
+// Soft-delete states accepted by UserFilter.Deleted.
+const (
+	UserDeletedExclude = "exclude"
+	UserDeletedOnly    = "only"
+	UserDeletedInclude = "include"
+)
+
+// Columns accepted by UserFilter.SortBy.
+const (
+	UserSortID        = "id"
+	UserSortCreatedAt = "created_at"
+	UserSortUpdatedAt = "updated_at"
+	UserSortUsername  = "username"
+	UserSortEmail     = "email"
+)
+
+// UserFilter selects, orders and pages users. Zero values disable a filter.
+// A non-empty Cursor continues after the last row of a previous page and
+// replaces Offset.
+type UserFilter struct {
+	Deleted     string     // UserDeletedExclude when empty
+	Status      string     // lifecycle status, see entity.StatusActive
+	CreatedFrom *time.Time // inclusive
+	CreatedTo   *time.Time // exclusive
+	EmailDomain string
+	Role        string
+	SortBy      string
+	SortDesc    bool
+	Limit       int
+	Offset      int
+	Cursor      string
+}
+
+// UserPage is one page of a user list.
+type UserPage struct {
+	Users      []*entity.User
+	Total      int64
+	NextCursor string // empty on the last page
+}
+
+// ProfilePatch changes the self-service profile fields of a user. Nil fields
+// are left unchanged; an empty Avatar removes the avatar.
+type ProfilePatch struct {
+	UserID          int64
+	FirstName       *string
+	LastName        *string
+	PhoneNumber     *string
+	Avatar          *string
+	ExpectedVersion int64 // when non-zero, the patch fails with ErrVersionMismatch on other versions
+}
+
+// UserDeletion soft-deletes a user.
+type UserDeletion struct {
+	UserID      int64
+	PurgeAt     *time.Time // when the user is permanently deleted; nil keeps the row until restored
+	SelfService bool       // the user deleted their own account and may restore it by logging in before PurgeAt
+}
+
+// DueAccount is a deleted user whose purge time has passed.
+type DueAccount struct {
+	UserID   int64
+	TenantID int64 // the purge runs in this tenant
+}
+
+// StatusChange moves a user from one lifecycle status to another.
+type StatusChange struct {
+	UserID int64
+	From   string // the change fails with ErrInvalidStatusTransition if the status is no longer From
+	To     string
+	Reason string
+}
+
+// UserSearch is a relevance-ordered search over usernames, names, emails
+// and phone numbers.
+type UserSearch struct {
+	Query          string
+	IncludeDeleted bool
+	Limit          int
+	Offset         int
+}
+
+// UserSearchHit is a search result. Highlight is the matched text with the
+// matching terms wrapped in <mark></mark>.
+type UserSearchHit struct {
+	User      *entity.User
+	Rank      float64
+	Highlight string
+}
+
 // UserRepository defines the interface for user persistence operations.
 // This is a port interface that the infrastructure layer must implement.
 type UserRepository interface {
@@ -15,6 +105,17 @@ type UserRepository interface {
 	// FindByID finds a user by their ID.
 	FindByID(ctx context.Context, id int64) (*entity.User, error)
 
+	// FindByIDWithDeleted finds a user by their ID, including soft-deleted users.
+	FindByIDWithDeleted(ctx context.Context, id int64) (*entity.User, error)
+
+	// List returns one page of the users matching filter, the total number of
+	// matches and the cursor of the next page.
+	List(ctx context.Context, filter UserFilter) (*UserPage, error)
+
+	// Search returns one page of the users matching search, best matches first,
+	// and the total number of matches.
+	Search(ctx context.Context, search UserSearch) ([]*UserSearchHit, int64, error)
+
 	// FindByUsername finds a user by their username.
 	FindByUsername(ctx context.Context, username string) (*entity.User, error)
 
@@ -24,9 +125,36 @@ type UserRepository interface {
 	// FindByUsernameOrEmail finds a user by username or email.
 	FindByUsernameOrEmail(ctx context.Context, usernameOrEmail string) (*entity.User, error)
 
-	// Update updates an existing user.
+	// FindByUsernameOrEmailWithDeleted finds a user by username or email,
+	// including soft-deleted users.
+	FindByUsernameOrEmailWithDeleted(ctx context.Context, usernameOrEmail string) (*entity.User, error)
+
+	// Update updates an existing user. Empty fields are left unchanged.
 	Update(ctx context.Context, user *entity.User) (*entity.User, error)
 
-	// Delete soft-deletes a user by their ID.
-	Delete(ctx context.Context, id int64) error
+	// PatchProfile applies patch to an active user and returns the updated user.
+	PatchProfile(ctx context.Context, patch ProfilePatch) (*entity.User, error)
+
+	// Delete soft-deletes a user.
+	Delete(ctx context.Context, deletion UserDeletion) error
+
+	// ChangeStatus applies change to an active (not deleted) user and returns
+	// the updated user.
+	ChangeStatus(ctx context.Context, change StatusChange) (*entity.User, error)
+
+	// Restore undoes the soft delete of a user and cancels its purge.
+	Restore(ctx context.Context, id int64) error
+
+	// ListDueForPurge returns up to limit deleted users whose purge time has
+	// passed, longest overdue first.
+	ListDueForPurge(ctx context.Context, limit int) ([]DueAccount, error)
+
+	// DeferPurge moves the purge time of a user whose purge time has passed
+	// to until, so a failing purge does not hold up the accounts behind it.
+	// It returns ErrUserNotFound when the user is gone, restored or not yet due.
+	DeferPurge(ctx context.Context, id int64, until time.Time) error
+
+	// Purge permanently deletes a user whose purge time has passed. It returns
+	// ErrUserNotFound when the user is gone, restored or not yet due.
+	Purge(ctx context.Context, id int64) error
 }
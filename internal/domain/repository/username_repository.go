@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"base-service/internal/domain/entity"
+)
+
+// UsernameRename changes the username of a user and reserves the old one.
+type UsernameRename struct {
+	UserID        int64
+	From          string // the rename fails with ErrUserNotFound if the username is no longer From
+	To            string
+	ReservedUntil time.Time
+}
+
+// UsernameRepository defines the interface for username changes and the
+// history of previous usernames.
+type UsernameRepository interface {
+	// Rename applies rename to an active user, records the old username in
+	// the history and returns the updated user. A taken username fails with
+	// ErrDuplicateUsername.
+	Rename(ctx context.Context, rename UsernameRename) (*entity.User, error)
+
+	// FindLatest returns the user's most recent username change, nil if none.
+	FindLatest(ctx context.Context, userID int64) (*entity.UsernameChange, error)
+
+	// FindReservation returns the most recent change away from username that
+	// still reserves it, nil if none.
+	FindReservation(ctx context.Context, username string) (*entity.UsernameChange, error)
+
+	// ListByUser returns the user's username changes, newest first.
+	ListByUser(ctx context.Context, userID int64) ([]*entity.UsernameChange, error)
+}
@@ -144,7 +144,7 @@ type HTTPResponse struct {
 }
 
 // =============================================================================
-// Object Storage Contracts (for future integration)
+// Object Storage Contracts
 // =============================================================================
 
 // ObjectStorage defines the contract for object storage operations.
@@ -154,6 +154,7 @@ type ObjectStorage interface {
 	Upload(ctx context.Context, bucket, key string, data io.Reader, contentType string) error
 	Download(ctx context.Context, bucket, key string) (io.ReadCloser, error)
 	Delete(ctx context.Context, bucket, key string) error
+	DeleteFolder(ctx context.Context, bucket, folder string) error // removes every object under folder + "/"
 	GetPresignedURL(ctx context.Context, bucket, key string, expiry time.Duration) (string, error)
 }
 
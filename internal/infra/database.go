@@ -13,6 +13,7 @@ import (
 
 const (
 	databaseName           = "postgresql"
+	systemDatabaseName     = "postgresql-system"
 	defaultHealthTimeout   = 5 * time.Second
 	defaultConnectTimeout  = 10 * time.Second
 	defaultHealthCheckPeriod = 1 * time.Minute
@@ -28,16 +29,80 @@ var (
 // DatabaseClient wraps pgxpool.Pool and implements Connection and TransactionalDB interfaces.
 // clean-arch: Infrastructure adapter implementing domain ports
 type DatabaseClient struct {
-	pool   *pgxpool.Pool
-	config *conf.DatabaseConfig
+	pool    *pgxpool.Pool
+	config  *conf.DatabaseConfig
+	tenancy bool
+	name    string
 }
 
 // NewDatabaseClient creates a new DatabaseClient with the given configuration.
-func NewDatabaseClient(dbConfig *conf.DatabaseConfig) (*DatabaseClient, error) {
+// With tenancy enabled, every statement and transaction runs with the
+// app.tenant_id of the tenant in its context, enforced by row-level security;
+// without a tenant they see no tenant data. With tenancy disabled, every
+// connection is in the default tenant.
+func NewDatabaseClient(dbConfig *conf.DatabaseConfig, tenancyConfig conf.TenancyConfig) (*DatabaseClient, error) {
 	if dbConfig == nil {
 		return nil, fmt.Errorf("database config is required")
 	}
 
+	config, err := newPoolConfig(dbConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Scope each acquired connection to the tenant of the request
+	if tenancyConfig.Enabled {
+		config.PrepareConn = prepareTenantConn
+	} else {
+		config.AfterConnect = setDefaultTenant
+	}
+
+	pool, err := connectPool(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DatabaseClient{
+		pool:    pool,
+		config:  dbConfig,
+		tenancy: tenancyConfig.Enabled,
+		name:    databaseName,
+	}, nil
+}
+
+// NewSystemDatabaseClient creates a DatabaseClient logged in as the system
+// role of dbConfig (see DatabaseConfig.SystemConfig). The role bypasses
+// row-level security and sees the rows of every tenant: only jobs working
+// across tenants use it, and they do the work of each tenant with the
+// regular client, scoped to that tenant.
+func NewSystemDatabaseClient(dbConfig *conf.DatabaseConfig) (*DatabaseClient, error) {
+	systemConfig := dbConfig.SystemConfig()
+	if systemConfig == nil {
+		return nil, fmt.Errorf("database system role is not configured")
+	}
+
+	config, err := newPoolConfig(systemConfig)
+	if err != nil {
+		return nil, err
+	}
+	// Background jobs need few connections
+	config.MaxConns = min(config.MaxConns, 2)
+	config.MinConns = 0
+
+	pool, err := connectPool(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DatabaseClient{
+		pool:   pool,
+		config: systemConfig,
+		name:   systemDatabaseName,
+	}, nil
+}
+
+// newPoolConfig builds the pool configuration of dbConfig.
+func newPoolConfig(dbConfig *conf.DatabaseConfig) (*pgxpool.Config, error) {
 	dbURL := dbConfig.BuildConnectionStringPostgres()
 
 	config, err := pgxpool.ParseConfig(dbURL)
@@ -56,6 +121,11 @@ func NewDatabaseClient(dbConfig *conf.DatabaseConfig) (*DatabaseClient, error) {
 	// Enable SQL query logging
 	config.ConnConfig.Tracer = &SQLTracer{}
 
+	return config, nil
+}
+
+// connectPool creates the pool of config and checks it can connect.
+func connectPool(config *pgxpool.Config) (*pgxpool.Pool, error) {
 	pool, err := pgxpool.NewWithConfig(context.Background(), config)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create connection pool: %w", err)
@@ -69,10 +139,7 @@ func NewDatabaseClient(dbConfig *conf.DatabaseConfig) (*DatabaseClient, error) {
 		return nil, fmt.Errorf("database ping failed: %w", err)
 	}
 
-	return &DatabaseClient{
-		pool:   pool,
-		config: dbConfig,
-	}, nil
+	return pool, nil
 }
 
 // =============================================================================
@@ -81,7 +148,7 @@ func NewDatabaseClient(dbConfig *conf.DatabaseConfig) (*DatabaseClient, error) {
 
 // Name returns the identifier for this connection.
 func (d *DatabaseClient) Name() string {
-	return databaseName
+	return d.name
 }
 
 // Ping checks if the database connection is alive.
@@ -127,8 +194,20 @@ func (d *DatabaseClient) Close() error {
 // TransactionalDB Interface Implementation
 // =============================================================================
 
-// BeginTx starts a new database transaction.
+// BeginTx starts a new database transaction, scoped to the tenant of ctx
+// when tenancy is enabled.
 func (d *DatabaseClient) BeginTx(ctx context.Context) (Transaction, error) {
+	tx, err := d.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pgxTransaction{tx: tx}, nil
+}
+
+// Begin starts a transaction like BeginTx and returns the pgx.Tx, for
+// repositories running sqlc queries in it. Repositories must begin
+// transactions here rather than on the pool, so they are tenant-scoped.
+func (d *DatabaseClient) Begin(ctx context.Context) (pgx.Tx, error) {
 	if d.pool == nil {
 		return nil, fmt.Errorf("database pool is nil")
 	}
@@ -138,7 +217,15 @@ func (d *DatabaseClient) BeginTx(ctx context.Context) (Transaction, error) {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
-	return &pgxTransaction{tx: tx}, nil
+	// SET LOCAL the tenant, so it holds for the whole transaction
+	if d.tenancy {
+		if err := setLocalTenant(ctx, tx); err != nil {
+			_ = tx.Rollback(ctx)
+			return nil, err
+		}
+	}
+
+	return tx, nil
 }
 
 // Stats returns the connection pool statistics.
@@ -0,0 +1,60 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"base-service/internal/domain/event"
+)
+
+// =============================================================================
+// Domain Events
+// clean-arch: In-process delivery of domain events to subscribers
+// =============================================================================
+
+// EventHandler handles a published domain event.
+type EventHandler func(ctx context.Context, e event.Event) error
+
+// EventBus delivers domain events synchronously to the handlers subscribed to
+// their name, in subscription order. Every event is logged, so the log is an
+// audit trail even without subscribers. A failing handler is logged and does
+// not stop the others or fail the change that published the event; forward
+// events to a MessagePublisher for reliable delivery to other services.
+type EventBus struct {
+	mu       sync.RWMutex
+	handlers map[string][]EventHandler
+}
+
+// NewEventBus creates an event bus without subscribers.
+func NewEventBus() *EventBus {
+	return &EventBus{handlers: make(map[string][]EventHandler)}
+}
+
+// Subscribe calls handler for every event named name.
+func (b *EventBus) Subscribe(name string, handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish delivers e to its subscribers.
+func (b *EventBus) Publish(ctx context.Context, e event.Event) {
+	b.mu.RLock()
+	handlers := b.handlers[e.EventName()]
+	b.mu.RUnlock()
+
+	slog.Info("Domain event",
+		"event", e.EventName(),
+		"payload", fmt.Sprintf("%+v", e),
+	)
+	for _, handler := range handlers {
+		if err := handler(ctx, e); err != nil {
+			slog.Error("Domain event handler failed",
+				"error", err,
+				"event", e.EventName(),
+			)
+		}
+	}
+}
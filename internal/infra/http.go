@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"base-service/config"
+	"base-service/internal/common"
 	"base-service/internal/middleware"
 	"base-service/util"
 
@@ -25,6 +26,7 @@ type HttpServer struct {
 	CORS    *config.MiddlewareConfig
 	Redis   *redis.Client
 	RedisCf *config.RedisConfig
+	Tenant  fiber.Handler // resolves the tenant of each request before rate limiting; nil skips it
 	app     *fiber.App
 }
 
@@ -71,6 +73,11 @@ func (r *HttpServer) InitHttpServer() {
 	// Apply CORS middleware with configuration
 	app.Use(middleware.CorsFilter(r.CORS.CORS))
 
+	// Resolve the tenant, so rate limits are kept per tenant
+	if r.Tenant != nil {
+		app.Use(r.Tenant)
+	}
+
 	// Apply general rate limiting to all API endpoints
 	if r.CORS != nil && r.CORS.RateLimit.Enabled {
 		var redisClient *redis.Client
@@ -128,5 +135,6 @@ func (r *HttpServer) ConfigFiber(conf *config.ServerInfo) fiber.Config {
 		DisableStartupMessage: false,
 		ReadTimeout:           time.Duration(conf.ReadTimeout) * time.Second,
 		WriteTimeout:          time.Duration(conf.WriteTimeout) * time.Second,
+		ErrorHandler:          common.ProblemErrorHandler, // unhandled errors and 404s as problem+json
 	}
 }
@@ -0,0 +1,91 @@
+package infra
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultHTTPClientTimeout = 10 * time.Second
+	maxHTTPResponseBodySize  = 10 << 20 // 10 MB
+)
+
+// Compile-time interface compliance check
+var _ HTTPClient = (*DefaultHTTPClient)(nil)
+
+// DefaultHTTPClient implements HTTPClient on top of net/http.
+// clean-arch: Infrastructure adapter for calling external HTTP services
+type DefaultHTTPClient struct {
+	client *http.Client
+}
+
+// NewHTTPClient creates a new HTTP client with the given timeout.
+func NewHTTPClient(timeout time.Duration) *DefaultHTTPClient {
+	if timeout <= 0 {
+		timeout = defaultHTTPClientTimeout
+	}
+	return &DefaultHTTPClient{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Get performs a GET request.
+func (h *DefaultHTTPClient) Get(ctx context.Context, url string, headers map[string]string) (*HTTPResponse, error) {
+	return h.do(ctx, http.MethodGet, url, nil, headers)
+}
+
+// Post performs a POST request.
+func (h *DefaultHTTPClient) Post(ctx context.Context, url string, body []byte, headers map[string]string) (*HTTPResponse, error) {
+	return h.do(ctx, http.MethodPost, url, body, headers)
+}
+
+// Put performs a PUT request.
+func (h *DefaultHTTPClient) Put(ctx context.Context, url string, body []byte, headers map[string]string) (*HTTPResponse, error) {
+	return h.do(ctx, http.MethodPut, url, body, headers)
+}
+
+// Delete performs a DELETE request.
+func (h *DefaultHTTPClient) Delete(ctx context.Context, url string, headers map[string]string) (*HTTPResponse, error) {
+	return h.do(ctx, http.MethodDelete, url, nil, headers)
+}
+
+func (h *DefaultHTTPClient) do(ctx context.Context, method, url string, body []byte, headers map[string]string) (*HTTPResponse, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %w", method, url, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPResponseBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	respHeaders := make(map[string]string, len(resp.Header))
+	for key := range resp.Header {
+		respHeaders[key] = resp.Header.Get(key)
+	}
+
+	return &HTTPResponse{
+		StatusCode: resp.StatusCode,
+		Body:       respBody,
+		Headers:    respHeaders,
+	}, nil
+}
@@ -0,0 +1,125 @@
+package infra
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // register GIF decoding
+	"image/jpeg"
+	_ "image/png" // register PNG decoding
+)
+
+// =============================================================================
+// Image Processing
+// clean-arch: Standard library decoding and resizing for uploaded images
+// =============================================================================
+
+const (
+	defaultImageMaxDimension = 4096
+	defaultJPEGQuality       = 85
+)
+
+var (
+	ErrImageDecode     = errors.New("image cannot be decoded")
+	ErrImageDimensions = errors.New("image dimensions exceed the limit")
+)
+
+// ImageProcessor decodes JPEG, PNG and GIF images and renders square JPEG
+// renditions. Transparent areas are flattened onto white.
+type ImageProcessor struct {
+	maxDimension int
+	quality      int
+}
+
+// NewImageProcessor creates a processor rejecting sources wider or taller than
+// maxDimension pixels (0 uses the default) before decoding them, which keeps
+// small files that expand to huge bitmaps from exhausting memory.
+func NewImageProcessor(maxDimension int) *ImageProcessor {
+	if maxDimension <= 0 {
+		maxDimension = defaultImageMaxDimension
+	}
+	return &ImageProcessor{
+		maxDimension: maxDimension,
+		quality:      defaultJPEGQuality,
+	}
+}
+
+// SquareJPEGs center-crops src to a square and returns it encoded as JPEG
+// once per size, in order. Images are never upscaled.
+func (p *ImageProcessor) SquareJPEGs(src []byte, sizes ...int) ([][]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(src))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrImageDecode, err)
+	}
+	if cfg.Width > p.maxDimension || cfg.Height > p.maxDimension {
+		return nil, fmt.Errorf("%w: %dx%d", ErrImageDimensions, cfg.Width, cfg.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(src))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrImageDecode, err)
+	}
+	square := cropSquare(img)
+
+	renditions := make([][]byte, 0, len(sizes))
+	for _, size := range sizes {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, downscale(square, size), &jpeg.Options{Quality: p.quality}); err != nil {
+			return nil, fmt.Errorf("failed to encode image: %w", err)
+		}
+		renditions = append(renditions, buf.Bytes())
+	}
+	return renditions, nil
+}
+
+// cropSquare returns the centered square of img on a white background.
+func cropSquare(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	offset := image.Pt(bounds.Min.X+(bounds.Dx()-side)/2, bounds.Min.Y+(bounds.Dy()-side)/2)
+
+	square := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(square, square.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(square, square.Bounds(), img, offset, draw.Over)
+	return square
+}
+
+// downscale resizes a square image to size×size by averaging the source
+// pixels covered by each destination pixel (box filter).
+func downscale(src *image.RGBA, size int) *image.RGBA {
+	side := src.Bounds().Dx()
+	if size <= 0 || size >= side {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		y0, y1 := y*side/size, (y+1)*side/size
+		for x := 0; x < size; x++ {
+			x0, x1 := x*side/size, (x+1)*side/size
+
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					px := row[sx*4 : sx*4+4]
+					r += uint32(px[0])
+					g += uint32(px[1])
+					b += uint32(px[2])
+					a += uint32(px[3])
+					n++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i+0] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
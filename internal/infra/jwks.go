@@ -0,0 +1,341 @@
+package infra
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	conf "base-service/config"
+	"base-service/internal/middleware"
+)
+
+const (
+	jwksName          = "jwks"
+	jwksFetchTimeout  = 5 * time.Second
+	jwksKeyUseSigning = "sig"
+)
+
+// Compile-time interface compliance check
+var (
+	_ Connection             = (*JWKSKeyResolver)(nil)
+	_ middleware.KeyResolver = (*JWKSKeyResolver)(nil)
+)
+
+// JWKSKeyResolver fetches and caches the JSON Web Key Sets of trusted external
+// issuers. Key sets are refreshed in the background and refetched on kid miss,
+// rate limited by the issuer's minimum refetch interval.
+// clean-arch: Infrastructure adapter implementing middleware.KeyResolver
+type JWKSKeyResolver struct {
+	client  HTTPClient
+	issuers map[string]*jwksIssuer
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// jwksIssuer holds the cached key set of a single issuer.
+type jwksIssuer struct {
+	config conf.ExternalIssuerConfig
+
+	fetchMu     sync.Mutex // serializes fetches
+	mu          sync.RWMutex
+	keys        map[string]crypto.PublicKey
+	fetchedAt   time.Time
+	lastAttempt time.Time
+	lastErr     error
+}
+
+// jsonWebKey is the subset of RFC 7517 fields needed for signature verification.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// NewJWKSKeyResolver creates a resolver for the given external issuers.
+// Call Start to load the key sets and begin background refresh.
+func NewJWKSKeyResolver(issuers []conf.ExternalIssuerConfig, client HTTPClient) *JWKSKeyResolver {
+	if client == nil {
+		client = NewHTTPClient(jwksFetchTimeout)
+	}
+
+	r := &JWKSKeyResolver{
+		client:  client,
+		issuers: make(map[string]*jwksIssuer, len(issuers)),
+	}
+	for _, issuer := range issuers {
+		r.issuers[issuer.Issuer] = &jwksIssuer{
+			config: issuer,
+			keys:   make(map[string]crypto.PublicKey),
+		}
+	}
+	return r
+}
+
+// Start performs the initial fetch for every issuer and starts background refresh.
+// Fetch failures are logged; the resolver retries on refresh and on kid miss.
+func (r *JWKSKeyResolver) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	for _, issuer := range r.issuers {
+		if err := r.refresh(ctx, issuer); err != nil {
+			slog.Error("Failed to load JWKS", "issuer", issuer.config.Issuer, "error", err)
+		}
+
+		r.wg.Add(1)
+		go r.refreshLoop(ctx, issuer)
+	}
+}
+
+func (r *JWKSKeyResolver) refreshLoop(ctx context.Context, issuer *jwksIssuer) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(issuer.config.GetRefreshInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.refresh(ctx, issuer); err != nil {
+				slog.Warn("Background JWKS refresh failed, keeping cached keys",
+					"issuer", issuer.config.Issuer,
+					"error", err,
+				)
+			}
+		}
+	}
+}
+
+// =============================================================================
+// KeyResolver Interface Implementation
+// =============================================================================
+
+// ResolveKey returns the key identified by kid, refetching the issuer's key
+// set once if the kid is unknown and the refetch interval has elapsed.
+func (r *JWKSKeyResolver) ResolveKey(ctx context.Context, issuerName, kid string) (crypto.PublicKey, error) {
+	issuer, ok := r.issuers[issuerName]
+	if !ok {
+		return nil, middleware.ErrInvalidIssuer
+	}
+
+	if key, ok := issuer.key(kid); ok {
+		return key, nil
+	}
+
+	if issuer.canRefetch() {
+		slog.Info("Unknown kid, refetching JWKS", "issuer", issuerName, "kid", kid)
+		if err := r.refresh(ctx, issuer); err != nil {
+			slog.Warn("JWKS refetch failed", "issuer", issuerName, "error", err)
+		}
+		if key, ok := issuer.key(kid); ok {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: kid %q for issuer %s", middleware.ErrUnknownSigningKey, kid, issuerName)
+}
+
+func (i *jwksIssuer) key(kid string) (crypto.PublicKey, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	key, ok := i.keys[kid]
+	return key, ok
+}
+
+func (i *jwksIssuer) canRefetch() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return time.Since(i.lastAttempt) >= i.config.GetMinRefetchInterval()
+}
+
+// refresh downloads and replaces the issuer's key set.
+func (r *JWKSKeyResolver) refresh(ctx context.Context, issuer *jwksIssuer) error {
+	issuer.fetchMu.Lock()
+	defer issuer.fetchMu.Unlock()
+
+	// Another request may have refreshed the set while we waited
+	if !issuer.canRefetch() && !issuer.lastAttempt.IsZero() && issuer.lastErr == nil {
+		return nil
+	}
+
+	issuer.mu.Lock()
+	issuer.lastAttempt = time.Now()
+	issuer.mu.Unlock()
+
+	keys, err := r.fetch(ctx, issuer.config.JWKSURL)
+
+	issuer.mu.Lock()
+	defer issuer.mu.Unlock()
+	issuer.lastErr = err
+	if err != nil {
+		return err
+	}
+	issuer.keys = keys
+	issuer.fetchedAt = time.Now()
+
+	slog.Debug("JWKS refreshed", "issuer", issuer.config.Issuer, "keys", len(keys))
+	return nil
+}
+
+func (r *JWKSKeyResolver) fetch(ctx context.Context, url string) (map[string]crypto.PublicKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, jwksFetchTimeout)
+	defer cancel()
+
+	resp, err := r.client.Get(ctx, url, map[string]string{"Accept": "application/json"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var set jsonWebKeySet
+	if err := json.Unmarshal(resp.Body, &set); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Kid == "" || (jwk.Use != "" && jwk.Use != jwksKeyUseSigning) {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			slog.Warn("Skipping unsupported JWK", "kid", jwk.Kid, "kty", jwk.Kty, "error", err)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("JWKS at %s contains no usable signing keys", url)
+	}
+	return keys, nil
+}
+
+// =============================================================================
+// JWK Decoding
+// =============================================================================
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBase64URL(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := decodeBase64URL(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %w", err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("exponent too large")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBase64URL(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x coordinate: %w", err)
+		}
+		y, err := decodeBase64URL(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y coordinate: %w", err)
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) > size || len(y) > size {
+			return nil, fmt.Errorf("invalid coordinate length")
+		}
+		point := make([]byte, 1+2*size)
+		point[0] = 4 // uncompressed point
+		copy(point[1+size-len(x):1+size], x)
+		copy(point[1+2*size-len(y):], y)
+		return ecdsa.ParseUncompressedPublicKey(curve, point)
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBase64URL(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+func decodeBase64URL(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(value)
+}
+
+// =============================================================================
+// Connection Interface Implementation
+// =============================================================================
+
+// Name returns the identifier for this connection.
+func (r *JWKSKeyResolver) Name() string {
+	return jwksName
+}
+
+// Ping returns an error if any issuer has no usable keys.
+func (r *JWKSKeyResolver) Ping(ctx context.Context) error {
+	for name, issuer := range r.issuers {
+		issuer.mu.RLock()
+		count, lastErr := len(issuer.keys), issuer.lastErr
+		issuer.mu.RUnlock()
+		if count == 0 {
+			return fmt.Errorf("no keys loaded for issuer %s: %v", name, lastErr)
+		}
+	}
+	return nil
+}
+
+// IsHealthy reports whether every issuer has a loaded key set.
+func (r *JWKSKeyResolver) IsHealthy(ctx context.Context) bool {
+	return r.Ping(ctx) == nil
+}
+
+// Close stops background refresh.
+func (r *JWKSKeyResolver) Close() error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
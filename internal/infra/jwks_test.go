@@ -0,0 +1,191 @@
+package infra
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	conf "base-service/config"
+	"base-service/internal/middleware"
+)
+
+// fakeJWKSClient serves the current key set and counts the fetches.
+type fakeJWKSClient struct {
+	HTTPClient
+	set     jsonWebKeySet
+	fetches int
+}
+
+func (c *fakeJWKSClient) Get(ctx context.Context, url string, headers map[string]string) (*HTTPResponse, error) {
+	c.fetches++
+	body, err := json.Marshal(c.set)
+	if err != nil {
+		return nil, err
+	}
+	return &HTTPResponse{StatusCode: http.StatusOK, Body: body}, nil
+}
+
+func rsaJWK(t *testing.T, kid string) (jsonWebKey, *rsa.PublicKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return jsonWebKey{
+		Kty: "RSA",
+		Kid: kid,
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}, &key.PublicKey
+}
+
+func TestJWKSResolveKey(t *testing.T) {
+	first, firstKey := rsaJWK(t, "key-1")
+	second, secondKey := rsaJWK(t, "key-2")
+
+	tests := []struct {
+		name        string
+		minRefetch  time.Duration
+		rotated     []jsonWebKey // the key set once the first fetch is done
+		issuer      string
+		kid         string
+		wantKey     *rsa.PublicKey
+		wantErr     error
+		wantFetches int
+	}{
+		{
+			name:        "known kid",
+			minRefetch:  time.Nanosecond,
+			issuer:      "idp",
+			kid:         "key-1",
+			wantKey:     firstKey,
+			wantFetches: 1,
+		},
+		{
+			name:        "unknown kid refetches the rotated set",
+			minRefetch:  time.Nanosecond,
+			rotated:     []jsonWebKey{first, second},
+			issuer:      "idp",
+			kid:         "key-2",
+			wantKey:     secondKey,
+			wantFetches: 2,
+		},
+		{
+			name:        "unknown kid within the refetch interval",
+			minRefetch:  time.Hour,
+			rotated:     []jsonWebKey{first, second},
+			issuer:      "idp",
+			kid:         "key-2",
+			wantErr:     middleware.ErrUnknownSigningKey,
+			wantFetches: 1,
+		},
+		{
+			name:        "kid missing after refetch",
+			minRefetch:  time.Nanosecond,
+			issuer:      "idp",
+			kid:         "key-3",
+			wantErr:     middleware.ErrUnknownSigningKey,
+			wantFetches: 2,
+		},
+		{
+			name:        "untrusted issuer",
+			minRefetch:  time.Nanosecond,
+			issuer:      "other-idp",
+			kid:         "key-1",
+			wantErr:     middleware.ErrInvalidIssuer,
+			wantFetches: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := &fakeJWKSClient{set: jsonWebKeySet{Keys: []jsonWebKey{first}}}
+			r := NewJWKSKeyResolver([]conf.ExternalIssuerConfig{
+				{Issuer: "idp", JWKSURL: "https://idp.example.com/jwks", MinRefetchInterval: tt.minRefetch},
+			}, client)
+			if err := r.refresh(ctx, r.issuers["idp"]); err != nil {
+				t.Fatalf("refresh: %v", err)
+			}
+			if tt.rotated != nil {
+				client.set.Keys = tt.rotated
+			}
+
+			key, err := r.ResolveKey(ctx, tt.issuer, tt.kid)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantKey != nil {
+				got, ok := key.(*rsa.PublicKey)
+				if !ok || !got.Equal(tt.wantKey) {
+					t.Errorf("key = %v, want %v", key, tt.wantKey)
+				}
+			}
+			if client.fetches != tt.wantFetches {
+				t.Errorf("fetches = %d, want %d", client.fetches, tt.wantFetches)
+			}
+		})
+	}
+}
+
+func TestJWKSFetchSkipsUnusableKeys(t *testing.T) {
+	signing, _ := rsaJWK(t, "sig")
+	encryption, _ := rsaJWK(t, "enc")
+	encryption.Use = "enc"
+	anonymous, _ := rsaJWK(t, "")
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPoint, err := ecKey.PublicKey.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &fakeJWKSClient{set: jsonWebKeySet{Keys: []jsonWebKey{
+		signing,
+		encryption,
+		anonymous,
+		{
+			Kty: "EC", Kid: "ec", Crv: "P-256",
+			X: base64.RawURLEncoding.EncodeToString(ecPoint[1:33]),
+			Y: base64.RawURLEncoding.EncodeToString(ecPoint[33:]),
+		},
+		{Kty: "OKP", Kid: "ed", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(edKey)},
+		{Kty: "EC", Kid: "unknown-curve", Crv: "P-192", X: "AA", Y: "AA"},
+		{Kty: "oct", Kid: "symmetric"},
+	}}}
+	r := NewJWKSKeyResolver(nil, client)
+
+	keys, err := r.fetch(context.Background(), "https://idp.example.com/jwks")
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if len(keys) != 3 {
+		t.Errorf("got %d keys, want 3: %v", len(keys), keys)
+	}
+	if _, ok := keys["sig"].(*rsa.PublicKey); !ok {
+		t.Errorf("sig = %T, want an RSA key", keys["sig"])
+	}
+	if got, ok := keys["ec"].(*ecdsa.PublicKey); !ok || !got.Equal(&ecKey.PublicKey) {
+		t.Errorf("ec = %v, want %v", keys["ec"], &ecKey.PublicKey)
+	}
+	if got, ok := keys["ed"].(ed25519.PublicKey); !ok || !got.Equal(edKey) {
+		t.Errorf("ed = %v, want %v", keys["ed"], edKey)
+	}
+}
@@ -0,0 +1,269 @@
+package infra
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"base-service/config"
+)
+
+// =============================================================================
+// Notifications
+// clean-arch: Infrastructure adapters implementing the NotificationSender port
+// =============================================================================
+
+const (
+	NotificationDriverLog  = "log"
+	NotificationDriverSMTP = "smtp"
+)
+
+var (
+	ErrUnsupportedNotifier = errors.New("unsupported notification driver")
+	ErrUnsupportedChannel  = errors.New("notification channel is not supported")
+)
+
+// NewNotificationSender creates the NotificationSender selected by cfg.Driver.
+func NewNotificationSender(cfg config.NotificationConfig) (NotificationSender, error) {
+	switch cfg.Driver {
+	case "", NotificationDriverLog:
+		slog.Warn("Notifications are logged, not delivered")
+		return &LogNotificationSender{}, nil
+	case NotificationDriverSMTP:
+		return NewSMTPNotificationSender(cfg.SMTP)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedNotifier, cfg.Driver)
+	}
+}
+
+// =============================================================================
+// Log Sender
+// =============================================================================
+
+// Compile-time interface compliance check
+var _ NotificationSender = (*LogNotificationSender)(nil)
+
+// LogNotificationSender writes notifications to the log instead of delivering
+// them. Meant for development; message bodies may contain secrets such as
+// download links.
+type LogNotificationSender struct{}
+
+// SendEmail logs the email.
+func (s *LogNotificationSender) SendEmail(_ context.Context, req EmailRequest) error {
+	slog.Info("Email notification",
+		"to", req.To,
+		"subject", req.Subject,
+		"body", req.Body,
+		"attachments", len(req.Attachments),
+	)
+	return nil
+}
+
+// SendSMS logs the SMS.
+func (s *LogNotificationSender) SendSMS(_ context.Context, req SMSRequest) error {
+	slog.Info("SMS notification", "to", req.To, "message", req.Message)
+	return nil
+}
+
+// SendPush logs the push notification.
+func (s *LogNotificationSender) SendPush(_ context.Context, req PushRequest) error {
+	slog.Info("Push notification",
+		"devices", len(req.DeviceTokens),
+		"title", req.Title,
+		"body", req.Body,
+	)
+	return nil
+}
+
+// =============================================================================
+// SMTP Sender
+// =============================================================================
+
+// Compile-time interface compliance check
+var _ NotificationSender = (*SMTPNotificationSender)(nil)
+
+// SMTPNotificationSender delivers emails through an SMTP server, upgrading to
+// TLS with STARTTLS when offered. SMS and push are not supported.
+type SMTPNotificationSender struct {
+	addr string
+	auth smtp.Auth
+	from *mail.Address
+}
+
+// NewSMTPNotificationSender creates an SMTP sender. Credentials are optional;
+// they are only sent over TLS or to localhost.
+func NewSMTPNotificationSender(cfg config.SMTPConfig) (*SMTPNotificationSender, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("smtp host is required")
+	}
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid smtp sender address %q: %w", cfg.From, err)
+	}
+	port := cfg.Port
+	if port == 0 {
+		port = 587
+	}
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return &SMTPNotificationSender{
+		addr: net.JoinHostPort(cfg.Host, strconv.Itoa(port)),
+		auth: auth,
+		from: from,
+	}, nil
+}
+
+// SendEmail sends the email to every To, CC and BCC recipient.
+func (s *SMTPNotificationSender) SendEmail(ctx context.Context, req EmailRequest) error {
+	recipients := make([]string, 0, len(req.To)+len(req.CC)+len(req.BCC))
+	for _, list := range [][]string{req.To, req.CC, req.BCC} {
+		for _, recipient := range list {
+			address, err := mail.ParseAddress(recipient)
+			if err != nil {
+				return fmt.Errorf("invalid recipient %q: %w", recipient, err)
+			}
+			recipients = append(recipients, address.Address)
+		}
+	}
+	if len(recipients) == 0 {
+		return fmt.Errorf("email has no recipients")
+	}
+
+	msg, err := s.message(req)
+	if err != nil {
+		return err
+	}
+	// net/smtp has no context support; at least skip sends nobody waits for
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := smtp.SendMail(s.addr, s.auth, s.from.Address, recipients, msg); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// SendSMS is not supported over SMTP.
+func (s *SMTPNotificationSender) SendSMS(context.Context, SMSRequest) error {
+	return ErrUnsupportedChannel
+}
+
+// SendPush is not supported over SMTP.
+func (s *SMTPNotificationSender) SendPush(context.Context, PushRequest) error {
+	return ErrUnsupportedChannel
+}
+
+// message renders req as a MIME message: a single quoted-printable part, or
+// multipart/mixed when there are attachments. BCC recipients are left out of
+// the headers.
+func (s *SMTPNotificationSender) message(req EmailRequest) ([]byte, error) {
+	var buf bytes.Buffer
+	header := textproto.MIMEHeader{}
+	header.Set("From", s.from.String())
+	header.Set("To", strings.Join(req.To, ", "))
+	if len(req.CC) > 0 {
+		header.Set("Cc", strings.Join(req.CC, ", "))
+	}
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", req.Subject))
+	header.Set("Date", time.Now().Format(time.RFC1123Z))
+	header.Set("MIME-Version", "1.0")
+
+	bodyType := "text/plain; charset=utf-8"
+	if req.IsHTML {
+		bodyType = "text/html; charset=utf-8"
+	}
+
+	if len(req.Attachments) == 0 {
+		header.Set("Content-Type", bodyType)
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+		writeHeader(&buf, header)
+		if err := writeQuotedPrintable(&buf, req.Body); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	mw := multipart.NewWriter(&buf)
+	header.Set("Content-Type", "multipart/mixed; boundary="+mw.Boundary())
+	writeHeader(&buf, header)
+
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {bodyType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := writeQuotedPrintable(part, req.Body); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range req.Attachments {
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeBase64Lines(part, attachment.Data); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeHeader(w io.Writer, header textproto.MIMEHeader) {
+	for name, values := range header {
+		for _, value := range values {
+			fmt.Fprintf(w, "%s: %s\r\n", name, value)
+		}
+	}
+	fmt.Fprint(w, "\r\n")
+}
+
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := io.WriteString(qp, body); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// writeBase64Lines writes data base64-encoded in 76 character lines (RFC 2045).
+func writeBase64Lines(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		if _, err := fmt.Fprintf(w, "%s\r\n", encoded[:76]); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err := fmt.Fprintf(w, "%s\r\n", encoded)
+	return err
+}
@@ -0,0 +1,198 @@
+package infra
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// AWS Signature Version 4
+// clean-arch: Request signing for S3-compatible object storage
+// =============================================================================
+
+const (
+	sigV4Algorithm  = "AWS4-HMAC-SHA256"
+	sigV4TimeFormat = "20060102T150405Z"
+	sigV4DateFormat = "20060102"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// sigV4Signer signs requests and presigns URLs with AWS Signature Version 4.
+type sigV4Signer struct {
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+	region          string
+	service         string
+	now             func() time.Time
+}
+
+// Sign adds the SigV4 headers to req. payloadHash is the hex SHA-256 of the
+// body, or UNSIGNED-PAYLOAD.
+func (s *sigV4Signer) Sign(req *http.Request, payloadHash string) {
+	now := s.now().UTC()
+	req.Header.Set("X-Amz-Date", now.Format(sigV4TimeFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.sessionToken)
+	}
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers, signedHeaders := canonicalHeaders(host, req.Header)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL),
+		canonicalQuery(req.URL.Query()),
+		headers,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := s.scope(now)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, s.accessKeyID, scope, signedHeaders, s.signature(now, scope, canonicalRequest)))
+}
+
+// Presign returns u with the SigV4 query parameters authorizing method for
+// expiry. Only the host header is signed, so any client can use the URL.
+func (s *sigV4Signer) Presign(method string, u *url.URL, expiry time.Duration) string {
+	now := s.now().UTC()
+	scope := s.scope(now)
+
+	query := u.Query()
+	query.Set("X-Amz-Algorithm", sigV4Algorithm)
+	query.Set("X-Amz-Credential", s.accessKeyID+"/"+scope)
+	query.Set("X-Amz-Date", now.Format(sigV4TimeFormat))
+	query.Set("X-Amz-Expires", strconv.FormatInt(int64(expiry/time.Second), 10))
+	query.Set("X-Amz-SignedHeaders", "host")
+	if s.sessionToken != "" {
+		query.Set("X-Amz-Security-Token", s.sessionToken)
+	}
+
+	headers, signedHeaders := canonicalHeaders(u.Host, nil)
+	rawQuery := canonicalQuery(query)
+	canonicalRequest := strings.Join([]string{
+		method,
+		canonicalURI(u),
+		rawQuery,
+		headers,
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	presigned := *u
+	presigned.RawQuery = rawQuery + "&X-Amz-Signature=" + s.signature(now, scope, canonicalRequest)
+	return presigned.String()
+}
+
+func (s *sigV4Signer) scope(now time.Time) string {
+	return now.Format(sigV4DateFormat) + "/" + s.region + "/" + s.service + "/aws4_request"
+}
+
+func (s *sigV4Signer) signature(now time.Time, scope, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{
+		sigV4Algorithm,
+		now.Format(sigV4TimeFormat),
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), now.Format(sigV4DateFormat))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, s.service)
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// canonicalHeaders returns the canonical header block and the signed header
+// list. The host, Content-Type, Content-MD5 and X-Amz-* headers are signed.
+func canonicalHeaders(host string, header http.Header) (string, string) {
+	values := map[string]string{"host": strings.ToLower(host)}
+	for name, vals := range header {
+		name = strings.ToLower(name)
+		if name == "content-type" || name == "content-md5" || strings.HasPrefix(name, "x-amz-") {
+			trimmed := make([]string, len(vals))
+			for i, v := range vals {
+				trimmed[i] = strings.Join(strings.Fields(v), " ")
+			}
+			values[name] = strings.Join(trimmed, ",")
+		}
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name + ":" + values[name] + "\n")
+	}
+	return b.String(), strings.Join(names, ";")
+}
+
+func canonicalURI(u *url.URL) string {
+	if path := u.EscapedPath(); path != "" {
+		return path
+	}
+	return "/"
+}
+
+// canonicalQuery encodes query sorted by key and value.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	pairs := make([]string, 0, len(query))
+	for _, key := range keys {
+		vals := slices.Clone(query[key])
+		slices.Sort(vals)
+		for _, v := range vals {
+			pairs = append(pairs, uriEncode(key, true)+"="+uriEncode(v, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes everything but the RFC 3986 unreserved characters,
+// and slashes unless encodeSlash is set, as SigV4 requires.
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
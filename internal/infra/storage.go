@@ -0,0 +1,101 @@
+package infra
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	"base-service/config"
+	"base-service/internal/common"
+)
+
+// =============================================================================
+// Object Storage
+// clean-arch: Driver selection and errors shared by ObjectStorage implementations
+// =============================================================================
+
+const (
+	StorageDriverLocal = "local"
+	StorageDriverS3    = "s3"
+)
+
+var (
+	ErrObjectNotFound     = errors.New("object not found")
+	ErrInvalidObjectKey   = errors.New("invalid bucket or object key")
+	ErrSignedURLInvalid   = errors.New("signed URL is invalid")
+	ErrSignedURLExpired   = errors.New("signed URL has expired")
+	ErrUnsupportedStorage = errors.New("unsupported storage driver")
+)
+
+var bucketNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,62}$`)
+
+// NewObjectStorage creates the ObjectStorage selected by cfg.Driver.
+func NewObjectStorage(cfg config.StorageConfig) (ObjectStorage, error) {
+	switch cfg.Driver {
+	case "", StorageDriverLocal:
+		return NewLocalObjectStorage(cfg.Local)
+	case StorageDriverS3:
+		return NewS3ObjectStorage(cfg.S3)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedStorage, cfg.Driver)
+	}
+}
+
+// cleanObjectKey validates bucket and returns key without empty, "." or ".."
+// segments, so it cannot escape the bucket.
+func cleanObjectKey(bucket, key string) (string, error) {
+	if !bucketNameRegex.MatchString(bucket) {
+		return "", ErrInvalidObjectKey
+	}
+	cleaned := strings.TrimPrefix(path.Clean("/"+key), "/")
+	if cleaned == "" || cleaned != strings.TrimPrefix(key, "/") || strings.Contains(cleaned, "\\") {
+		return "", ErrInvalidObjectKey
+	}
+	return cleaned, nil
+}
+
+// randomKey returns a 32-byte key for signing when none is configured.
+func randomKey() []byte {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+}
+
+func init() {
+	common.RegisterError(ErrObjectNotFound, common.ErrorDefinition{
+		Code:   "STORAGE_OBJECT_NOT_FOUND",
+		Status: http.StatusNotFound,
+		Messages: common.Messages{
+			"en": "File not found",
+			"vi": "Không tìm thấy tệp",
+		},
+	})
+	common.RegisterError(ErrInvalidObjectKey, common.ErrorDefinition{
+		Code:   "STORAGE_INVALID_KEY",
+		Status: http.StatusBadRequest,
+		Messages: common.Messages{
+			"en": "Invalid file path",
+			"vi": "Đường dẫn tệp không hợp lệ",
+		},
+	})
+	common.RegisterError(ErrSignedURLInvalid, common.ErrorDefinition{
+		Code:   "STORAGE_URL_INVALID",
+		Status: http.StatusForbidden,
+		Messages: common.Messages{
+			"en": "The file link is invalid",
+			"vi": "Liên kết tệp không hợp lệ",
+		},
+	})
+	common.RegisterError(ErrSignedURLExpired, common.ErrorDefinition{
+		Code:   "STORAGE_URL_EXPIRED",
+		Status: http.StatusForbidden,
+		Messages: common.Messages{
+			"en": "The file link has expired, reload to get a new one",
+			"vi": "Liên kết tệp đã hết hạn, vui lòng tải lại để nhận liên kết mới",
+		},
+	})
+}
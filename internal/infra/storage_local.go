@@ -0,0 +1,244 @@
+package infra
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"base-service/config"
+)
+
+const (
+	localStorageName       = "local-storage"
+	defaultLocalStorageURL = "/api/files"
+)
+
+// Compile-time interface compliance check
+var _ ObjectStorage = (*LocalObjectStorage)(nil)
+
+// LocalObjectStorage stores objects as files under root/bucket/key. Presigned
+// URLs point to the file route and carry an HMAC signature instead of cloud
+// credentials; OpenSigned verifies them.
+// clean-arch: Infrastructure adapter implementing the ObjectStorage port
+type LocalObjectStorage struct {
+	root    string
+	baseURL string
+	secret  []byte
+}
+
+// NewLocalObjectStorage creates the root directory if needed. An empty signing
+// secret falls back to a random per-process key.
+func NewLocalObjectStorage(cfg config.LocalStorageConfig) (*LocalObjectStorage, error) {
+	if cfg.Root == "" {
+		return nil, fmt.Errorf("local storage root is required")
+	}
+	if err := os.MkdirAll(cfg.Root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage root: %w", err)
+	}
+
+	secret := []byte(cfg.SigningSecret)
+	if len(secret) == 0 {
+		slog.Warn("No storage signing secret configured, signed URLs are only valid on this instance until restart")
+		secret = randomKey()
+	}
+	baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = defaultLocalStorageURL
+	}
+
+	return &LocalObjectStorage{
+		root:    cfg.Root,
+		baseURL: baseURL,
+		secret:  secret,
+	}, nil
+}
+
+// =============================================================================
+// Connection Interface Implementation
+// =============================================================================
+
+// Ping checks that the root directory is accessible.
+func (s *LocalObjectStorage) Ping(_ context.Context) error {
+	info, err := os.Stat(s.root)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("storage root %s is not a directory", s.root)
+	}
+	return nil
+}
+
+// IsHealthy returns true if the root directory is accessible.
+func (s *LocalObjectStorage) IsHealthy(ctx context.Context) bool {
+	return s.Ping(ctx) == nil
+}
+
+// Name returns the connection identifier.
+func (s *LocalObjectStorage) Name() string {
+	return localStorageName
+}
+
+// Close is a no-op; files are closed after each operation.
+func (s *LocalObjectStorage) Close() error {
+	return nil
+}
+
+// =============================================================================
+// ObjectStorage Interface Implementation
+// =============================================================================
+
+// Upload writes data to a temporary file and renames it into place, so readers
+// never see partial objects. The content type is implied by the key extension.
+func (s *LocalObjectStorage) Upload(_ context.Context, bucket, key string, data io.Reader, _ string) error {
+	target, err := s.objectPath(bucket, key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create object: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+
+	if _, err := io.Copy(tmp, data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return fmt.Errorf("failed to store object: %w", err)
+	}
+	return nil
+}
+
+// Download opens an object for reading.
+func (s *LocalObjectStorage) Download(_ context.Context, bucket, key string) (io.ReadCloser, error) {
+	target, err := s.objectPath(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+	return file, err
+}
+
+// Delete removes an object; deleting a missing object is not an error.
+func (s *LocalObjectStorage) Delete(_ context.Context, bucket, key string) error {
+	target, err := s.objectPath(bucket, key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	return nil
+}
+
+// DeleteFolder removes every object under folder; a missing folder is not an
+// error.
+func (s *LocalObjectStorage) DeleteFolder(_ context.Context, bucket, folder string) error {
+	target, err := s.objectPath(bucket, folder)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(target)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && !info.IsDir()) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete folder: %w", err)
+	}
+	if err := os.RemoveAll(target); err != nil {
+		return fmt.Errorf("failed to delete folder: %w", err)
+	}
+	return nil
+}
+
+// GetPresignedURL returns a download URL valid for expiry.
+func (s *LocalObjectStorage) GetPresignedURL(_ context.Context, bucket, key string, expiry time.Duration) (string, error) {
+	key, err := cleanObjectKey(bucket, key)
+	if err != nil {
+		return "", err
+	}
+	expires := time.Now().Add(expiry).Unix()
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.sign(bucket, key, expires))
+	return s.baseURL + "/" + bucket + "/" + escapeKey(key) + "?" + query.Encode(), nil
+}
+
+// =============================================================================
+// Signed Downloads
+// =============================================================================
+
+// OpenSigned verifies a presigned URL and opens its object. It returns the
+// object and its content type.
+func (s *LocalObjectStorage) OpenSigned(ctx context.Context, bucket, key string, expires int64, signature string) (io.ReadCloser, string, error) {
+	key, err := cleanObjectKey(bucket, key)
+	if err != nil {
+		return nil, "", err
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(bucket, key, expires))) {
+		return nil, "", ErrSignedURLInvalid
+	}
+	if time.Now().Unix() > expires {
+		return nil, "", ErrSignedURLExpired
+	}
+
+	object, err := s.Download(ctx, bucket, key)
+	if err != nil {
+		return nil, "", err
+	}
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return object, contentType, nil
+}
+
+func (s *LocalObjectStorage) sign(bucket, key string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s\n%s\n%d", bucket, key, expires)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *LocalObjectStorage) objectPath(bucket, key string) (string, error) {
+	key, err := cleanObjectKey(bucket, key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, bucket, filepath.FromSlash(key)), nil
+}
+
+// escapeKey escapes each segment of key but keeps the slashes.
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
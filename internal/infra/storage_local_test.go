@@ -0,0 +1,190 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"base-service/config"
+)
+
+func newTestLocalStorage(t *testing.T) *LocalObjectStorage {
+	t.Helper()
+	storage, err := NewLocalObjectStorage(config.LocalStorageConfig{
+		Root:          t.TempDir(),
+		BaseURL:       "https://api.example.com/api/files/",
+		SigningSecret: "signing-secret",
+	})
+	if err != nil {
+		t.Fatalf("NewLocalObjectStorage: %v", err)
+	}
+	return storage
+}
+
+// signedParams splits a presigned URL into the bucket, key, expiry and
+// signature the file route passes to OpenSigned.
+func signedParams(t *testing.T, signed string) (string, string, int64, string) {
+	t.Helper()
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatalf("parse %q: %v", signed, err)
+	}
+	bucket, key, ok := strings.Cut(strings.TrimPrefix(u.Path, "/api/files/"), "/")
+	if !ok {
+		t.Fatalf("no bucket in %q", signed)
+	}
+	expires, err := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+	if err != nil {
+		t.Fatalf("expires of %q: %v", signed, err)
+	}
+	return bucket, key, expires, u.Query().Get("signature")
+}
+
+func TestLocalObjectStorageObjects(t *testing.T) {
+	ctx := context.Background()
+	storage := newTestLocalStorage(t)
+
+	if err := storage.Upload(ctx, "avatars", "avatars/1/a.jpg", strings.NewReader("jpeg"), "image/jpeg"); err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	object, err := storage.Download(ctx, "avatars", "avatars/1/a.jpg")
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	data, _ := io.ReadAll(object)
+	object.Close()
+	if string(data) != "jpeg" {
+		t.Errorf("downloaded %q, want %q", data, "jpeg")
+	}
+
+	if err := storage.Delete(ctx, "avatars", "avatars/1/a.jpg"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := storage.Download(ctx, "avatars", "avatars/1/a.jpg"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Download after Delete err = %v, want %v", err, ErrObjectNotFound)
+	}
+	if err := storage.Delete(ctx, "avatars", "avatars/1/a.jpg"); err != nil {
+		t.Errorf("Delete of a missing object: %v", err)
+	}
+
+	for _, key := range []string{"../secret", "a/../../secret", "", "a\\b"} {
+		if err := storage.Upload(ctx, "avatars", key, strings.NewReader("x"), ""); !errors.Is(err, ErrInvalidObjectKey) {
+			t.Errorf("Upload(%q) err = %v, want %v", key, err, ErrInvalidObjectKey)
+		}
+	}
+	if err := storage.Upload(ctx, "../avatars", "a.jpg", strings.NewReader("x"), ""); !errors.Is(err, ErrInvalidObjectKey) {
+		t.Errorf("Upload to an invalid bucket err = %v, want %v", err, ErrInvalidObjectKey)
+	}
+}
+
+func TestLocalObjectStorageSignedURLs(t *testing.T) {
+	ctx := context.Background()
+	storage := newTestLocalStorage(t)
+	if err := storage.Upload(ctx, "avatars", "avatars/1/a b.jpg", strings.NewReader("jpeg"), "image/jpeg"); err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+
+	signed, err := storage.GetPresignedURL(ctx, "avatars", "avatars/1/a b.jpg", time.Hour)
+	if err != nil {
+		t.Fatalf("GetPresignedURL: %v", err)
+	}
+	if !strings.HasPrefix(signed, "https://api.example.com/api/files/avatars/avatars/1/a%20b.jpg?") {
+		t.Errorf("signed URL = %q", signed)
+	}
+	bucket, key, expires, signature := signedParams(t, signed)
+
+	tests := []struct {
+		name      string
+		key       string
+		expires   int64
+		signature string
+		wantErr   error
+	}{
+		{name: "valid", key: key, expires: expires, signature: signature},
+		{name: "tampered signature", key: key, expires: expires, signature: signature[1:] + "A", wantErr: ErrSignedURLInvalid},
+		{name: "other key", key: "avatars/2/a b.jpg", expires: expires, signature: signature, wantErr: ErrSignedURLInvalid},
+		{name: "extended expiry", key: key, expires: expires + 3600, signature: signature, wantErr: ErrSignedURLInvalid},
+		{name: "path traversal", key: "../avatars/1/a b.jpg", expires: expires, signature: signature, wantErr: ErrInvalidObjectKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			object, contentType, err := storage.OpenSigned(ctx, bucket, tt.key, tt.expires, tt.signature)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer object.Close()
+			if contentType != "image/jpeg" {
+				t.Errorf("content type = %q, want image/jpeg", contentType)
+			}
+		})
+	}
+
+	t.Run("expired", func(t *testing.T) {
+		expired, err := storage.GetPresignedURL(ctx, "avatars", "avatars/1/a b.jpg", -time.Minute)
+		if err != nil {
+			t.Fatalf("GetPresignedURL: %v", err)
+		}
+		bucket, key, expires, signature := signedParams(t, expired)
+		if _, _, err := storage.OpenSigned(ctx, bucket, key, expires, signature); !errors.Is(err, ErrSignedURLExpired) {
+			t.Errorf("err = %v, want %v", err, ErrSignedURLExpired)
+		}
+	})
+
+	t.Run("other secret", func(t *testing.T) {
+		other, err := NewLocalObjectStorage(config.LocalStorageConfig{Root: t.TempDir(), SigningSecret: "other-secret"})
+		if err != nil {
+			t.Fatalf("NewLocalObjectStorage: %v", err)
+		}
+		if _, _, err := other.OpenSigned(ctx, bucket, key, expires, signature); !errors.Is(err, ErrSignedURLInvalid) {
+			t.Errorf("err = %v, want %v", err, ErrSignedURLInvalid)
+		}
+	})
+}
+
+func TestLocalObjectStorageDeleteFolder(t *testing.T) {
+	ctx := context.Background()
+	storage := newTestLocalStorage(t)
+	for _, key := range []string{"1/a.zip", "1/nested/b.zip", "12/c.zip", "1"} {
+		bucket := "exports"
+		if key == "1" {
+			bucket = "other"
+		}
+		if err := storage.Upload(ctx, bucket, key, strings.NewReader("zip"), "application/zip"); err != nil {
+			t.Fatalf("Upload(%s): %v", key, err)
+		}
+	}
+
+	if err := storage.DeleteFolder(ctx, "exports", "1"); err != nil {
+		t.Fatalf("DeleteFolder: %v", err)
+	}
+	for _, key := range []string{"1/a.zip", "1/nested/b.zip"} {
+		if _, err := storage.Download(ctx, "exports", key); !errors.Is(err, ErrObjectNotFound) {
+			t.Errorf("Download(%s) err = %v, want %v", key, err, ErrObjectNotFound)
+		}
+	}
+	// Keys sharing the prefix outside the folder and files named like the folder are kept
+	if _, err := storage.Download(ctx, "exports", "12/c.zip"); err != nil {
+		t.Errorf("Download(12/c.zip): %v", err)
+	}
+	if err := storage.DeleteFolder(ctx, "other", "1"); err != nil {
+		t.Errorf("DeleteFolder of a file: %v", err)
+	}
+	if _, err := storage.Download(ctx, "other", "1"); err != nil {
+		t.Errorf("Download(1): %v", err)
+	}
+
+	if err := storage.DeleteFolder(ctx, "exports", "1"); err != nil {
+		t.Errorf("DeleteFolder of a missing folder: %v", err)
+	}
+	if err := storage.DeleteFolder(ctx, "exports", "../exports"); !errors.Is(err, ErrInvalidObjectKey) {
+		t.Errorf("DeleteFolder(../exports) err = %v, want %v", err, ErrInvalidObjectKey)
+	}
+}
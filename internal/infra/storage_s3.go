@@ -0,0 +1,425 @@
+package infra
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"base-service/config"
+)
+
+const (
+	s3StorageName     = "s3-storage"
+	defaultS3Region   = "us-east-1"
+	defaultS3PartSize = 8 << 20
+	minS3PartSize     = 5 << 20 // S3 rejects smaller parts, except the last one
+	defaultS3Timeout  = 30 * time.Second
+	maxPresignExpiry  = 7 * 24 * time.Hour
+	maxS3ErrorBody    = 64 << 10
+)
+
+// Compile-time interface compliance check
+var _ ObjectStorage = (*S3ObjectStorage)(nil)
+
+// S3Error is an error response of the S3 API.
+type S3Error struct {
+	XMLName    xml.Name `xml:"Error"`
+	StatusCode int      `xml:"-"`
+	Code       string   `xml:"Code"`
+	Message    string   `xml:"Message"`
+}
+
+func (e *S3Error) Error() string {
+	return fmt.Sprintf("s3: %s (status %d): %s", e.Code, e.StatusCode, e.Message)
+}
+
+// S3ObjectStorage stores objects in AWS S3 or an S3-compatible server (MinIO,
+// Ceph, R2...) through the REST API with SigV4 signing. Objects larger than
+// the part size are sent as multipart uploads.
+// clean-arch: Infrastructure adapter implementing the ObjectStorage port
+type S3ObjectStorage struct {
+	endpoint     *url.URL
+	pathStyle    bool
+	partSize     int64
+	healthBucket string
+	signer       *sigV4Signer
+	client       *http.Client
+}
+
+// NewS3ObjectStorage creates an S3 client. No request is made until first use.
+func NewS3ObjectStorage(cfg config.S3StorageConfig) (*S3ObjectStorage, error) {
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("s3 access key ID and secret access key are required")
+	}
+
+	region := cfg.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = "https://s3." + region + ".amazonaws.com"
+	}
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil || endpointURL.Scheme == "" || endpointURL.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", endpoint)
+	}
+	endpointURL.Path = strings.TrimSuffix(endpointURL.Path, "/")
+	endpointURL.RawPath = ""
+
+	partSize := cfg.PartSize
+	if partSize == 0 {
+		partSize = defaultS3PartSize
+	}
+	if partSize < minS3PartSize {
+		return nil, fmt.Errorf("s3 part size must be at least %d bytes", minS3PartSize)
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultS3Timeout
+	}
+
+	return &S3ObjectStorage{
+		endpoint:     endpointURL,
+		pathStyle:    cfg.UsePathStyle,
+		partSize:     partSize,
+		healthBucket: cfg.HealthBucket,
+		signer: &sigV4Signer{
+			accessKeyID:     cfg.AccessKeyID,
+			secretAccessKey: cfg.SecretAccessKey,
+			sessionToken:    cfg.SessionToken,
+			region:          region,
+			service:         "s3",
+			now:             time.Now,
+		},
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// =============================================================================
+// Connection Interface Implementation
+// =============================================================================
+
+// Ping checks that the health bucket is reachable, or lists the buckets when
+// none is configured.
+func (s *S3ObjectStorage) Ping(ctx context.Context) error {
+	method, target := http.MethodGet, s.bucketURL("")
+	if s.healthBucket != "" {
+		method, target = http.MethodHead, s.bucketURL(s.healthBucket)
+	}
+	resp, err := s.do(ctx, method, target, nil, nil)
+	if err != nil {
+		return err
+	}
+	return drainAndClose(resp)
+}
+
+// IsHealthy returns true if the storage is reachable.
+func (s *S3ObjectStorage) IsHealthy(ctx context.Context) bool {
+	return s.Ping(ctx) == nil
+}
+
+// Name returns the connection identifier.
+func (s *S3ObjectStorage) Name() string {
+	return s3StorageName
+}
+
+// Close releases idle HTTP connections.
+func (s *S3ObjectStorage) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// =============================================================================
+// ObjectStorage Interface Implementation
+// =============================================================================
+
+// Upload stores data in a single request, or as a multipart upload when it
+// exceeds the part size. At most one part is held in memory.
+func (s *S3ObjectStorage) Upload(ctx context.Context, bucket, key string, data io.Reader, contentType string) error {
+	target, err := s.objectURL(bucket, key)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, s.partSize)
+	n, err := io.ReadFull(data, buf)
+	switch {
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		resp, err := s.do(ctx, http.MethodPut, target, buf[:n], contentHeader(contentType))
+		if err != nil {
+			return fmt.Errorf("failed to upload object: %w", err)
+		}
+		return drainAndClose(resp)
+	case err != nil:
+		return fmt.Errorf("failed to read object: %w", err)
+	}
+	return s.multipartUpload(ctx, target, buf, data, contentType)
+}
+
+// Download opens an object for reading.
+func (s *S3ObjectStorage) Download(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	target, err := s.objectURL(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(ctx, http.MethodGet, target, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete removes an object; deleting a missing object is not an error.
+func (s *S3ObjectStorage) Delete(ctx context.Context, bucket, key string) error {
+	target, err := s.objectURL(bucket, key)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodDelete, target, nil, nil)
+	if errors.Is(err, ErrObjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	return drainAndClose(resp)
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// DeleteFolder removes every object under folder, one page of the listing at
+// a time; a missing folder is not an error.
+func (s *S3ObjectStorage) DeleteFolder(ctx context.Context, bucket, folder string) error {
+	folder, err := cleanObjectKey(bucket, folder)
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{"list-type": {"2"}, "prefix": {folder + "/"}}
+	for {
+		resp, err := s.do(ctx, http.MethodGet, withQuery(s.bucketURL(bucket), query), nil, nil)
+		if err != nil {
+			return fmt.Errorf("failed to list folder: %w", err)
+		}
+		var page listBucketResult
+		if err := decodeXML(resp, &page); err != nil {
+			return fmt.Errorf("failed to list folder: %w", err)
+		}
+
+		for _, object := range page.Contents {
+			if err := s.Delete(ctx, bucket, object.Key); err != nil {
+				return err
+			}
+		}
+		if !page.IsTruncated {
+			return nil
+		}
+		query.Set("continuation-token", page.NextContinuationToken)
+	}
+}
+
+// GetPresignedURL returns a download URL valid for expiry (at most 7 days).
+func (s *S3ObjectStorage) GetPresignedURL(_ context.Context, bucket, key string, expiry time.Duration) (string, error) {
+	return s.presign(http.MethodGet, bucket, key, expiry)
+}
+
+// GetPresignedUploadURL returns a URL valid for expiry (at most 7 days) that
+// lets a client PUT the object directly.
+func (s *S3ObjectStorage) GetPresignedUploadURL(_ context.Context, bucket, key string, expiry time.Duration) (string, error) {
+	return s.presign(http.MethodPut, bucket, key, expiry)
+}
+
+// =============================================================================
+// Multipart Uploads
+// =============================================================================
+
+type initiateMultipartUploadResult struct {
+	UploadID string `xml:"UploadId"`
+}
+
+type completeMultipartUpload struct {
+	XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+	Parts   []completedPart `xml:"Part"`
+}
+
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// multipartUpload sends first, then the rest of data, one part at a time. The
+// upload is aborted on failure so no parts are left billed.
+func (s *S3ObjectStorage) multipartUpload(ctx context.Context, target *url.URL, first []byte, data io.Reader, contentType string) error {
+	resp, err := s.do(ctx, http.MethodPost, withQuery(target, url.Values{"uploads": {""}}), nil, contentHeader(contentType))
+	if err != nil {
+		return fmt.Errorf("failed to start multipart upload: %w", err)
+	}
+	var initiated initiateMultipartUploadResult
+	if err := decodeXML(resp, &initiated); err != nil {
+		return fmt.Errorf("failed to start multipart upload: %w", err)
+	}
+
+	if err := s.uploadParts(ctx, target, initiated.UploadID, first, data); err != nil {
+		abortURL := withQuery(target, url.Values{"uploadId": {initiated.UploadID}})
+		if resp, abortErr := s.do(context.WithoutCancel(ctx), http.MethodDelete, abortURL, nil, nil); abortErr == nil {
+			_ = drainAndClose(resp)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *S3ObjectStorage) uploadParts(ctx context.Context, target *url.URL, uploadID string, part []byte, data io.Reader) error {
+	buf := part
+	var complete completeMultipartUpload
+	for number := 1; ; number++ {
+		partURL := withQuery(target, url.Values{
+			"partNumber": {strconv.Itoa(number)},
+			"uploadId":   {uploadID},
+		})
+		resp, err := s.do(ctx, http.MethodPut, partURL, part, nil)
+		if err != nil {
+			return fmt.Errorf("failed to upload part %d: %w", number, err)
+		}
+		_ = drainAndClose(resp)
+		complete.Parts = append(complete.Parts, completedPart{PartNumber: number, ETag: resp.Header.Get("ETag")})
+
+		n, err := io.ReadFull(data, buf)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("failed to read object: %w", err)
+		}
+		part = buf[:n]
+	}
+
+	body, err := xml.Marshal(complete)
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	resp, err := s.do(ctx, http.MethodPost, withQuery(target, url.Values{"uploadId": {uploadID}}), body, nil)
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	// Completion can fail after a 200 status; the error is then in the body
+	var completed struct {
+		XMLName xml.Name
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if err := decodeXML(resp, &completed); err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	if completed.XMLName.Local == "Error" {
+		return fmt.Errorf("failed to complete multipart upload: %w",
+			&S3Error{StatusCode: resp.StatusCode, Code: completed.Code, Message: completed.Message})
+	}
+	return nil
+}
+
+// =============================================================================
+// Requests
+// =============================================================================
+
+// do sends a signed request and turns non-2xx responses into errors; a
+// missing object becomes ErrObjectNotFound.
+func (s *S3ObjectStorage) do(ctx context.Context, method string, target *url.URL, body []byte, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	s.signer.Sign(req, hashHex(body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < http.StatusMultipleChoices {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	s3Err := &S3Error{StatusCode: resp.StatusCode, Code: http.StatusText(resp.StatusCode)}
+	// HEAD responses carry no body
+	_ = xml.NewDecoder(io.LimitReader(resp.Body, maxS3ErrorBody)).Decode(s3Err)
+	if resp.StatusCode == http.StatusNotFound && s3Err.Code != "NoSuchBucket" {
+		return nil, ErrObjectNotFound
+	}
+	return nil, s3Err
+}
+
+func (s *S3ObjectStorage) presign(method, bucket, key string, expiry time.Duration) (string, error) {
+	target, err := s.objectURL(bucket, key)
+	if err != nil {
+		return "", err
+	}
+	return s.signer.Presign(method, target, min(max(expiry, time.Second), maxPresignExpiry)), nil
+}
+
+func (s *S3ObjectStorage) objectURL(bucket, key string) (*url.URL, error) {
+	key, err := cleanObjectKey(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	target := s.bucketURL(bucket)
+	target.Path += "/" + key
+	target.RawPath = uriEncode(target.Path, false)
+	return target, nil
+}
+
+// bucketURL addresses bucket with path-style or virtual-hosted-style URLs;
+// an empty bucket addresses the service.
+func (s *S3ObjectStorage) bucketURL(bucket string) *url.URL {
+	target := *s.endpoint
+	switch {
+	case bucket == "":
+	case s.pathStyle:
+		target.Path += "/" + bucket
+	default:
+		target.Host = bucket + "." + target.Host
+	}
+	target.RawPath = uriEncode(target.Path, false)
+	return &target
+}
+
+func withQuery(target *url.URL, query url.Values) *url.URL {
+	withQuery := *target
+	withQuery.RawQuery = query.Encode()
+	return &withQuery
+}
+
+func contentHeader(contentType string) http.Header {
+	if contentType == "" {
+		return nil
+	}
+	return http.Header{"Content-Type": {contentType}}
+}
+
+func decodeXML(resp *http.Response, v any) error {
+	defer resp.Body.Close()
+	return xml.NewDecoder(resp.Body).Decode(v)
+}
+
+func drainAndClose(resp *http.Response) error {
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
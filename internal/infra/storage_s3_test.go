@@ -0,0 +1,360 @@
+package infra
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"base-service/config"
+)
+
+// fakeS3 is an in-memory S3 server with path-style addressing. It serves the
+// object, multipart upload and error responses S3ObjectStorage relies on.
+type fakeS3 struct {
+	mu        sync.Mutex
+	objects   map[string][]byte // "bucket/key" -> content
+	uploads   map[string]map[int][]byte
+	aborted   []string
+	denied    string // key refused with 403 AccessDenied
+	failParts bool   // part uploads fail with 500 InternalError
+	// completeErr makes multipart completion fail with a 200 status, as S3
+	// may do once it has started sending the response
+	completeErr bool
+	nextUpload  int
+	pageSize    int // keys per listing page; 0 is 1000
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{
+		objects: make(map[string][]byte),
+		uploads: make(map[string]map[int][]byte),
+	}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+		writeS3Error(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+	if r.Header.Get("X-Amz-Content-Sha256") != hashHex(body) {
+		writeS3Error(w, http.StatusBadRequest, "XAmzContentSHA256Mismatch")
+		return
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket == "missing" {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	if key != "" && key == f.denied {
+		writeS3Error(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+	name := bucket + "/" + key
+	query := r.URL.Query()
+
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		f.nextUpload++
+		uploadID := fmt.Sprintf("upload-%d", f.nextUpload)
+		f.uploads[uploadID] = make(map[int][]byte)
+		fmt.Fprintf(w, "<InitiateMultipartUploadResult><UploadId>%s</UploadId></InitiateMultipartUploadResult>", uploadID)
+	case r.Method == http.MethodPut && query.Has("partNumber"):
+		parts, ok := f.uploads[query.Get("uploadId")]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		if f.failParts {
+			writeS3Error(w, http.StatusInternalServerError, "InternalError")
+			return
+		}
+		var number int
+		fmt.Sscan(query.Get("partNumber"), &number)
+		parts[number] = body
+		w.Header().Set("ETag", fmt.Sprintf(`"etag-%d"`, number))
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		parts, ok := f.uploads[query.Get("uploadId")]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		if f.completeErr {
+			fmt.Fprint(w, "<Error><Code>InternalError</Code><Message>try again</Message></Error>")
+			return
+		}
+		var complete completeMultipartUpload
+		if err := xml.Unmarshal(body, &complete); err != nil {
+			writeS3Error(w, http.StatusBadRequest, "MalformedXML")
+			return
+		}
+		var object []byte
+		for i, part := range complete.Parts {
+			if part.PartNumber != i+1 || part.ETag != fmt.Sprintf(`"etag-%d"`, i+1) {
+				writeS3Error(w, http.StatusBadRequest, "InvalidPart")
+				return
+			}
+			object = append(object, parts[part.PartNumber]...)
+		}
+		delete(f.uploads, query.Get("uploadId"))
+		f.objects[name] = object
+		fmt.Fprint(w, "<CompleteMultipartUploadResult></CompleteMultipartUploadResult>")
+	case r.Method == http.MethodDelete && query.Has("uploadId"):
+		delete(f.uploads, query.Get("uploadId"))
+		f.aborted = append(f.aborted, query.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && query.Get("list-type") == "2":
+		f.list(w, bucket, query.Get("prefix"), query.Get("continuation-token"))
+	case r.Method == http.MethodPut:
+		f.objects[name] = body
+	case r.Method == http.MethodGet:
+		object, ok := f.objects[name]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Write(object)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeS3Error(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+// list writes one page of the keys of bucket starting with prefix; the
+// continuation token is the last key of the previous page.
+func (f *fakeS3) list(w http.ResponseWriter, bucket, prefix, after string) {
+	var keys []string
+	for name := range f.objects {
+		key := strings.TrimPrefix(name, bucket+"/")
+		if key != name && strings.HasPrefix(key, prefix) && key > after {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	pageSize := f.pageSize
+	if pageSize == 0 {
+		pageSize = 1000
+	}
+	truncated := len(keys) > pageSize
+	keys = keys[:min(len(keys), pageSize)]
+
+	fmt.Fprint(w, "<ListBucketResult>")
+	for _, key := range keys {
+		fmt.Fprintf(w, "<Contents><Key>%s</Key></Contents>", key)
+	}
+	if truncated {
+		fmt.Fprintf(w, "<IsTruncated>true</IsTruncated><NextContinuationToken>%s</NextContinuationToken>", keys[len(keys)-1])
+	}
+	fmt.Fprint(w, "</ListBucketResult>")
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}
+
+func newTestS3Storage(t *testing.T) (*S3ObjectStorage, *fakeS3) {
+	t.Helper()
+	fake := newFakeS3()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	storage, err := NewS3ObjectStorage(config.S3StorageConfig{
+		Endpoint:        server.URL,
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "secret",
+		UsePathStyle:    true,
+		PartSize:        minS3PartSize,
+	})
+	if err != nil {
+		t.Fatalf("NewS3ObjectStorage: %v", err)
+	}
+	return storage, fake
+}
+
+func TestS3ObjectStorageObjects(t *testing.T) {
+	ctx := context.Background()
+	storage, fake := newTestS3Storage(t)
+
+	if err := storage.Upload(ctx, "avatars", "avatars/1/a b.jpg", strings.NewReader("jpeg"), "image/jpeg"); err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	if got := string(fake.objects["avatars/avatars/1/a b.jpg"]); got != "jpeg" {
+		t.Errorf("stored %q, want %q", got, "jpeg")
+	}
+
+	object, err := storage.Download(ctx, "avatars", "avatars/1/a b.jpg")
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	data, _ := io.ReadAll(object)
+	object.Close()
+	if string(data) != "jpeg" {
+		t.Errorf("downloaded %q, want %q", data, "jpeg")
+	}
+
+	if err := storage.Delete(ctx, "avatars", "avatars/1/a b.jpg"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := storage.Download(ctx, "avatars", "avatars/1/a b.jpg"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Download after Delete err = %v, want %v", err, ErrObjectNotFound)
+	}
+	if err := storage.Delete(ctx, "avatars", "avatars/1/a b.jpg"); err != nil {
+		t.Errorf("Delete of a missing object: %v", err)
+	}
+}
+
+func TestS3ObjectStorageMultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	// Two full parts and a short last one
+	data := bytes.Repeat([]byte("0123456789abcdef"), (2*minS3PartSize+1024)/16)
+
+	t.Run("uploads the parts in order", func(t *testing.T) {
+		storage, fake := newTestS3Storage(t)
+		if err := storage.Upload(ctx, "exports", "1/archive.zip", bytes.NewReader(data), "application/zip"); err != nil {
+			t.Fatalf("Upload: %v", err)
+		}
+		if !bytes.Equal(fake.objects["exports/1/archive.zip"], data) {
+			t.Errorf("stored %d bytes, want the %d uploaded", len(fake.objects["exports/1/archive.zip"]), len(data))
+		}
+		if len(fake.uploads) != 0 || len(fake.aborted) != 0 {
+			t.Errorf("uploads left = %v, aborted = %v", fake.uploads, fake.aborted)
+		}
+	})
+
+	t.Run("a failed part aborts the upload", func(t *testing.T) {
+		storage, fake := newTestS3Storage(t)
+		fake.failParts = true
+		err := storage.Upload(ctx, "exports", "1/archive.zip", bytes.NewReader(data), "application/zip")
+		var s3Err *S3Error
+		if !errors.As(err, &s3Err) || s3Err.Code != "InternalError" {
+			t.Fatalf("err = %v, want an InternalError S3Error", err)
+		}
+		if len(fake.aborted) != 1 || len(fake.uploads) != 0 {
+			t.Errorf("aborted = %v, uploads left = %v; want the upload aborted", fake.aborted, fake.uploads)
+		}
+		if _, ok := fake.objects["exports/1/archive.zip"]; ok {
+			t.Error("object stored despite the failed part")
+		}
+	})
+
+	t.Run("an error in a successful completion response fails the upload", func(t *testing.T) {
+		storage, fake := newTestS3Storage(t)
+		fake.completeErr = true
+		err := storage.Upload(ctx, "exports", "1/archive.zip", bytes.NewReader(data), "application/zip")
+		var s3Err *S3Error
+		if !errors.As(err, &s3Err) || s3Err.Code != "InternalError" || s3Err.StatusCode != http.StatusOK {
+			t.Fatalf("err = %v, want an InternalError S3Error with status 200", err)
+		}
+		if len(fake.aborted) != 1 {
+			t.Errorf("aborted = %v, want the upload aborted", fake.aborted)
+		}
+	})
+}
+
+func TestS3ObjectStorageErrors(t *testing.T) {
+	ctx := context.Background()
+	storage, fake := newTestS3Storage(t)
+	fake.denied = "private.jpg"
+
+	tests := []struct {
+		name     string
+		call     func() error
+		wantErr  error
+		wantCode string
+	}{
+		{
+			name: "missing object",
+			call: func() error {
+				_, err := storage.Download(ctx, "avatars", "missing.jpg")
+				return err
+			},
+			wantErr: ErrObjectNotFound,
+		},
+		{
+			name: "missing bucket is not a missing object",
+			call: func() error {
+				_, err := storage.Download(ctx, "missing", "a.jpg")
+				return err
+			},
+			wantCode: "NoSuchBucket",
+		},
+		{
+			name: "denied upload",
+			call: func() error {
+				return storage.Upload(ctx, "avatars", "private.jpg", strings.NewReader("jpeg"), "image/jpeg")
+			},
+			wantCode: "AccessDenied",
+		},
+		{
+			name:     "denied delete",
+			call:     func() error { return storage.Delete(ctx, "avatars", "private.jpg") },
+			wantCode: "AccessDenied",
+		},
+		{
+			name:    "invalid key",
+			call:    func() error { return storage.Delete(ctx, "avatars", "../a.jpg") },
+			wantErr: ErrInvalidObjectKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			var s3Err *S3Error
+			if !errors.As(err, &s3Err) || s3Err.Code != tt.wantCode {
+				t.Fatalf("err = %v, want S3 error %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestS3ObjectStorageDeleteFolder(t *testing.T) {
+	ctx := context.Background()
+	storage, fake := newTestS3Storage(t)
+	fake.pageSize = 2
+	for _, name := range []string{"exports/1/a.zip", "exports/1/b.zip", "exports/1/nested/c.zip", "exports/12/d.zip", "avatars/1/e.jpg"} {
+		fake.objects[name] = []byte("data")
+	}
+
+	if err := storage.DeleteFolder(ctx, "exports", "1"); err != nil {
+		t.Fatalf("DeleteFolder: %v", err)
+	}
+	var left []string
+	for name := range fake.objects {
+		left = append(left, name)
+	}
+	slices.Sort(left)
+	if want := []string{"avatars/1/e.jpg", "exports/12/d.zip"}; !slices.Equal(left, want) {
+		t.Errorf("objects left = %v, want %v", left, want)
+	}
+
+	if err := storage.DeleteFolder(ctx, "exports", "1"); err != nil {
+		t.Errorf("DeleteFolder of a missing folder: %v", err)
+	}
+	var s3Err *S3Error
+	if err := storage.DeleteFolder(ctx, "missing", "1"); !errors.As(err, &s3Err) || s3Err.Code != "NoSuchBucket" {
+		t.Errorf("DeleteFolder in a missing bucket err = %v, want NoSuchBucket", err)
+	}
+}
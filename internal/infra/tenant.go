@@ -0,0 +1,60 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"base-service/internal/domain/entity"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// =============================================================================
+// Tenant Isolation
+// clean-arch: Scopes database work to the tenant of the request context
+// =============================================================================
+
+// setTenantSQL sets app.tenant_id, which the row-level security policies read
+// through app_tenant_id(). With $2 true the setting is transaction-local, like
+// SET LOCAL (which takes no parameters). With an empty tenant the policies
+// match no row, so work without a tenant cannot read or write tenant data.
+const setTenantSQL = "SELECT set_config('app.tenant_id', $1, $2)"
+
+// tenantSetting returns the app.tenant_id value for ctx, empty without a tenant.
+func tenantSetting(ctx context.Context) string {
+	tenant, ok := entity.TenantFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return strconv.FormatInt(tenant.ID, 10)
+}
+
+// prepareTenantConn scopes a connection acquired from the pool to the tenant
+// of the acquiring context, for the statements the pool runs outside of
+// explicit transactions. Every acquisition overwrites the setting, so it
+// never leaks to the next user of the connection. On failure the connection
+// is destroyed and the statement fails.
+func prepareTenantConn(ctx context.Context, conn *pgx.Conn) (bool, error) {
+	if _, err := conn.Exec(ctx, setTenantSQL, tenantSetting(ctx), false); err != nil {
+		return false, fmt.Errorf("failed to set connection tenant: %w", err)
+	}
+	return true, nil
+}
+
+// setDefaultTenant scopes a new connection to the default tenant for good,
+// when tenancy is disabled.
+func setDefaultTenant(ctx context.Context, conn *pgx.Conn) error {
+	if _, err := conn.Exec(ctx, setTenantSQL, strconv.FormatInt(entity.DefaultTenantID, 10), false); err != nil {
+		return fmt.Errorf("failed to set connection tenant: %w", err)
+	}
+	return nil
+}
+
+// setLocalTenant scopes a transaction to the tenant of ctx.
+func setLocalTenant(ctx context.Context, tx pgx.Tx) error {
+	if _, err := tx.Exec(ctx, setTenantSQL, tenantSetting(ctx), true); err != nil {
+		return fmt.Errorf("failed to set transaction tenant: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package infra
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"base-service/internal/domain/entity"
+)
+
+func TestTenantSetting(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"no tenant", context.Background(), ""},
+		{"nil tenant", entity.ContextWithTenant(context.Background(), nil), ""},
+		{"tenant", entity.ContextWithTenant(context.Background(), &entity.Tenant{ID: 7}), "7"},
+		{"detached tenant", entity.DetachTenant(entity.ContextWithTenant(context.Background(), &entity.Tenant{ID: 7})), "7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tenantSetting(tt.ctx); got != tt.want {
+				t.Errorf("tenantSetting() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+var (
+	enableRLSPattern = regexp.MustCompile(`ALTER TABLE (\w+) ENABLE ROW LEVEL SECURITY`)
+	forceRLSPattern  = regexp.MustCompile(`ALTER TABLE (\w+) FORCE ROW LEVEL SECURITY`)
+	policyPattern    = regexp.MustCompile(`(?s)CREATE POLICY tenant_isolation ON (\w+)(.*?);`)
+)
+
+// TestTenantPoliciesFailClosed checks that the row-level security policies
+// of the migrations and the schema match no row without app.tenant_id: an
+// unset setting must never widen what a statement sees.
+func TestTenantPoliciesFailClosed(t *testing.T) {
+	files, err := filepath.Glob("../database/migrations/*.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files = append(files, "../database/script/user.schema.sql")
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sql := string(data)
+
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			policies := make(map[string]string)
+			for _, m := range policyPattern.FindAllStringSubmatch(sql, -1) {
+				policies[m[1]] = m[2]
+			}
+			forced := make(map[string]bool)
+			for _, m := range forceRLSPattern.FindAllStringSubmatch(sql, -1) {
+				forced[m[1]] = true
+			}
+
+			for _, m := range enableRLSPattern.FindAllStringSubmatch(sql, -1) {
+				table := m[1]
+				if !forced[table] {
+					t.Errorf("%s: row-level security is not forced", table)
+				}
+				policy, ok := policies[table]
+				if !ok {
+					t.Errorf("%s: no tenant_isolation policy", table)
+					continue
+				}
+				if strings.Contains(policy, "IS NULL") {
+					t.Errorf("%s: policy lets statements without a tenant through:%s", table, policy)
+				}
+				if !strings.Contains(policy, "tenant_id = app_tenant_id()") && !strings.Contains(policy, "EXISTS (SELECT 1 FROM") {
+					t.Errorf("%s: policy neither checks the tenant nor a parent row:%s", table, policy)
+				}
+			}
+		})
+	}
+}
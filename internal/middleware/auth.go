@@ -2,18 +2,22 @@ package middleware
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	"base-service/config"
 	"base-service/internal/common"
+	"base-service/internal/domain/entity"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 // =============================================================================
@@ -27,6 +31,12 @@ var (
 	ErrMissingToken     = errors.New("missing token")
 	ErrInvalidSignature = errors.New("invalid token signature")
 	ErrInvalidPassword  = errors.New("invalid password")
+	ErrInvalidTokenType = errors.New("invalid token type")
+	ErrInvalidIssuer    = errors.New("invalid token issuer")
+	ErrInvalidAudience  = errors.New("invalid token audience")
+	ErrMissingTokenID   = errors.New("token has no id")
+	ErrTokenRevoked     = errors.New("token has been revoked")
+	ErrLogoutFailed     = errors.New("failed to complete logout")
 )
 
 // =============================================================================
@@ -39,6 +49,11 @@ const (
 	AccessTokenKeyName  = "accessToken"
 	AuthorizationHeader = "Authorization"
 	RefreshTokenHeader  = "RefreshToken"
+
+	// AccessTokenType is the "token_type" claim of access tokens.
+	AccessTokenType = "access"
+	// RefreshTokenType is the "token_type" claim of refresh tokens.
+	RefreshTokenType = "refresh"
 )
 
 // =============================================================================
@@ -58,9 +73,35 @@ type Claims struct {
 	UserId    int64  `json:"user_id,omitempty"`
 	UserName  string `json:"username,omitempty"`
 	TokenType string `json:"token_type,omitempty"` // "access" or "refresh"
+	// AuthTime is when the user last actively authenticated (OIDC "auth_time")
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
+	// ACR is the authentication context class ("basic" or "elevated")
+	ACR string `json:"acr,omitempty"`
+	// AMR lists the authentication methods used ("pwd", "otp")
+	AMR []string `json:"amr,omitempty"`
+	// SessionID ties all tokens of one login together
+	SessionID string `json:"sid,omitempty"`
+	// Role is the user's authorization role, checked by RequireRole
+	Role string `json:"role,omitempty"`
+	// TenantID is the tenant the token was issued for (multi-tenancy only)
+	TenantID int64 `json:"tid,omitempty"`
+	// Custom holds namespaced claims contributed by ClaimsEnrichers
+	Custom map[string]json.RawMessage `json:"ext,omitempty"`
+	// IssuedAtMilli is "iat" in Unix milliseconds, so that revocations can
+	// tell apart tokens issued in the same second
+	IssuedAtMilli int64 `json:"iat_ms,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// issuedAt returns when the token was issued, to the millisecond for locally
+// issued tokens and to the second otherwise.
+func (c *Claims) issuedAt() time.Time {
+	if c.IssuedAtMilli != 0 {
+		return time.UnixMilli(c.IssuedAtMilli)
+	}
+	return c.IssuedAt.Time
+}
+
 // =============================================================================
 // AuthMiddleware Implementation
 // clean-arch: Implements TokenService interface
@@ -74,6 +115,9 @@ type AuthMiddleware struct {
 	config         config.MiddlewareConfig
 	tokenCache     TokenCache
 	passwordHasher PasswordHasher
+	keyResolver    KeyResolver
+	sessionStore   SessionStore
+	enrichers      []ClaimsEnricher
 }
 
 // NewAuthenHandler creates a new AuthMiddleware (backward compatible).
@@ -103,33 +147,47 @@ func NewAuthMiddleware(config config.MiddlewareConfig, tokenCache TokenCache, pa
 // =============================================================================
 
 // GenerateAccessToken generates a new access token (implements TokenService).
-func (a *AuthMiddleware) GenerateAccessToken(userID int64, username string) (*TokenPair, error) {
-	accessSecretConfig := a.config.Token.AccessTokenSecret
-	accessExpireConfig := a.config.Token.AccessTokenExp
-	accessToken, accessExp, err := a.generateToken(userID, username, Prefix, accessSecretConfig, accessExpireConfig)
+// auth carries the original authentication, e.g. from the refresh token.
+func (a *AuthMiddleware) GenerateAccessToken(ctx context.Context, user *entity.User, auth Authentication) (*TokenPair, error) {
+	claims, err := a.newAccessClaims(ctx, user, auth)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := a.signToken(claims, a.config.Token.AccessTokenSecret)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 	return &TokenPair{
 		AccessToken: accessToken,
-		ExpiresAt:   accessExp,
+		ExpiresAt:   claims.ExpiresAt.Time,
 		TokenType:   Prefix,
 	}, nil
 }
 
 // GenerateTokenPair generates both access and refresh tokens (implements TokenService).
-func (a *AuthMiddleware) GenerateTokenPair(userID int64, username string) (*TokenPair, error) {
-	accessSecretConfig := a.config.Token.AccessTokenSecret
-	accessExpireConfig := a.config.Token.AccessTokenExp
-	refreshSecretConfig := a.config.Token.RefreshTokenSecret
-	refreshExpireConfig := a.config.Token.RefreshTokenExp
+// Registered ClaimsEnrichers are called with the user to extend the access token.
+// A new session is started unless auth already belongs to one.
+func (a *AuthMiddleware) GenerateTokenPair(ctx context.Context, user *entity.User, auth Authentication) (*TokenPair, error) {
+	if auth.SessionID == "" {
+		auth.SessionID = uuid.NewString()
+		if err := a.startSession(ctx, user.ID, auth.SessionID); err != nil {
+			return nil, err
+		}
+	}
 
-	accessToken, accessExp, err := a.generateToken(userID, username, Prefix, accessSecretConfig, accessExpireConfig)
+	accessClaims, err := a.newAccessClaims(ctx, user, auth)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := a.signToken(accessClaims, a.config.Token.AccessTokenSecret)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
-	refreshToken, _, err := a.generateToken(userID, username, Prefix, refreshSecretConfig, refreshExpireConfig)
+	refreshClaims := a.newClaims(user.ID, user.Username, RefreshTokenType, a.config.Token.RefreshTokenExp)
+	refreshClaims.setAuthentication(auth)
+	refreshClaims.setTenant(ctx)
+	refreshToken, err := a.signToken(refreshClaims, a.config.Token.RefreshTokenSecret)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
@@ -137,61 +195,94 @@ func (a *AuthMiddleware) GenerateTokenPair(userID int64, username string) (*Toke
 	return &TokenPair{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
-		ExpiresAt:    accessExp,
+		ExpiresAt:    accessClaims.ExpiresAt.Time,
 		TokenType:    Prefix,
 	}, nil
 }
 
 // ValidateAccessToken validates an access token (implements TokenService).
 func (a *AuthMiddleware) ValidateAccessToken(token string) (*Claims, error) {
-	return a.ValidateToken(token, a.config.Token.AccessTokenSecret, Prefix)
+	return a.ValidateToken(token, a.config.Token.AccessTokenSecret, AccessTokenType)
 }
 
 // ValidateRefreshToken validates a refresh token (implements TokenService).
 func (a *AuthMiddleware) ValidateRefreshToken(tokenString string) (*Claims, error) {
-	refreshSecretConfig := a.config.Token.RefreshTokenSecret
-	return a.ValidateToken(tokenString, refreshSecretConfig, Prefix)
+	claims, err := a.ValidateToken(tokenString, a.config.Token.RefreshTokenSecret, RefreshTokenType)
+	if err != nil {
+		return nil, err
+	}
+	// Revocations were recorded under the tenant the token was issued for
+	ctx := tenantContext(claims)
+	if a.tokenCache != nil && a.tokenCache.IsEnabled() && a.tokenCache.IsBlacklisted(ctx, claims.ID) {
+		return nil, ErrTokenRevoked
+	}
+	if a.isUserRevoked(ctx, claims) {
+		return nil, ErrTokenRevoked
+	}
+	if err := a.touchSession(ctx, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
 }
 
 // =============================================================================
 // Token Generation & Validation (Internal)
 // =============================================================================
 
-func (a *AuthMiddleware) generateToken(
-	userId int64,
-	username string,
-	tokenType string,
-	secretKey string,
-	expiration time.Duration,
-) (string, time.Time, error) {
+// newClaims builds the registered and custom claims for a new token.
+// Every token gets a unique "jti" so it can be revoked individually.
+func (a *AuthMiddleware) newClaims(userId int64, username, tokenType string, expiration time.Duration) *Claims {
 	now := time.Now()
-	expiresAt := now.Add(expiration)
-
-	claims := &Claims{
-		UserId:    userId,
-		UserName:  username,
-		TokenType: tokenType,
+	return &Claims{
+		UserId:        userId,
+		UserName:      username,
+		TokenType:     tokenType,
+		IssuedAtMilli: now.UnixMilli(),
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(expiration)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 			Subject:   fmt.Sprintf("%d", userId),
-			Issuer:    "client-side",
+			Issuer:    a.config.Token.GetIssuer(),
+			Audience:  jwt.ClaimStrings(a.config.Token.Audience),
 		},
 	}
+}
 
+// newAccessClaims builds access token claims including enriched custom claims.
+// Access tokens from login, registration and refresh are never elevated.
+func (a *AuthMiddleware) newAccessClaims(ctx context.Context, user *entity.User, auth Authentication) (*Claims, error) {
+	claims := a.newClaims(user.ID, user.Username, AccessTokenType, a.config.Token.AccessTokenExp)
+	claims.Role = user.Role
+	auth.Level = ACRBasic
+	claims.setAuthentication(auth)
+	claims.setTenant(ctx)
+	if err := a.enrichClaims(ctx, claims, user); err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+	return claims, nil
+}
+
+func (a *AuthMiddleware) signToken(claims *Claims, secretKey string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	signedToken, err := token.SignedString([]byte(secretKey))
 	if err != nil {
-		return "", time.Time{}, fmt.Errorf("failed to sign token: %w", err)
+		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
-
-	return signedToken, expiresAt, nil
+	return signedToken, nil
 }
 
 // ValidateToken validates a JWT token with the given secret and expected type.
+// Besides the signature and expiry it checks the token type, the issuer and
+// audience against the configured lists, and requires a "jti".
 func (a *AuthMiddleware) ValidateToken(tokenString, secretToken, expectedType string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	token, err := parser.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, ErrInvalidSignature
 		}
@@ -204,6 +295,9 @@ func (a *AuthMiddleware) ValidateToken(tokenString, secretToken, expectedType st
 		if errors.Is(err, jwt.ErrTokenMalformed) {
 			return nil, ErrMalformedToken
 		}
+		if errors.Is(err, jwt.ErrTokenSignatureInvalid) || errors.Is(err, ErrInvalidSignature) {
+			return nil, ErrInvalidSignature
+		}
 		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
 
@@ -212,20 +306,46 @@ func (a *AuthMiddleware) ValidateToken(tokenString, secretToken, expectedType st
 		return nil, ErrInvalidToken
 	}
 
-	if claims.TokenType != expectedType {
-		return nil, fmt.Errorf("invalid token type: expected %s, got %s", expectedType, claims.TokenType)
+	if err := a.validateClaims(claims, expectedType); err != nil {
+		return nil, err
 	}
 
 	return claims, nil
 }
 
+// validateClaims checks the claims that the JWT library does not know about.
+func (a *AuthMiddleware) validateClaims(claims *Claims, expectedType string) error {
+	if claims.TokenType != expectedType {
+		return fmt.Errorf("%w: expected %s, got %s", ErrInvalidTokenType, expectedType, claims.TokenType)
+	}
+
+	if claims.ID == "" {
+		return ErrMissingTokenID
+	}
+
+	if !slices.Contains(a.config.Token.GetValidIssuers(), claims.Issuer) {
+		return ErrInvalidIssuer
+	}
+
+	if audiences := a.config.Token.GetValidAudiences(); len(audiences) > 0 {
+		if !slices.ContainsFunc(claims.Audience, func(aud string) bool {
+			return slices.Contains(audiences, aud)
+		}) {
+			return ErrInvalidAudience
+		}
+	}
+
+	return nil
+}
+
 // =============================================================================
 // HTTP Middleware Handler
 // =============================================================================
 
 // AuthMiddleware returns a Fiber middleware handler for JWT authentication.
+// Tokens of trusted external issuers populate only the Principal; local user
+// helpers such as GetUserIDFromContext are reserved for locally issued tokens.
 func (a *AuthMiddleware) AuthMiddleware() fiber.Handler {
-	accessSecretConfig := a.config.Token.AccessTokenSecret
 	return func(c *fiber.Ctx) error {
 		ctx := c.Context()
 		auth := c.Get(AuthorizationHeader)
@@ -240,100 +360,84 @@ func (a *AuthMiddleware) AuthMiddleware() fiber.Handler {
 
 		tokenString := accessToken[1]
 
+		// Check cache first for valid token, otherwise validate it normally
+		claims, cached := a.cachedClaims(ctx, tokenString)
+		if !cached {
+			var err error
+			claims, err = a.validateAccessToken(ctx, tokenString)
+			if err != nil {
+				return a.handleError(c, err)
+			}
+		}
+
+		// Tokens are only valid for the tenant they were issued for
+		if err := a.checkTenant(ctx, claims); err != nil {
+			return a.handleError(c, err)
+		}
+
 		// Check if token is blacklisted (logged out)
-		if a.tokenCache != nil && a.tokenCache.IsEnabled() && a.tokenCache.IsBlacklisted(ctx, tokenString) {
+		if a.tokenCache != nil && a.tokenCache.IsEnabled() && a.tokenCache.IsBlacklisted(ctx, claims.ID) {
 			slog.Warn("Blocked blacklisted token attempt",
 				"ip", c.IP(),
 				"path", c.Path(),
+				"jti", claims.ID,
 			)
-			return a.handleError(c, errors.New("token has been revoked"))
+			return a.handleError(c, ErrTokenRevoked)
 		}
 
-		// Check cache first for valid token
-		if a.tokenCache != nil && a.tokenCache.IsEnabled() {
-			if userID, found := a.tokenCache.GetCachedToken(ctx, tokenString); found {
-				// Cache hit - use cached user ID
-				claims := &Claims{
-					UserId: userID,
-				}
-				SetUserInContext(c, claims)
-
-				slog.Debug("JWT cache hit, skipping validation",
-					"user_id", userID,
-					"path", c.Path(),
-				)
-
-				return c.Next()
-			}
+		// Suspended and banned users are refused with their status at once
+		if err := a.checkUserStatus(ctx, claims); err != nil {
+			return a.handleError(c, err)
 		}
 
-		// Cache miss or caching disabled - validate token normally
-		claims, err := a.ValidateToken(tokenString, accessSecretConfig, Prefix)
-		if err != nil {
-			return a.handleError(c, err)
+		// Check if the user was logged out everywhere after the token was issued
+		if a.isUserRevoked(ctx, claims) {
+			return a.handleError(c, ErrTokenRevoked)
+		}
+
+		// Enforce idle timeout and evictions (activity writes are coalesced)
+		if err := a.touchSession(ctx, claims); err != nil {
+			return a.handleSessionError(c, err)
 		}
 
 		// Cache the validated token
-		if a.tokenCache != nil && a.tokenCache.IsEnabled() && claims.ExpiresAt != nil {
-			_ = a.tokenCache.CacheToken(ctx, tokenString, claims.UserId, claims.ExpiresAt.Time)
+		if !cached && a.tokenCache != nil && a.tokenCache.IsEnabled() && claims.ExpiresAt != nil {
+			_ = a.tokenCache.CacheToken(ctx, tokenString, claims)
 		}
 
 		// Use type-safe context helpers
-		SetUserInContext(c, claims)
+		if a.isExternalIssuer(claims.Issuer) {
+			SetPrincipalInContext(c, NewPrincipal(claims, true))
+		} else {
+			SetUserInContext(c, claims)
+		}
 
 		return c.Next()
 	}
 }
 
-func (a *AuthMiddleware) handleError(c *fiber.Ctx, err error) error {
-	status := fiber.StatusUnauthorized
-	message := "Authentication failed"
-
-	switch {
-	case errors.Is(err, ErrMissingToken):
-		status = fiber.StatusBadRequest
-		message = "Missing authentication token"
-	case errors.Is(err, ErrMalformedToken):
-		status = fiber.StatusBadRequest
-		message = "Malformed authentication token"
-	case errors.Is(err, ErrTokenExpired):
-		message = "Token has expired"
-	case errors.Is(err, ErrInvalidSignature):
-		message = "Invalid token signature"
-	case errors.Is(err, ErrInvalidToken):
-		message = "Invalid token"
-	}
-	slog.Error(fmt.Sprintf("Status error: %d, message: %s", status, message))
-	return common.ResponseApi(c, nil, err)
-}
-
-// =============================================================================
-// Refresh Token Handler
-// =============================================================================
-
-// RefreshToken handles token refresh requests.
-func (a *AuthMiddleware) RefreshToken(c *fiber.Ctx) error {
-	refreshToken := c.Get(AuthorizationHeader)
-	refreshSecretConfig := a.config.Token.RefreshTokenSecret
-	if refreshToken == "" {
-		return a.handleError(c, ErrMissingToken)
-	}
-
-	refreshToken = strings.TrimPrefix(refreshToken, Prefix)
-
-	claims, err := a.ValidateToken(refreshToken, refreshSecretConfig, RefreshTokenKeyName)
-	if err != nil {
-		return a.handleError(c, err)
+// cachedClaims returns the claims of a previously validated token, if cached.
+func (a *AuthMiddleware) cachedClaims(ctx context.Context, tokenString string) (*Claims, bool) {
+	if a.tokenCache == nil || !a.tokenCache.IsEnabled() {
+		return nil, false
 	}
-
-	tokenPair, err := a.GenerateTokenPair(claims.UserId, claims.UserName)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to generate new tokens",
-		})
+	claims, found := a.tokenCache.GetCachedToken(ctx, tokenString)
+	if !found {
+		return nil, false
 	}
+	slog.Debug("JWT cache hit, skipping validation",
+		"user_id", claims.UserId,
+		"jti", claims.ID,
+	)
+	return claims, true
+}
 
-	return c.JSON(tokenPair)
+// handleError answers authentication failures with problem+json; the status,
+// code and message come from the registrations in error_catalog.go.
+func (a *AuthMiddleware) handleError(c *fiber.Ctx, err error) error {
+	problem := common.NewProblem(c, err)
+	slog.Error(fmt.Sprintf("Status error: %d, code: %s", problem.Status, problem.Code))
+	return common.WriteProblem(c, problem)
 }
 
 // =============================================================================
@@ -376,32 +480,26 @@ func (a *AuthMiddleware) Logout(c *fiber.Ctx) error {
 	// Extract token from Authorization header
 	auth := c.Get(AuthorizationHeader)
 	if auth == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "missing_token",
-			"message": "No token provided",
-		})
+		return common.ResponseProblem(c, ErrMissingToken)
 	}
 
 	accessToken := strings.Split(auth, " ")
 	if len(accessToken) != 2 || !strings.EqualFold(accessToken[0], Prefix) {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "invalid_token_format",
-			"message": "Invalid token format",
-		})
+		return common.ResponseProblem(c, ErrMalformedToken)
 	}
 
 	tokenString := accessToken[1]
 
 	// Validate token to get expiration time
 	accessSecretConfig := a.config.Token.AccessTokenSecret
-	claims, err := a.ValidateToken(tokenString, accessSecretConfig, Prefix)
+	claims, err := a.ValidateToken(tokenString, accessSecretConfig, AccessTokenType)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error":   "invalid_token",
-			"message": "Invalid or expired token",
-		})
+		return a.handleError(c, err)
 	}
 
+	// End the session so its refresh token stops working too
+	a.endSession(c.Context(), claims)
+
 	// Blacklist the token if caching is enabled
 	if a.tokenCache != nil && a.tokenCache.IsEnabled() && claims.ExpiresAt != nil {
 		return a.expireTokenCache(c, tokenString, claims)
@@ -419,16 +517,13 @@ func (a *AuthMiddleware) Logout(c *fiber.Ctx) error {
 
 func (a *AuthMiddleware) expireTokenCache(c *fiber.Ctx, tokenString string, claims *Claims) error {
 	ctx := c.Context()
-	err := a.tokenCache.BlacklistToken(ctx, tokenString, claims.ExpiresAt.Time)
+	err := a.tokenCache.BlacklistToken(ctx, claims.ID, claims.ExpiresAt.Time)
 	if err != nil {
 		slog.Error("Failed to blacklist token during logout",
 			"error", err,
 			"user_id", claims.UserId,
 		)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   "logout_failed",
-			"message": "Failed to complete logout",
-		})
+		return common.ResponseProblem(c, fmt.Errorf("%w: %w", ErrLogoutFailed, err))
 	}
 
 	// Also invalidate from valid token cache
@@ -437,6 +532,7 @@ func (a *AuthMiddleware) expireTokenCache(c *fiber.Ctx, tokenString string, clai
 	slog.Info("User logged out successfully",
 		"user_id", claims.UserId,
 		"username", claims.UserName,
+		"jti", claims.ID,
 	)
 
 	return c.JSON(fiber.Map{
@@ -458,6 +554,17 @@ func (a *AuthMiddleware) VerifyPassword(password, encodedHash string) (bool, err
 	return a.passwordHasher.Verify(password, encodedHash)
 }
 
+// NeedsRehash reports whether a verified password hash should be replaced by
+// a hash of the current algorithm, as imported legacy hashes are.
+func (a *AuthMiddleware) NeedsRehash(encodedHash string) bool {
+	return IsLegacyPasswordHash(encodedHash)
+}
+
+// ValidatePasswordHash checks that a pre-hashed password can be verified.
+func (a *AuthMiddleware) ValidatePasswordHash(encodedHash string) error {
+	return ValidatePasswordHash(encodedHash)
+}
+
 // PasswordHasher returns the password hasher for direct access.
 func (a *AuthMiddleware) PasswordHasher() PasswordHasher {
 	return a.passwordHasher
@@ -493,5 +600,6 @@ func (a *AuthMiddleware) GenerateGuestUsername(ctx context.Context, email string
 
 // GenerateAcessToken is an alias for GenerateAccessToken (fixes typo, backward compatible).
 func (a *AuthMiddleware) GenerateAcessToken(userId int64, userName string) (*TokenPair, error) {
-	return a.GenerateAccessToken(userId, userName)
+	user := &entity.User{ID: userId, Username: userName}
+	return a.GenerateAccessToken(context.Background(), user, NewAuthentication(ACRBasic))
 }
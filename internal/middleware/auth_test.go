@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"base-service/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestValidateToken(t *testing.T) {
+	a := NewAuthMiddleware(config.MiddlewareConfig{
+		Token: config.TokenConfig{
+			AccessTokenSecret:  "access-secret",
+			RefreshTokenSecret: "refresh-secret",
+			Issuer:             "base-service",
+			Audience:           []string{"api"},
+			ValidIssuers:       []string{"base-service", "legacy-service"},
+		},
+	}, nil, nil)
+
+	tests := []struct {
+		name    string
+		typ     string
+		secret  string
+		method  jwt.SigningMethod
+		modify  func(*Claims)
+		wantErr error
+	}{
+		{name: "valid access token", typ: AccessTokenType},
+		{name: "accepted legacy issuer", typ: AccessTokenType, modify: func(c *Claims) { c.Issuer = "legacy-service" }},
+		{name: "refresh token as access token", typ: RefreshTokenType, wantErr: ErrInvalidTokenType},
+		{name: "missing type", typ: "", wantErr: ErrInvalidTokenType},
+		{name: "missing jti", typ: AccessTokenType, modify: func(c *Claims) { c.ID = "" }, wantErr: ErrMissingTokenID},
+		{name: "unknown issuer", typ: AccessTokenType, modify: func(c *Claims) { c.Issuer = "other-service" }, wantErr: ErrInvalidIssuer},
+		{name: "wrong audience", typ: AccessTokenType, modify: func(c *Claims) { c.Audience = jwt.ClaimStrings{"admin"} }, wantErr: ErrInvalidAudience},
+		{name: "missing audience", typ: AccessTokenType, modify: func(c *Claims) { c.Audience = nil }, wantErr: ErrInvalidAudience},
+		{name: "one of several audiences", typ: AccessTokenType, modify: func(c *Claims) { c.Audience = jwt.ClaimStrings{"admin", "api"} }},
+		{name: "expired", typ: AccessTokenType, modify: func(c *Claims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute)) }, wantErr: ErrTokenExpired},
+		{name: "signed with another secret", typ: AccessTokenType, secret: "refresh-secret", wantErr: ErrInvalidSignature},
+		{name: "other algorithm", typ: AccessTokenType, method: jwt.SigningMethodHS384, wantErr: ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := a.newClaims(1, "alice", tt.typ, time.Hour)
+			if tt.modify != nil {
+				tt.modify(claims)
+			}
+			secret, method := tt.secret, tt.method
+			if secret == "" {
+				secret = "access-secret"
+			}
+			if method == nil {
+				method = jwt.SigningMethodHS256
+			}
+			token, err := jwt.NewWithClaims(method, claims).SignedString([]byte(secret))
+			if err != nil {
+				t.Fatalf("sign: %v", err)
+			}
+
+			got, err := a.ValidateAccessToken(token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got.UserId != 1 {
+				t.Errorf("user = %d, want 1", got.UserId)
+			}
+		})
+	}
+
+	t.Run("malformed", func(t *testing.T) {
+		if _, err := a.ValidateAccessToken("not-a-token"); !errors.Is(err, ErrMalformedToken) {
+			t.Errorf("err = %v, want %v", err, ErrMalformedToken)
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"base-service/internal/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// =============================================================================
+// Custom Claims
+// clean-arch: Extension point for product-specific token claims
+// =============================================================================
+
+const (
+	// defaultMaxCustomClaimsSize bounds the encoded size of all custom claims.
+	defaultMaxCustomClaimsSize = 1024
+)
+
+var (
+	ErrCustomClaimsTooLarge  = errors.New("custom claims exceed size limit")
+	ErrInvalidClaimNamespace = errors.New("invalid custom claim namespace")
+	ErrCustomClaimNotFound   = errors.New("custom claim not found")
+
+	// claimNamespaceRegex restricts namespaces to short lowercase identifiers
+	claimNamespaceRegex = regexp.MustCompile(`^[a-z][a-z0-9_.-]{0,31}$`)
+)
+
+// ClaimsEnricher contributes custom claims to access tokens issued for a user.
+// Each enricher owns one namespace; its claims are stored under "ext.<namespace>".
+type ClaimsEnricher interface {
+	// Namespace returns the key the enricher's claims are stored under
+	Namespace() string
+	// Enrich returns the claims for the user; nil means nothing to add
+	Enrich(ctx context.Context, user *entity.User) (any, error)
+}
+
+// AddClaimsEnricher registers an enricher invoked on every access token issuance.
+func (a *AuthMiddleware) AddClaimsEnricher(enricher ClaimsEnricher) error {
+	namespace := enricher.Namespace()
+	if !claimNamespaceRegex.MatchString(namespace) {
+		return fmt.Errorf("%w: %q", ErrInvalidClaimNamespace, namespace)
+	}
+	for _, existing := range a.enrichers {
+		if existing.Namespace() == namespace {
+			return fmt.Errorf("%w: %q already registered", ErrInvalidClaimNamespace, namespace)
+		}
+	}
+	a.enrichers = append(a.enrichers, enricher)
+	return nil
+}
+
+// enrichClaims runs all enrichers for the user and stores the result in claims.
+func (a *AuthMiddleware) enrichClaims(ctx context.Context, claims *Claims, user *entity.User) error {
+	if len(a.enrichers) == 0 || user == nil {
+		return nil
+	}
+
+	custom := make(map[string]json.RawMessage, len(a.enrichers))
+	size := 0
+	for _, enricher := range a.enrichers {
+		value, err := enricher.Enrich(ctx, user)
+		if err != nil {
+			return fmt.Errorf("claims enricher %s failed: %w", enricher.Namespace(), err)
+		}
+		if value == nil {
+			continue
+		}
+
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("claims enricher %s returned unencodable claims: %w", enricher.Namespace(), err)
+		}
+		custom[enricher.Namespace()] = raw
+		size += len(enricher.Namespace()) + len(raw)
+	}
+
+	if limit := a.maxCustomClaimsSize(); size > limit {
+		slog.Error("Custom claims exceed size limit",
+			"user_id", user.ID,
+			"size", size,
+			"limit", limit,
+		)
+		return fmt.Errorf("%w: %d > %d bytes", ErrCustomClaimsTooLarge, size, limit)
+	}
+
+	if len(custom) > 0 {
+		claims.Custom = custom
+	}
+	return nil
+}
+
+func (a *AuthMiddleware) maxCustomClaimsSize() int {
+	if a.config.Token.MaxCustomClaimsSize > 0 {
+		return a.config.Token.MaxCustomClaimsSize
+	}
+	return defaultMaxCustomClaimsSize
+}
+
+// =============================================================================
+// Typed Accessors
+// =============================================================================
+
+// DecodeCustom decodes the custom claims stored under namespace into dst.
+func (c *Claims) DecodeCustom(namespace string, dst any) error {
+	raw, ok := c.Custom[namespace]
+	if !ok {
+		return ErrCustomClaimNotFound
+	}
+	return json.Unmarshal(raw, dst)
+}
+
+// CustomClaim returns the authenticated caller's custom claims for namespace,
+// decoded into T. It works for both local and external principals; claims
+// that grant access must be read with LocalCustomClaim.
+func CustomClaim[T any](c *fiber.Ctx, namespace string) (T, bool) {
+	var value T
+	principal, ok := GetPrincipalFromContext(c)
+	if !ok || principal.Claims == nil {
+		return value, false
+	}
+	if err := principal.Claims.DecodeCustom(namespace, &value); err != nil {
+		return value, false
+	}
+	return value, true
+}
+
+// LocalCustomClaim is CustomClaim for tokens issued by this service only.
+// A trusted external issuer can put any custom claims in its tokens, so
+// claims that grant access, such as organization roles, are ignored for
+// external principals.
+func LocalCustomClaim[T any](c *fiber.Ctx, namespace string) (T, bool) {
+	var value T
+	principal, ok := GetPrincipalFromContext(c)
+	if !ok || !principal.IsLocalUser() {
+		return value, false
+	}
+	return CustomClaim[T](c, namespace)
+}
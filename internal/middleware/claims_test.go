@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"base-service/config"
+	"base-service/internal/domain/entity"
+)
+
+// staticEnricher returns the same claims for every user.
+type staticEnricher struct {
+	namespace string
+	value     any
+	err       error
+}
+
+func (e staticEnricher) Namespace() string { return e.namespace }
+
+func (e staticEnricher) Enrich(ctx context.Context, user *entity.User) (any, error) {
+	return e.value, e.err
+}
+
+func TestAddClaimsEnricher(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		wantErr   error
+	}{
+		{name: "lowercase identifier", namespace: "billing.plan"},
+		{name: "uppercase", namespace: "Tier", wantErr: ErrInvalidClaimNamespace},
+		{name: "empty", namespace: "", wantErr: ErrInvalidClaimNamespace},
+		{name: "leading digit", namespace: "1tier", wantErr: ErrInvalidClaimNamespace},
+		{name: "too long", namespace: "a" + strings.Repeat("b", 32), wantErr: ErrInvalidClaimNamespace},
+		{name: "already registered", namespace: "tier", wantErr: ErrInvalidClaimNamespace},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAuthMiddleware(config.MiddlewareConfig{}, nil, nil)
+			if err := a.AddClaimsEnricher(staticEnricher{namespace: "tier"}); err != nil {
+				t.Fatalf("AddClaimsEnricher(tier): %v", err)
+			}
+
+			err := a.AddClaimsEnricher(staticEnricher{namespace: tt.namespace})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEnrichClaims(t *testing.T) {
+	enrichErr := errors.New("tier lookup failed")
+
+	tests := []struct {
+		name      string
+		maxSize   int
+		enrichers []ClaimsEnricher
+		wantErr   error
+		wantKeys  []string
+	}{
+		{
+			name: "claims are stored under their namespace",
+			enrichers: []ClaimsEnricher{
+				staticEnricher{namespace: "tier", value: map[string]string{"code": "pro"}},
+				staticEnricher{namespace: "org", value: map[string]int{"id": 7}},
+			},
+			wantKeys: []string{"tier", "org"},
+		},
+		{
+			name: "nil claims are skipped",
+			enrichers: []ClaimsEnricher{
+				staticEnricher{namespace: "tier", value: nil},
+				staticEnricher{namespace: "org", value: map[string]int{"id": 7}},
+			},
+			wantKeys: []string{"org"},
+		},
+		{
+			name:      "claims over the default limit are refused",
+			enrichers: []ClaimsEnricher{staticEnricher{namespace: "tier", value: strings.Repeat("x", defaultMaxCustomClaimsSize)}},
+			wantErr:   ErrCustomClaimsTooLarge,
+		},
+		{
+			name:      "the namespace counts towards the configured limit",
+			maxSize:   len(`"abcd"`) + len("tier") - 1,
+			enrichers: []ClaimsEnricher{staticEnricher{namespace: "tier", value: "abcd"}},
+			wantErr:   ErrCustomClaimsTooLarge,
+		},
+		{
+			name:      "claims at the configured limit are kept",
+			maxSize:   len(`"abcd"`) + len("tier"),
+			enrichers: []ClaimsEnricher{staticEnricher{namespace: "tier", value: "abcd"}},
+			wantKeys:  []string{"tier"},
+		},
+		{
+			name:      "a failing enricher fails the issuance",
+			enrichers: []ClaimsEnricher{staticEnricher{namespace: "tier", err: enrichErr}},
+			wantErr:   enrichErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAuthMiddleware(config.MiddlewareConfig{
+				Token: config.TokenConfig{MaxCustomClaimsSize: tt.maxSize},
+			}, nil, nil)
+			for _, enricher := range tt.enrichers {
+				if err := a.AddClaimsEnricher(enricher); err != nil {
+					t.Fatalf("AddClaimsEnricher: %v", err)
+				}
+			}
+
+			claims := &Claims{}
+			err := a.enrichClaims(context.Background(), claims, &entity.User{ID: 1})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if claims.Custom != nil {
+					t.Errorf("custom claims = %s, want none", claims.Custom)
+				}
+				return
+			}
+
+			if len(claims.Custom) != len(tt.wantKeys) {
+				t.Fatalf("custom claims = %s, want namespaces %v", claims.Custom, tt.wantKeys)
+			}
+			for _, key := range tt.wantKeys {
+				if _, ok := claims.Custom[key]; !ok {
+					t.Errorf("namespace %q missing from %s", key, claims.Custom)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeCustom(t *testing.T) {
+	a := NewAuthMiddleware(config.MiddlewareConfig{}, nil, nil)
+	if err := a.AddClaimsEnricher(staticEnricher{namespace: "tier", value: map[string]string{"code": "pro"}}); err != nil {
+		t.Fatalf("AddClaimsEnricher: %v", err)
+	}
+	claims := &Claims{}
+	if err := a.enrichClaims(context.Background(), claims, &entity.User{ID: 1}); err != nil {
+		t.Fatalf("enrichClaims: %v", err)
+	}
+
+	var tier struct {
+		Code string `json:"code"`
+	}
+	if err := claims.DecodeCustom("tier", &tier); err != nil || tier.Code != "pro" {
+		t.Errorf("DecodeCustom(tier) = %+v, %v; want code pro", tier, err)
+	}
+	if err := claims.DecodeCustom("org", &tier); !errors.Is(err, ErrCustomClaimNotFound) {
+		t.Errorf("DecodeCustom(org) err = %v, want %v", err, ErrCustomClaimNotFound)
+	}
+}
@@ -2,8 +2,11 @@ package middleware
 
 import (
 	"context"
+	"crypto"
 	"time"
 
+	"base-service/internal/domain/entity"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -15,10 +18,10 @@ import (
 // TokenService defines the contract for JWT token operations.
 // clean-arch: Port interface for token generation and validation
 type TokenService interface {
-	// GenerateAccessToken generates a new access token
-	GenerateAccessToken(userID int64, username string) (*TokenPair, error)
-	// GenerateTokenPair generates both access and refresh tokens
-	GenerateTokenPair(userID int64, username string) (*TokenPair, error)
+	// GenerateAccessToken generates a new access token for the user
+	GenerateAccessToken(ctx context.Context, user *entity.User, auth Authentication) (*TokenPair, error)
+	// GenerateTokenPair generates both access and refresh tokens for the user
+	GenerateTokenPair(ctx context.Context, user *entity.User, auth Authentication) (*TokenPair, error)
 	// ValidateAccessToken validates an access token and returns claims
 	ValidateAccessToken(token string) (*Claims, error)
 	// ValidateRefreshToken validates a refresh token and returns claims
@@ -35,22 +38,75 @@ type PasswordHasher interface {
 }
 
 // TokenCache defines the contract for token caching and blacklisting.
+// Validated tokens are cached by token; revocation is keyed by the token's jti.
 // clean-arch: Port interface for token persistence (Redis, memory, etc.)
 type TokenCache interface {
-	// CacheToken caches a validated token
-	CacheToken(ctx context.Context, token string, userID int64, expiresAt time.Time) error
-	// GetCachedToken retrieves cached token data
-	GetCachedToken(ctx context.Context, token string) (userID int64, found bool)
+	// CacheToken caches the claims of a validated token until it expires
+	CacheToken(ctx context.Context, token string, claims *Claims) error
+	// GetCachedToken retrieves the cached claims of a token
+	GetCachedToken(ctx context.Context, token string) (claims *Claims, found bool)
 	// InvalidateToken removes a token from cache
 	InvalidateToken(ctx context.Context, token string) error
-	// BlacklistToken adds a token to blacklist (for logout)
-	BlacklistToken(ctx context.Context, token string, expiresAt time.Time) error
-	// IsBlacklisted checks if a token is blacklisted
-	IsBlacklisted(ctx context.Context, token string) bool
+	// BlacklistToken revokes a token by its jti until expiresAt (for logout)
+	BlacklistToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	// IsBlacklisted checks if the token with the given jti is revoked
+	IsBlacklisted(ctx context.Context, tokenID string) bool
+	// RevokeUserTokens revokes every token of the user issued up to now, for ttl (force logout)
+	RevokeUserTokens(ctx context.Context, userID int64, ttl time.Duration) error
+	// IsUserRevoked checks if a token of the user issued at issuedAt was revoked by RevokeUserTokens
+	IsUserRevoked(ctx context.Context, userID int64, issuedAt time.Time) bool
+	// SetUserStatus records the user's lifecycle status for ttl; an empty status clears it
+	SetUserStatus(ctx context.Context, userID int64, status string, ttl time.Duration) error
+	// GetUserStatus returns the status recorded by SetUserStatus, empty if none
+	GetUserStatus(ctx context.Context, userID int64) string
+	// RevokeOrgAccess revokes the organization claims of the user's tokens issued up to now, for ttl
+	RevokeOrgAccess(ctx context.Context, orgID, userID int64, ttl time.Duration) error
+	// IsOrgAccessRevoked checks if the organization claims of a token issued at issuedAt were revoked by RevokeOrgAccess
+	IsOrgAccessRevoked(ctx context.Context, orgID, userID int64, issuedAt time.Time) bool
 	// IsEnabled returns whether caching is enabled
 	IsEnabled() bool
 }
 
+// TenantResolver defines the contract for looking up the tenant a request names.
+// clean-arch: Port interface implemented by the tenant use case
+type TenantResolver interface {
+	// ResolveTenant finds a tenant by ID or slug; unknown tenants fail with ErrTenantNotFound
+	ResolveTenant(ctx context.Context, key string) (*entity.Tenant, error)
+}
+
+// KeyResolver defines the contract for resolving keys of external token issuers.
+// clean-arch: Port interface for remote key sets (JWKS over HTTP, static keys, etc.)
+type KeyResolver interface {
+	// ResolveKey returns the verification key identified by kid for the issuer
+	ResolveKey(ctx context.Context, issuer, kid string) (crypto.PublicKey, error)
+}
+
+// SessionStore defines the contract for server-side login session tracking.
+// A session starts at login and is shared by all tokens carrying its "sid".
+// clean-arch: Port interface for session persistence (Redis, memory, etc.)
+type SessionStore interface {
+	// Start registers a new session, enforcing the concurrent session limit
+	Start(ctx context.Context, userID int64, sessionID string) error
+	// Touch records activity; returns ErrSessionExpired or ErrSessionEvicted if the session is gone
+	Touch(ctx context.Context, userID int64, sessionID string) error
+	// End terminates a session (logout)
+	End(ctx context.Context, userID int64, sessionID string) error
+	// EndAll terminates every session of the user (force logout)
+	EndAll(ctx context.Context, userID int64) error
+	// List returns the live sessions of the user, oldest first
+	List(ctx context.Context, userID int64) ([]SessionInfo, error)
+	// IsEnabled returns whether session tracking is enabled
+	IsEnabled() bool
+}
+
+// SessionInfo describes a live session.
+type SessionInfo struct {
+	ID        string
+	StartedAt time.Time
+	// ExpiresAt is when the session ends without further activity
+	ExpiresAt time.Time
+}
+
 // RateLimiter defines the contract for rate limiting.
 // clean-arch: Port interface for rate limiting strategies
 type RateLimiter interface {
@@ -88,14 +144,67 @@ const (
 	userIDKey
 	// usernameKey stores the username
 	usernameKey
+	// principalKey stores the authenticated principal
+	principalKey
 )
 
+// =============================================================================
+// Principal
+// =============================================================================
+
+// Principal is the unified view of the authenticated caller, whether the
+// token was issued by this service or by a trusted external issuer.
+type Principal struct {
+	Subject  string   `json:"sub"`
+	UserID   int64    `json:"user_id,omitempty"` // Local user ID; 0 for external principals
+	Username string   `json:"username,omitempty"`
+	Issuer   string   `json:"iss"`
+	Audience []string `json:"aud,omitempty"`
+	TokenID  string   `json:"jti,omitempty"`
+	External bool     `json:"external"`
+	Claims   *Claims  `json:"-"`
+}
+
+// IsLocalUser reports whether the principal is a user of this service.
+func (p *Principal) IsLocalUser() bool {
+	return p != nil && !p.External && p.UserID > 0
+}
+
+// NewPrincipal builds a principal from validated claims.
+func NewPrincipal(claims *Claims, external bool) *Principal {
+	p := &Principal{
+		Subject:  claims.Subject,
+		Username: claims.UserName,
+		Issuer:   claims.Issuer,
+		Audience: claims.Audience,
+		TokenID:  claims.ID,
+		External: external,
+		Claims:   claims,
+	}
+	if !external {
+		p.UserID = claims.UserId
+	}
+	return p
+}
+
 // =============================================================================
 // Context Helpers for Auth
 // =============================================================================
 
-// SetUserInContext stores user information in fiber context.
+// SetPrincipalInContext stores the authenticated principal in fiber context.
+func SetPrincipalInContext(c *fiber.Ctx, principal *Principal) {
+	c.Locals(principalKey, principal)
+}
+
+// GetPrincipalFromContext retrieves the authenticated principal from fiber context.
+func GetPrincipalFromContext(c *fiber.Ctx) (*Principal, bool) {
+	principal, ok := c.Locals(principalKey).(*Principal)
+	return principal, ok
+}
+
+// SetUserInContext stores local user information in fiber context.
 func SetUserInContext(c *fiber.Ctx, claims *Claims) {
+	SetPrincipalInContext(c, NewPrincipal(claims, false))
 	c.Locals(userClaimsKey, claims)
 	c.Locals(userIDKey, claims.UserId)
 	c.Locals(usernameKey, claims.UserName)
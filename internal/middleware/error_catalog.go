@@ -0,0 +1,229 @@
+package middleware
+
+import (
+	"base-service/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// init registers every middleware error in the error catalog.
+// Codes are part of the API contract: never change or reuse them.
+func init() {
+	// Token
+	common.RegisterError(ErrMissingToken, common.ErrorDefinition{
+		Code:   "AUTH_TOKEN_MISSING",
+		Status: fiber.StatusBadRequest,
+		Messages: common.Messages{
+			"en": "Missing authentication token",
+			"vi": "Thiếu mã xác thực",
+		},
+	})
+	common.RegisterError(ErrMalformedToken, common.ErrorDefinition{
+		Code:   "AUTH_TOKEN_MALFORMED",
+		Status: fiber.StatusBadRequest,
+		Messages: common.Messages{
+			"en": "Malformed authentication token",
+			"vi": "Mã xác thực không đúng định dạng",
+		},
+	})
+	common.RegisterError(ErrInvalidToken, common.ErrorDefinition{
+		Code:   "AUTH_TOKEN_INVALID",
+		Status: fiber.StatusUnauthorized,
+		Messages: common.Messages{
+			"en": "Invalid authentication token",
+			"vi": "Mã xác thực không hợp lệ",
+		},
+	})
+	common.RegisterError(ErrTokenExpired, common.ErrorDefinition{
+		Code:   "AUTH_TOKEN_EXPIRED",
+		Status: fiber.StatusUnauthorized,
+		Messages: common.Messages{
+			"en": "Authentication token has expired",
+			"vi": "Mã xác thực đã hết hạn",
+		},
+	})
+	common.RegisterError(ErrInvalidSignature, common.ErrorDefinition{
+		Code:   "AUTH_TOKEN_INVALID_SIGNATURE",
+		Status: fiber.StatusUnauthorized,
+		Messages: common.Messages{
+			"en": "Invalid token signature",
+			"vi": "Chữ ký mã xác thực không hợp lệ",
+		},
+	})
+	common.RegisterError(ErrInvalidTokenType, common.ErrorDefinition{
+		Code:   "AUTH_TOKEN_INVALID_TYPE",
+		Status: fiber.StatusUnauthorized,
+		Messages: common.Messages{
+			"en": "Invalid token type",
+			"vi": "Loại mã xác thực không hợp lệ",
+		},
+	})
+	common.RegisterError(ErrInvalidIssuer, common.ErrorDefinition{
+		Code:   "AUTH_TOKEN_INVALID_ISSUER",
+		Status: fiber.StatusUnauthorized,
+		Messages: common.Messages{
+			"en": "Token was not issued by a trusted issuer",
+			"vi": "Mã xác thực không do bên phát hành tin cậy cấp",
+		},
+	})
+	common.RegisterError(ErrInvalidAudience, common.ErrorDefinition{
+		Code:   "AUTH_TOKEN_INVALID_AUDIENCE",
+		Status: fiber.StatusUnauthorized,
+		Messages: common.Messages{
+			"en": "Token was not issued for this service",
+			"vi": "Mã xác thực không được cấp cho dịch vụ này",
+		},
+	})
+	common.RegisterError(ErrMissingTokenID, common.ErrorDefinition{
+		Code:   "AUTH_TOKEN_MISSING_ID",
+		Status: fiber.StatusUnauthorized,
+		Messages: common.Messages{
+			"en": "Token has no id",
+			"vi": "Mã xác thực không có định danh",
+		},
+	})
+	common.RegisterError(ErrTokenRevoked, common.ErrorDefinition{
+		Code:   "AUTH_TOKEN_REVOKED",
+		Status: fiber.StatusUnauthorized,
+		Messages: common.Messages{
+			"en": "Token has been revoked",
+			"vi": "Mã xác thực đã bị thu hồi",
+		},
+	})
+	common.RegisterError(ErrUnknownSigningKey, common.ErrorDefinition{
+		Code:   "AUTH_TOKEN_UNKNOWN_KEY",
+		Status: fiber.StatusUnauthorized,
+		Messages: common.Messages{
+			"en": "Token was signed with an unknown key",
+			"vi": "Mã xác thực được ký bằng khóa không xác định",
+		},
+	})
+
+	// Step-up and sessions
+	common.RegisterError(ErrStepUpRequired, common.ErrorDefinition{
+		Code:   StepUpRequiredCode,
+		Status: fiber.StatusUnauthorized,
+		Messages: common.Messages{
+			"en": "Recent authentication required, please sign in again",
+			"vi": "Cần xác thực lại, vui lòng đăng nhập lại",
+		},
+	})
+	common.RegisterError(ErrSessionExpired, common.ErrorDefinition{
+		Code:   SessionExpiredCode,
+		Status: fiber.StatusUnauthorized,
+		Messages: common.Messages{
+			"en": "Your session has expired, please sign in again",
+			"vi": "Phiên đăng nhập đã hết hạn, vui lòng đăng nhập lại",
+		},
+	})
+	common.RegisterError(ErrSessionEvicted, common.ErrorDefinition{
+		Code:   SessionEvictedCode,
+		Status: fiber.StatusUnauthorized,
+		Messages: common.Messages{
+			"en": "Your session was ended by a sign-in on another device",
+			"vi": "Phiên đăng nhập đã kết thúc do đăng nhập trên thiết bị khác",
+		},
+	})
+	common.RegisterError(ErrTooManySessions, common.ErrorDefinition{
+		Code:   TooManySessionsCode,
+		Status: fiber.StatusConflict,
+		Messages: common.Messages{
+			"en": "Too many active sessions",
+			"vi": "Có quá nhiều phiên đăng nhập đang hoạt động",
+		},
+	})
+	common.RegisterError(ErrForbidden, common.ErrorDefinition{
+		Code:   "AUTH_FORBIDDEN",
+		Status: fiber.StatusForbidden,
+		Messages: common.Messages{
+			"en": "You do not have permission to perform this action",
+			"vi": "Bạn không có quyền thực hiện thao tác này",
+		},
+	})
+	common.RegisterError(ErrFeatureUnavailable, common.ErrorDefinition{
+		Code:   "TIER_FEATURE_UNAVAILABLE",
+		Status: fiber.StatusForbidden,
+		Messages: common.Messages{
+			"en": "This feature is not available in your plan",
+			"vi": "Tính năng này không có trong gói dịch vụ của bạn",
+		},
+	})
+	common.RegisterError(ErrOrgAccessRevoked, common.ErrorDefinition{
+		Code:   "ORG_ACCESS_REVOKED",
+		Status: fiber.StatusUnauthorized,
+		Messages: common.Messages{
+			"en": "Your organization access has changed, please refresh your token",
+			"vi": "Quyền truy cập tổ chức của bạn đã thay đổi, vui lòng làm mới token",
+		},
+	})
+	common.RegisterError(ErrLogoutFailed, common.ErrorDefinition{
+		Code:   "AUTH_LOGOUT_FAILED",
+		Status: fiber.StatusInternalServerError,
+		Messages: common.Messages{
+			"en": "Failed to complete logout",
+			"vi": "Không thể đăng xuất",
+		},
+	})
+
+	// Custom claims
+	common.RegisterError(ErrCustomClaimsTooLarge, common.ErrorDefinition{
+		Code:   "AUTH_CUSTOM_CLAIMS_TOO_LARGE",
+		Status: fiber.StatusInternalServerError,
+		Messages: common.Messages{
+			"en": "Internal server error",
+			"vi": "Lỗi máy chủ nội bộ",
+		},
+	})
+	common.RegisterError(ErrInvalidClaimNamespace, common.ErrorDefinition{
+		Code:   "AUTH_CUSTOM_CLAIMS_INVALID_NAMESPACE",
+		Status: fiber.StatusInternalServerError,
+		Messages: common.Messages{
+			"en": "Internal server error",
+			"vi": "Lỗi máy chủ nội bộ",
+		},
+	})
+	common.RegisterError(ErrCustomClaimNotFound, common.ErrorDefinition{
+		Code:   "AUTH_CUSTOM_CLAIM_NOT_FOUND",
+		Status: fiber.StatusForbidden,
+		Messages: common.Messages{
+			"en": "Required claim is missing from the token",
+			"vi": "Mã xác thực thiếu thông tin bắt buộc",
+		},
+	})
+
+	// Tenants
+	common.RegisterError(ErrTenantRequired, common.ErrorDefinition{
+		Code:   "TENANT_REQUIRED",
+		Status: fiber.StatusBadRequest,
+		Messages: common.Messages{
+			"en": "The request does not name a tenant",
+			"vi": "Yêu cầu không xác định khách hàng thuê",
+		},
+	})
+	common.RegisterError(ErrTenantMismatch, common.ErrorDefinition{
+		Code:   "TENANT_MISMATCH",
+		Status: fiber.StatusUnauthorized,
+		Messages: common.Messages{
+			"en": "The token was issued for another tenant",
+			"vi": "Mã xác thực được cấp cho khách hàng thuê khác",
+		},
+	})
+
+	// Rate limiting
+	common.RegisterError(ErrRateLimitExceeded, common.ErrorDefinition{
+		Code:   "RATE_LIMITED",
+		Status: fiber.StatusTooManyRequests,
+		Messages: common.Messages{
+			"en": "Too many requests, please try again later",
+			"vi": "Quá nhiều yêu cầu, vui lòng thử lại sau",
+		},
+	})
+	common.RegisterError(ErrAuthRateLimitExceeded, common.ErrorDefinition{
+		Code:   "AUTH_RATE_LIMITED",
+		Status: fiber.StatusTooManyRequests,
+		Messages: common.Messages{
+			"en": "Too many authentication attempts, please try again later",
+			"vi": "Quá nhiều lần xác thực, vui lòng thử lại sau",
+		},
+	})
+}
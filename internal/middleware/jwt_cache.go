@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"time"
@@ -16,9 +17,14 @@ import (
 // clean-arch: Implements TokenCache interface using Redis
 // =============================================================================
 
+// Keys are prefixed with the tenant of the context (see TenantKey), so
+// revocations and cached tokens of one tenant never apply to another.
 const (
 	jwtValidKeyPrefix     = "jwt:valid:%s"
 	jwtBlacklistKeyPrefix = "jwt:blacklist:%s"
+	jwtRevokedUserKey     = "jwt:revoked:user:%d"
+	jwtUserStatusKey      = "jwt:status:user:%d"
+	jwtRevokedOrgKey      = "jwt:revoked:org:%d:user:%d"
 )
 
 // Compile-time interface compliance check
@@ -54,14 +60,13 @@ func (c *JWTCache) IsEnabled() bool {
 	return c.enabled && c.redis != nil
 }
 
-// IsBlacklisted checks if a token is blacklisted (implements TokenCache).
-func (c *JWTCache) IsBlacklisted(ctx context.Context, token string) bool {
-	if !c.IsEnabled() {
+// IsBlacklisted checks if the token with the given jti is blacklisted (implements TokenCache).
+func (c *JWTCache) IsBlacklisted(ctx context.Context, tokenID string) bool {
+	if !c.IsEnabled() || tokenID == "" {
 		return false
 	}
 
-	tokenHash := c.hashToken(token)
-	key := fmt.Sprintf(jwtBlacklistKeyPrefix, tokenHash)
+	key := TenantKey(ctx, fmt.Sprintf(jwtBlacklistKeyPrefix, tokenID))
 
 	exists, err := c.redis.Exists(ctx, key).Result()
 	if err != nil {
@@ -75,29 +80,32 @@ func (c *JWTCache) IsBlacklisted(ctx context.Context, token string) bool {
 	isBlacklisted := exists > 0
 	if isBlacklisted {
 		slog.Warn("Blocked blacklisted token",
-			"token_hash", tokenHash,
+			"jti", tokenID,
 		)
 	}
 
 	return isBlacklisted
 }
 
-// BlacklistToken adds a token to the blacklist (implements TokenCache).
+// BlacklistToken adds a token's jti to the blacklist (implements TokenCache).
 // The token will be blacklisted until its natural expiration.
-func (c *JWTCache) BlacklistToken(ctx context.Context, token string, expiresAt time.Time) error {
+func (c *JWTCache) BlacklistToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
 	if !c.IsEnabled() {
 		slog.Warn("JWT caching is disabled, cannot blacklist token")
 		return nil
 	}
 
-	tokenHash := c.hashToken(token)
-	key := fmt.Sprintf(jwtBlacklistKeyPrefix, tokenHash)
+	if tokenID == "" {
+		return ErrMissingTokenID
+	}
+
+	key := TenantKey(ctx, fmt.Sprintf(jwtBlacklistKeyPrefix, tokenID))
 
 	// Calculate TTL: time until token naturally expires
 	ttl := time.Until(expiresAt)
 	if ttl <= 0 {
 		slog.Info("Token already expired, no need to blacklist",
-			"token_hash", tokenHash,
+			"jti", tokenID,
 		)
 		return nil
 	}
@@ -114,31 +122,187 @@ func (c *JWTCache) BlacklistToken(ctx context.Context, token string, expiresAt t
 	}
 
 	slog.Info("Token blacklisted successfully",
-		"token_hash", tokenHash,
+		"jti", tokenID,
 		"ttl", ttl,
 	)
 
 	return nil
 }
 
-// CacheToken caches a validated token (implements TokenCache).
-// Stores minimal data: just the user ID and expiration.
-func (c *JWTCache) CacheToken(ctx context.Context, token string, userID int64, expiresAt time.Time) error {
+// RevokeUserTokens records that every token of the user issued up to now is
+// revoked (implements TokenCache). The time is recorded in Unix milliseconds.
+// ttl should cover the longest token lifetime.
+func (c *JWTCache) RevokeUserTokens(ctx context.Context, userID int64, ttl time.Duration) error {
+	if !c.IsEnabled() {
+		slog.Warn("JWT caching is disabled, cannot revoke user tokens")
+		return nil
+	}
+
+	key := TenantKey(ctx, fmt.Sprintf(jwtRevokedUserKey, userID))
+	if err := c.redis.Set(ctx, key, time.Now().UnixMilli(), ttl).Err(); err != nil {
+		slog.Error("Failed to revoke user tokens",
+			"error", err,
+			"user_id", userID,
+		)
+		return fmt.Errorf("failed to revoke user tokens: %w", err)
+	}
+
+	slog.Info("User tokens revoked",
+		"user_id", userID,
+		"ttl", ttl,
+	)
+	return nil
+}
+
+// IsUserRevoked checks if a token of the user issued at issuedAt was revoked
+// by RevokeUserTokens (implements TokenCache), see issuedBeforeRevocation.
+func (c *JWTCache) IsUserRevoked(ctx context.Context, userID int64, issuedAt time.Time) bool {
+	if !c.IsEnabled() || userID == 0 {
+		return false
+	}
+
+	key := TenantKey(ctx, fmt.Sprintf(jwtRevokedUserKey, userID))
+	revokedAt, err := c.redis.Get(ctx, key).Int64()
+	if err == redis.Nil {
+		return false
+	}
+	if err != nil {
+		slog.Error("Failed to check user token revocation",
+			"error", err,
+			"key", key,
+		)
+		return false // Fail open - allow request if Redis is down
+	}
+
+	return issuedBeforeRevocation(issuedAt, revokedAt)
+}
+
+// issuedBeforeRevocation reports whether a token issued at issuedAt is covered
+// by a revocation recorded at revokedAt, in Unix milliseconds. Tokens issued
+// in the millisecond of the revocation or later stay valid, so the pair
+// returned right after a role change or organization switch is accepted
+// while every earlier token is refused. Tokens without "iat_ms" only have
+// second precision and count as issued at the start of their second.
+func issuedBeforeRevocation(issuedAt time.Time, revokedAt int64) bool {
+	return issuedAt.UnixMilli() < revokedAt
+}
+
+// SetUserStatus records the lifecycle status of the user for ttl (implements
+// TokenCache). An empty status clears it.
+func (c *JWTCache) SetUserStatus(ctx context.Context, userID int64, status string, ttl time.Duration) error {
+	if !c.IsEnabled() {
+		slog.Warn("JWT caching is disabled, cannot record user status")
+		return nil
+	}
+
+	key := TenantKey(ctx, fmt.Sprintf(jwtUserStatusKey, userID))
+	var err error
+	if status == "" {
+		err = c.redis.Del(ctx, key).Err()
+	} else {
+		err = c.redis.Set(ctx, key, status, ttl).Err()
+	}
+	if err != nil {
+		slog.Error("Failed to record user status",
+			"error", err,
+			"user_id", userID,
+			"status", status,
+		)
+		return fmt.Errorf("failed to record user status: %w", err)
+	}
+	return nil
+}
+
+// GetUserStatus returns the status recorded by SetUserStatus (implements
+// TokenCache), empty if none.
+func (c *JWTCache) GetUserStatus(ctx context.Context, userID int64) string {
+	if !c.IsEnabled() || userID == 0 {
+		return ""
+	}
+
+	key := TenantKey(ctx, fmt.Sprintf(jwtUserStatusKey, userID))
+	status, err := c.redis.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return ""
+	}
+	if err != nil {
+		slog.Error("Failed to check user status",
+			"error", err,
+			"key", key,
+		)
+		return "" // Fail open - allow request if Redis is down
+	}
+	return status
+}
+
+// RevokeOrgAccess records that the organization claims of every token of the
+// user issued up to now are revoked (implements TokenCache). The time is
+// recorded in Unix milliseconds. ttl should cover the longest token lifetime.
+func (c *JWTCache) RevokeOrgAccess(ctx context.Context, orgID, userID int64, ttl time.Duration) error {
 	if !c.IsEnabled() {
+		slog.Warn("JWT caching is disabled, cannot revoke organization access")
+		return nil
+	}
+
+	key := TenantKey(ctx, fmt.Sprintf(jwtRevokedOrgKey, orgID, userID))
+	if err := c.redis.Set(ctx, key, time.Now().UnixMilli(), ttl).Err(); err != nil {
+		slog.Error("Failed to revoke organization access",
+			"error", err,
+			"organization_id", orgID,
+			"user_id", userID,
+		)
+		return fmt.Errorf("failed to revoke organization access: %w", err)
+	}
+	return nil
+}
+
+// IsOrgAccessRevoked checks if the organization claims of a token of the user
+// issued at issuedAt were revoked by RevokeOrgAccess (implements TokenCache),
+// see issuedBeforeRevocation.
+func (c *JWTCache) IsOrgAccessRevoked(ctx context.Context, orgID, userID int64, issuedAt time.Time) bool {
+	if !c.IsEnabled() || userID == 0 {
+		return false
+	}
+
+	key := TenantKey(ctx, fmt.Sprintf(jwtRevokedOrgKey, orgID, userID))
+	revokedAt, err := c.redis.Get(ctx, key).Int64()
+	if err == redis.Nil {
+		return false
+	}
+	if err != nil {
+		slog.Error("Failed to check organization access revocation",
+			"error", err,
+			"key", key,
+		)
+		return false // Fail open - allow request if Redis is down
+	}
+
+	return issuedBeforeRevocation(issuedAt, revokedAt)
+}
+
+// CacheToken caches the claims of a validated token (implements TokenCache).
+// The cache entry expires together with the token.
+func (c *JWTCache) CacheToken(ctx context.Context, token string, claims *Claims) error {
+	if !c.IsEnabled() || claims == nil || claims.ExpiresAt == nil {
 		return nil
 	}
 
 	tokenHash := c.hashToken(token)
-	key := fmt.Sprintf(jwtValidKeyPrefix, tokenHash)
+	key := TenantKey(ctx, fmt.Sprintf(jwtValidKeyPrefix, tokenHash))
 
 	// Calculate TTL
-	ttl := time.Until(expiresAt)
+	ttl := time.Until(claims.ExpiresAt.Time)
 	if ttl <= 0 {
 		return nil // Don't cache expired tokens
 	}
 
-	// Store user ID with TTL matching token expiration
-	err := c.redis.Set(ctx, key, userID, ttl).Err()
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return fmt.Errorf("failed to encode token claims: %w", err)
+	}
+
+	// Store claims with TTL matching token expiration
+	err = c.redis.Set(ctx, key, payload, ttl).Err()
 	if err != nil {
 		slog.Error("Failed to cache valid token",
 			"error", err,
@@ -149,42 +313,48 @@ func (c *JWTCache) CacheToken(ctx context.Context, token string, userID int64, e
 
 	slog.Debug("Token cached successfully",
 		"token_hash", tokenHash,
-		"user_id", userID,
+		"user_id", claims.UserId,
 		"ttl", ttl,
 	)
 
 	return nil
 }
 
-// GetCachedToken retrieves a cached token's user ID (implements TokenCache).
-// Returns 0 and false if not cached or cache miss.
-func (c *JWTCache) GetCachedToken(ctx context.Context, token string) (int64, bool) {
+// GetCachedToken retrieves a cached token's claims (implements TokenCache).
+// Returns nil and false if not cached or cache miss.
+func (c *JWTCache) GetCachedToken(ctx context.Context, token string) (*Claims, bool) {
 	if !c.IsEnabled() {
-		return 0, false
+		return nil, false
 	}
 
 	tokenHash := c.hashToken(token)
-	key := fmt.Sprintf(jwtValidKeyPrefix, tokenHash)
+	key := TenantKey(ctx, fmt.Sprintf(jwtValidKeyPrefix, tokenHash))
 
-	userID, err := c.redis.Get(ctx, key).Int64()
+	payload, err := c.redis.Get(ctx, key).Bytes()
 	if err == redis.Nil {
 		// Cache miss - not an error
-		return 0, false
+		return nil, false
 	}
 	if err != nil {
 		slog.Error("Failed to get cached token",
 			"error", err,
 			"key", key,
 		)
-		return 0, false
+		return nil, false
+	}
+
+	claims := &Claims{}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		// Entries written by older versions only held the user ID; treat as a miss
+		return nil, false
 	}
 
 	slog.Debug("Token cache hit",
 		"token_hash", tokenHash,
-		"user_id", userID,
+		"user_id", claims.UserId,
 	)
 
-	return userID, true
+	return claims, true
 }
 
 // InvalidateToken removes a token from the valid cache (implements TokenCache).
@@ -195,7 +365,7 @@ func (c *JWTCache) InvalidateToken(ctx context.Context, token string) error {
 	}
 
 	tokenHash := c.hashToken(token)
-	key := fmt.Sprintf(jwtValidKeyPrefix, tokenHash)
+	key := TenantKey(ctx, fmt.Sprintf(jwtValidKeyPrefix, tokenHash))
 
 	err := c.redis.Del(ctx, key).Err()
 	if err != nil {
@@ -228,7 +398,7 @@ func (c *JWTCache) hashToken(token string) string {
 // Additional Methods (Stats, etc.)
 // =============================================================================
 
-// GetCacheStats returns statistics about the JWT cache.
+// GetCacheStats returns statistics about the JWT cache, across all tenants.
 func (c *JWTCache) GetCacheStats(ctx context.Context) (map[string]int64, error) {
 	if !c.IsEnabled() {
 		return map[string]int64{
@@ -237,13 +407,13 @@ func (c *JWTCache) GetCacheStats(ctx context.Context) (map[string]int64, error)
 	}
 
 	// Count valid tokens
-	validKeys, err := c.redis.Keys(ctx, "jwt:valid:*").Result()
+	validKeys, err := c.redis.Keys(ctx, "*jwt:valid:*").Result()
 	if err != nil {
 		return nil, err
 	}
 
 	// Count blacklisted tokens
-	blacklistKeys, err := c.redis.Keys(ctx, "jwt:blacklist:*").Result()
+	blacklistKeys, err := c.redis.Keys(ctx, "*jwt:blacklist:*").Result()
 	if err != nil {
 		return nil, err
 	}
@@ -260,6 +430,6 @@ func (c *JWTCache) GetCacheStats(ctx context.Context) (map[string]int64, error)
 // =============================================================================
 
 // CacheValidToken is an alias for CacheToken (backward compatible).
-func (c *JWTCache) CacheValidToken(ctx context.Context, token string, userID int64, expiresAt time.Time) error {
-	return c.CacheToken(ctx, token, userID, expiresAt)
+func (c *JWTCache) CacheValidToken(ctx context.Context, token string, claims *Claims) error {
+	return c.CacheToken(ctx, token, claims)
 }
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestIssuedBeforeRevocation(t *testing.T) {
+	revokedAt := time.Date(2026, 1, 2, 3, 4, 5, 500_000_000, time.UTC)
+
+	tests := []struct {
+		name     string
+		issuedAt time.Time
+		want     bool
+	}{
+		{"issued seconds before", revokedAt.Add(-10 * time.Second), true},
+		{"issued earlier in the revocation second", revokedAt.Add(-time.Millisecond), true},
+		{"issued in the revocation millisecond", revokedAt.Add(500 * time.Microsecond), false},
+		{"issued later in the revocation second", revokedAt.Add(400 * time.Millisecond), false},
+		{"issued after", revokedAt.Add(time.Minute), false},
+		{"second precision token of the revocation second", revokedAt.Truncate(time.Second), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := issuedBeforeRevocation(tt.issuedAt, revokedAt.UnixMilli()); got != tt.want {
+				t.Errorf("issuedBeforeRevocation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// memoryTokenCache is an in-memory TokenCache recording revocations like
+// JWTCache does.
+type memoryTokenCache struct {
+	blacklist  map[string]bool
+	revoked    map[int64]int64
+	orgRevoked map[[2]int64]int64
+	statuses   map[int64]string
+}
+
+func newMemoryTokenCache() *memoryTokenCache {
+	return &memoryTokenCache{
+		blacklist:  make(map[string]bool),
+		revoked:    make(map[int64]int64),
+		orgRevoked: make(map[[2]int64]int64),
+		statuses:   make(map[int64]string),
+	}
+}
+
+func (m *memoryTokenCache) CacheToken(ctx context.Context, token string, claims *Claims) error {
+	return nil
+}
+
+func (m *memoryTokenCache) GetCachedToken(ctx context.Context, token string) (*Claims, bool) {
+	return nil, false
+}
+
+func (m *memoryTokenCache) InvalidateToken(ctx context.Context, token string) error {
+	return nil
+}
+
+func (m *memoryTokenCache) BlacklistToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	m.blacklist[tokenID] = true
+	return nil
+}
+
+func (m *memoryTokenCache) IsBlacklisted(ctx context.Context, tokenID string) bool {
+	return m.blacklist[tokenID]
+}
+
+func (m *memoryTokenCache) RevokeUserTokens(ctx context.Context, userID int64, ttl time.Duration) error {
+	m.revoked[userID] = time.Now().UnixMilli()
+	return nil
+}
+
+func (m *memoryTokenCache) IsUserRevoked(ctx context.Context, userID int64, issuedAt time.Time) bool {
+	revokedAt, ok := m.revoked[userID]
+	return ok && issuedBeforeRevocation(issuedAt, revokedAt)
+}
+
+func (m *memoryTokenCache) SetUserStatus(ctx context.Context, userID int64, status string, ttl time.Duration) error {
+	m.statuses[userID] = status
+	return nil
+}
+
+func (m *memoryTokenCache) GetUserStatus(ctx context.Context, userID int64) string {
+	return m.statuses[userID]
+}
+
+func (m *memoryTokenCache) RevokeOrgAccess(ctx context.Context, orgID, userID int64, ttl time.Duration) error {
+	m.orgRevoked[[2]int64{orgID, userID}] = time.Now().UnixMilli()
+	return nil
+}
+
+func (m *memoryTokenCache) IsOrgAccessRevoked(ctx context.Context, orgID, userID int64, issuedAt time.Time) bool {
+	revokedAt, ok := m.orgRevoked[[2]int64{orgID, userID}]
+	return ok && issuedBeforeRevocation(issuedAt, revokedAt)
+}
+
+func (m *memoryTokenCache) IsEnabled() bool {
+	return true
+}
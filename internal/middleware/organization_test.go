@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"base-service/internal/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// authenticateAs returns a handler authenticating the request with claims
+// carrying value under namespace, as a local or an external principal.
+func authenticateAs(t *testing.T, namespace string, value any, external bool) fiber.Handler {
+	t.Helper()
+	claims := &Claims{UserId: 1, UserName: "alice"}
+	claims.Issuer = "base-service"
+	claims.Subject = "1"
+	if external {
+		claims.Issuer = "trusted-idp"
+	}
+	if value != nil {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			t.Fatalf("marshal %s claims: %v", namespace, err)
+		}
+		claims.Custom = map[string]json.RawMessage{namespace: raw}
+	}
+	return func(c *fiber.Ctx) error {
+		if external {
+			SetPrincipalInContext(c, NewPrincipal(claims, true))
+		} else {
+			SetUserInContext(c, claims)
+		}
+		return c.Next()
+	}
+}
+
+// orgRoleStatus runs RequireOrgRole(minRole) for a request authenticated with
+// claims carrying org.
+func orgRoleStatus(t *testing.T, a *AuthMiddleware, org *OrgClaims, external bool, minRole string) int {
+	t.Helper()
+	var value any
+	if org != nil {
+		value = org
+	}
+	app := fiber.New()
+	app.Get("/", authenticateAs(t, OrgClaimsNamespace, value, external), a.RequireOrgRole(minRole), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return resp.StatusCode
+}
+
+func TestRequireOrgRole(t *testing.T) {
+	a := newTestAuthMiddleware()
+	owner := &OrgClaims{ID: 7, Slug: "acme", Role: entity.OrgRoleOwner}
+	member := &OrgClaims{ID: 7, Slug: "acme", Role: entity.OrgRoleMember}
+
+	tests := []struct {
+		name     string
+		org      *OrgClaims
+		external bool
+		minRole  string
+		allowed  bool
+	}{
+		{"owner", owner, false, entity.OrgRoleAdmin, true},
+		{"member below the required role", member, false, entity.OrgRoleAdmin, false},
+		{"member", member, false, entity.OrgRoleMember, true},
+		{"no active organization", nil, false, entity.OrgRoleMember, false},
+		{"organization claims of an external issuer", owner, true, entity.OrgRoleMember, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := orgRoleStatus(t, a, tt.org, tt.external, tt.minRole)
+			if allowed := got == fiber.StatusNoContent; allowed != tt.allowed {
+				t.Errorf("status = %d, want allowed: %v", got, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestRevokeOrgAccess(t *testing.T) {
+	cache := newMemoryTokenCache()
+	a := newTestAuthMiddleware()
+	a.tokenCache = cache
+	const orgID, userID = 7, 1
+
+	beforeRevocation := time.Now().Add(-time.Millisecond)
+	time.Sleep(time.Millisecond)
+	if err := a.RevokeOrgAccess(context.Background(), orgID, userID); err != nil {
+		t.Fatalf("RevokeOrgAccess: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		orgID    int64
+		issuedAt time.Time
+		want     bool
+	}{
+		{"token issued before the revocation", orgID, time.Now().Add(-time.Minute), true},
+		{"token issued just before the revocation", orgID, beforeRevocation, true},
+		{"token issued right after the revocation", orgID, time.Now(), false},
+		{"token of another organization", orgID + 1, time.Now().Add(-time.Minute), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := &Claims{UserId: userID, IssuedAtMilli: tt.issuedAt.UnixMilli()}
+			claims.IssuedAt = jwt.NewNumericDate(tt.issuedAt)
+			if got := a.isOrgAccessRevoked(context.Background(), tt.orgID, claims); got != tt.want {
+				t.Errorf("isOrgAccessRevoked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // =============================================================================
@@ -111,6 +112,9 @@ func (h *Argon2PasswordHasher) Verify(password, encodedHash string) (bool, error
 	if encodedHash == "" {
 		return false, errors.New("hash cannot be empty")
 	}
+	if scheme, hash, ok := parseLegacyHash(encodedHash); ok {
+		return verifyLegacyHash(scheme, password, hash)
+	}
 
 	// Parse the encoded hash to extract salt and parameters
 	// Format: $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>
@@ -163,3 +167,76 @@ func (h *Argon2PasswordHasher) Verify(password, encodedHash string) (bool, error
 	// Use constant-time comparison to prevent timing attacks
 	return subtle.ConstantTimeCompare(storedHash, newHash) == 1, nil
 }
+
+// =============================================================================
+// Legacy Password Hashes
+// clean-arch: Hashes imported from other systems, upgraded at the next login
+// =============================================================================
+
+// Legacy hashes are stored with a marker naming their scheme, e.g.
+// {bcrypt}$2a$10$... They are verified with the scheme's algorithm; callers
+// replace them with an argon2id hash once the password is known.
+var legacyVerifiers = map[string]func(password, hash string) (bool, error){
+	"bcrypt": verifyBcrypt,
+}
+
+// IsLegacyPasswordHash reports whether hash carries a legacy scheme marker.
+func IsLegacyPasswordHash(hash string) bool {
+	_, _, ok := parseLegacyHash(hash)
+	return ok
+}
+
+// ValidatePasswordHash checks that hash is an argon2id hash or a legacy hash
+// of a supported scheme, without verifying any password.
+func ValidatePasswordHash(encodedHash string) error {
+	if scheme, hash, ok := parseLegacyHash(encodedHash); ok {
+		switch scheme {
+		case "bcrypt":
+			if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+				return fmt.Errorf("invalid bcrypt hash: %w", err)
+			}
+			return nil
+		default:
+			return fmt.Errorf("unsupported password hash scheme %q", scheme)
+		}
+	}
+
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return errors.New("password hash must be argon2id or carry a legacy scheme marker")
+	}
+	return nil
+}
+
+// parseLegacyHash splits a {scheme}hash legacy hash.
+func parseLegacyHash(encodedHash string) (scheme, hash string, ok bool) {
+	if !strings.HasPrefix(encodedHash, "{") {
+		return "", "", false
+	}
+	end := strings.Index(encodedHash, "}")
+	if end < 0 {
+		return "", "", false
+	}
+	return encodedHash[1:end], encodedHash[end+1:], true
+}
+
+// verifyLegacyHash verifies a password against a legacy hash.
+func verifyLegacyHash(scheme, password, hash string) (bool, error) {
+	verify, ok := legacyVerifiers[scheme]
+	if !ok {
+		return false, fmt.Errorf("unsupported password hash scheme %q", scheme)
+	}
+	return verify(password, hash)
+}
+
+// verifyBcrypt verifies a password against a bcrypt hash.
+func verifyBcrypt(password, hash string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to verify bcrypt hash: %w", err)
+	}
+	return true, nil
+}
@@ -1,11 +1,12 @@
 package middleware
 
 import (
-	"fmt"
+	"errors"
 	"log/slog"
 	"time"
 
 	"base-service/config"
+	"base-service/internal/common"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
@@ -13,6 +14,11 @@ import (
 	goredis "github.com/redis/go-redis/v9"
 )
 
+var (
+	ErrRateLimitExceeded     = errors.New("rate limit exceeded")
+	ErrAuthRateLimitExceeded = errors.New("auth rate limit exceeded")
+)
+
 // RateLimitFilter creates a standard rate limiter middleware for general API endpoints
 // Default: 100 requests per minute per IP
 // If redisClient is provided, uses distributed rate limiting (shared across servers)
@@ -35,11 +41,6 @@ func RateLimitFilter(rateLimitConfig config.RateLimitConfig, rediscf *config.Red
 		expiration = 1 * time.Minute
 	}
 
-	limitReached := rateLimitConfig.LimitReached
-	if limitReached == "" {
-		limitReached = "Too many requests, please try again later."
-	}
-
 	// Configure storage (Redis for distributed, memory for single-server)
 	var storage fiber.Storage
 	storageType := "memory"
@@ -61,8 +62,8 @@ func RateLimitFilter(rateLimitConfig config.RateLimitConfig, rediscf *config.Red
 		SkipFailedRequests:     rateLimitConfig.SkipFailedReq,
 		SkipSuccessfulRequests: rateLimitConfig.SkipSuccessReq,
 		KeyGenerator: func(c *fiber.Ctx) string {
-			// Use IP address as the key
-			return c.IP()
+			// Use IP address as the key, per tenant
+			return TenantKey(c.Context(), c.IP())
 		},
 		LimitReached: func(c *fiber.Ctx) error {
 			slog.Warn("Rate limit exceeded",
@@ -70,10 +71,7 @@ func RateLimitFilter(rateLimitConfig config.RateLimitConfig, rediscf *config.Red
 				"path", c.Path(),
 				"method", c.Method(),
 			)
-			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
-				"error":   "rate_limit_exceeded",
-				"message": limitReached,
-			})
+			return common.ResponseProblem(c, ErrRateLimitExceeded)
 		},
 	}
 
@@ -133,9 +131,9 @@ func AuthRateLimitFilter(rateLimitConfig config.RateLimitConfig, rediscf *config
 		Max:        max,
 		Expiration: expiration,
 		KeyGenerator: func(c *fiber.Ctx) string {
-			// Use IP address as the key
+			// Use IP address as the key, per tenant
 			// Could also use username from request body for more sophisticated limiting
-			return c.IP()
+			return TenantKey(c.Context(), c.IP())
 		},
 		LimitReached: func(c *fiber.Ctx) error {
 			slog.Warn("Auth rate limit exceeded",
@@ -143,10 +141,7 @@ func AuthRateLimitFilter(rateLimitConfig config.RateLimitConfig, rediscf *config
 				"path", c.Path(),
 				"method", c.Method(),
 			)
-			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
-				"error":   "auth_rate_limit_exceeded",
-				"message": fmt.Sprintf("Too many authentication attempts. Please try again in %v.", expiration),
-			})
+			return common.ResponseProblem(c, ErrAuthRateLimitExceeded)
 		},
 	}
 
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"base-service/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// =============================================================================
+// Role-Based Access Control
+// clean-arch: "role" claim checks and forced logout of every session of a user
+// =============================================================================
+
+var (
+	ErrForbidden = errors.New("insufficient role")
+)
+
+// RequireRole returns a middleware that only lets locally issued tokens with
+// one of roles through. It must run after AuthMiddleware.
+func RequireRole(roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := GetUserFromContext(c)
+		if !ok || !slices.Contains(roles, claims.Role) {
+			slog.Warn("Rejected request with insufficient role",
+				"path", c.Path(),
+				"required", roles,
+			)
+			return common.ResponseProblem(c, ErrForbidden)
+		}
+		return c.Next()
+	}
+}
+
+// RevokeUserSessions logs the user out everywhere: every token issued so far
+// is rejected and, when sessions are tracked, every session is ended.
+// Without a token cache tokens cannot be revoked and stay valid until they
+// expire.
+func (a *AuthMiddleware) RevokeUserSessions(ctx context.Context, userID int64) error {
+	if a.tokenCache == nil || !a.tokenCache.IsEnabled() {
+		slog.Warn("Token cache is disabled; the user's tokens stay valid until they expire", "user_id", userID)
+	} else if err := a.tokenCache.RevokeUserTokens(ctx, userID, a.config.Token.RefreshTokenExp); err != nil {
+		return fmt.Errorf("%w: %w", ErrLogoutFailed, err)
+	}
+	if a.sessionsEnabled() {
+		if err := a.sessionStore.EndAll(ctx, userID); err != nil {
+			return fmt.Errorf("%w: %w", ErrLogoutFailed, err)
+		}
+	}
+	return nil
+}
+
+// isUserRevoked checks if the claims' user was logged out everywhere after
+// the token was issued.
+func (a *AuthMiddleware) isUserRevoked(ctx context.Context, claims *Claims) bool {
+	if a.tokenCache == nil || !a.tokenCache.IsEnabled() || claims.IssuedAt == nil || a.isExternalIssuer(claims.Issuer) {
+		return false
+	}
+	return a.tokenCache.IsUserRevoked(ctx, claims.UserId, claims.issuedAt())
+}
@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"base-service/internal/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// signTestToken signs an access token of user issued at issuedAt.
+func signTestToken(t *testing.T, a *AuthMiddleware, user *entity.User, issuedAt time.Time) string {
+	t.Helper()
+	claims, err := a.newAccessClaims(context.Background(), user, NewAuthentication("", AMRPassword))
+	if err != nil {
+		t.Fatalf("newAccessClaims: %v", err)
+	}
+	claims.IssuedAtMilli = issuedAt.UnixMilli()
+	claims.IssuedAt = jwt.NewNumericDate(issuedAt)
+	claims.NotBefore = jwt.NewNumericDate(issuedAt)
+	token, err := a.signToken(claims, a.config.Token.AccessTokenSecret)
+	if err != nil {
+		t.Fatalf("signToken: %v", err)
+	}
+	return token
+}
+
+// endAllSessionStore records the users whose sessions were all ended.
+type endAllSessionStore struct {
+	fakeSessionStore
+	ended []int64
+	err   error
+}
+
+func (s *endAllSessionStore) EndAll(ctx context.Context, userID int64) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.ended = append(s.ended, userID)
+	return nil
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	cache := newMemoryTokenCache()
+	sessions := &endAllSessionStore{}
+	a := newTestAuthMiddleware()
+	a.tokenCache = cache
+	a.SetSessionStore(sessions)
+	user := &entity.User{ID: 1, Username: "alice", Role: entity.RoleUser}
+	other := &entity.User{ID: 2, Username: "bob", Role: entity.RoleUser}
+
+	// Tokens issued before the revocation, one of them a moment before
+	oldToken := signTestToken(t, a, user, time.Now().Add(-time.Minute))
+	recentToken := signTestToken(t, a, user, time.Now().Add(-time.Millisecond))
+	otherToken := signTestToken(t, a, other, time.Now().Add(-time.Minute))
+	time.Sleep(time.Millisecond)
+
+	if err := a.RevokeUserSessions(context.Background(), user.ID); err != nil {
+		t.Fatalf("RevokeUserSessions: %v", err)
+	}
+	if len(sessions.ended) != 1 || sessions.ended[0] != user.ID {
+		t.Errorf("ended sessions of %v, want [%d]", sessions.ended, user.ID)
+	}
+
+	// The pair returned right after the revocation, usually in the same second
+	newPair, err := a.GenerateAccessToken(context.Background(), user, NewAuthentication("", AMRPassword))
+	if err != nil {
+		t.Fatalf("GenerateAccessToken: %v", err)
+	}
+
+	app := fiber.New()
+	app.Get("/", a.AuthMiddleware(), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	tests := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"token issued before the revocation", oldToken, fiber.StatusUnauthorized},
+		{"token issued just before the revocation", recentToken, fiber.StatusUnauthorized},
+		{"token issued right after the revocation", newPair.AccessToken, fiber.StatusNoContent},
+		{"token of another user", otherToken, fiber.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			req.Header.Set(AuthorizationHeader, Prefix+" "+tt.token)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestRevokeUserSessionsFailure(t *testing.T) {
+	storeErr := errors.New("redis unavailable")
+	a := newTestAuthMiddleware()
+	a.tokenCache = newMemoryTokenCache()
+	a.SetSessionStore(&endAllSessionStore{err: storeErr})
+
+	err := a.RevokeUserSessions(context.Background(), 1)
+	if !errors.Is(err, ErrLogoutFailed) || !errors.Is(err, storeErr) {
+		t.Errorf("err = %v, want %v wrapping %v", err, ErrLogoutFailed, storeErr)
+	}
+}
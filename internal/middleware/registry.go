@@ -2,10 +2,12 @@ package middleware
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"sync"
 
 	"base-service/config"
+	"base-service/internal/domain/entity"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/redis/go-redis/v9"
@@ -139,6 +141,44 @@ func (r *Registry) AuthHandler() fiber.Handler {
 	return r.authMiddleware.AuthMiddleware()
 }
 
+// RegisterKeyResolver enables verification of tokens from trusted external issuers.
+func (r *Registry) RegisterKeyResolver(resolver KeyResolver) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.authMiddleware != nil {
+		r.authMiddleware.SetKeyResolver(resolver)
+	}
+	slog.Info("Registered external key resolver")
+}
+
+// RegisterSessionStore enables idle timeouts and concurrent session limits.
+func (r *Registry) RegisterSessionStore(store SessionStore) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.authMiddleware != nil {
+		r.authMiddleware.SetSessionStore(store)
+	}
+	slog.Info("Registered session store")
+}
+
+// RegisterClaimsEnricher registers a ClaimsEnricher with the auth middleware.
+// GenerateTokenPair calls every registered enricher with the user.
+func (r *Registry) RegisterClaimsEnricher(enricher ClaimsEnricher) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.authMiddleware == nil {
+		return fmt.Errorf("auth middleware not initialized")
+	}
+	if err := r.authMiddleware.AddClaimsEnricher(enricher); err != nil {
+		return err
+	}
+	slog.Info("Registered claims enricher", "namespace", enricher.Namespace())
+	return nil
+}
+
 // RegisterHandler registers a custom middleware handler.
 func (r *Registry) RegisterHandler(name string, handler MiddlewareHandler) {
 	r.mu.Lock()
@@ -249,14 +289,14 @@ func (r *Registry) VerifyPassword(password, hash string) (bool, error) {
 }
 
 // GenerateTokenPair generates a token pair using the token service.
-func (r *Registry) GenerateTokenPair(userID int64, username string) (*TokenPair, error) {
+func (r *Registry) GenerateTokenPair(ctx context.Context, user *entity.User, auth Authentication) (*TokenPair, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	if r.tokenService == nil {
 		return nil, nil
 	}
-	return r.tokenService.GenerateTokenPair(userID, username)
+	return r.tokenService.GenerateTokenPair(ctx, user, auth)
 }
 
 // ValidateAccessToken validates an access token using the token service.
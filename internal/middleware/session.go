@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"base-service/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// =============================================================================
+// Sessions
+// clean-arch: Idle timeout and concurrent session limits keyed by the "sid" claim
+// =============================================================================
+
+const (
+	// SessionExpiredCode is returned when a session ended through inactivity or logout.
+	SessionExpiredCode = "SESSION_EXPIRED"
+	// SessionEvictedCode is returned when a newer login evicted the session.
+	SessionEvictedCode = "SESSION_EVICTED"
+	// TooManySessionsCode is returned when a login is refused by the session limit.
+	TooManySessionsCode = "TOO_MANY_SESSIONS"
+)
+
+var (
+	ErrSessionExpired  = errors.New("session has expired")
+	ErrSessionEvicted  = errors.New("session was ended by a newer sign-in")
+	ErrTooManySessions = errors.New("too many active sessions")
+)
+
+// SetSessionStore enables idle timeouts and concurrent session limits.
+// Without a store tokens are valid until they expire.
+func (a *AuthMiddleware) SetSessionStore(store SessionStore) {
+	a.sessionStore = store
+}
+
+func (a *AuthMiddleware) sessionsEnabled() bool {
+	return a.sessionStore != nil && a.sessionStore.IsEnabled()
+}
+
+// startSession registers the session of a new login.
+func (a *AuthMiddleware) startSession(ctx context.Context, userID int64, sessionID string) error {
+	if !a.sessionsEnabled() {
+		return nil
+	}
+	return a.sessionStore.Start(ctx, userID, sessionID)
+}
+
+// touchSession records activity on the session of the claims. Tokens minted
+// before sessions were tracked carry no "sid" and are not checked.
+func (a *AuthMiddleware) touchSession(ctx context.Context, claims *Claims) error {
+	if !a.sessionsEnabled() || claims.SessionID == "" {
+		return nil
+	}
+	return a.sessionStore.Touch(ctx, claims.UserId, claims.SessionID)
+}
+
+// ListUserSessions returns the live sessions of the user; none when sessions
+// are not tracked.
+func (a *AuthMiddleware) ListUserSessions(ctx context.Context, userID int64) ([]SessionInfo, error) {
+	if !a.sessionsEnabled() {
+		return nil, nil
+	}
+	return a.sessionStore.List(ctx, userID)
+}
+
+// endSession terminates the session of the claims.
+func (a *AuthMiddleware) endSession(ctx context.Context, claims *Claims) {
+	if !a.sessionsEnabled() || claims.SessionID == "" {
+		return
+	}
+	if err := a.sessionStore.End(ctx, claims.UserId, claims.SessionID); err != nil {
+		slog.Error("Failed to end session during logout",
+			"error", err,
+			"user_id", claims.UserId,
+		)
+	}
+}
+
+// handleSessionError responds 401 with a code telling the client why the
+// session ended, so it can show "signed in elsewhere" instead of a generic error.
+func (a *AuthMiddleware) handleSessionError(c *fiber.Ctx, err error) error {
+	problem := common.NewProblem(c, err)
+
+	slog.Info("Rejected request for ended session",
+		"path", c.Path(),
+		"code", problem.Code,
+	)
+
+	return common.WriteProblem(c, problem)
+}
@@ -0,0 +1,337 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"base-service/config"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// =============================================================================
+// Session Store Implementation
+// clean-arch: Implements SessionStore interface using Redis
+// =============================================================================
+
+const (
+	sessionKeyPrefix        = "session:"
+	sessionEvictedKeyPrefix = "session:evicted:"
+	sessionUserKeyPrefix    = "session:user:%d"
+
+	// sessionActivitySweepSize triggers pruning of the local activity map
+	sessionActivitySweepSize = 10000
+)
+
+// startSessionScript atomically prunes dead sessions, applies the limit policy
+// and registers the new session. Returns -1 when the login must be refused,
+// otherwise the list of evicted session IDs.
+//
+// KEYS[1] user session index (zset: sid -> start time)
+// ARGV[1] sid, ARGV[2] now (ms), ARGV[3] max sessions, ARGV[4] "1" to reject,
+// ARGV[5] session TTL (ms), ARGV[6] evicted marker / index TTL (ms),
+// ARGV[7] session key prefix, ARGV[8] evicted key prefix, ARGV[9] user ID
+var startSessionScript = redis.NewScript(`
+local members = redis.call('ZRANGE', KEYS[1], 0, -1)
+for _, sid in ipairs(members) do
+  if redis.call('EXISTS', ARGV[7] .. sid) == 0 then
+    redis.call('ZREM', KEYS[1], sid)
+  end
+end
+
+local evicted = {}
+local max = tonumber(ARGV[3])
+if max > 0 then
+  local count = redis.call('ZCARD', KEYS[1])
+  if count >= max then
+    if ARGV[4] == '1' then
+      return -1
+    end
+    local oldest = redis.call('ZRANGE', KEYS[1], 0, count - max)
+    for _, sid in ipairs(oldest) do
+      redis.call('ZREM', KEYS[1], sid)
+      redis.call('DEL', ARGV[7] .. sid)
+      redis.call('SET', ARGV[8] .. sid, '1', 'PX', ARGV[6])
+      table.insert(evicted, sid)
+    end
+  end
+end
+
+redis.call('SET', ARGV[7] .. ARGV[1], ARGV[9], 'PX', ARGV[5])
+redis.call('ZADD', KEYS[1], ARGV[2], ARGV[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[6])
+return evicted
+`)
+
+// Compile-time interface compliance check
+var _ SessionStore = (*RedisSessionStore)(nil)
+
+// RedisSessionStore tracks sessions in Redis with a sliding idle timeout.
+// Activity writes are coalesced per session: a request only reaches Redis when
+// ActivityWriteInterval has passed since the last write on this instance, so
+// idle expiry and evictions are detected within that interval.
+type RedisSessionStore struct {
+	redis  *redis.Client
+	config config.SessionConfig
+	// lifetime bounds a session when idle timeout is disabled (refresh token lifetime)
+	lifetime time.Duration
+
+	mu        sync.Mutex
+	lastWrite map[string]time.Time
+}
+
+// NewRedisSessionStore creates a session store. lifetime is the longest a
+// session may live, normally the refresh token lifetime.
+func NewRedisSessionStore(redisClient *redis.Client, cfg config.SessionConfig, lifetime time.Duration) *RedisSessionStore {
+	if redisClient == nil || !cfg.Enabled {
+		slog.Info("Session tracking is disabled")
+		return &RedisSessionStore{config: cfg}
+	}
+
+	slog.Info("Session tracking is enabled",
+		"idle_timeout", cfg.IdleTimeout,
+		"max_concurrent", cfg.MaxConcurrent,
+		"limit_policy", cfg.GetLimitPolicy(),
+	)
+	return &RedisSessionStore{
+		redis:     redisClient,
+		config:    cfg,
+		lifetime:  lifetime,
+		lastWrite: make(map[string]time.Time),
+	}
+}
+
+// =============================================================================
+// SessionStore Interface Implementation
+// =============================================================================
+
+// IsEnabled returns whether session tracking is enabled (implements SessionStore).
+func (s *RedisSessionStore) IsEnabled() bool {
+	return s.config.Enabled && s.redis != nil
+}
+
+// Start registers a new session for the user (implements SessionStore).
+// When the user is at the limit the oldest sessions are evicted, or the
+// login is refused with ErrTooManySessions under the "reject" policy.
+func (s *RedisSessionStore) Start(ctx context.Context, userID int64, sessionID string) error {
+	if !s.IsEnabled() {
+		return nil
+	}
+
+	reject := "0"
+	if s.config.GetLimitPolicy() == config.SessionLimitReject {
+		reject = "1"
+	}
+
+	result, err := startSessionScript.Run(ctx, s.redis,
+		[]string{fmt.Sprintf(sessionUserKeyPrefix, userID)},
+		sessionID,
+		time.Now().UnixMilli(),
+		s.config.MaxConcurrent,
+		reject,
+		s.sessionTTL().Milliseconds(),
+		s.lifetimeTTL().Milliseconds(),
+		sessionKeyPrefix,
+		sessionEvictedKeyPrefix,
+		strconv.FormatInt(userID, 10),
+	).Result()
+	if err != nil {
+		slog.Error("Failed to start session",
+			"error", err,
+			"user_id", userID,
+		)
+		return nil // Fail open - allow login if Redis is down
+	}
+
+	if code, ok := result.(int64); ok && code == -1 {
+		slog.Warn("Login refused, concurrent session limit reached",
+			"user_id", userID,
+			"max_concurrent", s.config.MaxConcurrent,
+		)
+		return ErrTooManySessions
+	}
+
+	if evicted, ok := result.([]interface{}); ok && len(evicted) > 0 {
+		slog.Info("Evicted oldest sessions",
+			"user_id", userID,
+			"evicted", evicted,
+		)
+	}
+
+	s.markWritten(sessionID, time.Now())
+	return nil
+}
+
+// Touch records activity on the session (implements SessionStore).
+func (s *RedisSessionStore) Touch(ctx context.Context, userID int64, sessionID string) error {
+	if !s.IsEnabled() || sessionID == "" {
+		return nil
+	}
+
+	now := time.Now()
+	if !s.shouldWrite(sessionID, now) {
+		return nil
+	}
+
+	alive, err := s.redis.PExpire(ctx, sessionKeyPrefix+sessionID, s.sessionTTL()).Result()
+	if err != nil {
+		slog.Error("Failed to record session activity",
+			"error", err,
+			"sid", sessionID,
+		)
+		return nil // Fail open - allow request if Redis is down
+	}
+
+	if !alive {
+		s.forget(sessionID)
+		evicted, err := s.redis.Exists(ctx, sessionEvictedKeyPrefix+sessionID).Result()
+		if err == nil && evicted > 0 {
+			return ErrSessionEvicted
+		}
+		return ErrSessionExpired
+	}
+
+	s.markWritten(sessionID, now)
+	return nil
+}
+
+// End terminates the session (implements SessionStore).
+func (s *RedisSessionStore) End(ctx context.Context, userID int64, sessionID string) error {
+	if !s.IsEnabled() || sessionID == "" {
+		return nil
+	}
+
+	s.forget(sessionID)
+
+	pipe := s.redis.TxPipeline()
+	pipe.Del(ctx, sessionKeyPrefix+sessionID)
+	pipe.ZRem(ctx, fmt.Sprintf(sessionUserKeyPrefix, userID), sessionID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		slog.Error("Failed to end session",
+			"error", err,
+			"sid", sessionID,
+		)
+		return fmt.Errorf("failed to end session: %w", err)
+	}
+
+	slog.Debug("Session ended", "user_id", userID, "sid", sessionID)
+	return nil
+}
+
+// EndAll terminates every session of the user (implements SessionStore).
+func (s *RedisSessionStore) EndAll(ctx context.Context, userID int64) error {
+	if !s.IsEnabled() {
+		return nil
+	}
+
+	indexKey := fmt.Sprintf(sessionUserKeyPrefix, userID)
+	sessionIDs, err := s.redis.ZRange(ctx, indexKey, 0, -1).Result()
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	pipe := s.redis.TxPipeline()
+	for _, sessionID := range sessionIDs {
+		s.forget(sessionID)
+		pipe.Del(ctx, sessionKeyPrefix+sessionID)
+	}
+	pipe.Del(ctx, indexKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		slog.Error("Failed to end user sessions",
+			"error", err,
+			"user_id", userID,
+		)
+		return fmt.Errorf("failed to end user sessions: %w", err)
+	}
+
+	slog.Info("All sessions ended", "user_id", userID, "count", len(sessionIDs))
+	return nil
+}
+
+// List returns the live sessions of the user (implements SessionStore).
+func (s *RedisSessionStore) List(ctx context.Context, userID int64) ([]SessionInfo, error) {
+	if !s.IsEnabled() {
+		return nil, nil
+	}
+
+	members, err := s.redis.ZRangeWithScores(ctx, fmt.Sprintf(sessionUserKeyPrefix, userID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	pipe := s.redis.Pipeline()
+	ttls := make([]*redis.DurationCmd, len(members))
+	for i, member := range members {
+		ttls[i] = pipe.PTTL(ctx, sessionKeyPrefix+member.Member.(string))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	now := time.Now()
+	sessions := make([]SessionInfo, 0, len(members))
+	for i, member := range members {
+		ttl := ttls[i].Val()
+		if ttl <= 0 {
+			continue // ended but not yet pruned from the index
+		}
+		sessions = append(sessions, SessionInfo{
+			ID:        member.Member.(string),
+			StartedAt: time.UnixMilli(int64(member.Score)),
+			ExpiresAt: now.Add(ttl),
+		})
+	}
+	return sessions, nil
+}
+
+// =============================================================================
+// Internal Methods
+// =============================================================================
+
+// sessionTTL is the Redis TTL of a session key. The write interval is added to
+// the idle timeout because activity between coalesced writes is not recorded.
+func (s *RedisSessionStore) sessionTTL() time.Duration {
+	if s.config.IdleTimeout > 0 {
+		return s.config.IdleTimeout + s.config.GetActivityWriteInterval()
+	}
+	return s.lifetimeTTL()
+}
+
+func (s *RedisSessionStore) lifetimeTTL() time.Duration {
+	if s.lifetime > 0 {
+		return s.lifetime
+	}
+	return 24 * time.Hour
+}
+
+func (s *RedisSessionStore) shouldWrite(sessionID string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	last, ok := s.lastWrite[sessionID]
+	return !ok || now.Sub(last) >= s.config.GetActivityWriteInterval()
+}
+
+func (s *RedisSessionStore) markWritten(sessionID string, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastWrite[sessionID] = now
+
+	// Drop entries of sessions that have been quiet long enough to need a write anyway
+	if len(s.lastWrite) > sessionActivitySweepSize {
+		for sid, last := range s.lastWrite {
+			if now.Sub(last) >= s.config.GetActivityWriteInterval() {
+				delete(s.lastWrite, sid)
+			}
+		}
+	}
+}
+
+func (s *RedisSessionStore) forget(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.lastWrite, sessionID)
+}
@@ -0,0 +1,222 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"base-service/config"
+	"base-service/internal/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// fakeSessionStore answers Start and Touch with fixed errors and records the
+// sessions it was asked about.
+type fakeSessionStore struct {
+	SessionStore
+	startErr error
+	touchErr error
+	started  []string
+	touched  []string
+}
+
+func (s *fakeSessionStore) IsEnabled() bool { return true }
+
+func (s *fakeSessionStore) Start(ctx context.Context, userID int64, sessionID string) error {
+	if s.startErr != nil {
+		return s.startErr
+	}
+	s.started = append(s.started, sessionID)
+	return nil
+}
+
+func (s *fakeSessionStore) Touch(ctx context.Context, userID int64, sessionID string) error {
+	s.touched = append(s.touched, sessionID)
+	return s.touchErr
+}
+
+func TestSessionLimitAtLogin(t *testing.T) {
+	user := &entity.User{ID: 1, Username: "alice"}
+
+	tests := []struct {
+		name    string
+		auth    Authentication
+		err     error
+		wantErr error
+		wantNew bool
+	}{
+		{name: "new session", auth: NewAuthentication("", AMRPassword), wantNew: true},
+		{name: "refused by the reject policy", auth: NewAuthentication("", AMRPassword), err: ErrTooManySessions, wantErr: ErrTooManySessions},
+		{name: "existing session is kept", auth: Authentication{Time: time.Now(), SessionID: "sid-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeSessionStore{startErr: tt.err}
+			a := newTestAuthMiddleware()
+			a.SetSessionStore(store)
+
+			pair, err := a.GenerateTokenPair(context.Background(), user, tt.auth)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := len(store.started) == 1; got != tt.wantNew {
+				t.Errorf("started sessions = %v, want a new one: %v", store.started, tt.wantNew)
+			}
+			claims, err := a.ValidateAccessToken(pair.AccessToken)
+			if err != nil {
+				t.Fatalf("ValidateAccessToken: %v", err)
+			}
+			if claims.SessionID == "" || (!tt.wantNew && claims.SessionID != tt.auth.SessionID) {
+				t.Errorf("sid = %q", claims.SessionID)
+			}
+		})
+	}
+}
+
+func TestEndedSessionRejected(t *testing.T) {
+	user := &entity.User{ID: 1, Username: "alice"}
+
+	tests := []struct {
+		name      string
+		sessionID string
+		touchErr  error
+		want      int
+		wantCode  string
+	}{
+		{name: "live session", sessionID: "sid-1", want: fiber.StatusNoContent},
+		{name: "evicted by a newer sign-in", sessionID: "sid-1", touchErr: ErrSessionEvicted, want: fiber.StatusUnauthorized, wantCode: SessionEvictedCode},
+		{name: "idle timeout", sessionID: "sid-1", touchErr: ErrSessionExpired, want: fiber.StatusUnauthorized, wantCode: SessionExpiredCode},
+		{name: "token without a session is not checked", touchErr: ErrSessionExpired, want: fiber.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeSessionStore{touchErr: tt.touchErr}
+			a := newTestAuthMiddleware()
+			a.SetSessionStore(store)
+			pair, err := a.GenerateAccessToken(context.Background(), user, Authentication{Time: time.Now(), SessionID: tt.sessionID})
+			if err != nil {
+				t.Fatalf("GenerateAccessToken: %v", err)
+			}
+
+			app := fiber.New()
+			app.Get("/", a.AuthMiddleware(), func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusNoContent)
+			})
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			req.Header.Set(AuthorizationHeader, Prefix+" "+pair.AccessToken)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+			if tt.wantCode != "" {
+				var problem struct {
+					Code string `json:"code"`
+				}
+				if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil {
+					t.Fatalf("decode problem: %v", err)
+				}
+				if problem.Code != tt.wantCode {
+					t.Errorf("code = %q, want %q", problem.Code, tt.wantCode)
+				}
+			}
+			if tt.sessionID == "" && len(store.touched) > 0 {
+				t.Errorf("touched %v for a token without a session", store.touched)
+			}
+		})
+	}
+}
+
+func TestRedisSessionStoreTTL(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   config.SessionConfig
+		lifetime time.Duration
+		want     time.Duration
+	}{
+		{
+			name:   "idle timeout plus the write interval",
+			config: config.SessionConfig{IdleTimeout: 30 * time.Minute, ActivityWriteInterval: 2 * time.Minute},
+			want:   32 * time.Minute,
+		},
+		{
+			name:   "idle timeout plus the default write interval",
+			config: config.SessionConfig{IdleTimeout: 30 * time.Minute},
+			want:   31 * time.Minute,
+		},
+		{
+			name:     "no idle timeout lives as long as the refresh token",
+			lifetime: 7 * 24 * time.Hour,
+			want:     7 * 24 * time.Hour,
+		},
+		{
+			name: "no idle timeout and no lifetime",
+			want: 24 * time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &RedisSessionStore{config: tt.config, lifetime: tt.lifetime}
+			if got := s.sessionTTL(); got != tt.want {
+				t.Errorf("sessionTTL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedisSessionStoreCoalescesWrites(t *testing.T) {
+	s := &RedisSessionStore{
+		config:    config.SessionConfig{ActivityWriteInterval: time.Minute},
+		lastWrite: make(map[string]time.Time),
+	}
+	start := time.Now()
+
+	if !s.shouldWrite("sid-1", start) {
+		t.Fatal("first activity of a session is not written")
+	}
+	s.markWritten("sid-1", start)
+
+	if s.shouldWrite("sid-1", start.Add(30*time.Second)) {
+		t.Error("activity within the write interval is written")
+	}
+	if !s.shouldWrite("sid-1", start.Add(time.Minute)) {
+		t.Error("activity after the write interval is not written")
+	}
+	if !s.shouldWrite("sid-2", start.Add(30*time.Second)) {
+		t.Error("activity of another session is coalesced")
+	}
+
+	s.forget("sid-1")
+	if !s.shouldWrite("sid-1", start.Add(30*time.Second)) {
+		t.Error("activity of an ended session is coalesced")
+	}
+}
+
+func TestRedisSessionStoreDisabled(t *testing.T) {
+	s := NewRedisSessionStore(nil, config.SessionConfig{Enabled: true, MaxConcurrent: 1}, time.Hour)
+	if s.IsEnabled() {
+		t.Fatal("store without Redis is enabled")
+	}
+	ctx := context.Background()
+	if err := s.Start(ctx, 1, "sid-1"); err != nil {
+		t.Errorf("Start: %v", err)
+	}
+	if err := s.Touch(ctx, 1, "sid-1"); err != nil {
+		t.Errorf("Touch: %v", err)
+	}
+	if err := s.End(ctx, 1, "sid-1"); err != nil {
+		t.Errorf("End: %v", err)
+	}
+	if err := s.EndAll(ctx, 1); err != nil {
+		t.Errorf("EndAll: %v", err)
+	}
+}
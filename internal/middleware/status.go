@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+)
+
+// =============================================================================
+// Account Status
+// clean-arch: Lifecycle status enforcement for tokens issued before a status change
+// =============================================================================
+
+// SetUserStatus records the user's lifecycle status in the token cache so
+// AuthMiddleware refuses their tokens with the status error on every instance
+// at once. Active clears it. The record lasts as long as the longest-lived
+// token; no new tokens are issued while the user is not active. Without the
+// token cache, tokens stay valid until they expire.
+func (a *AuthMiddleware) SetUserStatus(ctx context.Context, userID int64, status string) error {
+	if a.tokenCache == nil || !a.tokenCache.IsEnabled() {
+		return nil
+	}
+	if status == entity.StatusActive {
+		status = ""
+	}
+	if err := a.tokenCache.SetUserStatus(ctx, userID, status, a.config.Token.RefreshTokenExp); err != nil {
+		return fmt.Errorf("%w: %w", ErrLogoutFailed, err)
+	}
+	return nil
+}
+
+// checkUserStatus returns the status error of the claims' user, if the user
+// is not active.
+func (a *AuthMiddleware) checkUserStatus(ctx context.Context, claims *Claims) error {
+	if a.tokenCache == nil || !a.tokenCache.IsEnabled() || a.isExternalIssuer(claims.Issuer) {
+		return nil
+	}
+	return domainerrors.UserStatusError(a.tokenCache.GetUserStatus(ctx, claims.UserId))
+}
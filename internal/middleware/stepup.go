@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"base-service/internal/common"
+	"base-service/internal/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// =============================================================================
+// Step-Up Authentication
+// clean-arch: auth_time/acr claims and recent-authentication enforcement
+// =============================================================================
+
+const (
+	// ACRBasic is the "acr" of tokens obtained by login, registration or refresh.
+	ACRBasic = "basic"
+	// ACRElevated is the "acr" of short-lived tokens obtained by re-authentication.
+	ACRElevated = "elevated"
+
+	// AMRPassword is the "amr" value for password authentication.
+	AMRPassword = "pwd"
+	// AMROneTimePassword is the "amr" value for TOTP authentication.
+	AMROneTimePassword = "otp"
+
+	// StepUpRequiredCode is the machine-readable code returned by RequireRecentAuth.
+	StepUpRequiredCode = "STEP_UP_REQUIRED"
+
+	defaultStepUpTokenExp = 5 * time.Minute
+	stepUpReauthURL       = "/api/v1/auth/reauth"
+)
+
+var (
+	ErrStepUpRequired = errors.New("recent authentication required")
+)
+
+// Authentication describes when and how the user last proved their identity.
+type Authentication struct {
+	Time      time.Time
+	Level     string   // acr
+	Methods   []string // amr
+	SessionID string   // sid; empty starts a new session
+}
+
+// NewAuthentication returns an Authentication that happened now.
+func NewAuthentication(level string, methods ...string) Authentication {
+	return Authentication{
+		Time:    time.Now(),
+		Level:   level,
+		Methods: methods,
+	}
+}
+
+// Authentication returns the authentication recorded in the claims.
+func (c *Claims) Authentication() Authentication {
+	auth := Authentication{Level: c.ACR, Methods: c.AMR, SessionID: c.SessionID}
+	if c.AuthTime != nil {
+		auth.Time = c.AuthTime.Time
+	} else if c.IssuedAt != nil {
+		// Tokens issued before auth_time existed: fall back to iat
+		auth.Time = c.IssuedAt.Time
+	}
+	return auth
+}
+
+func (c *Claims) setAuthentication(auth Authentication) {
+	if auth.Time.IsZero() {
+		auth.Time = time.Now()
+	}
+	c.AuthTime = jwt.NewNumericDate(auth.Time)
+	c.ACR = auth.Level
+	c.AMR = auth.Methods
+	c.SessionID = auth.SessionID
+}
+
+// GenerateStepUpToken issues a short-lived elevated access token after the
+// user re-authenticated. No refresh token is issued for elevated sessions.
+func (a *AuthMiddleware) GenerateStepUpToken(ctx context.Context, user *entity.User, auth Authentication) (*TokenPair, error) {
+	expiration := a.config.Token.StepUpTokenExp
+	if expiration <= 0 {
+		expiration = defaultStepUpTokenExp
+	}
+
+	claims := a.newClaims(user.ID, user.Username, AccessTokenType, expiration)
+	claims.Role = user.Role
+	auth.Level = ACRElevated
+	claims.setAuthentication(auth)
+	claims.setTenant(ctx)
+	if err := a.enrichClaims(ctx, claims, user); err != nil {
+		return nil, fmt.Errorf("failed to generate step-up token: %w", err)
+	}
+
+	accessToken, err := a.signToken(claims, a.config.Token.AccessTokenSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate step-up token: %w", err)
+	}
+
+	return &TokenPair{
+		AccessToken: accessToken,
+		ExpiresAt:   claims.ExpiresAt.Time,
+		TokenType:   Prefix,
+	}, nil
+}
+
+// RequireRecentAuth returns a middleware that only lets requests through with
+// an elevated token from a re-authentication within maxAge; a login token
+// does not qualify however recent. Tokens of external issuers qualify only if
+// their issuer is trusted for step-up and their auth_time is within maxAge. It
+// must run after AuthMiddleware.
+// Otherwise it responds 401 with code STEP_UP_REQUIRED, max_age and reauth_url
+// members and an RFC 9470 WWW-Authenticate challenge so clients know to call
+// the re-auth endpoint.
+func (a *AuthMiddleware) RequireRecentAuth(maxAge time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		principal, ok := GetPrincipalFromContext(c)
+		if ok && a.isSteppedUp(principal, maxAge) {
+			return c.Next()
+		}
+
+		slog.Info("Step-up authentication required",
+			"path", c.Path(),
+			"max_age", maxAge,
+		)
+
+		seconds := int64(maxAge.Seconds())
+		c.Set(fiber.HeaderWWWAuthenticate, fmt.Sprintf(
+			`%s error="insufficient_user_authentication", error_description="%s", max_age=%d`,
+			Prefix, ErrStepUpRequired.Error(), seconds,
+		))
+
+		problem := common.NewProblem(c, ErrStepUpRequired)
+		problem.Extensions = map[string]any{
+			"max_age":    seconds,
+			"reauth_url": stepUpReauthURL,
+		}
+		return common.WriteProblem(c, problem)
+	}
+}
+
+// isSteppedUp reports whether principal re-authenticated within maxAge.
+func (a *AuthMiddleware) isSteppedUp(principal *Principal, maxAge time.Duration) bool {
+	if principal == nil || principal.Claims == nil || principal.Claims.AuthTime == nil {
+		return false
+	}
+	if principal.External {
+		issuer, trusted := a.config.Token.FindExternalIssuer(principal.Issuer)
+		if !trusted || !issuer.StepUp {
+			return false
+		}
+	} else if principal.Claims.ACR != ACRElevated {
+		return false
+	}
+	return time.Since(principal.Claims.AuthTime.Time) <= maxAge
+}
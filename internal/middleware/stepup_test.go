@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"base-service/config"
+	"base-service/internal/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testReauthMaxAge = 5 * time.Minute
+
+func newTestAuthMiddleware() *AuthMiddleware {
+	return NewAuthMiddleware(config.MiddlewareConfig{
+		Token: config.TokenConfig{
+			AccessTokenSecret: "access-secret",
+			AccessTokenExp:    time.Hour,
+			ExternalIssuers: []config.ExternalIssuerConfig{
+				{Issuer: "trusted-idp", StepUp: true},
+				{Issuer: "other-idp"},
+			},
+		},
+	}, nil, nil)
+}
+
+// stepUpStatus runs RequireRecentAuth for a request authenticated as principal.
+func stepUpStatus(t *testing.T, a *AuthMiddleware, principal *Principal) int {
+	t.Helper()
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		if principal != nil {
+			SetPrincipalInContext(c, principal)
+		}
+		return c.Next()
+	}, a.RequireRecentAuth(testReauthMaxAge), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return resp.StatusCode
+}
+
+func testClaims(issuer, acr string, authTime *time.Time) *Claims {
+	claims := &Claims{UserId: 1, ACR: acr}
+	claims.Issuer = issuer
+	claims.IssuedAt = jwt.NewNumericDate(time.Now())
+	if authTime != nil {
+		claims.AuthTime = jwt.NewNumericDate(*authTime)
+	}
+	return claims
+}
+
+func TestRequireRecentAuth(t *testing.T) {
+	a := newTestAuthMiddleware()
+	fresh := time.Now().Add(-time.Minute)
+	stale := time.Now().Add(-testReauthMaxAge - time.Minute)
+
+	tests := []struct {
+		name      string
+		principal *Principal
+		want      int
+	}{
+		{"no principal", nil, fiber.StatusUnauthorized},
+		{"fresh elevated token", NewPrincipal(testClaims("", ACRElevated, &fresh), false), fiber.StatusNoContent},
+		{"stale elevated token", NewPrincipal(testClaims("", ACRElevated, &stale), false), fiber.StatusUnauthorized},
+		{"fresh basic token", NewPrincipal(testClaims("", ACRBasic, &fresh), false), fiber.StatusUnauthorized},
+		{"fresh token without acr", NewPrincipal(testClaims("", "", &fresh), false), fiber.StatusUnauthorized},
+		{"elevated token without auth_time", NewPrincipal(testClaims("", ACRElevated, nil), false), fiber.StatusUnauthorized},
+		{"external issuer trusted for step-up", NewPrincipal(testClaims("trusted-idp", "", &fresh), true), fiber.StatusNoContent},
+		{"external issuer trusted for step-up, stale", NewPrincipal(testClaims("trusted-idp", "", &stale), true), fiber.StatusUnauthorized},
+		{"external issuer not trusted for step-up", NewPrincipal(testClaims("other-idp", ACRElevated, &fresh), true), fiber.StatusUnauthorized},
+		{"unknown external issuer", NewPrincipal(testClaims("unknown-idp", ACRElevated, &fresh), true), fiber.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stepUpStatus(t, a, tt.principal); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireRecentAuthMintedTokens(t *testing.T) {
+	a := newTestAuthMiddleware()
+	user := &entity.User{ID: 1, Username: "alice", Role: entity.RoleUser}
+	auth := NewAuthentication("", AMRPassword)
+
+	login, err := a.GenerateAccessToken(context.Background(), user, auth)
+	if err != nil {
+		t.Fatalf("GenerateAccessToken: %v", err)
+	}
+	stepUp, err := a.GenerateStepUpToken(context.Background(), user, auth)
+	if err != nil {
+		t.Fatalf("GenerateStepUpToken: %v", err)
+	}
+
+	app := fiber.New()
+	app.Get("/", a.AuthMiddleware(), a.RequireRecentAuth(testReauthMaxAge), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	tests := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"login token issued just now", login.AccessToken, fiber.StatusUnauthorized},
+		{"step-up token", stepUp.AccessToken, fiber.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			req.Header.Set(AuthorizationHeader, Prefix+" "+tt.token)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+			if tt.want == fiber.StatusUnauthorized && resp.Header.Get(fiber.HeaderWWWAuthenticate) == "" {
+				t.Error("missing WWW-Authenticate challenge")
+			}
+		})
+	}
+}
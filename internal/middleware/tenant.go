@@ -0,0 +1,184 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"base-service/config"
+	"base-service/internal/common"
+	"base-service/internal/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// =============================================================================
+// Multi-Tenancy
+// clean-arch: Resolves the tenant of each request and checks tokens against it
+// =============================================================================
+
+// DefaultTenantHeader is the request header naming the tenant by slug or ID.
+const DefaultTenantHeader = "X-Tenant-ID"
+
+var (
+	ErrTenantRequired = errors.New("tenant required")
+	ErrTenantMismatch = errors.New("token was issued for another tenant")
+)
+
+// TenantMiddleware resolves the tenant of each request and stores it in the
+// request context (see entity.TenantFromContext), where the database pool
+// and the Redis key helpers pick it up. The tenant is taken from, in order:
+//   - the subdomain of <tenant>.<baseDomain>
+//   - the tenant header (X-Tenant-ID by default)
+//   - the "tid" claim of the bearer token, read unverified here and checked
+//     against the resolved tenant by AuthMiddleware
+//   - the configured default tenant
+//
+// Requests naming an unknown tenant are refused with ErrTenantNotFound;
+// requests naming none continue without a tenant (see RequireTenant).
+func TenantMiddleware(tenancyConfig config.TenancyConfig, resolver TenantResolver) fiber.Handler {
+	if !tenancyConfig.Enabled {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	header := tenancyConfig.Header
+	if header == "" {
+		header = DefaultTenantHeader
+	}
+
+	slog.Info("Configuring tenant resolution",
+		"base_domain", tenancyConfig.BaseDomain,
+		"header", header,
+		"default_tenant", tenancyConfig.DefaultTenant,
+	)
+
+	return func(c *fiber.Ctx) error {
+		key, source := requestTenantKey(c, tenancyConfig.BaseDomain, header)
+		if key == "" {
+			key, source = tenancyConfig.DefaultTenant, "default"
+		}
+		if key == "" {
+			return c.Next()
+		}
+
+		tenant, err := resolver.ResolveTenant(c.Context(), key)
+		if err != nil {
+			slog.Warn("Failed to resolve tenant",
+				"path", c.Path(),
+				"tenant", key,
+				"source", source,
+				"error", err,
+			)
+			return common.ResponseProblem(c, err)
+		}
+
+		c.Locals(entity.TenantContextKey, tenant)
+		c.SetUserContext(entity.ContextWithTenant(c.UserContext(), tenant))
+		return c.Next()
+	}
+}
+
+// RequireTenant returns a middleware that refuses requests without a tenant
+// with ErrTenantRequired, so they never run without tenant isolation. It
+// must run after TenantMiddleware and does nothing when tenancy is disabled.
+func RequireTenant(tenancyConfig config.TenancyConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !tenancyConfig.Enabled {
+			return c.Next()
+		}
+		if _, ok := GetTenantFromContext(c); !ok {
+			return common.ResponseProblem(c, ErrTenantRequired)
+		}
+		return c.Next()
+	}
+}
+
+// GetTenantFromContext retrieves the request's tenant from fiber context.
+func GetTenantFromContext(c *fiber.Ctx) (*entity.Tenant, bool) {
+	return entity.TenantFromContext(c.Context())
+}
+
+// TenantKey prefixes a Redis key with the tenant carried by ctx, so tenants
+// never share cache entries, revocations or rate limits. Keys are unchanged
+// without a tenant.
+func TenantKey(ctx context.Context, key string) string {
+	tenant, ok := entity.TenantFromContext(ctx)
+	if !ok {
+		return key
+	}
+	return fmt.Sprintf("tenant:%d:%s", tenant.ID, key)
+}
+
+// requestTenantKey returns the tenant named by the request and where it was found.
+func requestTenantKey(c *fiber.Ctx, baseDomain, header string) (string, string) {
+	if baseDomain != "" {
+		host, _, _ := strings.Cut(strings.ToLower(c.Hostname()), ":")
+		if sub, ok := strings.CutSuffix(host, "."+strings.ToLower(baseDomain)); ok && sub != "" && !strings.Contains(sub, ".") {
+			return sub, "subdomain"
+		}
+	}
+	if key := strings.TrimSpace(c.Get(header)); key != "" {
+		return key, "header"
+	}
+	if tenantID := bearerTenantID(c); tenantID != 0 {
+		return strconv.FormatInt(tenantID, 10), "token"
+	}
+	return "", ""
+}
+
+// bearerTenantID reads the "tid" claim of the bearer token without verifying
+// it. That is no more trusted than the tenant header; AuthMiddleware verifies
+// the token and refuses it for any other tenant, and refreshing reloads the
+// user within the request's tenant.
+func bearerTenantID(c *fiber.Ctx) int64 {
+	scheme, token, ok := strings.Cut(c.Get(AuthorizationHeader), " ")
+	if !ok || !strings.EqualFold(scheme, Prefix) {
+		return 0
+	}
+	claims := &Claims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return 0
+	}
+	return claims.TenantID
+}
+
+// setTenant records the tenant of ctx in the claims of a new token.
+func (c *Claims) setTenant(ctx context.Context) {
+	if tenant, ok := entity.TenantFromContext(ctx); ok {
+		c.TenantID = tenant.ID
+	}
+}
+
+// tenantContext returns a context carrying the tenant of the claims, for
+// token checks that run outside of a request.
+func tenantContext(claims *Claims) context.Context {
+	ctx := context.Background()
+	if claims.TenantID == 0 {
+		return ctx
+	}
+	return entity.ContextWithTenant(ctx, &entity.Tenant{ID: claims.TenantID})
+}
+
+// checkTenant refuses tokens issued for another tenant than the request's.
+// Tokens of external issuers carry no tenant and are accepted for any.
+func (a *AuthMiddleware) checkTenant(ctx context.Context, claims *Claims) error {
+	tenant, ok := entity.TenantFromContext(ctx)
+	if !ok || a.isExternalIssuer(claims.Issuer) {
+		return nil
+	}
+	if claims.TenantID != tenant.ID {
+		slog.Warn("Rejected token of another tenant",
+			"tenant_id", tenant.ID,
+			"token_tenant_id", claims.TenantID,
+			"user_id", claims.UserId,
+		)
+		return ErrTenantMismatch
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"base-service/config"
+	"base-service/internal/common"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// =============================================================================
+// Subscription Tiers
+// clean-arch: "ext.tier" claim checks for feature gating and per-user rate limits
+// =============================================================================
+
+// TierClaimsNamespace is the custom claims namespace of the caller's tier.
+const TierClaimsNamespace = "tier"
+
+var (
+	ErrFeatureUnavailable = errors.New("feature not available in tier")
+)
+
+// TierClaims are the custom claims of the caller's current tier, added to
+// access tokens by the tier module's ClaimsEnricher. Tokens reflect tier
+// changes from their next refresh.
+type TierClaims struct {
+	Code      string   `json:"code"`
+	RateLimit int      `json:"rate_limit,omitempty"`
+	Features  []string `json:"features,omitempty"`
+}
+
+// RequireFeature returns a middleware that only lets callers whose tier
+// enables feature through. Tier claims of external issuers are ignored. It
+// must run after AuthMiddleware.
+func RequireFeature(feature string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		tier, ok := LocalCustomClaim[TierClaims](c, TierClaimsNamespace)
+		if !ok || !slices.Contains(tier.Features, feature) {
+			slog.Warn("Rejected request for feature outside tier",
+				"path", c.Path(),
+				"feature", feature,
+				"tier", tier.Code,
+			)
+			return common.ResponseProblem(c, ErrFeatureUnavailable)
+		}
+		return c.Next()
+	}
+}
+
+// TierRateLimitFilter limits every caller whose tier has a rate limit to that
+// many requests per window. It must run after AuthMiddleware; callers without
+// a tier limit, external principals included, are only subject to the
+// general per-IP limit.
+// If redisClient is provided, uses distributed rate limiting (shared across servers)
+func TierRateLimitFilter(rateLimitConfig config.RateLimitConfig, rediscf *config.RedisConfig, redisClient *goredis.Client) fiber.Handler {
+	if !rateLimitConfig.TierEnabled {
+		slog.Info("Tier rate limiting is disabled")
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	expiration := rateLimitConfig.Expiration
+	if expiration == 0 {
+		expiration = 1 * time.Minute
+	}
+
+	// Configure storage (Redis for distributed, memory for single-server)
+	var storage fiber.Storage
+	storageType := "memory"
+	if redisClient != nil {
+		storage, storageType = NewRedisStorage(redisClient, rediscf, rateLimitConfig)
+	}
+
+	slog.Info("Configuring tier rate limiting middleware",
+		"expiration", expiration,
+		"storage", storageType,
+	)
+
+	// One limiter per distinct limit, created on first use
+	var mu sync.Mutex
+	limiters := make(map[int]fiber.Handler)
+	limiterFor := func(max int) fiber.Handler {
+		mu.Lock()
+		defer mu.Unlock()
+		if handler, ok := limiters[max]; ok {
+			return handler
+		}
+		handler := limiter.New(limiter.Config{
+			Storage:                storage,
+			Max:                    max,
+			Expiration:             expiration,
+			SkipFailedRequests:     rateLimitConfig.SkipFailedReq,
+			SkipSuccessfulRequests: rateLimitConfig.SkipSuccessReq,
+			KeyGenerator: func(c *fiber.Ctx) string {
+				// The limit is part of the key so a tier change starts a new window
+				principal, _ := GetPrincipalFromContext(c)
+				return TenantKey(c.Context(), fmt.Sprintf("tier:%d:%s:%s", max, principal.Issuer, principal.Subject))
+			},
+			LimitReached: func(c *fiber.Ctx) error {
+				slog.Warn("Tier rate limit exceeded",
+					"ip", c.IP(),
+					"path", c.Path(),
+					"method", c.Method(),
+					"limit", max,
+				)
+				return common.ResponseProblem(c, ErrRateLimitExceeded)
+			},
+		})
+		limiters[max] = handler
+		return handler
+	}
+
+	return func(c *fiber.Ctx) error {
+		tier, ok := LocalCustomClaim[TierClaims](c, TierClaimsNamespace)
+		if !ok || tier.RateLimit <= 0 {
+			return c.Next()
+		}
+		return limiterFor(tier.RateLimit)(c)
+	}
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"base-service/config"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRequireFeature(t *testing.T) {
+	pro := TierClaims{Code: "pro", Features: []string{"exports", "api"}}
+
+	tests := []struct {
+		name     string
+		tier     any
+		external bool
+		want     int
+	}{
+		{"feature of the tier", pro, false, fiber.StatusNoContent},
+		{"feature outside the tier", TierClaims{Code: "free"}, false, fiber.StatusForbidden},
+		{"no tier", nil, false, fiber.StatusForbidden},
+		{"tier claims of an external issuer", pro, true, fiber.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", authenticateAs(t, TierClaimsNamespace, tt.tier, tt.external), RequireFeature("exports"), func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusNoContent)
+			})
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestTierRateLimitFilter(t *testing.T) {
+	limited := TierClaims{Code: "free", RateLimit: 1}
+
+	tests := []struct {
+		name     string
+		tier     any
+		external bool
+		want     int
+	}{
+		{"tier rate limit", limited, false, fiber.StatusTooManyRequests},
+		{"tier without a rate limit", TierClaims{Code: "pro"}, false, fiber.StatusNoContent},
+		{"tier claims of an external issuer", limited, true, fiber.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := TierRateLimitFilter(config.RateLimitConfig{TierEnabled: true, Expiration: time.Minute}, nil, nil)
+			app := fiber.New()
+			app.Get("/", authenticateAs(t, TierClaimsNamespace, tt.tier, tt.external), filter, func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusNoContent)
+			})
+
+			var status int
+			for range 2 {
+				resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+				if err != nil {
+					t.Fatalf("request failed: %v", err)
+				}
+				status = resp.StatusCode
+			}
+			if status != tt.want {
+				t.Errorf("second request status = %d, want %d", status, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ const (
 	GET_METHOD    = "GET"
 	POST_METHOD   = "POST"
 	PUT_METHOD    = "PUT"
+	PATCH_METHOD  = "PATCH"
 	DELETE_METHOD = "DELETE"
 )
 
@@ -21,6 +22,10 @@ func PUT(app fiber.Router, relativePath string, f fiber.Handler) {
 	route(app, PUT_METHOD, relativePath, f)
 }
 
+func PATCH(app fiber.Router, relativePath string, f fiber.Handler) {
+	route(app, PATCH_METHOD, relativePath, f)
+}
+
 func DELETE(app fiber.Router, relativePath string, f fiber.Handler) {
 	route(app, DELETE_METHOD, relativePath, f)
 }
@@ -33,6 +38,8 @@ func route(app fiber.Router, method string, relativePath string, f fiber.Handler
 		app.Get(relativePath, f)
 	case PUT_METHOD:
 		app.Put(relativePath, f)
+	case PATCH_METHOD:
+		app.Patch(relativePath, f)
 	case DELETE_METHOD:
 		app.Delete(relativePath, f)
 	}
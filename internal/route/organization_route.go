@@ -0,0 +1,70 @@
+package route
+
+import (
+	"log/slog"
+
+	"base-service/config"
+	adapterAuth "base-service/internal/adapter/auth"
+	adapterHandler "base-service/internal/adapter/http/handler"
+	adapterNotification "base-service/internal/adapter/notification"
+	adapterRepository "base-service/internal/adapter/repository"
+	"base-service/internal/domain/entity"
+	"base-service/internal/infra"
+	"base-service/internal/middleware"
+	"base-service/internal/usecase/export"
+	"base-service/internal/usecase/organization"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SetupOrganizationRoute sets up organization, membership and invitation
+// routes. Routes under /organizations/current act on the caller's active
+// organization, taken from the "ext.org" token claim.
+func SetupOrganizationRoute(r fiber.Router, authHandler *middleware.AuthMiddleware, db *pgxpool.Pool, conf *config.Config, notifier infra.NotificationSender, exporters *export.Registry) {
+	// === Infrastructure Layer ===
+	orgRepo := adapterRepository.NewOrganizationRepository(db)
+	userRepo := adapterRepository.NewUserRepository(db)
+
+	// === Adapter Layer ===
+	authAdapter := adapterAuth.NewAuthAdapter(authHandler)
+	mailer := adapterNotification.NewEmailAdapter(notifier)
+
+	// === Application Layer ===
+	orgUseCase := organization.NewOrganizationUseCase(orgRepo, userRepo, authAdapter, authAdapter, mailer, organization.Options{
+		InvitationExpiry: conf.Organization.InvitationExpiry,
+		InvitationURL:    conf.Organization.InvitationURL,
+	})
+	if err := authHandler.AddClaimsEnricher(adapterAuth.NewOrgClaimsEnricher(orgUseCase)); err != nil {
+		slog.Error("Failed to register organization claims", "error", err)
+	}
+	exporters.Register(organization.NewOrganizationExporter(orgRepo))
+
+	// === Interface Layer ===
+	orgHTTPHandler := adapterHandler.NewOrganizationHandler(orgUseCase, authHandler)
+
+	// === Routes ===
+	// Organizations of the caller (protected)
+	orgsGroup := r.Group("/organizations", authHandler.AuthMiddleware())
+	GET(orgsGroup, "", orgHTTPHandler.ListOrganizations)
+	POST(orgsGroup, "", orgHTTPHandler.CreateOrganization)
+	POST(orgsGroup, "/:id/switch", orgHTTPHandler.SwitchOrganization)
+
+	// Invitations: accepting needs the invited account, declining only the token
+	acceptGroup := r.Group("/invitations/accept", authHandler.AuthMiddleware())
+	POST(acceptGroup, "", orgHTTPHandler.AcceptInvitation)
+	POST(r, "/invitations/decline", orgHTTPHandler.DeclineInvitation)
+
+	// Active organization (org-scoped roles; member changes are checked
+	// against the caller's current role by the use case)
+	currentGroup := orgsGroup.Group("/current", authHandler.RequireOrgRole(entity.OrgRoleMember))
+	GET(currentGroup, "", orgHTTPHandler.GetOrganization)
+	GET(currentGroup, "/members", orgHTTPHandler.ListMembers)
+	PUT(currentGroup, "/members/:id", orgHTTPHandler.ChangeMemberRole)
+	DELETE(currentGroup, "/members/:id", orgHTTPHandler.RemoveMember)
+
+	invitationGroup := currentGroup.Group("/invitations", authHandler.RequireOrgRole(entity.OrgRoleAdmin))
+	GET(invitationGroup, "", orgHTTPHandler.ListInvitations)
+	POST(invitationGroup, "", orgHTTPHandler.InviteMember)
+	DELETE(invitationGroup, "/:id", orgHTTPHandler.RevokeInvitation)
+}
@@ -1,31 +1,70 @@
 package route
 
 import (
+	"context"
+
 	"base-service/config"
+	"base-service/internal/common"
 	"base-service/internal/infra"
 	"base-service/internal/middleware"
-
-	"github.com/jackc/pgx/v5/pgxpool"
+	"base-service/internal/usecase/account"
+	"base-service/internal/usecase/export"
 )
 
-func InitRoute(cf *config.Config, pool *pgxpool.Pool, redisClient *infra.RedisClient) {
+// InitRoute wires the modules and starts the HTTP server. system is the
+// client of the database system role, nil when none is configured.
+func InitRoute(cf *config.Config, db *infra.DatabaseClient, system *infra.DatabaseClient, redisClient *infra.RedisClient, storage infra.ObjectStorage, notifier infra.NotificationSender) {
+	pool := db.GetPool()
 	httpClient := infra.HttpServer{
 		AppName: cf.Server.Http.AppName,
 		Conf:    &cf.Server.Http,
 		CORS:    &cf.Middleware,
 		Redis:   redisClient.Redis(),
 		RedisCf: &cf.Redis,
+		Tenant:  newTenantMiddleware(cf, pool, redisClient),
 	}
 	httpClient.InitHttpServer()
+	common.SetCursorSecret([]byte(cf.Pagination.CursorSecret))
 	jwtCache := middleware.NewJWTCache(redisClient.Redis(), true)
 	auth := middleware.NewAuthenHandler(cf.Middleware, jwtCache)
 
+	// Trust tokens issued by other services (verified against their JWKS)
+	if len(cf.Middleware.Token.ExternalIssuers) > 0 {
+		keyResolver := infra.NewJWKSKeyResolver(cf.Middleware.Token.ExternalIssuers, infra.NewHTTPClient(0))
+		keyResolver.Start(context.Background())
+		defer keyResolver.Close()
+		auth.SetKeyResolver(keyResolver)
+	}
+
+	// Idle timeout and concurrent session limits
+	if cf.Middleware.Session.Enabled {
+		sessionStore := middleware.NewRedisSessionStore(redisClient.Redis(), cf.Middleware.Session, cf.Middleware.Token.RefreshTokenExp)
+		auth.SetSessionStore(sessionStore)
+	}
+
 	// Health and metrics endpoints (no auth required)
 	api := httpClient.App().Group("/api")
 	SetupHealthRoute(api, pool, redisClient.Redis())
+	SetupFileRoute(api, storage)
+
+	// Modules register the user data they hold for personal data exports
+	exporters := export.NewRegistry()
+	exportUseCase := newExportUseCase(cf, pool, storage, notifier, exporters)
+
+	// Modules register the user data they delete when accounts are purged
+	purgers := account.NewRegistry()
+	accountUseCase := newAccountUseCase(cf, pool, system, auth, notifier, purgers)
+	purgeJob := account.NewPurgeJob(accountUseCase, cf.Account.PurgeInterval)
+	purgeJob.Start(context.Background())
+	defer purgeJob.Close()
+
+	// Modules publish domain events here and subscribe to those of others
+	events := infra.NewEventBus()
 
-	apiv1 := api.Group("/v1")
-	SetupUserRoute(apiv1, auth, pool, cf, redisClient.Redis())
+	// API routes never run without a tenant when tenancy is enabled
+	apiv1 := api.Group("/v1", middleware.RequireTenant(cf.Tenancy))
+	SetupUserRoute(apiv1, auth, db, cf, redisClient.Redis(), redisClient, storage, exporters, exportUseCase, purgers, accountUseCase, events)
+	SetupOrganizationRoute(apiv1, auth, pool, cf, notifier, exporters)
 
 	// Print only API routes (not middleware routes)
 	httpClient.Start()
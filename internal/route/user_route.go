@@ -1,13 +1,25 @@
 package route
 
 import (
+	"log/slog"
+
 	"base-service/config"
 	adapterAuth "base-service/internal/adapter/auth"
 	adapterHandler "base-service/internal/adapter/http/handler"
+	adapterNotification "base-service/internal/adapter/notification"
 	adapterRepository "base-service/internal/adapter/repository"
+	"base-service/internal/domain/entity"
+	"base-service/internal/infra"
 	"base-service/internal/middleware"
+	"base-service/internal/usecase/account"
 	"base-service/internal/usecase/auth"
+	"base-service/internal/usecase/export"
+	"base-service/internal/usecase/port"
+	"base-service/internal/usecase/settings"
+	"base-service/internal/usecase/tenant"
+	"base-service/internal/usecase/tier"
 	"base-service/internal/usecase/user"
+	"base-service/internal/usecase/userimport"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -15,10 +27,22 @@ import (
 )
 
 // SetupUserRoute sets up user and auth routes using clean architecture.
-func SetupUserRoute(r fiber.Router, authHandler *middleware.AuthMiddleware, db *pgxpool.Pool, conf *config.Config, redisClient *redis.Client) {
+func SetupUserRoute(r fiber.Router, authHandler *middleware.AuthMiddleware, database *infra.DatabaseClient, conf *config.Config, redisClient *redis.Client, cache infra.CacheStore, storage infra.ObjectStorage, exporters *export.Registry, exportUseCase port.ExportUseCase, purgers *account.Registry, accountUseCase port.AccountUseCase, events *infra.EventBus) {
 	// === Infrastructure Layer ===
 	// Create repository adapters (implements domain interfaces)
+	db := database.GetPool()
 	userRepo := adapterRepository.NewUserRepository(db)
+	totpRepo := adapterRepository.NewTOTPRepository(db)
+	tierRepo := adapterRepository.NewTierRepository(db)
+	usernameRepo := adapterRepository.NewUsernameRepository(db)
+	settingsRepo := adapterRepository.NewCachedSettingsRepository(adapterRepository.NewSettingsRepository(db), cache, conf.Settings.CacheTTL)
+	avatarStore := user.NewAvatarStore(storage, infra.NewImageProcessor(conf.Avatar.MaxDimension), user.AvatarOptions{
+		Bucket:        conf.Avatar.Bucket,
+		MaxSize:       conf.Avatar.MaxSize,
+		Size:          conf.Avatar.Size,
+		ThumbnailSize: conf.Avatar.ThumbnailSize,
+		URLExpiry:     conf.Avatar.URLExpiry,
+	})
 
 	// === Adapter Layer ===
 	// Create auth adapter (wraps middleware for use case layer)
@@ -26,13 +50,46 @@ func SetupUserRoute(r fiber.Router, authHandler *middleware.AuthMiddleware, db *
 
 	// === Application Layer ===
 	// Create use cases with their dependencies
-	authUseCase := auth.NewAuthUseCase(userRepo, authAdapter, authAdapter)
-	userUseCase := user.NewUserUseCase(userRepo)
+	authUseCase := auth.NewAuthUseCase(userRepo, totpRepo, usernameRepo, authAdapter, authAdapter, authAdapter)
+	userUseCase := user.NewUserUseCase(userRepo, avatarStore)
+	usernameUseCase := user.NewUsernameUseCase(userRepo, usernameRepo, authAdapter, authAdapter, events, user.UsernameOptions{
+		ChangeCooldown: conf.Username.ChangeCooldown,
+		Reservation:    conf.Username.Reservation,
+	})
+	adminUseCase := user.NewAdminUserUseCase(userRepo, authAdapter, authAdapter, events, conf.Account.DeletedRetention)
+	importUseCase := NewUserImportUseCase(conf, database, storage, authHandler)
+	tierUseCase := tier.NewTierUseCase(tierRepo, userRepo)
+	if err := authHandler.AddClaimsEnricher(adapterAuth.NewTierClaimsEnricher(tierUseCase)); err != nil {
+		slog.Error("Failed to register tier claims", "error", err)
+	}
+	settingsSchema := settings.NewSchema()
+	if err := settingsSchema.Register(settings.UserDefinitions()...); err != nil {
+		slog.Error("Failed to register user settings", "error", err)
+	}
+	settingsUseCase := settings.NewSettingsUseCase(settingsRepo, settingsSchema, events)
+	exporters.Register(
+		user.NewProfileExporter(userRepo, avatarStore),
+		user.NewUsernameHistoryExporter(usernameRepo),
+		auth.NewSecurityExporter(totpRepo, authAdapter),
+		tier.NewTierExporter(tierRepo),
+		settings.NewSettingsExporter(settingsRepo),
+	)
+	purgers.Register(
+		user.NewProfilePurger(userRepo, avatarStore),
+		auth.NewSecurityPurger(totpRepo, authAdapter),
+		export.NewArchivePurger(storage, conf.Export.Bucket),
+	)
 
 	// === Interface Layer ===
 	// Create HTTP handlers
 	authHTTPHandler := adapterHandler.NewAuthHandler(authUseCase, authHandler)
-	userHTTPHandler := adapterHandler.NewUserHandler(userUseCase, authHandler)
+	userHTTPHandler := adapterHandler.NewUserHandler(userUseCase, tierUseCase, usernameUseCase, authHandler)
+	adminHTTPHandler := adapterHandler.NewAdminHandler(adminUseCase, authHandler)
+	importHTTPHandler := adapterHandler.NewImportHandler(importUseCase, authHandler)
+	tierHTTPHandler := adapterHandler.NewTierHandler(tierUseCase, authHandler)
+	settingsHTTPHandler := adapterHandler.NewSettingsHandler(settingsUseCase, authHandler)
+	exportHTTPHandler := adapterHandler.NewExportHandler(exportUseCase, authHandler)
+	accountHTTPHandler := adapterHandler.NewAccountHandler(accountUseCase, authHandler)
 
 	// === Routes ===
 	// Auth routes (public)
@@ -50,10 +107,129 @@ func SetupUserRoute(r fiber.Router, authHandler *middleware.AuthMiddleware, db *
 	POST(authGroup, "/refresh", authHTTPHandler.RefreshToken)
 	POST(authGroup, "/logout", authHTTPHandler.Logout)
 
+	// Re-authentication (step-up) requires a signed-in user
+	reauthGroup := authGroup.Group("/reauth", authHandler.AuthMiddleware())
+	POST(reauthGroup, "", authHTTPHandler.Reauthenticate)
+
+	// Per-user limits of the caller's tier, for routes behind AuthMiddleware
+	var tierRedis *redis.Client
+	if conf.Middleware.RateLimit.UseRedis && redisClient != nil {
+		tierRedis = redisClient
+	}
+	tierRateLimit := middleware.TierRateLimitFilter(conf.Middleware.RateLimit, &conf.Redis, tierRedis)
+
 	// User routes (protected)
 	groupUser := r.Group("/user")
-	protectedRoute := groupUser.Use(authHandler.AuthMiddleware())
+	protectedRoute := groupUser.Use(authHandler.AuthMiddleware(), tierRateLimit)
 	GET(protectedRoute, "profile", userHTTPHandler.Profile)
+	PATCH(protectedRoute, "profile", userHTTPHandler.PatchProfile)
+	GET(protectedRoute, "profiles/:username", userHTTPHandler.PublicProfile)
+	PUT(protectedRoute, "username", userHTTPHandler.ChangeUsername)
+	GET(protectedRoute, "username/history", userHTTPHandler.UsernameHistory)
+	POST(protectedRoute, "avatar", userHTTPHandler.UploadAvatar)
+	DELETE(protectedRoute, "avatar", userHTTPHandler.DeleteAvatar)
+	POST(protectedRoute, "export", exportHTTPHandler.RequestExport)
+	GET(protectedRoute, "settings", settingsHTTPHandler.GetSettings)
+	PUT(protectedRoute, "settings", settingsHTTPHandler.ReplaceSettings)
+	PATCH(protectedRoute, "settings", settingsHTTPHandler.PatchSettings)
+
+	// Account deletion also requires a recent re-authentication
+	accountGroup := protectedRoute.Group("/account", authHandler.RequireRecentAuth(conf.Account.ReauthMaxAge))
+	DELETE(accountGroup, "", accountHTTPHandler.DeleteAccount)
+
+	// Admin routes (protected, admin role only)
+	adminGroup := r.Group("/admin", authHandler.AuthMiddleware(), middleware.RequireRole(entity.RoleAdmin))
+	GET(adminGroup, "/users", adminHTTPHandler.ListUsers)
+	GET(adminGroup, "/users/search", adminHTTPHandler.SearchUsers) // before /users/:id
+	// Bulk imports, also before /users/:id
+	GET(adminGroup, "/users/imports", importHTTPHandler.ListImports)
+	POST(adminGroup, "/users/imports", importHTTPHandler.CreateImport)
+	GET(adminGroup, "/users/imports/:id", importHTTPHandler.GetImport)
+	GET(adminGroup, "/users/imports/:id/errors", importHTTPHandler.ListImportErrors)
+	POST(adminGroup, "/users/imports/:id/resume", importHTTPHandler.ResumeImport)
+	GET(adminGroup, "/users/:id", adminHTTPHandler.GetUser)
+	PUT(adminGroup, "/users/:id", adminHTTPHandler.UpdateUser)
+	DELETE(adminGroup, "/users/:id", adminHTTPHandler.DeleteUser)
+	POST(adminGroup, "/users/:id/restore", adminHTTPHandler.RestoreUser)
+	POST(adminGroup, "/users/:id/logout", adminHTTPHandler.ForceLogout)
+	POST(adminGroup, "/users/:id/suspend", adminHTTPHandler.SuspendUser)
+	POST(adminGroup, "/users/:id/ban", adminHTTPHandler.BanUser)
+	POST(adminGroup, "/users/:id/reinstate", adminHTTPHandler.ReinstateUser)
+	GET(adminGroup, "/tiers", tierHTTPHandler.ListTiers)
+	GET(adminGroup, "/users/:id/tiers", tierHTTPHandler.ListUserTiers)
+	POST(adminGroup, "/users/:id/tiers", tierHTTPHandler.AssignTier)
+	DELETE(adminGroup, "/users/:id/tiers/current", tierHTTPHandler.EndTier)
+}
+
+// newExportUseCase creates the personal data export use case. Archives are
+// built from the exporters every module registers.
+func newExportUseCase(conf *config.Config, db *pgxpool.Pool, storage infra.ObjectStorage, notifier infra.NotificationSender, exporters *export.Registry) port.ExportUseCase {
+	userRepo := adapterRepository.NewUserRepository(db)
+	mailer := adapterNotification.NewEmailAdapter(notifier)
+
+	return export.NewExportUseCase(userRepo, exporters, storage, mailer, export.Options{
+		Bucket:     conf.Export.Bucket,
+		LinkExpiry: conf.Export.LinkExpiry,
+		Timeout:    conf.Export.Timeout,
+	})
+}
+
+// NewUserImportUseCase creates the bulk user import use case. It is exported
+// for the import command, which runs imports without the HTTP server.
+func NewUserImportUseCase(conf *config.Config, db *infra.DatabaseClient, storage infra.ObjectStorage, authHandler *middleware.AuthMiddleware) port.UserImportUseCase {
+	importRepo := adapterRepository.NewUserImportRepository(db)
+	authAdapter := adapterAuth.NewAuthAdapter(authHandler)
+
+	return userimport.NewUserImportUseCase(importRepo, storage, authAdapter, userimport.Options{
+		Bucket:     conf.Import.Bucket,
+		BatchSize:  conf.Import.BatchSize,
+		StaleAfter: conf.Import.StaleAfter,
+		Timeout:    conf.Import.Timeout,
+	})
+}
+
+// newAccountUseCase creates the account deletion use case. Purges run the
+// purgers every module registers. With tenancy enabled, the accounts due for
+// purge are listed across tenants by the system role; without one, no
+// account is purged.
+func newAccountUseCase(conf *config.Config, db *pgxpool.Pool, system *infra.DatabaseClient, authHandler *middleware.AuthMiddleware, notifier infra.NotificationSender, purgers *account.Registry) port.AccountUseCase {
+	userRepo := adapterRepository.NewUserRepository(db)
+	authAdapter := adapterAuth.NewAuthAdapter(authHandler)
+	mailer := adapterNotification.NewEmailAdapter(notifier)
+
+	var dueAccounts account.DueAccountLister = userRepo
+	if conf.Tenancy.Enabled {
+		if system != nil {
+			dueAccounts = adapterRepository.NewUserRepository(system.GetPool())
+		} else {
+			slog.Warn("Account purge needs database.systemUserName with tenancy enabled; deleted accounts are not purged")
+		}
+	}
+
+	return account.NewAccountUseCase(userRepo, dueAccounts, purgers, authAdapter, mailer, account.Options{
+		GracePeriod:     conf.Account.GracePeriod,
+		PurgeBatchSize:  conf.Account.PurgeBatchSize,
+		PurgeRetryDelay: conf.Account.PurgeRetryDelay,
+		Tenancy:         conf.Tenancy.Enabled,
+	})
+}
+
+// newTenantMiddleware creates the middleware resolving the tenant of each
+// request. Looked up tenants are cached in Redis.
+func newTenantMiddleware(conf *config.Config, db *pgxpool.Pool, cache infra.CacheStore) fiber.Handler {
+	tenantRepo := adapterRepository.NewCachedTenantRepository(adapterRepository.NewTenantRepository(db), cache, conf.Tenancy.CacheTTL)
+	return middleware.TenantMiddleware(conf.Tenancy, tenant.NewTenantUseCase(tenantRepo))
+}
+
+// SetupFileRoute serves signed URLs of the local object storage. Other
+// storage drivers sign URLs pointing to the provider instead.
+func SetupFileRoute(r fiber.Router, storage infra.ObjectStorage) {
+	local, ok := storage.(*infra.LocalObjectStorage)
+	if !ok {
+		return
+	}
+	fileHandler := adapterHandler.NewFileHandler(local)
+	GET(r, "/files/:bucket/*", fileHandler.Download)
 }
 
 // SetupHealthRoute sets up health and metrics routes using clean architecture.
@@ -65,4 +241,7 @@ func SetupHealthRoute(r fiber.Router, db *pgxpool.Pool, redisClient *redis.Clien
 
 	metricsHandler := adapterHandler.NewMetricsHandler(db, redisClient)
 	GET(r, "/metrics", metricsHandler.Metrics)
+
+	errorCatalogHandler := adapterHandler.NewErrorCatalogHandler()
+	GET(r, "/errors", errorCatalogHandler.ErrorCatalog)
 }
@@ -0,0 +1,222 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+const (
+	defaultGracePeriod    = 30 * 24 * time.Hour
+	defaultPurgeBatchSize = 100
+	defaultPurgeRetry     = time.Hour
+)
+
+// SessionRevoker defines the interface for logging a user out everywhere.
+type SessionRevoker interface {
+	RevokeUserSessions(ctx context.Context, userID int64) error
+}
+
+// DueAccountLister defines the interface for listing the accounts due for
+// purge of every tenant. With tenancy enabled it must bypass row-level
+// security, see infra.NewSystemDatabaseClient.
+type DueAccountLister interface {
+	ListDueForPurge(ctx context.Context, limit int) ([]repository.DueAccount, error)
+}
+
+// Mailer defines the interface for sending plain text emails.
+type Mailer interface {
+	SendEmail(ctx context.Context, to, subject, body string) error
+}
+
+// Options configures account deletion. Zero values use the defaults.
+type Options struct {
+	// GracePeriod is how long a deleted account can be restored by logging in
+	GracePeriod time.Duration
+	// PurgeBatchSize limits the accounts purged by one PurgeDue call
+	PurgeBatchSize int
+	// PurgeRetryDelay is how long a failed purge waits before it is retried
+	PurgeRetryDelay time.Duration
+	// Tenancy runs each purge in the tenant of its account
+	Tenancy bool
+}
+
+// Registry collects the DataPurgers of all modules. Modules register theirs
+// while being wired; purges run every purger registered at that time.
+type Registry struct {
+	mu      sync.RWMutex
+	purgers []port.DataPurger
+}
+
+// NewRegistry creates an empty purger registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds purgers to every future purge.
+func (r *Registry) Register(purgers ...port.DataPurger) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.purgers = append(r.purgers, purgers...)
+}
+
+// Purgers returns the registered purgers in registration order.
+func (r *Registry) Purgers() []port.DataPurger {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]port.DataPurger(nil), r.purgers...)
+}
+
+type accountUseCase struct {
+	userRepo    repository.UserRepository
+	dueAccounts DueAccountLister
+	purgers     *Registry
+	revoker     SessionRevoker
+	mailer      Mailer
+	opts        Options
+}
+
+// NewAccountUseCase creates a new account use case.
+func NewAccountUseCase(userRepo repository.UserRepository, dueAccounts DueAccountLister, purgers *Registry, revoker SessionRevoker, mailer Mailer, opts Options) port.AccountUseCase {
+	if opts.GracePeriod <= 0 {
+		opts.GracePeriod = defaultGracePeriod
+	}
+	if opts.PurgeBatchSize <= 0 {
+		opts.PurgeBatchSize = defaultPurgeBatchSize
+	}
+	if opts.PurgeRetryDelay <= 0 {
+		opts.PurgeRetryDelay = defaultPurgeRetry
+	}
+	return &accountUseCase{
+		userRepo:    userRepo,
+		dueAccounts: dueAccounts,
+		purgers:     purgers,
+		revoker:     revoker,
+		mailer:      mailer,
+		opts:        opts,
+	}
+}
+
+// DeleteAccount soft-deletes the user's own account, logs them out everywhere
+// and schedules the purge after the grace period. The confirmation email is
+// best effort: the account is deleted either way.
+func (uc *accountUseCase) DeleteAccount(ctx context.Context, userID int64) (time.Time, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return time.Time{}, domainerrors.ErrUserNotFound
+	}
+
+	purgeAt := time.Now().Add(uc.opts.GracePeriod)
+	if err := uc.userRepo.Delete(ctx, repository.UserDeletion{
+		UserID:      userID,
+		PurgeAt:     &purgeAt,
+		SelfService: true,
+	}); err != nil {
+		return time.Time{}, err
+	}
+	if err := uc.revoker.RevokeUserSessions(ctx, userID); err != nil {
+		return time.Time{}, err
+	}
+
+	slog.Info("Account deleted by its owner",
+		"user_id", userID,
+		"purge_at", purgeAt,
+	)
+	if err := uc.mailer.SendEmail(ctx, user.Email, "Your account has been deleted", deletionEmailBody(user, purgeAt)); err != nil {
+		slog.Error("Failed to send account deletion email",
+			"error", err,
+			"user_id", userID,
+		)
+	}
+	return purgeAt, nil
+}
+
+// PurgeDue purges up to one batch of accounts whose purge time has passed,
+// each in its own tenant. A failed account is logged and its purge deferred by
+// PurgeRetryDelay, so accounts that keep failing do not fill every batch and
+// hold up the ones listed after them.
+func (uc *accountUseCase) PurgeDue(ctx context.Context) (int, error) {
+	accounts, err := uc.dueAccounts.ListDueForPurge(ctx, uc.opts.PurgeBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list accounts due for purge: %w", err)
+	}
+
+	purged := 0
+	for _, account := range accounts {
+		if err := ctx.Err(); err != nil {
+			return purged, err
+		}
+		purgeCtx := ctx
+		if uc.opts.Tenancy {
+			purgeCtx = entity.ContextWithTenant(ctx, &entity.Tenant{ID: account.TenantID})
+		}
+		if err := uc.purge(purgeCtx, account.UserID); err != nil {
+			slog.Error("Failed to purge account",
+				"error", err,
+				"user_id", account.UserID,
+				"tenant_id", account.TenantID,
+			)
+			uc.deferPurge(purgeCtx, account)
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// purge runs every module's purger, then deletes the users row. The row goes
+// last so a failed purge is still found and retried; deleting it releases the
+// username and email.
+func (uc *accountUseCase) purge(ctx context.Context, userID int64) error {
+	for _, purger := range uc.purgers.Purgers() {
+		if err := purger.Purge(ctx, userID); err != nil {
+			return fmt.Errorf("purger %s failed: %w", purger.Name(), err)
+		}
+	}
+
+	err := uc.userRepo.Purge(ctx, userID)
+	if errors.Is(err, domainerrors.ErrUserNotFound) {
+		// Purged by another instance or restored in the meantime
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Account purged", "user_id", userID)
+	return nil
+}
+
+// deferPurge moves the purge of a failed account behind the accounts due now.
+// Should that fail too, the account stays first and is retried by the next call.
+func (uc *accountUseCase) deferPurge(ctx context.Context, account repository.DueAccount) {
+	until := time.Now().Add(uc.opts.PurgeRetryDelay)
+	err := uc.userRepo.DeferPurge(ctx, account.UserID, until)
+	if err != nil && !errors.Is(err, domainerrors.ErrUserNotFound) {
+		slog.Error("Failed to defer account purge",
+			"error", err,
+			"user_id", account.UserID,
+			"tenant_id", account.TenantID,
+		)
+	}
+}
+
+func deletionEmailBody(user *entity.User, purgeAt time.Time) string {
+	return fmt.Sprintf(`Hello %s,
+
+Your account has been deleted and you have been signed out everywhere.
+
+Your account and personal data will be permanently erased on %s. Until then
+you can cancel the deletion by signing in again.
+
+If you did not delete your account, sign in now and change your password.
+`, user.Username, purgeAt.UTC().Format("January 2, 2006 15:04 MST"))
+}
@@ -0,0 +1,137 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"base-service/internal/domain/entity"
+	"base-service/internal/domain/repository"
+)
+
+// fakeUserRepo records the tenant each user row is purged in and the new
+// purge time of deferred purges.
+type fakeUserRepo struct {
+	repository.UserRepository
+	purgedIn map[int64]int64
+	deferred map[int64]time.Time
+}
+
+func (r *fakeUserRepo) Purge(ctx context.Context, id int64) error {
+	r.purgedIn[id] = tenantID(ctx)
+	return nil
+}
+
+func (r *fakeUserRepo) DeferPurge(ctx context.Context, id int64, until time.Time) error {
+	r.deferred[id] = until
+	return nil
+}
+
+type fakeDueAccounts []repository.DueAccount
+
+func (f fakeDueAccounts) ListDueForPurge(ctx context.Context, limit int) ([]repository.DueAccount, error) {
+	return f, nil
+}
+
+// fakePurger records the tenant each purge runs in and fails for failFor.
+type fakePurger struct {
+	ranIn   map[int64]int64
+	failFor int64
+}
+
+func (p *fakePurger) Name() string { return "fake" }
+
+func (p *fakePurger) Purge(ctx context.Context, userID int64) error {
+	if userID == p.failFor {
+		return errors.New("purge failed")
+	}
+	p.ranIn[userID] = tenantID(ctx)
+	return nil
+}
+
+// tenantID returns the tenant of ctx, 0 without one.
+func tenantID(ctx context.Context) int64 {
+	tenant, ok := entity.TenantFromContext(ctx)
+	if !ok {
+		return 0
+	}
+	return tenant.ID
+}
+
+func TestPurgeDue(t *testing.T) {
+	due := fakeDueAccounts{
+		{UserID: 1, TenantID: 10},
+		{UserID: 2, TenantID: 20},
+		{UserID: 3, TenantID: 10},
+	}
+
+	tests := []struct {
+		name         string
+		tenancy      bool
+		failFor      int64
+		wantPurged   int
+		wantIn       map[int64]int64
+		wantDeferred []int64
+	}{
+		{
+			name:       "tenancy runs each purge in the account's tenant",
+			tenancy:    true,
+			wantPurged: 3,
+			wantIn:     map[int64]int64{1: 10, 2: 20, 3: 10},
+		},
+		{
+			name:       "without tenancy purges run without a tenant",
+			tenancy:    false,
+			wantPurged: 3,
+			wantIn:     map[int64]int64{1: 0, 2: 0, 3: 0},
+		},
+		{
+			name:         "a failed purge keeps the users row, defers it and continues",
+			tenancy:      true,
+			failFor:      2,
+			wantPurged:   2,
+			wantIn:       map[int64]int64{1: 10, 3: 10},
+			wantDeferred: []int64{2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := &fakeUserRepo{purgedIn: make(map[int64]int64), deferred: make(map[int64]time.Time)}
+			purger := &fakePurger{ranIn: make(map[int64]int64), failFor: tt.failFor}
+			purgers := NewRegistry()
+			purgers.Register(purger)
+			uc := NewAccountUseCase(userRepo, due, purgers, nil, nil, Options{Tenancy: tt.tenancy, PurgeRetryDelay: time.Hour})
+
+			start := time.Now()
+			purged, err := uc.PurgeDue(context.Background())
+			if err != nil {
+				t.Fatalf("PurgeDue: %v", err)
+			}
+			if purged != tt.wantPurged {
+				t.Errorf("purged = %d, want %d", purged, tt.wantPurged)
+			}
+			for _, got := range []map[int64]int64{purger.ranIn, userRepo.purgedIn} {
+				if len(got) != len(tt.wantIn) {
+					t.Errorf("purged users = %v, want %v", got, tt.wantIn)
+					continue
+				}
+				for userID, tenant := range tt.wantIn {
+					if got[userID] != tenant {
+						t.Errorf("user %d purged in tenant %d, want %d", userID, got[userID], tenant)
+					}
+				}
+			}
+
+			if len(userRepo.deferred) != len(tt.wantDeferred) {
+				t.Fatalf("deferred = %v, want %v", userRepo.deferred, tt.wantDeferred)
+			}
+			for _, userID := range tt.wantDeferred {
+				// Moved behind the accounts due now by the retry delay
+				if until := userRepo.deferred[userID]; until.Before(start.Add(time.Hour)) || until.After(time.Now().Add(time.Hour)) {
+					t.Errorf("user %d deferred until %v, want an hour from now", userID, until)
+				}
+			}
+		})
+	}
+}
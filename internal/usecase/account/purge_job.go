@@ -0,0 +1,82 @@
+package account
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"base-service/internal/usecase/port"
+)
+
+const defaultPurgeInterval = time.Hour
+
+// PurgeJob periodically purges the accounts due for purge. Every instance may
+// run one: purges are idempotent, so concurrent runs only repeat work.
+type PurgeJob struct {
+	accounts port.AccountUseCase
+	interval time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewPurgeJob creates a purge job running every interval (default one hour).
+func NewPurgeJob(accounts port.AccountUseCase, interval time.Duration) *PurgeJob {
+	if interval <= 0 {
+		interval = defaultPurgeInterval
+	}
+	return &PurgeJob{
+		accounts: accounts,
+		interval: interval,
+	}
+}
+
+// Start runs the job in the background until ctx is done or Close is called.
+func (j *PurgeJob) Start(ctx context.Context) {
+	ctx, j.cancel = context.WithCancel(ctx)
+
+	j.wg.Add(1)
+	go j.loop(ctx)
+}
+
+func (j *PurgeJob) loop(ctx context.Context) {
+	defer j.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.run(ctx)
+		}
+	}
+}
+
+// run purges batches until no account is due, so a backlog does not wait for
+// the next tick.
+func (j *PurgeJob) run(ctx context.Context) {
+	for ctx.Err() == nil {
+		purged, err := j.accounts.PurgeDue(ctx)
+		if err != nil {
+			slog.Error("Account purge failed", "error", err)
+			return
+		}
+		if purged == 0 {
+			return
+		}
+		slog.Info("Purged deleted accounts", "count", purged)
+	}
+}
+
+// Close stops the job and waits for a running purge to finish.
+func (j *PurgeJob) Close() error {
+	if j.cancel != nil {
+		j.cancel()
+	}
+	j.wg.Wait()
+	return nil
+}
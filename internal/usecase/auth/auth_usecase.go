@@ -3,6 +3,8 @@ package auth
 import (
 	"context"
 	"errors"
+	"log/slog"
+	"time"
 
 	"base-service/internal/domain/entity"
 	domainerrors "base-service/internal/domain/errors"
@@ -14,37 +16,64 @@ import (
 type PasswordHasher interface {
 	HashPassword(password string) (string, error)
 	VerifyPassword(password, hash string) (bool, error)
+	// NeedsRehash reports whether a verified hash should be replaced, e.g. an imported legacy hash.
+	NeedsRehash(hash string) bool
 }
 
 // TokenGenerator defines the interface for JWT token operations.
 type TokenGenerator interface {
-	GenerateTokenPair(userID int64, username string) (*port.TokenPair, error)
-	GenerateAccessToken(userID int64, username string) (*port.TokenPair, error)
-	ValidateRefreshToken(token string) (userID int64, username string, err error)
+	GenerateTokenPair(ctx context.Context, user *entity.User, auth port.Authentication) (*port.TokenPair, error)
+	GenerateAccessToken(ctx context.Context, user *entity.User, auth port.Authentication) (*port.TokenPair, error)
+	GenerateStepUpToken(ctx context.Context, user *entity.User, auth port.Authentication) (*port.StepUpOutput, error)
+	ValidateRefreshToken(token string) (userID int64, username string, auth port.Authentication, err error)
 	InvalidateToken(token string) error
 }
 
+// OTPVerifier defines the interface for one-time password verification.
+type OTPVerifier interface {
+	// VerifyOTP returns the matched time step when code is valid for secret at the given time.
+	VerifyOTP(secret, code string, at time.Time) (step int64, ok bool)
+}
+
 type authUseCase struct {
 	userRepo       repository.UserRepository
+	totpRepo       repository.TOTPRepository
+	usernameRepo   repository.UsernameRepository
 	passwordHasher PasswordHasher
 	tokenGenerator TokenGenerator
+	otpVerifier    OTPVerifier
 }
 
 // NewAuthUseCase creates a new authentication use case.
 func NewAuthUseCase(
 	userRepo repository.UserRepository,
+	totpRepo repository.TOTPRepository,
+	usernameRepo repository.UsernameRepository,
 	passwordHasher PasswordHasher,
 	tokenGenerator TokenGenerator,
+	otpVerifier OTPVerifier,
 ) port.AuthUseCase {
 	return &authUseCase{
 		userRepo:       userRepo,
+		totpRepo:       totpRepo,
+		usernameRepo:   usernameRepo,
 		passwordHasher: passwordHasher,
 		tokenGenerator: tokenGenerator,
+		otpVerifier:    otpVerifier,
 	}
 }
 
 // Register creates a new user account.
 func (uc *authUseCase) Register(ctx context.Context, input *port.RegisterInput) (*port.RegisterOutput, error) {
+	// Usernames recently changed away from are kept for their previous owner
+	reservation, err := uc.usernameRepo.FindReservation(ctx, input.Username)
+	if err != nil {
+		return nil, err
+	}
+	if reservation != nil {
+		return nil, domainerrors.ErrUsernameReserved
+	}
+
 	// Hash the password
 	hashedPassword, err := uc.passwordHasher.HashPassword(input.Password)
 	if err != nil {
@@ -68,7 +97,7 @@ func (uc *authUseCase) Register(ctx context.Context, input *port.RegisterInput)
 	}
 
 	// Generate tokens
-	tokenPair, err := uc.tokenGenerator.GenerateTokenPair(createdUser.ID, createdUser.Username)
+	tokenPair, err := uc.tokenGenerator.GenerateTokenPair(ctx, createdUser, passwordAuthentication())
 	if err != nil {
 		return nil, err
 	}
@@ -80,10 +109,11 @@ func (uc *authUseCase) Register(ctx context.Context, input *port.RegisterInput)
 	}, nil
 }
 
-// Login authenticates a user and returns tokens.
+// Login authenticates a user and returns tokens. Logging in to an account the
+// user deleted within the grace period restores it.
 func (uc *authUseCase) Login(ctx context.Context, input *port.LoginInput) (*port.LoginOutput, error) {
 	// Find user by username or email
-	user, err := uc.userRepo.FindByUsernameOrEmail(ctx, input.UsernameOrEmail)
+	user, err := uc.userRepo.FindByUsernameOrEmailWithDeleted(ctx, input.UsernameOrEmail)
 	if err != nil {
 		return nil, domainerrors.ErrInvalidCredentials
 	}
@@ -94,8 +124,31 @@ func (uc *authUseCase) Login(ctx context.Context, input *port.LoginInput) (*port
 		return nil, domainerrors.ErrInvalidCredentials
 	}
 
+	// Deleted accounts look like unknown ones unless they can still be restored
+	if user.IsDeleted() && !user.IsRestorableByLogin(time.Now()) {
+		return nil, domainerrors.ErrInvalidCredentials
+	}
+
+	// Users who are not active learn why only after proving who they are
+	if err := domainerrors.UserStatusError(user.Status); err != nil {
+		return nil, err
+	}
+
+	restored := false
+	if user.IsDeleted() {
+		if user, err = uc.restore(ctx, user.ID); err != nil {
+			return nil, err
+		}
+		restored = true
+	}
+
+	// Imported legacy hashes are replaced now that the password is known
+	if uc.passwordHasher.NeedsRehash(user.HashPassword) {
+		uc.rehashPassword(ctx, user.ID, input.Password)
+	}
+
 	// Generate tokens
-	tokenPair, err := uc.tokenGenerator.GenerateTokenPair(user.ID, user.Username)
+	tokenPair, err := uc.tokenGenerator.GenerateTokenPair(ctx, user, passwordAuthentication())
 	if err != nil {
 		return nil, err
 	}
@@ -104,17 +157,52 @@ func (uc *authUseCase) Login(ctx context.Context, input *port.LoginInput) (*port
 		User:         user,
 		AccessToken:  tokenPair.AccessToken,
 		RefreshToken: tokenPair.RefreshToken,
+		Restored:     restored,
 	}, nil
 }
 
+// restore cancels the pending deletion of a self-deleted account.
+func (uc *authUseCase) restore(ctx context.Context, userID int64) (*entity.User, error) {
+	if err := uc.userRepo.Restore(ctx, userID); err != nil {
+		return nil, err
+	}
+	slog.Info("Deleted account restored by login", "user_id", userID)
+	return uc.userRepo.FindByID(ctx, userID)
+}
+
+// rehashPassword replaces the user's password hash with one of the current
+// algorithm. Failures are logged; the old hash keeps working.
+func (uc *authUseCase) rehashPassword(ctx context.Context, userID int64, password string) {
+	hash, err := uc.passwordHasher.HashPassword(password)
+	if err == nil {
+		_, err = uc.userRepo.Update(ctx, &entity.User{ID: userID, HashPassword: hash})
+	}
+	if err != nil {
+		slog.Warn("Failed to upgrade legacy password hash", "user_id", userID, "error", err)
+		return
+	}
+	slog.Info("Upgraded legacy password hash", "user_id", userID)
+}
+
 // RefreshToken generates a new access token using a refresh token.
 func (uc *authUseCase) RefreshToken(ctx context.Context, refreshToken string) (*port.TokenPair, error) {
-	userID, username, err := uc.tokenGenerator.ValidateRefreshToken(refreshToken)
+	userID, _, auth, err := uc.tokenGenerator.ValidateRefreshToken(refreshToken)
 	if err != nil {
 		return nil, err
 	}
 
-	tokenPair, err := uc.tokenGenerator.GenerateAccessToken(userID, username)
+	// Reload the user so the username and custom claims reflect the current
+	// state; the token's username may predate a username change
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, domainerrors.ErrUserNotFound
+	}
+	if err := domainerrors.UserStatusError(user.Status); err != nil {
+		return nil, err
+	}
+
+	// Refreshing does not re-authenticate: keep the original auth_time
+	tokenPair, err := uc.tokenGenerator.GenerateAccessToken(ctx, user, auth)
 	if err != nil {
 		return nil, err
 	}
@@ -124,7 +212,72 @@ func (uc *authUseCase) RefreshToken(ctx context.Context, refreshToken string) (*
 	return tokenPair, nil
 }
 
+// Reauthenticate verifies the signed-in user's password or one-time code and
+// issues a short-lived elevated token for sensitive operations.
+func (uc *authUseCase) Reauthenticate(ctx context.Context, input *port.ReauthInput) (*port.StepUpOutput, error) {
+	user, err := uc.userRepo.FindByID(ctx, input.UserID)
+	if err != nil {
+		return nil, domainerrors.ErrUserNotFound
+	}
+	if err := domainerrors.UserStatusError(user.Status); err != nil {
+		return nil, err
+	}
+
+	var method string
+	switch {
+	case input.TOTPCode != "":
+		if err := uc.verifyTOTP(ctx, user.ID, input.TOTPCode); err != nil {
+			return nil, err
+		}
+		method = port.AuthMethodOTP
+	case input.Password != "":
+		valid, err := uc.passwordHasher.VerifyPassword(input.Password, user.HashPassword)
+		if err != nil || !valid {
+			return nil, domainerrors.ErrInvalidCredentials
+		}
+		method = port.AuthMethodPassword
+	default:
+		return nil, domainerrors.ErrInvalidCredentials
+	}
+
+	return uc.tokenGenerator.GenerateStepUpToken(ctx, user, port.Authentication{
+		Time:      time.Now(),
+		Methods:   []string{method},
+		SessionID: input.SessionID,
+	})
+}
+
+// verifyTOTP checks the code and marks its time step as used to prevent replay.
+func (uc *authUseCase) verifyTOTP(ctx context.Context, userID int64, code string) error {
+	secret, err := uc.totpRepo.FindByUserID(ctx, userID)
+	if err != nil || !secret.Enabled {
+		return domainerrors.ErrTOTPNotEnabled
+	}
+
+	step, ok := uc.otpVerifier.VerifyOTP(secret.Secret, code, time.Now())
+	if !ok || step <= secret.LastUsedStep {
+		return domainerrors.ErrInvalidCredentials
+	}
+
+	consumed, err := uc.totpRepo.ConsumeStep(ctx, userID, step)
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return domainerrors.ErrInvalidCredentials
+	}
+	return nil
+}
+
 // Logout invalidates the user's tokens.
 func (uc *authUseCase) Logout(ctx context.Context, accessToken string) error {
 	return uc.tokenGenerator.InvalidateToken(accessToken)
 }
+
+// passwordAuthentication describes a password login happening now.
+func passwordAuthentication() port.Authentication {
+	return port.Authentication{
+		Time:    time.Now(),
+		Methods: []string{port.AuthMethodPassword},
+	}
+}
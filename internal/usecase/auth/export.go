@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+// Compile-time interface compliance check
+var _ port.DataExporter = (*SecurityExporter)(nil)
+
+// SessionLister defines the interface for listing a user's live sessions.
+type SessionLister interface {
+	ListUserSessions(ctx context.Context, userID int64) ([]port.Session, error)
+}
+
+// twoFactorExport is the two_factor.json record of a data export. The TOTP
+// secret is never exported.
+type twoFactorExport struct {
+	TOTPEnabled bool `json:"totp_enabled"`
+}
+
+// sessionExport is a record of sessions.json in a data export.
+type sessionExport struct {
+	ID        string    `json:"id"`
+	StartedAt time.Time `json:"started_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SecurityExporter exports the user's two-factor enrollment and sessions.
+type SecurityExporter struct {
+	totpRepo repository.TOTPRepository
+	sessions SessionLister
+}
+
+// NewSecurityExporter creates the auth module's data exporter.
+func NewSecurityExporter(totpRepo repository.TOTPRepository, sessions SessionLister) *SecurityExporter {
+	return &SecurityExporter{
+		totpRepo: totpRepo,
+		sessions: sessions,
+	}
+}
+
+// Name identifies the exporter in logs.
+func (e *SecurityExporter) Name() string {
+	return "security"
+}
+
+// Export writes two_factor.json and sessions.json.
+func (e *SecurityExporter) Export(ctx context.Context, userID int64, archive port.ExportArchive) error {
+	var twoFactor twoFactorExport
+	secret, err := e.totpRepo.FindByUserID(ctx, userID)
+	switch {
+	case err == nil:
+		twoFactor.TOTPEnabled = secret.Enabled
+	case !errors.Is(err, domainerrors.ErrTOTPNotEnabled):
+		return fmt.Errorf("failed to read two-factor enrollment: %w", err)
+	}
+	if err := archive.WriteJSON("two_factor.json", twoFactor); err != nil {
+		return err
+	}
+
+	sessions, err := e.sessions.ListUserSessions(ctx, userID)
+	if err != nil {
+		return err
+	}
+	records := make([]sessionExport, 0, len(sessions))
+	for _, session := range sessions {
+		records = append(records, sessionExport{
+			ID:        session.ID,
+			StartedAt: session.StartedAt,
+			ExpiresAt: session.ExpiresAt,
+		})
+	}
+	return archive.WriteJSON("sessions.json", records)
+}
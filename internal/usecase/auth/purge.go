@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+// Compile-time interface compliance check
+var _ port.DataPurger = (*SecurityPurger)(nil)
+
+// SessionRevoker defines the interface for logging a user out everywhere.
+type SessionRevoker interface {
+	RevokeUserSessions(ctx context.Context, userID int64) error
+}
+
+// SecurityPurger deletes the two-factor enrollment and sessions of a purged user.
+type SecurityPurger struct {
+	totpRepo repository.TOTPRepository
+	sessions SessionRevoker
+}
+
+// NewSecurityPurger creates the auth module's data purger.
+func NewSecurityPurger(totpRepo repository.TOTPRepository, sessions SessionRevoker) *SecurityPurger {
+	return &SecurityPurger{
+		totpRepo: totpRepo,
+		sessions: sessions,
+	}
+}
+
+// Name identifies the purger in logs.
+func (p *SecurityPurger) Name() string {
+	return "security"
+}
+
+// Purge deletes the TOTP secret and ends every session and token.
+func (p *SecurityPurger) Purge(ctx context.Context, userID int64) error {
+	if err := p.totpRepo.Delete(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete two-factor enrollment: %w", err)
+	}
+	return p.sessions.RevokeUserSessions(ctx, userID)
+}
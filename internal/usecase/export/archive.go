@@ -0,0 +1,89 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"base-service/internal/usecase/port"
+)
+
+// Compile-time interface compliance check
+var _ port.ExportArchive = (*zipArchive)(nil)
+
+// zipArchive writes an export as a ZIP file. Entry names must be unique
+// relative paths.
+type zipArchive struct {
+	zw        *zip.Writer
+	createdAt time.Time
+	files     []string
+}
+
+func newZipArchive(w io.Writer) *zipArchive {
+	return &zipArchive{
+		zw:        zip.NewWriter(w),
+		createdAt: time.Now(),
+	}
+}
+
+// WriteJSON adds the file name holding v as indented JSON.
+func (a *zipArchive) WriteJSON(name string, v any) error {
+	w, err := a.create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// WriteFile adds the file name with the content of r.
+func (a *zipArchive) WriteFile(name string, r io.Reader) error {
+	w, err := a.create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// Files returns the names of the files written so far.
+func (a *zipArchive) Files() []string {
+	return a.files
+}
+
+// Close finishes the ZIP file; the underlying writer is left open.
+func (a *zipArchive) Close() error {
+	return a.zw.Close()
+}
+
+func (a *zipArchive) create(name string) (io.Writer, error) {
+	if name == "" || path.Clean(name) != name || strings.HasPrefix(name, "/") || strings.HasPrefix(name, "..") {
+		return nil, fmt.Errorf("invalid export file name %q", name)
+	}
+	for _, existing := range a.files {
+		if existing == name {
+			return nil, fmt.Errorf("duplicate export file name %q", name)
+		}
+	}
+
+	w, err := a.zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: a.createdAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	a.files = append(a.files, name)
+	return w, nil
+}
@@ -0,0 +1,222 @@
+package export
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+const (
+	archiveContentType = "application/zip"
+	manifestFile       = "export.json"
+
+	defaultExportBucket     = "exports"
+	defaultExportLinkExpiry = 24 * time.Hour
+	defaultExportTimeout    = 10 * time.Minute
+)
+
+// ObjectStore defines the object storage operations used for export archives.
+type ObjectStore interface {
+	Upload(ctx context.Context, bucket, key string, data io.Reader, contentType string) error
+	GetPresignedURL(ctx context.Context, bucket, key string, expiry time.Duration) (string, error)
+}
+
+// Mailer defines the interface for sending plain text emails.
+type Mailer interface {
+	SendEmail(ctx context.Context, to, subject, body string) error
+}
+
+// Options configures data exports. Zero values use the defaults.
+type Options struct {
+	// Bucket receives the archives; expire its objects with a lifecycle rule
+	Bucket     string
+	LinkExpiry time.Duration
+	Timeout    time.Duration
+}
+
+// Registry collects the DataExporters of all modules. Modules register theirs
+// while being wired; exports run every exporter registered at that time.
+type Registry struct {
+	mu        sync.RWMutex
+	exporters []port.DataExporter
+}
+
+// NewRegistry creates an empty exporter registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds exporters to every future export.
+func (r *Registry) Register(exporters ...port.DataExporter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exporters = append(r.exporters, exporters...)
+}
+
+// Exporters returns the registered exporters in registration order.
+func (r *Registry) Exporters() []port.DataExporter {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]port.DataExporter(nil), r.exporters...)
+}
+
+type exportUseCase struct {
+	userRepo  repository.UserRepository
+	exporters *Registry
+	store     ObjectStore
+	mailer    Mailer
+	opts      Options
+
+	// running holds the IDs of users with an export in progress on this instance
+	running sync.Map
+}
+
+// NewExportUseCase creates a new data export use case.
+func NewExportUseCase(userRepo repository.UserRepository, exporters *Registry, store ObjectStore, mailer Mailer, opts Options) port.ExportUseCase {
+	if opts.Bucket == "" {
+		opts.Bucket = defaultExportBucket
+	}
+	if opts.LinkExpiry <= 0 {
+		opts.LinkExpiry = defaultExportLinkExpiry
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultExportTimeout
+	}
+	return &exportUseCase{
+		userRepo:  userRepo,
+		exporters: exporters,
+		store:     store,
+		mailer:    mailer,
+		opts:      opts,
+	}
+}
+
+// RequestExport starts building the user's data export in the background.
+func (uc *exportUseCase) RequestExport(ctx context.Context, userID int64) error {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return domainerrors.ErrUserNotFound
+	}
+	if user.IsDeleted() {
+		return domainerrors.ErrUserDeleted
+	}
+
+	if _, busy := uc.running.LoadOrStore(userID, struct{}{}); busy {
+		return domainerrors.ErrExportInProgress
+	}
+	go uc.run(entity.DetachTenant(ctx), user)
+	return nil
+}
+
+// run builds the export and emails its link. It outlives the request, so it
+// gets its own context, scoped to the request's tenant.
+func (uc *exportUseCase) run(ctx context.Context, user *entity.User) {
+	defer uc.running.Delete(user.ID)
+
+	ctx, cancel := context.WithTimeout(ctx, uc.opts.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	link, err := uc.build(ctx, user.ID)
+	if err != nil {
+		slog.Error("Failed to export user data",
+			"error", err,
+			"user_id", user.ID,
+		)
+		return
+	}
+
+	if err := uc.mailer.SendEmail(ctx, user.Email, "Your data export is ready", exportEmailBody(user, link, uc.opts.LinkExpiry)); err != nil {
+		slog.Error("Failed to send data export email",
+			"error", err,
+			"user_id", user.ID,
+		)
+		return
+	}
+
+	slog.Info("User data exported",
+		"user_id", user.ID,
+		"duration", time.Since(start),
+	)
+}
+
+// build runs every exporter into a ZIP file, stores it and returns a signed
+// download URL. The archive is spooled to a temporary file, not memory.
+func (uc *exportUseCase) build(ctx context.Context, userID int64) (string, error) {
+	file, err := os.CreateTemp("", "export-*.zip")
+	if err != nil {
+		return "", fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	archive := newZipArchive(file)
+	for _, exporter := range uc.exporters.Exporters() {
+		if err := exporter.Export(ctx, userID, archive); err != nil {
+			return "", fmt.Errorf("exporter %s failed: %w", exporter.Name(), err)
+		}
+	}
+	if err := archive.WriteJSON(manifestFile, exportManifest{
+		UserID:    userID,
+		CreatedAt: archive.createdAt,
+		Files:     archive.Files(),
+	}); err != nil {
+		return "", err
+	}
+	if err := archive.Close(); err != nil {
+		return "", fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to read archive: %w", err)
+	}
+	key := archiveFolder(userID) + "/" + randomToken() + ".zip"
+	if err := uc.store.Upload(ctx, uc.opts.Bucket, key, file, archiveContentType); err != nil {
+		return "", fmt.Errorf("failed to store archive: %w", err)
+	}
+	return uc.store.GetPresignedURL(ctx, uc.opts.Bucket, key, uc.opts.LinkExpiry)
+}
+
+// exportManifest describes an archive; it is written last, as export.json.
+type exportManifest struct {
+	UserID    int64     `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+	Files     []string  `json:"files"`
+}
+
+func exportEmailBody(user *entity.User, link string, expiry time.Duration) string {
+	return fmt.Sprintf(`Hello %s,
+
+The copy of your personal data you requested is ready. Download it within %s:
+
+%s
+
+If you did not request this export, change your password.
+`, user.Username, formatExpiry(expiry), link)
+}
+
+// formatExpiry formats whole hours as "24 hours" rather than "24h0m0s".
+func formatExpiry(expiry time.Duration) string {
+	if expiry >= time.Hour && expiry%time.Hour == 0 {
+		return fmt.Sprintf("%d hours", expiry/time.Hour)
+	}
+	return expiry.String()
+}
+
+// randomToken returns an unguessable archive name.
+func randomToken() string {
+	token := make([]byte, 16)
+	_, _ = rand.Read(token)
+	return hex.EncodeToString(token)
+}
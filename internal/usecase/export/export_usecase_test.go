@@ -0,0 +1,263 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+var errStoreUnavailable = errors.New("storage unavailable")
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	users map[int64]*entity.User
+}
+
+func (r *fakeUserRepo) FindByID(ctx context.Context, id int64) (*entity.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, domainerrors.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// fakeObjectStore keeps the uploaded archives in memory.
+type fakeObjectStore struct {
+	objects   map[string][]byte
+	uploadErr error
+}
+
+func (s *fakeObjectStore) Upload(ctx context.Context, bucket, key string, data io.Reader, contentType string) error {
+	if s.uploadErr != nil {
+		return s.uploadErr
+	}
+	content, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	s.objects[bucket+"/"+key] = content
+	return nil
+}
+
+func (s *fakeObjectStore) GetPresignedURL(ctx context.Context, bucket, key string, expiry time.Duration) (string, error) {
+	return "https://files.example.com/" + bucket + "/" + key, nil
+}
+
+type sentEmail struct {
+	to, subject, body string
+}
+
+// fakeMailer hands the sent emails to the test.
+type fakeMailer struct {
+	sent chan sentEmail
+}
+
+func (m *fakeMailer) SendEmail(ctx context.Context, to, subject, body string) error {
+	m.sent <- sentEmail{to: to, subject: subject, body: body}
+	return nil
+}
+
+// fakeExporter writes files, or fails. A non-nil release blocks it until
+// closed.
+type fakeExporter struct {
+	name    string
+	write   func(archive port.ExportArchive) error
+	release chan struct{}
+}
+
+func (e *fakeExporter) Name() string {
+	return e.name
+}
+
+func (e *fakeExporter) Export(ctx context.Context, userID int64, archive port.ExportArchive) error {
+	if e.release != nil {
+		<-e.release
+	}
+	return e.write(archive)
+}
+
+func profileExporter() *fakeExporter {
+	return &fakeExporter{name: "profile", write: func(archive port.ExportArchive) error {
+		if err := archive.WriteJSON("profile.json", map[string]string{"username": "jane"}); err != nil {
+			return err
+		}
+		return archive.WriteFile("files/avatar.jpg", strings.NewReader("jpeg"))
+	}}
+}
+
+func newTestExportUseCase(exporters ...port.DataExporter) (*exportUseCase, *fakeObjectStore, *fakeMailer) {
+	registry := NewRegistry()
+	registry.Register(exporters...)
+	users := &fakeUserRepo{users: map[int64]*entity.User{
+		42: {ID: 42, Username: "jane", Email: "jane@example.com"},
+		43: {ID: 43, Username: "john", Email: "john@example.com", DeletedAt: &time.Time{}},
+	}}
+	store := &fakeObjectStore{objects: make(map[string][]byte)}
+	mailer := &fakeMailer{sent: make(chan sentEmail, 1)}
+	uc := NewExportUseCase(users, registry, store, mailer, Options{}).(*exportUseCase)
+	return uc, store, mailer
+}
+
+// readArchive returns the files of a stored archive by name.
+func readArchive(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		content, _ := io.ReadAll(r)
+		r.Close()
+		files[f.Name] = string(content)
+	}
+	return files
+}
+
+func TestExportBuild(t *testing.T) {
+	uc, store, _ := newTestExportUseCase(profileExporter())
+
+	link, err := uc.build(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if len(store.objects) != 1 {
+		t.Fatalf("stored %d objects, want 1", len(store.objects))
+	}
+	for name, data := range store.objects {
+		if !strings.HasPrefix(name, defaultExportBucket+"/42/") || !strings.HasSuffix(name, ".zip") {
+			t.Errorf("stored %s, want %s/42/<token>.zip", name, defaultExportBucket)
+		}
+		if link != "https://files.example.com/"+name {
+			t.Errorf("link = %q, want the signed URL of %s", link, name)
+		}
+
+		files := readArchive(t, data)
+		if files["files/avatar.jpg"] != "jpeg" {
+			t.Errorf("files/avatar.jpg = %q, want %q", files["files/avatar.jpg"], "jpeg")
+		}
+		var profile map[string]string
+		if err := json.Unmarshal([]byte(files["profile.json"]), &profile); err != nil || profile["username"] != "jane" {
+			t.Errorf("profile.json = %q, %v", files["profile.json"], err)
+		}
+		var manifest exportManifest
+		if err := json.Unmarshal([]byte(files[manifestFile]), &manifest); err != nil {
+			t.Fatalf("%s: %v", manifestFile, err)
+		}
+		want := []string{"profile.json", "files/avatar.jpg"}
+		if manifest.UserID != 42 || strings.Join(manifest.Files, ",") != strings.Join(want, ",") {
+			t.Errorf("manifest = %+v, want user 42 and files %v", manifest, want)
+		}
+	}
+}
+
+func TestExportBuildErrors(t *testing.T) {
+	writeJSON := func(name string) *fakeExporter {
+		return &fakeExporter{name: name, write: func(archive port.ExportArchive) error {
+			return archive.WriteJSON(name, struct{}{})
+		}}
+	}
+	exporterErr := errors.New("query failed")
+
+	tests := []struct {
+		name      string
+		exporters []port.DataExporter
+		uploadErr error
+		wantErr   error
+	}{
+		{
+			name: "failing exporter",
+			exporters: []port.DataExporter{profileExporter(), &fakeExporter{name: "tiers", write: func(port.ExportArchive) error {
+				return exporterErr
+			}}},
+			wantErr: exporterErr,
+		},
+		{name: "duplicate file name", exporters: []port.DataExporter{profileExporter(), writeJSON("profile.json")}},
+		{name: "file name outside the archive", exporters: []port.DataExporter{writeJSON("../profile.json")}},
+		{name: "absolute file name", exporters: []port.DataExporter{writeJSON("/profile.json")}},
+		{name: "unclean file name", exporters: []port.DataExporter{writeJSON("files/../profile.json")}},
+		{name: "failed upload", exporters: []port.DataExporter{profileExporter()}, uploadErr: errStoreUnavailable, wantErr: errStoreUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, store, _ := newTestExportUseCase(tt.exporters...)
+			store.uploadErr = tt.uploadErr
+
+			_, err := uc.build(context.Background(), 42)
+			if err == nil {
+				t.Fatal("build succeeded, want an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if len(store.objects) != 0 {
+				t.Errorf("stored %v after a failed build", store.objects)
+			}
+		})
+	}
+}
+
+func TestRequestExport(t *testing.T) {
+	ctx := context.Background()
+	exporter := profileExporter()
+	exporter.release = make(chan struct{})
+	uc, store, mailer := newTestExportUseCase(exporter)
+
+	if err := uc.RequestExport(ctx, 7); !errors.Is(err, domainerrors.ErrUserNotFound) {
+		t.Errorf("unknown user err = %v, want %v", err, domainerrors.ErrUserNotFound)
+	}
+	if err := uc.RequestExport(ctx, 43); !errors.Is(err, domainerrors.ErrUserDeleted) {
+		t.Errorf("deleted user err = %v, want %v", err, domainerrors.ErrUserDeleted)
+	}
+
+	if err := uc.RequestExport(ctx, 42); err != nil {
+		t.Fatalf("RequestExport: %v", err)
+	}
+	if err := uc.RequestExport(ctx, 42); !errors.Is(err, domainerrors.ErrExportInProgress) {
+		t.Errorf("second request err = %v, want %v", err, domainerrors.ErrExportInProgress)
+	}
+
+	close(exporter.release)
+	var email sentEmail
+	select {
+	case email = <-mailer.sent:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no export email sent")
+	}
+	if email.to != "jane@example.com" || !strings.Contains(email.body, "https://files.example.com/"+defaultExportBucket+"/42/") {
+		t.Errorf("email = %+v, want the download link sent to jane@example.com", email)
+	}
+	if !strings.Contains(email.body, "24 hours") {
+		t.Errorf("email body %q does not give the link expiry", email.body)
+	}
+	if len(store.objects) != 1 {
+		t.Errorf("stored %d archives, want 1", len(store.objects))
+	}
+
+	// The finished export no longer blocks new ones
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, busy := uc.running.Load(int64(42)); !busy {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("export still marked in progress after it finished")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
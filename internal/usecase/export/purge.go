@@ -0,0 +1,51 @@
+package export
+
+import (
+	"context"
+	"strconv"
+
+	"base-service/internal/usecase/port"
+)
+
+// Compile-time interface compliance check
+var _ port.DataPurger = (*ArchivePurger)(nil)
+
+// FolderDeleter defines the object storage operation used to delete the
+// archives of a user.
+type FolderDeleter interface {
+	DeleteFolder(ctx context.Context, bucket, folder string) error
+}
+
+// ArchivePurger deletes the export archives of a purged user, including those
+// whose download link has not expired yet.
+type ArchivePurger struct {
+	store  FolderDeleter
+	bucket string
+}
+
+// NewArchivePurger creates the export module's data purger. An empty bucket
+// uses the default of Options.
+func NewArchivePurger(store FolderDeleter, bucket string) *ArchivePurger {
+	if bucket == "" {
+		bucket = defaultExportBucket
+	}
+	return &ArchivePurger{
+		store:  store,
+		bucket: bucket,
+	}
+}
+
+// Name identifies the purger in logs.
+func (p *ArchivePurger) Name() string {
+	return "exports"
+}
+
+// Purge deletes the user's archive folder.
+func (p *ArchivePurger) Purge(ctx context.Context, userID int64) error {
+	return p.store.DeleteFolder(ctx, p.bucket, archiveFolder(userID))
+}
+
+// archiveFolder returns the folder holding the archives of a user.
+func archiveFolder(userID int64) string {
+	return strconv.FormatInt(userID, 10)
+}
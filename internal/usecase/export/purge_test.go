@@ -0,0 +1,38 @@
+package export
+
+import (
+	"context"
+	"testing"
+)
+
+// fakeFolderDeleter records the deleted folders.
+type fakeFolderDeleter struct {
+	deleted []string
+}
+
+func (f *fakeFolderDeleter) DeleteFolder(ctx context.Context, bucket, folder string) error {
+	f.deleted = append(f.deleted, bucket+"/"+folder)
+	return nil
+}
+
+func TestArchivePurger(t *testing.T) {
+	tests := []struct {
+		name   string
+		bucket string
+		want   string
+	}{
+		{name: "configured bucket", bucket: "user-exports", want: "user-exports/42"},
+		{name: "default bucket", want: defaultExportBucket + "/42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeFolderDeleter{}
+			if err := NewArchivePurger(store, tt.bucket).Purge(context.Background(), 42); err != nil {
+				t.Fatalf("Purge: %v", err)
+			}
+			if len(store.deleted) != 1 || store.deleted[0] != tt.want {
+				t.Errorf("deleted = %v, want [%s]", store.deleted, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package organization
+
+import (
+	"context"
+	"time"
+
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+// Compile-time interface compliance check
+var _ port.DataExporter = (*OrganizationExporter)(nil)
+
+// membershipExport is a record of organizations.json in a data export.
+type membershipExport struct {
+	Organization string    `json:"organization"`
+	Name         string    `json:"name"`
+	Role         string    `json:"role"`
+	JoinedAt     time.Time `json:"joined_at"`
+}
+
+// OrganizationExporter exports the user's organization memberships.
+type OrganizationExporter struct {
+	orgRepo repository.OrganizationRepository
+}
+
+// NewOrganizationExporter creates the organization module's data exporter.
+func NewOrganizationExporter(orgRepo repository.OrganizationRepository) *OrganizationExporter {
+	return &OrganizationExporter{orgRepo: orgRepo}
+}
+
+// Name identifies the exporter in logs.
+func (e *OrganizationExporter) Name() string {
+	return "organization"
+}
+
+// Export writes organizations.json.
+func (e *OrganizationExporter) Export(ctx context.Context, userID int64, archive port.ExportArchive) error {
+	memberships, err := e.orgRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	records := make([]membershipExport, 0, len(memberships))
+	for _, membership := range memberships {
+		records = append(records, membershipExport{
+			Organization: membership.Organization.Slug,
+			Name:         membership.Organization.Name,
+			Role:         membership.Role,
+			JoinedAt:     membership.CreatedAt,
+		})
+	}
+	return archive.WriteJSON("organizations.json", records)
+}
@@ -0,0 +1,374 @@
+package organization
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+const (
+	defaultInvitationExpiry = 7 * 24 * time.Hour
+)
+
+// TokenIssuer defines the interface for issuing access tokens that carry the
+// user's current custom claims.
+type TokenIssuer interface {
+	GenerateAccessToken(ctx context.Context, user *entity.User, auth port.Authentication) (*port.TokenPair, error)
+}
+
+// AccessRevoker defines the interface for revoking the organization claims
+// of a member's existing tokens.
+type AccessRevoker interface {
+	RevokeOrgAccess(ctx context.Context, orgID, userID int64) error
+}
+
+// Mailer defines the interface for sending plain text emails.
+type Mailer interface {
+	SendEmail(ctx context.Context, to, subject, body string) error
+}
+
+// Options configures invitations. Zero values use the defaults.
+type Options struct {
+	// InvitationExpiry is how long an invitation can be accepted
+	InvitationExpiry time.Duration
+	// InvitationURL is the page accepting invitations; the token is added as
+	// the "token" query parameter. Empty emails the bare token.
+	InvitationURL string
+}
+
+type organizationUseCase struct {
+	orgRepo  repository.OrganizationRepository
+	userRepo repository.UserRepository
+	tokens   TokenIssuer
+	revoker  AccessRevoker
+	mailer   Mailer
+	opts     Options
+}
+
+// NewOrganizationUseCase creates a new organization use case.
+func NewOrganizationUseCase(orgRepo repository.OrganizationRepository, userRepo repository.UserRepository, tokens TokenIssuer, revoker AccessRevoker, mailer Mailer, opts Options) port.OrganizationUseCase {
+	if opts.InvitationExpiry <= 0 {
+		opts.InvitationExpiry = defaultInvitationExpiry
+	}
+	return &organizationUseCase{
+		orgRepo:  orgRepo,
+		userRepo: userRepo,
+		tokens:   tokens,
+		revoker:  revoker,
+		mailer:   mailer,
+		opts:     opts,
+	}
+}
+
+// CreateOrganization creates an organization owned by the user.
+func (uc *organizationUseCase) CreateOrganization(ctx context.Context, input *port.CreateOrganizationInput) (*entity.Organization, error) {
+	org, err := uc.orgRepo.Create(ctx, &entity.Organization{
+		Slug:      input.Slug,
+		Name:      input.Name,
+		CreatedBy: input.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Organization created",
+		"organization_id", org.ID,
+		"slug", org.Slug,
+		"user_id", input.UserID,
+	)
+	return org, nil
+}
+
+// ListOrganizations returns the user's memberships with their organization.
+func (uc *organizationUseCase) ListOrganizations(ctx context.Context, userID int64) ([]*entity.OrganizationMember, error) {
+	return uc.orgRepo.ListByUser(ctx, userID)
+}
+
+// ActiveOrganization returns the membership of the user's active
+// organization, nil if none.
+func (uc *organizationUseCase) ActiveOrganization(ctx context.Context, userID int64) (*entity.OrganizationMember, error) {
+	active, err := uc.orgRepo.FindActive(ctx, userID)
+	if errors.Is(err, domainerrors.ErrNoActiveOrganization) {
+		return nil, nil
+	}
+	return active, err
+}
+
+// SwitchOrganization makes an organization of the user their active one and
+// issues an access token carrying it. Refreshed tokens keep carrying it.
+func (uc *organizationUseCase) SwitchOrganization(ctx context.Context, input *port.SwitchOrganizationInput) (*port.TokenPair, error) {
+	user, err := uc.userRepo.FindByID(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.orgRepo.Select(ctx, input.OrganizationID, input.UserID); err != nil {
+		return nil, err
+	}
+	return uc.tokens.GenerateAccessToken(ctx, user, input.Auth)
+}
+
+// GetOrganization returns an organization.
+func (uc *organizationUseCase) GetOrganization(ctx context.Context, orgID int64) (*entity.Organization, error) {
+	return uc.orgRepo.FindByID(ctx, orgID)
+}
+
+// ListMembers returns the members of an organization.
+func (uc *organizationUseCase) ListMembers(ctx context.Context, orgID int64) ([]*entity.OrganizationMember, error) {
+	return uc.orgRepo.ListMembers(ctx, orgID)
+}
+
+// ChangeMemberRole changes a member's role. Admins manage members and
+// admins; only owners grant or take away ownership, and the last owner
+// cannot step down.
+func (uc *organizationUseCase) ChangeMemberRole(ctx context.Context, input *port.OrgMemberInput) (*entity.OrganizationMember, error) {
+	actor, err := uc.actor(ctx, input.OrganizationID, input.ActorID, entity.OrgRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if !entity.IsValidOrgRole(input.Role) || !entity.OrgRoleAtLeast(actor.Role, input.Role) {
+		return nil, domainerrors.ErrInsufficientOrgRole
+	}
+
+	target, err := uc.orgRepo.FindMember(ctx, input.OrganizationID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !entity.OrgRoleAtLeast(actor.Role, target.Role) {
+		return nil, domainerrors.ErrInsufficientOrgRole
+	}
+	if target.Role == input.Role {
+		return target, nil
+	}
+
+	// The repository refuses to demote the last owner
+	member, err := uc.orgRepo.UpdateMemberRole(ctx, input.OrganizationID, input.UserID, input.Role)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.revoker.RevokeOrgAccess(ctx, input.OrganizationID, input.UserID); err != nil {
+		return nil, err
+	}
+
+	slog.Info("Organization member role changed",
+		"organization_id", input.OrganizationID,
+		"user_id", input.UserID,
+		"role", input.Role,
+		"actor_id", input.ActorID,
+	)
+	return member, nil
+}
+
+// RemoveMember removes a member from an organization. Any member can leave;
+// removing others needs a role at least theirs, and admin at minimum.
+func (uc *organizationUseCase) RemoveMember(ctx context.Context, input *port.OrgMemberInput) error {
+	minRole := entity.OrgRoleAdmin
+	if input.ActorID == input.UserID {
+		minRole = entity.OrgRoleMember
+	}
+	actor, err := uc.actor(ctx, input.OrganizationID, input.ActorID, minRole)
+	if err != nil {
+		return err
+	}
+
+	if input.ActorID != input.UserID {
+		target, err := uc.orgRepo.FindMember(ctx, input.OrganizationID, input.UserID)
+		if err != nil {
+			return err
+		}
+		if !entity.OrgRoleAtLeast(actor.Role, target.Role) {
+			return domainerrors.ErrInsufficientOrgRole
+		}
+	}
+
+	// The repository refuses to remove the last owner
+	if err := uc.orgRepo.RemoveMember(ctx, input.OrganizationID, input.UserID); err != nil {
+		return err
+	}
+	if err := uc.revoker.RevokeOrgAccess(ctx, input.OrganizationID, input.UserID); err != nil {
+		return err
+	}
+
+	slog.Info("Organization member removed",
+		"organization_id", input.OrganizationID,
+		"user_id", input.UserID,
+		"actor_id", input.ActorID,
+	)
+	return nil
+}
+
+// InviteMember emails an invitation to join the organization. Inviting an
+// address again replaces its pending invitation, so only the latest link
+// works. Invitations cannot grant a role above the inviter's, nor ownership.
+func (uc *organizationUseCase) InviteMember(ctx context.Context, input *port.InviteMemberInput) (*entity.OrganizationInvitation, error) {
+	actor, err := uc.actor(ctx, input.OrganizationID, input.ActorID, entity.OrgRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if input.Role == entity.OrgRoleOwner || !entity.OrgRoleAtLeast(actor.Role, input.Role) {
+		return nil, domainerrors.ErrInsufficientOrgRole
+	}
+
+	email := strings.ToLower(strings.TrimSpace(input.Email))
+	if invitee, err := uc.userRepo.FindByEmail(ctx, email); err == nil {
+		if _, err := uc.orgRepo.FindMember(ctx, input.OrganizationID, invitee.ID); err == nil {
+			return nil, domainerrors.ErrOrgMemberExists
+		}
+	}
+
+	org, err := uc.orgRepo.FindByID(ctx, input.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, tokenHash, err := newInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+	invitation, err := uc.orgRepo.CreateInvitation(ctx, repository.InvitationCreation{
+		OrganizationID: input.OrganizationID,
+		Email:          email,
+		Role:           input.Role,
+		TokenHash:      tokenHash,
+		InvitedBy:      input.ActorID,
+		ExpiresAt:      time.Now().Add(uc.opts.InvitationExpiry),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	subject := fmt.Sprintf("You have been invited to join %s", org.Name)
+	if err := uc.mailer.SendEmail(ctx, email, subject, uc.invitationEmailBody(org, invitation, token)); err != nil {
+		return nil, fmt.Errorf("failed to send invitation email: %w", err)
+	}
+
+	slog.Info("Organization invitation sent",
+		"organization_id", input.OrganizationID,
+		"invitation_id", invitation.ID,
+		"role", input.Role,
+		"actor_id", input.ActorID,
+	)
+	return invitation, nil
+}
+
+// ListInvitations returns the pending invitations of an organization.
+func (uc *organizationUseCase) ListInvitations(ctx context.Context, orgID int64) ([]*entity.OrganizationInvitation, error) {
+	return uc.orgRepo.ListInvitations(ctx, orgID)
+}
+
+// RevokeInvitation deletes a pending invitation of an organization.
+func (uc *organizationUseCase) RevokeInvitation(ctx context.Context, orgID, invitationID int64) error {
+	return uc.orgRepo.DeleteInvitation(ctx, orgID, invitationID)
+}
+
+// AcceptInvitation adds the user to the organization of the invitation
+// token, with the invited role.
+func (uc *organizationUseCase) AcceptInvitation(ctx context.Context, userID int64, token string) (*entity.OrganizationMember, error) {
+	invitation, err := uc.orgRepo.FindInvitationByToken(ctx, hashInvitationToken(token))
+	if err != nil {
+		return nil, err
+	}
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, domainerrors.ErrInvitationEmailMismatch
+	}
+
+	member, err := uc.orgRepo.AcceptInvitation(ctx, invitation.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Organization invitation accepted",
+		"organization_id", invitation.OrganizationID,
+		"invitation_id", invitation.ID,
+		"user_id", userID,
+	)
+	return member, nil
+}
+
+// DeclineInvitation deletes the invitation of the token. Holding the token is
+// enough to decline, so invitees need no account.
+func (uc *organizationUseCase) DeclineInvitation(ctx context.Context, token string) error {
+	invitation, err := uc.orgRepo.FindInvitationByToken(ctx, hashInvitationToken(token))
+	if err != nil {
+		return err
+	}
+	if err := uc.orgRepo.DeleteInvitation(ctx, invitation.OrganizationID, invitation.ID); err != nil {
+		return err
+	}
+
+	slog.Info("Organization invitation declined",
+		"organization_id", invitation.OrganizationID,
+		"invitation_id", invitation.ID,
+	)
+	return nil
+}
+
+// actor returns the actor's membership if their current role is at least
+// minRole. Tokens may carry an outdated role; this checks the stored one.
+func (uc *organizationUseCase) actor(ctx context.Context, orgID, actorID int64, minRole string) (*entity.OrganizationMember, error) {
+	actor, err := uc.orgRepo.FindMember(ctx, orgID, actorID)
+	if errors.Is(err, domainerrors.ErrOrgMemberNotFound) {
+		return nil, domainerrors.ErrInsufficientOrgRole
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !entity.OrgRoleAtLeast(actor.Role, minRole) {
+		return nil, domainerrors.ErrInsufficientOrgRole
+	}
+	return actor, nil
+}
+
+func (uc *organizationUseCase) invitationEmailBody(org *entity.Organization, invitation *entity.OrganizationInvitation, token string) string {
+	accept := "Your invitation code is:\n\n    " + token
+	if uc.opts.InvitationURL != "" {
+		sep := "?"
+		if strings.Contains(uc.opts.InvitationURL, "?") {
+			sep = "&"
+		}
+		accept = "Open this link to accept or decline it:\n\n    " + uc.opts.InvitationURL + sep + "token=" + url.QueryEscape(token)
+	}
+	return fmt.Sprintf(`Hello,
+
+You have been invited to join %s as %s.
+
+%s
+
+Sign in with this email address to accept the invitation. It expires on %s.
+
+If you were not expecting this invitation, you can ignore this email.
+`, org.Name, invitation.Role, accept, invitation.ExpiresAt.UTC().Format("January 2, 2006 15:04 MST"))
+}
+
+// newInvitationToken returns an unguessable invitation token and the hash
+// stored in its place.
+func newInvitationToken() (token, hash string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashInvitationToken(token), nil
+}
+
+// hashInvitationToken returns the hex SHA-256 of an invitation token.
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
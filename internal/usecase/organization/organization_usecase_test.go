@@ -0,0 +1,190 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+const testOrgID = 1
+
+// fakeOrgRepo holds the members of one organization and, like the database,
+// refuses to demote or remove its last owner.
+type fakeOrgRepo struct {
+	repository.OrganizationRepository
+	members map[int64]string // user ID -> role
+}
+
+func (r *fakeOrgRepo) FindMember(ctx context.Context, orgID, userID int64) (*entity.OrganizationMember, error) {
+	role, ok := r.members[userID]
+	if !ok || orgID != testOrgID {
+		return nil, domainerrors.ErrOrgMemberNotFound
+	}
+	return &entity.OrganizationMember{OrganizationID: orgID, UserID: userID, Role: role}, nil
+}
+
+func (r *fakeOrgRepo) UpdateMemberRole(ctx context.Context, orgID, userID int64, role string) (*entity.OrganizationMember, error) {
+	if _, err := r.FindMember(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	if role != entity.OrgRoleOwner && r.lastOwner(userID) {
+		return nil, domainerrors.ErrLastOrgOwner
+	}
+	r.members[userID] = role
+	return &entity.OrganizationMember{OrganizationID: orgID, UserID: userID, Role: role}, nil
+}
+
+func (r *fakeOrgRepo) RemoveMember(ctx context.Context, orgID, userID int64) error {
+	if _, err := r.FindMember(ctx, orgID, userID); err != nil {
+		return err
+	}
+	if r.lastOwner(userID) {
+		return domainerrors.ErrLastOrgOwner
+	}
+	delete(r.members, userID)
+	return nil
+}
+
+func (r *fakeOrgRepo) lastOwner(userID int64) bool {
+	if r.members[userID] != entity.OrgRoleOwner {
+		return false
+	}
+	for id, role := range r.members {
+		if id != userID && role == entity.OrgRoleOwner {
+			return false
+		}
+	}
+	return true
+}
+
+type fakeAccessRevoker struct {
+	revoked []int64
+}
+
+func (r *fakeAccessRevoker) RevokeOrgAccess(ctx context.Context, orgID, userID int64) error {
+	r.revoked = append(r.revoked, userID)
+	return nil
+}
+
+// Members of the test organization
+const (
+	owner   int64 = 1
+	admin   int64 = 2
+	member  int64 = 3
+	outside int64 = 9
+)
+
+func newTestUseCase(members map[int64]string) (port.OrganizationUseCase, *fakeOrgRepo, *fakeAccessRevoker) {
+	repo := &fakeOrgRepo{members: members}
+	revoker := &fakeAccessRevoker{}
+	return NewOrganizationUseCase(repo, nil, nil, revoker, nil, Options{}), repo, revoker
+}
+
+func TestChangeMemberRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		owners   int // owners besides owner
+		actorID  int64
+		userID   int64
+		role     string
+		wantErr  error
+		wantRole string
+	}{
+		{name: "admin promotes a member", actorID: admin, userID: member, role: entity.OrgRoleAdmin, wantRole: entity.OrgRoleAdmin},
+		{name: "owner grants ownership", actorID: owner, userID: admin, role: entity.OrgRoleOwner, wantRole: entity.OrgRoleOwner},
+		{name: "admin cannot grant ownership", actorID: admin, userID: member, role: entity.OrgRoleOwner, wantErr: domainerrors.ErrInsufficientOrgRole},
+		{name: "admin cannot demote an owner", actorID: admin, userID: owner, role: entity.OrgRoleMember, wantErr: domainerrors.ErrInsufficientOrgRole},
+		{name: "member cannot change roles", actorID: member, userID: member, role: entity.OrgRoleAdmin, wantErr: domainerrors.ErrInsufficientOrgRole},
+		{name: "non-member cannot change roles", actorID: outside, userID: member, role: entity.OrgRoleAdmin, wantErr: domainerrors.ErrInsufficientOrgRole},
+		{name: "unknown role", actorID: owner, userID: member, role: "guest", wantErr: domainerrors.ErrInsufficientOrgRole},
+		{name: "missing member", actorID: owner, userID: outside, role: entity.OrgRoleAdmin, wantErr: domainerrors.ErrOrgMemberNotFound},
+		{name: "last owner cannot step down", actorID: owner, userID: owner, role: entity.OrgRoleAdmin, wantErr: domainerrors.ErrLastOrgOwner},
+		{name: "owner steps down next to another", owners: 1, actorID: owner, userID: owner, role: entity.OrgRoleAdmin, wantRole: entity.OrgRoleAdmin},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			members := map[int64]string{owner: entity.OrgRoleOwner, admin: entity.OrgRoleAdmin, member: entity.OrgRoleMember}
+			for i := range tt.owners {
+				members[int64(10+i)] = entity.OrgRoleOwner
+			}
+			uc, repo, revoker := newTestUseCase(members)
+
+			got, err := uc.ChangeMemberRole(context.Background(), &port.OrgMemberInput{
+				OrganizationID: testOrgID,
+				UserID:         tt.userID,
+				ActorID:        tt.actorID,
+				Role:           tt.role,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(revoker.revoked) != 0 {
+					t.Errorf("revoked %v after a refused change", revoker.revoked)
+				}
+				return
+			}
+			if got.Role != tt.wantRole || repo.members[tt.userID] != tt.wantRole {
+				t.Errorf("role = %s, stored %s; want %s", got.Role, repo.members[tt.userID], tt.wantRole)
+			}
+			if len(revoker.revoked) != 1 || revoker.revoked[0] != tt.userID {
+				t.Errorf("revoked %v, want the access of %d", revoker.revoked, tt.userID)
+			}
+		})
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	tests := []struct {
+		name    string
+		owners  int // owners besides owner
+		actorID int64
+		userID  int64
+		wantErr error
+	}{
+		{name: "member leaves", actorID: member, userID: member},
+		{name: "admin removes a member", actorID: admin, userID: member},
+		{name: "owner removes an admin", actorID: owner, userID: admin},
+		{name: "member cannot remove others", actorID: member, userID: admin, wantErr: domainerrors.ErrInsufficientOrgRole},
+		{name: "admin cannot remove an owner", actorID: admin, userID: owner, wantErr: domainerrors.ErrInsufficientOrgRole},
+		{name: "missing member", actorID: owner, userID: outside, wantErr: domainerrors.ErrOrgMemberNotFound},
+		{name: "last owner cannot leave", actorID: owner, userID: owner, wantErr: domainerrors.ErrLastOrgOwner},
+		{name: "owner leaves another owner behind", owners: 1, actorID: owner, userID: owner},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			members := map[int64]string{owner: entity.OrgRoleOwner, admin: entity.OrgRoleAdmin, member: entity.OrgRoleMember}
+			for i := range tt.owners {
+				members[int64(10+i)] = entity.OrgRoleOwner
+			}
+			uc, repo, revoker := newTestUseCase(members)
+
+			err := uc.RemoveMember(context.Background(), &port.OrgMemberInput{
+				OrganizationID: testOrgID,
+				UserID:         tt.userID,
+				ActorID:        tt.actorID,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			_, stillMember := repo.members[tt.userID]
+			if tt.wantErr != nil {
+				if len(revoker.revoked) != 0 {
+					t.Errorf("revoked %v after a refused removal", revoker.revoked)
+				}
+				return
+			}
+			if stillMember {
+				t.Errorf("user %d is still a member", tt.userID)
+			}
+			if len(revoker.revoked) != 1 || revoker.revoked[0] != tt.userID {
+				t.Errorf("revoked %v, want the access of %d", revoker.revoked, tt.userID)
+			}
+		})
+	}
+}
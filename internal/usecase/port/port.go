@@ -2,6 +2,8 @@ package port
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"base-service/internal/domain/entity"
 )
@@ -29,11 +31,13 @@ type RegisterOutput struct {
 	RefreshToken string
 }
 
-// LoginOutput represents output from user login.
+// LoginOutput represents output from user login. Restored is set when the
+// login cancelled the pending deletion of the account.
 type LoginOutput struct {
 	User         *entity.User
 	AccessToken  string
 	RefreshToken string
+	Restored     bool
 }
 
 // TokenPair represents a pair of access and refresh tokens.
@@ -42,6 +46,34 @@ type TokenPair struct {
 	RefreshToken string
 }
 
+// Authentication describes when and how a user last proved their identity.
+type Authentication struct {
+	Time      time.Time
+	Methods   []string
+	SessionID string // empty starts a new session
+}
+
+// Authentication methods (RFC 8176 "amr" values).
+const (
+	AuthMethodPassword = "pwd"
+	AuthMethodOTP      = "otp"
+)
+
+// ReauthInput represents input for re-authentication of a signed-in user.
+// Exactly one of Password or TOTPCode is expected.
+type ReauthInput struct {
+	UserID    int64
+	SessionID string
+	Password  string
+	TOTPCode  string
+}
+
+// StepUpOutput represents a short-lived elevated access token.
+type StepUpOutput struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
 // AuthUseCase defines the interface for authentication operations.
 type AuthUseCase interface {
 	// Register creates a new user account.
@@ -53,10 +85,32 @@ type AuthUseCase interface {
 	// RefreshToken generates a new access token using a refresh token.
 	RefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error)
 
+	// Reauthenticate verifies the signed-in user again and returns an elevated token.
+	Reauthenticate(ctx context.Context, input *ReauthInput) (*StepUpOutput, error)
+
 	// Logout invalidates the user's tokens.
 	Logout(ctx context.Context, accessToken string) error
 }
 
+// PatchProfileInput represents a partial profile update. Nil fields are left
+// unchanged; an empty Avatar removes the avatar. IfMatch lists the versions
+// the client accepts to overwrite; nil accepts any version.
+type PatchProfileInput struct {
+	UserID      int64
+	FirstName   *string
+	LastName    *string
+	PhoneNumber *string
+	Avatar      *string
+	IfMatch     []int64
+}
+
+// AvatarURLs represents the download URLs of an avatar. Stored avatars get
+// short-lived signed URLs; both are empty without an avatar.
+type AvatarURLs struct {
+	URL          string
+	ThumbnailURL string
+}
+
 // UserUseCase defines the interface for user operations.
 type UserUseCase interface {
 	// GetProfile returns the user's profile by username.
@@ -67,4 +121,371 @@ type UserUseCase interface {
 
 	// Update updates a user's profile.
 	Update(ctx context.Context, user *entity.User) (*entity.User, error)
+
+	// PatchProfile partially updates a user's profile with optimistic concurrency.
+	PatchProfile(ctx context.Context, input *PatchProfileInput) (*entity.User, error)
+
+	// UploadAvatar replaces a user's avatar with an uploaded image.
+	UploadAvatar(ctx context.Context, userID int64, file io.Reader) (*entity.User, error)
+
+	// RemoveAvatar clears a user's avatar.
+	RemoveAvatar(ctx context.Context, userID int64) (*entity.User, error)
+
+	// AvatarURLs returns the download URLs of a user's avatar.
+	AvatarURLs(ctx context.Context, user *entity.User) (*AvatarURLs, error)
+}
+
+// ListUsersInput represents the filters, sort order and page of an admin user list.
+// A Cursor from a previous output replaces Page.
+type ListUsersInput struct {
+	Deleted     string
+	Status      string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	EmailDomain string
+	Role        string
+	SortBy      string
+	SortDesc    bool
+	Page        int
+	PageSize    int
+	Cursor      string
+}
+
+// ListUsersOutput represents one page of users and the total number of matches.
+type ListUsersOutput struct {
+	Users      []*entity.User
+	Total      int64
+	Page       int
+	PageSize   int
+	NextCursor string
+}
+
+// SearchUsersInput represents an admin user search.
+type SearchUsersInput struct {
+	Query          string
+	IncludeDeleted bool
+	Page           int
+	PageSize       int
+}
+
+// UserSearchResult represents a user matching a search, with the matched text
+// highlighted in <mark></mark>.
+type UserSearchResult struct {
+	User      *entity.User
+	Rank      float64
+	Highlight string
+}
+
+// SearchUsersOutput represents one page of search results, best matches first.
+type SearchUsersOutput struct {
+	Results  []*UserSearchResult
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+// UserStatusInput represents an administrator changing a user's lifecycle status.
+type UserStatusInput struct {
+	UserID  int64
+	ActorID int64
+	Reason  string
+}
+
+// AdminUserUseCase defines the interface for user management by administrators.
+type AdminUserUseCase interface {
+	// ListUsers returns one page of users matching the filters.
+	ListUsers(ctx context.Context, input *ListUsersInput) (*ListUsersOutput, error)
+
+	// SearchUsers returns one page of users matching a free-text query.
+	SearchUsers(ctx context.Context, input *SearchUsersInput) (*SearchUsersOutput, error)
+
+	// GetUser returns a user by their ID, including soft-deleted users.
+	GetUser(ctx context.Context, id int64) (*entity.User, error)
+
+	// UpdateUser updates a user's profile or role.
+	UpdateUser(ctx context.Context, user *entity.User) (*entity.User, error)
+
+	// DeleteUser soft-deletes a user and logs them out everywhere.
+	DeleteUser(ctx context.Context, id int64) error
+
+	// RestoreUser undoes the soft delete of a user.
+	RestoreUser(ctx context.Context, id int64) (*entity.User, error)
+
+	// ForceLogout revokes every token and session of a user.
+	ForceLogout(ctx context.Context, id int64) error
+
+	// SuspendUser temporarily blocks a user and logs them out everywhere.
+	SuspendUser(ctx context.Context, input *UserStatusInput) (*entity.User, error)
+
+	// BanUser permanently blocks a user and logs them out everywhere.
+	BanUser(ctx context.Context, input *UserStatusInput) (*entity.User, error)
+
+	// ReinstateUser makes a pending, suspended or banned user active again.
+	ReinstateUser(ctx context.Context, input *UserStatusInput) (*entity.User, error)
+}
+
+// Session represents a live login session of a user.
+type Session struct {
+	ID        string
+	StartedAt time.Time
+	ExpiresAt time.Time
+}
+
+// ExportArchive receives the files of a personal data export.
+type ExportArchive interface {
+	// WriteJSON adds the file name (e.g. "profile.json") holding v as JSON.
+	WriteJSON(name string, v any) error
+
+	// WriteFile adds the file name with the content of r.
+	WriteFile(name string, r io.Reader) error
+}
+
+// DataExporter contributes one module's data to personal data exports. Every
+// module storing user data registers one, so exports stay complete as tables
+// are added.
+type DataExporter interface {
+	// Name identifies the exporter in logs.
+	Name() string
+
+	// Export writes the user's data to archive.
+	Export(ctx context.Context, userID int64, archive ExportArchive) error
+}
+
+// ExportUseCase defines the interface for self-service personal data exports.
+type ExportUseCase interface {
+	// RequestExport starts building the user's data export in the background.
+	// A time-limited download link is emailed when it is ready.
+	RequestExport(ctx context.Context, userID int64) error
+}
+
+// DataPurger removes one module's data of a user whose account is being
+// permanently deleted. Every module storing user data that does not cascade
+// from the users row registers one. Purge must be idempotent: a failed purge
+// is retried from the start.
+type DataPurger interface {
+	// Name identifies the purger in logs.
+	Name() string
+
+	// Purge removes or anonymizes the user's data.
+	Purge(ctx context.Context, userID int64) error
+}
+
+// AccountUseCase defines the interface for self-service account deletion.
+type AccountUseCase interface {
+	// DeleteAccount soft-deletes the user's own account, logs them out
+	// everywhere and returns when it will be permanently deleted. Logging in
+	// before then restores the account.
+	DeleteAccount(ctx context.Context, userID int64) (time.Time, error)
+
+	// PurgeDue permanently deletes deleted accounts whose purge time has passed
+	// and returns how many were purged.
+	PurgeDue(ctx context.Context) (int, error)
+}
+
+// AssignTierInput represents an administrator assigning a tier to a user.
+// A nil ValidFrom starts the assignment now; a nil ValidTo never ends it.
+type AssignTierInput struct {
+	UserID    int64
+	TierCode  string
+	ValidFrom *time.Time
+	ValidTo   *time.Time
+	ActorID   int64
+}
+
+// TierUseCase defines the interface for subscription tiers.
+type TierUseCase interface {
+	// ListTiers returns every tier.
+	ListTiers(ctx context.Context) ([]*entity.Tier, error)
+
+	// CurrentTier returns the user's current tier assignment, nil if none.
+	CurrentTier(ctx context.Context, userID int64) (*entity.UserTier, error)
+
+	// ListUserTiers returns every tier assignment of a user, newest first.
+	ListUserTiers(ctx context.Context, userID int64) ([]*entity.UserTier, error)
+
+	// AssignTier assigns a tier to a user for a period.
+	AssignTier(ctx context.Context, input *AssignTierInput) (*entity.UserTier, error)
+
+	// EndTier ends the user's current tier assignments.
+	EndTier(ctx context.Context, userID int64) error
+}
+
+// SettingsUseCase defines the interface for per-user settings. Settings are
+// returned with the defaults of the settings the user has not set.
+type SettingsUseCase interface {
+	// GetSettings returns every setting of the user.
+	GetSettings(ctx context.Context, userID int64) (entity.Settings, error)
+
+	// ReplaceSettings sets the given settings and resets the others to their
+	// defaults.
+	ReplaceSettings(ctx context.Context, userID int64, values entity.Settings) (entity.Settings, error)
+
+	// PatchSettings sets the given settings; nil values reset a setting to
+	// its default. Other settings are left unchanged.
+	PatchSettings(ctx context.Context, userID int64, patch entity.Settings) (entity.Settings, error)
+}
+
+// CreateOrganizationInput represents a user creating an organization. The
+// user becomes its first owner.
+type CreateOrganizationInput struct {
+	UserID int64
+	Slug   string
+	Name   string
+}
+
+// SwitchOrganizationInput represents a user switching their active
+// organization. Auth is the authentication of the caller's current token,
+// carried over to the new one.
+type SwitchOrganizationInput struct {
+	UserID         int64
+	OrganizationID int64
+	Auth           Authentication
+}
+
+// OrgMemberInput represents a member of an organization acting on another
+// member (or themselves). Role is only used when changing roles.
+type OrgMemberInput struct {
+	OrganizationID int64
+	UserID         int64
+	ActorID        int64
+	Role           string
+}
+
+// InviteMemberInput represents a member of an organization inviting an
+// email address to join it.
+type InviteMemberInput struct {
+	OrganizationID int64
+	Email          string
+	Role           string
+	ActorID        int64
+}
+
+// OrganizationUseCase defines the interface for organizations, their members
+// and invitations. Callers authorize org-scoped actions by the caller's role;
+// actions on members and invitations also check the actor's current role
+// against the roles involved.
+type OrganizationUseCase interface {
+	// CreateOrganization creates an organization owned by the user.
+	CreateOrganization(ctx context.Context, input *CreateOrganizationInput) (*entity.Organization, error)
+
+	// ListOrganizations returns the user's memberships with their organization.
+	ListOrganizations(ctx context.Context, userID int64) ([]*entity.OrganizationMember, error)
+
+	// ActiveOrganization returns the membership of the user's active
+	// organization, nil if none.
+	ActiveOrganization(ctx context.Context, userID int64) (*entity.OrganizationMember, error)
+
+	// SwitchOrganization makes an organization of the user their active one
+	// and issues an access token carrying it.
+	SwitchOrganization(ctx context.Context, input *SwitchOrganizationInput) (*TokenPair, error)
+
+	// GetOrganization returns an organization.
+	GetOrganization(ctx context.Context, orgID int64) (*entity.Organization, error)
+
+	// ListMembers returns the members of an organization.
+	ListMembers(ctx context.Context, orgID int64) ([]*entity.OrganizationMember, error)
+
+	// ChangeMemberRole changes a member's role. The member's tokens lose
+	// access to the organization until refreshed.
+	ChangeMemberRole(ctx context.Context, input *OrgMemberInput) (*entity.OrganizationMember, error)
+
+	// RemoveMember removes a member, or lets members leave. The member loses
+	// access to the organization immediately.
+	RemoveMember(ctx context.Context, input *OrgMemberInput) error
+
+	// InviteMember emails an invitation to join the organization.
+	InviteMember(ctx context.Context, input *InviteMemberInput) (*entity.OrganizationInvitation, error)
+
+	// ListInvitations returns the pending invitations of an organization.
+	ListInvitations(ctx context.Context, orgID int64) ([]*entity.OrganizationInvitation, error)
+
+	// RevokeInvitation deletes a pending invitation of an organization.
+	RevokeInvitation(ctx context.Context, orgID, invitationID int64) error
+
+	// AcceptInvitation adds the user to the organization of the invitation
+	// token. The invitation must have been sent to the user's email.
+	AcceptInvitation(ctx context.Context, userID int64, token string) (*entity.OrganizationMember, error)
+
+	// DeclineInvitation deletes the invitation of the token.
+	DeclineInvitation(ctx context.Context, token string) error
+}
+
+// ChangeUsernameInput represents a user renaming themselves. Auth is the
+// authentication of the caller's current token, carried over to the new one.
+type ChangeUsernameInput struct {
+	UserID   int64
+	Username string
+	Auth     Authentication
+}
+
+// ChangeUsernameOutput represents a renamed user and the tokens that replace
+// their revoked ones. The tokens are empty if they could not be issued.
+type ChangeUsernameOutput struct {
+	User         *entity.User
+	AccessToken  string
+	RefreshToken string
+}
+
+// UsernameUseCase defines the interface for username changes. Old usernames
+// stay reserved for their user for a period, during which lookups of them
+// resolve to the renamed user.
+type UsernameUseCase interface {
+	// ChangeUsername renames the user, revokes every token and session of
+	// theirs and returns a token pair in a new session carrying the new
+	// username. Without a token cache nothing can be revoked: old tokens
+	// keep the old username until they expire.
+	ChangeUsername(ctx context.Context, input *ChangeUsernameInput) (*ChangeUsernameOutput, error)
+
+	// ResolveUsername finds the user with username or, while it is reserved,
+	// the user who changed away from it; renamed reports the latter.
+	ResolveUsername(ctx context.Context, username string) (user *entity.User, renamed bool, err error)
+
+	// ListUsernameHistory returns the user's previous usernames, newest first.
+	ListUsernameHistory(ctx context.Context, userID int64) ([]*entity.UsernameChange, error)
+}
+
+// TenantUseCase defines the interface for tenants of a multi-tenant
+// deployment.
+type TenantUseCase interface {
+	// ResolveTenant finds a tenant by ID or slug.
+	ResolveTenant(ctx context.Context, key string) (*entity.Tenant, error)
+}
+
+// UserImportInput represents an uploaded user import file.
+type UserImportInput struct {
+	Format    string // entity.ImportFormatCSV or entity.ImportFormatNDJSON
+	File      io.Reader
+	DryRun    bool
+	CreatedBy int64
+}
+
+// UserImportErrorsOutput represents one page of the rejected rows of an import.
+type UserImportErrorsOutput struct {
+	Errors   []*entity.UserImportError
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+// UserImportUseCase defines the interface for bulk user imports from CSV or
+// NDJSON files. Imports are checkpointed per batch, so a failed or
+// interrupted import resumes after the last stored batch.
+type UserImportUseCase interface {
+	// CreateImport stores the file and creates a pending import.
+	CreateImport(ctx context.Context, input *UserImportInput) (*entity.UserImport, error)
+
+	// StartImport starts or resumes an import in the background.
+	StartImport(ctx context.Context, importID int64) (*entity.UserImport, error)
+
+	// RunImport starts or resumes an import and returns once it has finished.
+	RunImport(ctx context.Context, importID int64) (*entity.UserImport, error)
+
+	// GetImport returns an import and its progress.
+	GetImport(ctx context.Context, importID int64) (*entity.UserImport, error)
+
+	// ListImports returns the most recent imports, newest first.
+	ListImports(ctx context.Context, limit int) ([]*entity.UserImport, error)
+
+	// ListImportErrors returns one page of the rejected rows of an import.
+	ListImportErrors(ctx context.Context, importID int64, page, pageSize int) (*UserImportErrorsOutput, error)
 }
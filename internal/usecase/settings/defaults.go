@@ -0,0 +1,30 @@
+package settings
+
+import (
+	"fmt"
+	"time"
+)
+
+// UserDefinitions declares the settings shared by every frontend.
+func UserDefinitions() []Definition {
+	return []Definition{
+		{Key: "locale", Type: TypeEnum, Default: "en", Values: []string{"en", "vi"}},
+		{Key: "timezone", Type: TypeString, Default: "UTC", MaxLength: 64, Check: checkTimezone},
+		{Key: "theme", Type: TypeEnum, Default: "system", Values: []string{"system", "light", "dark"}},
+		{Key: "notifications.email", Type: TypeBool, Default: true},
+		{Key: "notifications.sms", Type: TypeBool, Default: false},
+		{Key: "notifications.push", Type: TypeBool, Default: true},
+	}
+}
+
+// checkTimezone accepts IANA time zone names, e.g. "Asia/Ho_Chi_Minh".
+func checkTimezone(value any) error {
+	name := value.(string)
+	if name == "" || name == "Local" {
+		return fmt.Errorf("unknown time zone")
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("unknown time zone")
+	}
+	return nil
+}
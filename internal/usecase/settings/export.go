@@ -0,0 +1,35 @@
+package settings
+
+import (
+	"context"
+
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+// Compile-time interface compliance check
+var _ port.DataExporter = (*SettingsExporter)(nil)
+
+// SettingsExporter exports the settings the user has set.
+type SettingsExporter struct {
+	settingsRepo repository.SettingsRepository
+}
+
+// NewSettingsExporter creates the settings module's data exporter.
+func NewSettingsExporter(settingsRepo repository.SettingsRepository) *SettingsExporter {
+	return &SettingsExporter{settingsRepo: settingsRepo}
+}
+
+// Name identifies the exporter in logs.
+func (e *SettingsExporter) Name() string {
+	return "settings"
+}
+
+// Export writes settings.json.
+func (e *SettingsExporter) Export(ctx context.Context, userID int64, archive port.ExportArchive) error {
+	stored, err := e.settingsRepo.Find(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return archive.WriteJSON("settings.json", stored)
+}
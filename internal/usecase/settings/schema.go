@@ -0,0 +1,169 @@
+package settings
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sync"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+)
+
+// Setting value types.
+const (
+	TypeString = "string"
+	TypeBool   = "bool"
+	TypeInt    = "int"
+	TypeEnum   = "enum"
+)
+
+// Definition declares a user setting: its type, default and constraints.
+type Definition struct {
+	// Key names the setting, e.g. "notifications.email"
+	Key string
+	// Type is one of TypeString, TypeBool, TypeInt or TypeEnum
+	Type string
+	// Default is returned while the user has not set the setting
+	Default any
+	// Values lists the allowed values of enum settings
+	Values []string
+	// MaxLength bounds string settings (default 255)
+	MaxLength int
+	// Min and Max bound int settings when Min < Max
+	Min, Max int
+	// Check optionally validates the value further, e.g. a time zone name
+	Check func(value any) error
+}
+
+const defaultMaxLength = 255
+
+// validate checks value against the definition. Numbers are float64, as
+// decoded from JSON.
+func (d Definition) validate(value any) error {
+	switch d.Type {
+	case TypeString, TypeEnum:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%w: %s must be a string", domainerrors.ErrInvalidSetting, d.Key)
+		}
+		if d.Type == TypeEnum && !slices.Contains(d.Values, s) {
+			return fmt.Errorf("%w: %s must be one of %v", domainerrors.ErrInvalidSetting, d.Key, d.Values)
+		}
+		maxLength := d.MaxLength
+		if maxLength <= 0 {
+			maxLength = defaultMaxLength
+		}
+		if len(s) > maxLength {
+			return fmt.Errorf("%w: %s is longer than %d", domainerrors.ErrInvalidSetting, d.Key, maxLength)
+		}
+	case TypeBool:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%w: %s must be a boolean", domainerrors.ErrInvalidSetting, d.Key)
+		}
+	case TypeInt:
+		n, ok := toFloat(value)
+		if !ok || n != math.Trunc(n) {
+			return fmt.Errorf("%w: %s must be an integer", domainerrors.ErrInvalidSetting, d.Key)
+		}
+		if d.Min < d.Max && (n < float64(d.Min) || n > float64(d.Max)) {
+			return fmt.Errorf("%w: %s must be between %d and %d", domainerrors.ErrInvalidSetting, d.Key, d.Min, d.Max)
+		}
+	default:
+		return fmt.Errorf("%w: %s has unknown type %q", domainerrors.ErrInvalidSetting, d.Key, d.Type)
+	}
+
+	if d.Check != nil {
+		if err := d.Check(value); err != nil {
+			return fmt.Errorf("%w: %s: %w", domainerrors.ErrInvalidSetting, d.Key, err)
+		}
+	}
+	return nil
+}
+
+func toFloat(value any) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// Schema collects the setting definitions of all modules. Modules register
+// theirs while being wired; only registered settings can be stored.
+type Schema struct {
+	mu          sync.RWMutex
+	definitions map[string]Definition
+}
+
+// NewSchema creates an empty schema.
+func NewSchema() *Schema {
+	return &Schema{definitions: make(map[string]Definition)}
+}
+
+// Register adds definitions. It fails if a key is taken or a default does
+// not match its own definition.
+func (s *Schema) Register(definitions ...Definition) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, definition := range definitions {
+		if _, ok := s.definitions[definition.Key]; ok || definition.Key == "" {
+			return fmt.Errorf("setting %q is already registered or has no key", definition.Key)
+		}
+		if err := definition.validate(definition.Default); err != nil {
+			return fmt.Errorf("invalid default: %w", err)
+		}
+		s.definitions[definition.Key] = definition
+	}
+	return nil
+}
+
+// Validate checks every value of values against its definition.
+func (s *Schema) Validate(values entity.Settings) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		definition, ok := s.definitions[key]
+		if !ok {
+			return fmt.Errorf("%w: %s", domainerrors.ErrUnknownSetting, key)
+		}
+		if err := definition.validate(values[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Known checks if key is a registered setting.
+func (s *Schema) Known(key string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.definitions[key]
+	return ok
+}
+
+// Resolve returns every registered setting: the stored value when it is
+// still valid, the default otherwise. Stored values of settings no longer
+// registered are left out.
+func (s *Schema) Resolve(stored entity.Settings) entity.Settings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	settings := make(entity.Settings, len(s.definitions))
+	for key, definition := range s.definitions {
+		value, ok := stored[key]
+		if !ok || definition.validate(value) != nil {
+			value = definition.Default
+		}
+		settings[key] = value
+	}
+	return settings
+}
@@ -0,0 +1,116 @@
+package settings
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+)
+
+func newTestSchema(t *testing.T) *Schema {
+	t.Helper()
+	schema := NewSchema()
+	definitions := append(UserDefinitions(),
+		Definition{Key: "nickname", Type: TypeString, Default: "", MaxLength: 8},
+		Definition{Key: "page_size", Type: TypeInt, Default: 20, Min: 10, Max: 100},
+		Definition{Key: "retries", Type: TypeInt, Default: 3},
+	)
+	if err := schema.Register(definitions...); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	return schema
+}
+
+func TestSchemaValidate(t *testing.T) {
+	schema := newTestSchema(t)
+
+	tests := []struct {
+		name    string
+		values  entity.Settings
+		wantErr error
+	}{
+		{name: "valid values", values: entity.Settings{"locale": "vi", "notifications.sms": true, "page_size": float64(50)}},
+		{name: "unknown key", values: entity.Settings{"colour": "red"}, wantErr: domainerrors.ErrUnknownSetting},
+		{name: "enum outside its values", values: entity.Settings{"theme": "blue"}, wantErr: domainerrors.ErrInvalidSetting},
+		{name: "enum of the wrong type", values: entity.Settings{"locale": 1.0}, wantErr: domainerrors.ErrInvalidSetting},
+		{name: "string at its max length", values: entity.Settings{"nickname": strings.Repeat("a", 8)}},
+		{name: "string over its max length", values: entity.Settings{"nickname": strings.Repeat("a", 9)}, wantErr: domainerrors.ErrInvalidSetting},
+		{name: "bool of the wrong type", values: entity.Settings{"notifications.email": "yes"}, wantErr: domainerrors.ErrInvalidSetting},
+		{name: "fractional int", values: entity.Settings{"page_size": 20.5}, wantErr: domainerrors.ErrInvalidSetting},
+		{name: "int below its minimum", values: entity.Settings{"page_size": float64(9)}, wantErr: domainerrors.ErrInvalidSetting},
+		{name: "int above its maximum", values: entity.Settings{"page_size": float64(101)}, wantErr: domainerrors.ErrInvalidSetting},
+		{name: "unbounded int", values: entity.Settings{"retries": float64(-1000)}},
+		{name: "valid time zone", values: entity.Settings{"timezone": "Asia/Ho_Chi_Minh"}},
+		{name: "unknown time zone", values: entity.Settings{"timezone": "Mars/Olympus"}, wantErr: domainerrors.ErrInvalidSetting},
+		{name: "local time zone", values: entity.Settings{"timezone": "Local"}, wantErr: domainerrors.ErrInvalidSetting},
+		{name: "one invalid value fails all", values: entity.Settings{"locale": "vi", "theme": "blue"}, wantErr: domainerrors.ErrInvalidSetting},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := schema.Validate(tt.values); !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchemaRegister(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition Definition
+		wantErr    bool
+	}{
+		{name: "valid definition", definition: Definition{Key: "compact", Type: TypeBool, Default: false}},
+		{name: "key taken", definition: Definition{Key: "locale", Type: TypeEnum, Default: "en", Values: []string{"en"}}, wantErr: true},
+		{name: "no key", definition: Definition{Type: TypeBool, Default: false}, wantErr: true},
+		{name: "default outside the enum", definition: Definition{Key: "density", Type: TypeEnum, Default: "huge", Values: []string{"small"}}, wantErr: true},
+		{name: "default of the wrong type", definition: Definition{Key: "compact", Type: TypeBool, Default: "false"}, wantErr: true},
+		{name: "unknown type", definition: Definition{Key: "compact", Type: "date", Default: "2020-01-01"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := NewSchema()
+			if err := schema.Register(UserDefinitions()...); err != nil {
+				t.Fatalf("Register(UserDefinitions): %v", err)
+			}
+			err := schema.Register(tt.definition)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !schema.Known(tt.definition.Key) {
+				t.Errorf("Known(%q) = false after Register", tt.definition.Key)
+			}
+		})
+	}
+}
+
+func TestSchemaResolve(t *testing.T) {
+	schema := newTestSchema(t)
+
+	resolved := schema.Resolve(entity.Settings{
+		"locale":    "vi",
+		"theme":     "blue",
+		"page_size": float64(50),
+		"removed":   "kept out",
+	})
+	want := entity.Settings{
+		"locale":    "vi",
+		"theme":     "system",
+		"page_size": float64(50),
+		"nickname":  "",
+		"retries":   3,
+	}
+	for key, value := range want {
+		if resolved[key] != value {
+			t.Errorf("%s = %v, want %v", key, resolved[key], value)
+		}
+	}
+	if _, ok := resolved["removed"]; ok {
+		t.Error("unregistered setting resolved")
+	}
+	if len(resolved) != len(UserDefinitions())+3 {
+		t.Errorf("resolved %d settings, want every registered one: %v", len(resolved), resolved)
+	}
+}
@@ -0,0 +1,113 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/event"
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+// EventPublisher defines the interface for publishing domain events.
+type EventPublisher interface {
+	Publish(ctx context.Context, e event.Event)
+}
+
+type settingsUseCase struct {
+	settingsRepo repository.SettingsRepository
+	schema       *Schema
+	events       EventPublisher
+}
+
+// NewSettingsUseCase creates a new settings use case validating against schema.
+func NewSettingsUseCase(settingsRepo repository.SettingsRepository, schema *Schema, events EventPublisher) port.SettingsUseCase {
+	return &settingsUseCase{
+		settingsRepo: settingsRepo,
+		schema:       schema,
+		events:       events,
+	}
+}
+
+// GetSettings returns every setting of the user.
+func (uc *settingsUseCase) GetSettings(ctx context.Context, userID int64) (entity.Settings, error) {
+	stored, err := uc.settingsRepo.Find(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.schema.Resolve(stored), nil
+}
+
+// ReplaceSettings sets the given settings and resets the others to their
+// defaults.
+func (uc *settingsUseCase) ReplaceSettings(ctx context.Context, userID int64, values entity.Settings) (entity.Settings, error) {
+	if err := uc.schema.Validate(values); err != nil {
+		return nil, err
+	}
+
+	previous, err := uc.settingsRepo.Find(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := uc.settingsRepo.Replace(ctx, userID, values)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every key set before or now may have changed
+	keys := slices.Collect(maps.Keys(values))
+	for key := range previous {
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	uc.publish(ctx, userID, keys)
+	return uc.schema.Resolve(stored), nil
+}
+
+// PatchSettings sets the given settings; nil values reset a setting to its
+// default. Concurrent patches of different settings are all kept.
+func (uc *settingsUseCase) PatchSettings(ctx context.Context, userID int64, patch entity.Settings) (entity.Settings, error) {
+	values := entity.Settings{}
+	var reset []string
+	for key, value := range patch {
+		if value != nil {
+			values[key] = value
+			continue
+		}
+		if !uc.schema.Known(key) {
+			return nil, fmt.Errorf("%w: %s", domainerrors.ErrUnknownSetting, key)
+		}
+		reset = append(reset, key)
+	}
+	if err := uc.schema.Validate(values); err != nil {
+		return nil, err
+	}
+
+	stored, err := uc.settingsRepo.Merge(ctx, userID, values, reset)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.publish(ctx, userID, slices.Collect(maps.Keys(patch)))
+	return uc.schema.Resolve(stored), nil
+}
+
+// publish announces the changed settings so other devices and modules can
+// pick them up.
+func (uc *settingsUseCase) publish(ctx context.Context, userID int64, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	slices.Sort(keys)
+	uc.events.Publish(ctx, event.UserSettingsChanged{
+		UserID:     userID,
+		Keys:       keys,
+		OccurredAt: time.Now(),
+	})
+}
@@ -0,0 +1,34 @@
+package tenant
+
+import (
+	"context"
+	"strconv"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+type tenantUseCase struct {
+	tenantRepo repository.TenantRepository
+}
+
+// NewTenantUseCase creates a new tenant use case.
+func NewTenantUseCase(tenantRepo repository.TenantRepository) port.TenantUseCase {
+	return &tenantUseCase{
+		tenantRepo: tenantRepo,
+	}
+}
+
+// ResolveTenant finds a tenant by ID or slug. Slugs start with a letter, so
+// a numeric key is always an ID.
+func (uc *tenantUseCase) ResolveTenant(ctx context.Context, key string) (*entity.Tenant, error) {
+	if key == "" {
+		return nil, domainerrors.ErrTenantNotFound
+	}
+	if id, err := strconv.ParseInt(key, 10, 64); err == nil {
+		return uc.tenantRepo.FindByID(ctx, id)
+	}
+	return uc.tenantRepo.FindBySlug(ctx, key)
+}
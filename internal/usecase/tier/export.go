@@ -0,0 +1,51 @@
+package tier
+
+import (
+	"context"
+	"time"
+
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+// Compile-time interface compliance check
+var _ port.DataExporter = (*TierExporter)(nil)
+
+// tierExport is a record of tiers.json in a data export.
+type tierExport struct {
+	Tier      string     `json:"tier"`
+	ValidFrom time.Time  `json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to,omitempty"`
+}
+
+// TierExporter exports the user's tier assignments.
+type TierExporter struct {
+	tierRepo repository.TierRepository
+}
+
+// NewTierExporter creates the tier module's data exporter.
+func NewTierExporter(tierRepo repository.TierRepository) *TierExporter {
+	return &TierExporter{tierRepo: tierRepo}
+}
+
+// Name identifies the exporter in logs.
+func (e *TierExporter) Name() string {
+	return "tier"
+}
+
+// Export writes tiers.json.
+func (e *TierExporter) Export(ctx context.Context, userID int64, archive port.ExportArchive) error {
+	assignments, err := e.tierRepo.ListAssignments(ctx, userID)
+	if err != nil {
+		return err
+	}
+	records := make([]tierExport, 0, len(assignments))
+	for _, assignment := range assignments {
+		records = append(records, tierExport{
+			Tier:      assignment.Tier.Code,
+			ValidFrom: assignment.ValidFrom,
+			ValidTo:   assignment.ValidTo,
+		})
+	}
+	return archive.WriteJSON("tiers.json", records)
+}
@@ -0,0 +1,104 @@
+package tier
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+type tierUseCase struct {
+	tierRepo repository.TierRepository
+	userRepo repository.UserRepository
+}
+
+// NewTierUseCase creates a new tier use case.
+func NewTierUseCase(tierRepo repository.TierRepository, userRepo repository.UserRepository) port.TierUseCase {
+	return &tierUseCase{
+		tierRepo: tierRepo,
+		userRepo: userRepo,
+	}
+}
+
+// ListTiers returns every tier.
+func (uc *tierUseCase) ListTiers(ctx context.Context) ([]*entity.Tier, error) {
+	return uc.tierRepo.List(ctx)
+}
+
+// CurrentTier returns the user's current tier assignment, nil if none.
+func (uc *tierUseCase) CurrentTier(ctx context.Context, userID int64) (*entity.UserTier, error) {
+	current, err := uc.tierRepo.FindCurrent(ctx, userID)
+	if errors.Is(err, domainerrors.ErrTierNotAssigned) {
+		return nil, nil
+	}
+	return current, err
+}
+
+// ListUserTiers returns every tier assignment of a user, newest first.
+func (uc *tierUseCase) ListUserTiers(ctx context.Context, userID int64) ([]*entity.UserTier, error) {
+	if _, err := uc.userRepo.FindByIDWithDeleted(ctx, userID); err != nil {
+		return nil, err
+	}
+	return uc.tierRepo.ListAssignments(ctx, userID)
+}
+
+// AssignTier assigns a tier to a user for a period. The latest assignment
+// valid at a time wins, so an assignment with an end temporarily overrides an
+// open-ended one. Tokens carry the new tier from their next refresh.
+func (uc *tierUseCase) AssignTier(ctx context.Context, input *port.AssignTierInput) (*entity.UserTier, error) {
+	if _, err := uc.userRepo.FindByID(ctx, input.UserID); err != nil {
+		return nil, err
+	}
+	tier, err := uc.tierRepo.FindByCode(ctx, input.TierCode)
+	if err != nil {
+		return nil, err
+	}
+
+	validFrom := time.Now()
+	if input.ValidFrom != nil {
+		validFrom = *input.ValidFrom
+	}
+	if input.ValidTo != nil && !input.ValidTo.After(validFrom) {
+		return nil, domainerrors.ErrInvalidTierPeriod
+	}
+
+	assignment, err := uc.tierRepo.Assign(ctx, repository.TierAssignment{
+		UserID:     input.UserID,
+		TierID:     tier.ID,
+		ValidFrom:  validFrom,
+		ValidTo:    input.ValidTo,
+		AssignedBy: input.ActorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	assignment.Tier = tier
+
+	slog.Info("Tier assigned",
+		"user_id", input.UserID,
+		"tier", tier.Code,
+		"valid_from", validFrom,
+		"valid_to", input.ValidTo,
+		"actor_id", input.ActorID,
+	)
+	return assignment, nil
+}
+
+// EndTier ends the user's current tier assignments. Scheduled assignments
+// are kept.
+func (uc *tierUseCase) EndTier(ctx context.Context, userID int64) error {
+	ended, err := uc.tierRepo.EndCurrent(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if ended == 0 {
+		return domainerrors.ErrTierNotAssigned
+	}
+	slog.Info("Tier ended", "user_id", userID)
+	return nil
+}
@@ -0,0 +1,259 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/event"
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// SessionRevoker defines the interface for logging a user out everywhere.
+type SessionRevoker interface {
+	RevokeUserSessions(ctx context.Context, userID int64) error
+}
+
+// StatusEnforcer defines the interface for refusing the tokens of users who
+// are not active.
+type StatusEnforcer interface {
+	SetUserStatus(ctx context.Context, userID int64, status string) error
+}
+
+// EventPublisher defines the interface for publishing domain events.
+type EventPublisher interface {
+	Publish(ctx context.Context, e event.Event)
+}
+
+type adminUserUseCase struct {
+	userRepo  repository.UserRepository
+	revoker   SessionRevoker
+	enforcer  StatusEnforcer
+	events    EventPublisher
+	retention time.Duration
+}
+
+// NewAdminUserUseCase creates a new admin user use case. Users deleted by an
+// administrator are purged after retention; zero keeps them until restored.
+func NewAdminUserUseCase(userRepo repository.UserRepository, revoker SessionRevoker, enforcer StatusEnforcer, events EventPublisher, retention time.Duration) port.AdminUserUseCase {
+	return &adminUserUseCase{
+		userRepo:  userRepo,
+		revoker:   revoker,
+		enforcer:  enforcer,
+		events:    events,
+		retention: retention,
+	}
+}
+
+// ListUsers returns one page of users matching the filters.
+func (uc *adminUserUseCase) ListUsers(ctx context.Context, input *port.ListUsersInput) (*port.ListUsersOutput, error) {
+	page, pageSize := normalizePage(input.Page, input.PageSize)
+
+	result, err := uc.userRepo.List(ctx, repository.UserFilter{
+		Deleted:     input.Deleted,
+		Status:      input.Status,
+		CreatedFrom: input.CreatedFrom,
+		CreatedTo:   input.CreatedTo,
+		EmailDomain: input.EmailDomain,
+		Role:        input.Role,
+		SortBy:      input.SortBy,
+		SortDesc:    input.SortDesc,
+		Limit:       pageSize,
+		Offset:      (page - 1) * pageSize,
+		Cursor:      input.Cursor,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return &port.ListUsersOutput{
+		Users:      result.Users,
+		Total:      result.Total,
+		Page:       page,
+		PageSize:   pageSize,
+		NextCursor: result.NextCursor,
+	}, nil
+}
+
+// SearchUsers returns one page of users matching a free-text query, best matches first.
+func (uc *adminUserUseCase) SearchUsers(ctx context.Context, input *port.SearchUsersInput) (*port.SearchUsersOutput, error) {
+	page, pageSize := normalizePage(input.Page, input.PageSize)
+
+	hits, total, err := uc.userRepo.Search(ctx, repository.UserSearch{
+		Query:          input.Query,
+		IncludeDeleted: input.IncludeDeleted,
+		Limit:          pageSize,
+		Offset:         (page - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	results := make([]*port.UserSearchResult, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, &port.UserSearchResult{
+			User:      hit.User,
+			Rank:      hit.Rank,
+			Highlight: hit.Highlight,
+		})
+	}
+	return &port.SearchUsersOutput{
+		Results:  results,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// normalizePage applies the default and maximum page size.
+func normalizePage(page, pageSize int) (int, int) {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	return max(page, 1), min(pageSize, maxPageSize)
+}
+
+// GetUser returns a user by their ID, including soft-deleted users.
+func (uc *adminUserUseCase) GetUser(ctx context.Context, id int64) (*entity.User, error) {
+	return uc.userRepo.FindByIDWithDeleted(ctx, id)
+}
+
+// UpdateUser updates a user's profile or role. A role change logs the user
+// out so that new tokens carry the new role.
+func (uc *adminUserUseCase) UpdateUser(ctx context.Context, user *entity.User) (*entity.User, error) {
+	if user.Role != "" && !entity.IsValidRole(user.Role) {
+		return nil, domainerrors.ErrInvalidRole
+	}
+
+	existing, err := uc.userRepo.FindByIDWithDeleted(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.IsDeleted() {
+		return nil, domainerrors.ErrUserDeleted
+	}
+
+	updated, err := uc.userRepo.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role != "" && user.Role != existing.Role {
+		if err := uc.revoker.RevokeUserSessions(ctx, user.ID); err != nil {
+			return nil, err
+		}
+	}
+	return updated, nil
+}
+
+// DeleteUser soft-deletes a user and logs them out everywhere. Unlike users
+// deleting their own account, the user cannot restore it by logging in.
+func (uc *adminUserUseCase) DeleteUser(ctx context.Context, id int64) error {
+	deletion := repository.UserDeletion{UserID: id}
+	if uc.retention > 0 {
+		purgeAt := time.Now().Add(uc.retention)
+		deletion.PurgeAt = &purgeAt
+	}
+	if err := uc.userRepo.Delete(ctx, deletion); err != nil {
+		return err
+	}
+	return uc.revoker.RevokeUserSessions(ctx, id)
+}
+
+// RestoreUser undoes the soft delete of a user.
+func (uc *adminUserUseCase) RestoreUser(ctx context.Context, id int64) (*entity.User, error) {
+	existing, err := uc.userRepo.FindByIDWithDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !existing.IsDeleted() {
+		return nil, domainerrors.ErrUserNotDeleted
+	}
+
+	if err := uc.userRepo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	return uc.userRepo.FindByID(ctx, id)
+}
+
+// ForceLogout revokes every token and session of a user.
+func (uc *adminUserUseCase) ForceLogout(ctx context.Context, id int64) error {
+	if _, err := uc.userRepo.FindByIDWithDeleted(ctx, id); err != nil {
+		return err
+	}
+	return uc.revoker.RevokeUserSessions(ctx, id)
+}
+
+// SuspendUser temporarily blocks a user and logs them out everywhere.
+func (uc *adminUserUseCase) SuspendUser(ctx context.Context, input *port.UserStatusInput) (*entity.User, error) {
+	return uc.changeStatus(ctx, input, entity.StatusSuspended)
+}
+
+// BanUser permanently blocks a user and logs them out everywhere.
+func (uc *adminUserUseCase) BanUser(ctx context.Context, input *port.UserStatusInput) (*entity.User, error) {
+	return uc.changeStatus(ctx, input, entity.StatusBanned)
+}
+
+// ReinstateUser makes a pending, suspended or banned user active again.
+func (uc *adminUserUseCase) ReinstateUser(ctx context.Context, input *port.UserStatusInput) (*entity.User, error) {
+	return uc.changeStatus(ctx, input, entity.StatusActive)
+}
+
+// changeStatus moves a user to status, records it for AuthMiddleware so the
+// user's tokens are refused at once, and publishes UserStatusChanged.
+// Administrators cannot change their own status, so they cannot lock
+// themselves out.
+func (uc *adminUserUseCase) changeStatus(ctx context.Context, input *port.UserStatusInput, status string) (*entity.User, error) {
+	if input.UserID == input.ActorID {
+		return nil, domainerrors.ErrOwnStatusChange
+	}
+
+	existing, err := uc.userRepo.FindByIDWithDeleted(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.IsDeleted() {
+		return nil, domainerrors.ErrUserDeleted
+	}
+	if !existing.CanChangeStatusTo(status) {
+		return nil, domainerrors.ErrInvalidStatusTransition
+	}
+
+	updated, err := uc.userRepo.ChangeStatus(ctx, repository.StatusChange{
+		UserID: input.UserID,
+		From:   existing.Status,
+		To:     status,
+		Reason: input.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.enforcer.SetUserStatus(ctx, input.UserID, status); err != nil {
+		return nil, err
+	}
+	if !updated.IsActive() {
+		// Also ends the sessions, so refresh tokens cannot outlive the block
+		if err := uc.revoker.RevokeUserSessions(ctx, input.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.events.Publish(ctx, event.UserStatusChanged{
+		UserID:     input.UserID,
+		From:       existing.Status,
+		To:         status,
+		Reason:     input.Reason,
+		ActorID:    input.ActorID,
+		OccurredAt: time.Now(),
+	})
+	return updated, nil
+}
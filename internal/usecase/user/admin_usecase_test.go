@@ -0,0 +1,157 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/event"
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+func (r *fakeUserRepo) FindByIDWithDeleted(ctx context.Context, id int64) (*entity.User, error) {
+	return r.FindByID(ctx, id)
+}
+
+func TestForceLogout(t *testing.T) {
+	revokeErr := errors.New("redis unavailable")
+
+	tests := []struct {
+		name      string
+		id        int64
+		revokeErr error
+		wantErr   error
+	}{
+		{name: "revokes every session", id: 1},
+		{name: "unknown user", id: 2, wantErr: domainerrors.ErrUserNotFound},
+		{name: "failed revocation", id: 1, revokeErr: revokeErr, wantErr: revokeErr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revoker := &fakeRevoker{err: tt.revokeErr}
+			uc := NewAdminUserUseCase(&fakeUserRepo{user: &entity.User{ID: 1, Username: "alice"}}, revoker, nil, &fakePublisher{}, 0)
+
+			err := uc.ForceLogout(context.Background(), tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			wantRevoked := 0
+			if tt.wantErr == nil {
+				wantRevoked = 1
+			}
+			if len(revoker.revoked) != wantRevoked {
+				t.Errorf("revoked %v, want %d revocations", revoker.revoked, wantRevoked)
+			}
+		})
+	}
+}
+
+func (r *fakeUserRepo) ChangeStatus(ctx context.Context, change repository.StatusChange) (*entity.User, error) {
+	if r.user.Status != change.From {
+		return nil, domainerrors.ErrInvalidStatusTransition
+	}
+	updated := *r.user
+	updated.Status, updated.StatusReason = change.To, change.Reason
+	r.user = &updated
+	return r.user, nil
+}
+
+// fakeEnforcer records the statuses set for AuthMiddleware.
+type fakeEnforcer struct {
+	statuses map[int64]string
+	err      error
+}
+
+func (e *fakeEnforcer) SetUserStatus(ctx context.Context, userID int64, status string) error {
+	if e.err != nil {
+		return e.err
+	}
+	e.statuses[userID] = status
+	return nil
+}
+
+func TestChangeUserStatus(t *testing.T) {
+	const adminID int64 = 9
+	storeErr := errors.New("redis unavailable")
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name        string
+		change      func(uc port.AdminUserUseCase, ctx context.Context, input *port.UserStatusInput) (*entity.User, error)
+		status      string
+		deletedAt   *time.Time
+		id          int64
+		actorID     int64
+		enforceErr  error
+		revokeErr   error
+		wantErr     error
+		wantStatus  string
+		wantRevoked bool
+	}{
+		{name: "suspend logs the user out", change: port.AdminUserUseCase.SuspendUser, status: entity.StatusActive, wantStatus: entity.StatusSuspended, wantRevoked: true},
+		{name: "ban a suspended user", change: port.AdminUserUseCase.BanUser, status: entity.StatusSuspended, wantStatus: entity.StatusBanned, wantRevoked: true},
+		{name: "ban a pending user", change: port.AdminUserUseCase.BanUser, status: entity.StatusPending, wantStatus: entity.StatusBanned, wantRevoked: true},
+		{name: "reinstate keeps the sessions", change: port.AdminUserUseCase.ReinstateUser, status: entity.StatusBanned, wantStatus: entity.StatusActive},
+		{name: "banned users can only be reinstated", change: port.AdminUserUseCase.SuspendUser, status: entity.StatusBanned, wantErr: domainerrors.ErrInvalidStatusTransition},
+		{name: "same status", change: port.AdminUserUseCase.BanUser, status: entity.StatusBanned, wantErr: domainerrors.ErrInvalidStatusTransition},
+		{name: "active users are not reinstated", change: port.AdminUserUseCase.ReinstateUser, status: entity.StatusActive, wantErr: domainerrors.ErrInvalidStatusTransition},
+		{name: "own status", change: port.AdminUserUseCase.BanUser, status: entity.StatusActive, actorID: 1, wantErr: domainerrors.ErrOwnStatusChange},
+		{name: "deleted user", change: port.AdminUserUseCase.SuspendUser, status: entity.StatusActive, deletedAt: &deletedAt, wantErr: domainerrors.ErrUserDeleted},
+		{name: "unknown user", change: port.AdminUserUseCase.SuspendUser, status: entity.StatusActive, id: 2, wantErr: domainerrors.ErrUserNotFound},
+		{name: "failed status record", change: port.AdminUserUseCase.SuspendUser, status: entity.StatusActive, enforceErr: storeErr, wantErr: storeErr},
+		{name: "failed revocation", change: port.AdminUserUseCase.BanUser, status: entity.StatusActive, revokeErr: storeErr, wantErr: storeErr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeUserRepo{user: &entity.User{ID: 1, Username: "alice", Status: tt.status, DeletedAt: tt.deletedAt}}
+			revoker := &fakeRevoker{err: tt.revokeErr}
+			enforcer := &fakeEnforcer{statuses: make(map[int64]string), err: tt.enforceErr}
+			events := &fakePublisher{}
+			uc := NewAdminUserUseCase(repo, revoker, enforcer, events, 0)
+
+			id, actorID := tt.id, tt.actorID
+			if id == 0 {
+				id = 1
+			}
+			if actorID == 0 {
+				actorID = adminID
+			}
+			updated, err := tt.change(uc, context.Background(), &port.UserStatusInput{UserID: id, ActorID: actorID, Reason: "spam"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(events.events) != 0 {
+					t.Errorf("published %v after a failed change", events.events)
+				}
+				return
+			}
+
+			if updated.Status != tt.wantStatus || updated.StatusReason != "spam" {
+				t.Errorf("status, reason = %s, %q; want %s, %q", updated.Status, updated.StatusReason, tt.wantStatus, "spam")
+			}
+			if enforcer.statuses[1] != tt.wantStatus {
+				t.Errorf("enforced status = %q, want %q", enforcer.statuses[1], tt.wantStatus)
+			}
+			if revoked := len(revoker.revoked) == 1; revoked != tt.wantRevoked {
+				t.Errorf("revoked %v, want sessions revoked %v", revoker.revoked, tt.wantRevoked)
+			}
+			want := event.UserStatusChanged{UserID: 1, From: tt.status, To: tt.wantStatus, Reason: "spam", ActorID: adminID}
+			if len(events.events) != 1 {
+				t.Fatalf("published %v, want one event", events.events)
+			}
+			got, ok := events.events[0].(event.UserStatusChanged)
+			if got.OccurredAt.IsZero() {
+				t.Error("event without OccurredAt")
+			}
+			got.OccurredAt = time.Time{}
+			if !ok || got != want {
+				t.Errorf("event = %+v, want %+v", events.events[0], want)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	domainerrors "base-service/internal/domain/errors"
+)
+
+const (
+	avatarKeyPrefix       = "avatars/"
+	avatarThumbnailSuffix = "_thumb.jpg"
+	avatarContentType     = "image/jpeg"
+	avatarSniffLength     = 512 // bytes considered by http.DetectContentType
+
+	defaultAvatarBucket        = "avatars"
+	defaultAvatarMaxSize       = 2 << 20
+	defaultAvatarSize          = 256
+	defaultAvatarThumbnailSize = 64
+	defaultAvatarURLExpiry     = time.Hour
+)
+
+// allowedAvatarTypes are the sniffed content types accepted for upload.
+var allowedAvatarTypes = []string{"image/jpeg", "image/png", "image/gif"}
+
+// ObjectStore defines the object storage operations used for avatars.
+type ObjectStore interface {
+	Upload(ctx context.Context, bucket, key string, data io.Reader, contentType string) error
+	Download(ctx context.Context, bucket, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, bucket, key string) error
+	GetPresignedURL(ctx context.Context, bucket, key string, expiry time.Duration) (string, error)
+}
+
+// ImageProcessor defines the image decoding and resizing used for avatars.
+type ImageProcessor interface {
+	// SquareJPEGs center-crops src to a square and returns it encoded as JPEG
+	// once per size, in order.
+	SquareJPEGs(src []byte, sizes ...int) ([][]byte, error)
+}
+
+// AvatarOptions configures avatar uploads. Zero values use the defaults.
+type AvatarOptions struct {
+	Bucket        string
+	MaxSize       int64
+	Size          int
+	ThumbnailSize int
+	URLExpiry     time.Duration
+}
+
+// AvatarStore keeps avatar renditions in object storage. The users.avatar
+// column holds the object key of the main rendition; other values (external
+// URLs) are passed through untouched.
+type AvatarStore struct {
+	store  ObjectStore
+	images ImageProcessor
+	opts   AvatarOptions
+}
+
+// NewAvatarStore creates an avatar store.
+func NewAvatarStore(store ObjectStore, images ImageProcessor, opts AvatarOptions) *AvatarStore {
+	if opts.Bucket == "" {
+		opts.Bucket = defaultAvatarBucket
+	}
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = defaultAvatarMaxSize
+	}
+	if opts.Size <= 0 {
+		opts.Size = defaultAvatarSize
+	}
+	if opts.ThumbnailSize <= 0 {
+		opts.ThumbnailSize = defaultAvatarThumbnailSize
+	}
+	if opts.URLExpiry <= 0 {
+		opts.URLExpiry = defaultAvatarURLExpiry
+	}
+	return &AvatarStore{
+		store:  store,
+		images: images,
+		opts:   opts,
+	}
+}
+
+// Save checks, resizes and stores an uploaded image and returns the key to
+// keep in users.avatar.
+func (s *AvatarStore) Save(ctx context.Context, userID int64, file io.Reader) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(file, s.opts.MaxSize+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read avatar: %w", err)
+	}
+	if int64(len(data)) > s.opts.MaxSize {
+		return "", domainerrors.ErrImageTooLarge
+	}
+	// The declared content type is client input; sniff the bytes instead
+	if !slices.Contains(allowedAvatarTypes, http.DetectContentType(data[:min(len(data), avatarSniffLength)])) {
+		return "", domainerrors.ErrUnsupportedImageType
+	}
+
+	renditions, err := s.images.SquareJPEGs(data, s.opts.Size, s.opts.ThumbnailSize)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", domainerrors.ErrInvalidImage, err)
+	}
+
+	key := fmt.Sprintf("%s%d/%s.jpg", avatarKeyPrefix, userID, randomToken())
+	if err := s.store.Upload(ctx, s.opts.Bucket, key, bytes.NewReader(renditions[0]), avatarContentType); err != nil {
+		return "", fmt.Errorf("failed to store avatar: %w", err)
+	}
+	if err := s.store.Upload(ctx, s.opts.Bucket, thumbnailKey(key), bytes.NewReader(renditions[1]), avatarContentType); err != nil {
+		s.Remove(ctx, key)
+		return "", fmt.Errorf("failed to store avatar thumbnail: %w", err)
+	}
+	return key, nil
+}
+
+// Remove deletes the objects of a stored avatar. Failures are logged rather
+// than returned: a leftover object must not fail the request that replaced it.
+func (s *AvatarStore) Remove(ctx context.Context, avatar string) {
+	if err := s.Delete(ctx, avatar); err != nil {
+		slog.Error("Failed to delete avatar objects",
+			"error", err,
+			"avatar", avatar,
+		)
+	}
+}
+
+// Delete deletes the objects of a stored avatar; external URLs are ignored.
+// Missing objects are not an error.
+func (s *AvatarStore) Delete(ctx context.Context, avatar string) error {
+	if !isStoredAvatar(avatar) {
+		return nil
+	}
+	var errs []error
+	for _, key := range []string{avatar, thumbnailKey(avatar)} {
+		if err := s.store.Delete(ctx, s.opts.Bucket, key); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete avatar object %s: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// URLs returns the download URLs of an avatar and its thumbnail. Stored
+// avatars get signed URLs; external URLs are returned for both.
+func (s *AvatarStore) URLs(ctx context.Context, avatar string) (string, string, error) {
+	if !isStoredAvatar(avatar) {
+		return avatar, avatar, nil
+	}
+	url, err := s.store.GetPresignedURL(ctx, s.opts.Bucket, avatar, s.opts.URLExpiry)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to sign avatar URL: %w", err)
+	}
+	thumbnailURL, err := s.store.GetPresignedURL(ctx, s.opts.Bucket, thumbnailKey(avatar), s.opts.URLExpiry)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to sign avatar URL: %w", err)
+	}
+	return url, thumbnailURL, nil
+}
+
+// Open opens the main rendition of a stored avatar.
+func (s *AvatarStore) Open(ctx context.Context, avatar string) (io.ReadCloser, error) {
+	return s.store.Download(ctx, s.opts.Bucket, avatar)
+}
+
+func isStoredAvatar(avatar string) bool {
+	return strings.HasPrefix(avatar, avatarKeyPrefix)
+}
+
+func thumbnailKey(key string) string {
+	return strings.TrimSuffix(key, ".jpg") + avatarThumbnailSuffix
+}
+
+// randomToken returns an unguessable object name, so replaced avatars never
+// collide with cached copies of the old one.
+func randomToken() string {
+	token := make([]byte, 16)
+	_, _ = rand.Read(token)
+	return hex.EncodeToString(token)
+}
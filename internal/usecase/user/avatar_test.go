@@ -0,0 +1,156 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	domainerrors "base-service/internal/domain/errors"
+)
+
+// pngHeader is sniffed as image/png.
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
+var errStorageUnavailable = errors.New("storage unavailable")
+
+// fakeObjectStore keeps objects in memory and fails the uploads failKey
+// matches.
+type fakeObjectStore struct {
+	objects map[string]string
+	failKey func(key string) bool
+}
+
+func newFakeObjectStore() *fakeObjectStore {
+	return &fakeObjectStore{objects: make(map[string]string)}
+}
+
+func (s *fakeObjectStore) Upload(ctx context.Context, bucket, key string, data io.Reader, contentType string) error {
+	if s.failKey != nil && s.failKey(key) {
+		return errStorageUnavailable
+	}
+	content, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	s.objects[bucket+"/"+key] = string(content)
+	return nil
+}
+
+func (s *fakeObjectStore) Download(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(s.objects[bucket+"/"+key])), nil
+}
+
+func (s *fakeObjectStore) Delete(ctx context.Context, bucket, key string) error {
+	delete(s.objects, bucket+"/"+key)
+	return nil
+}
+
+func (s *fakeObjectStore) GetPresignedURL(ctx context.Context, bucket, key string, expiry time.Duration) (string, error) {
+	return "https://files.example.com/" + bucket + "/" + key, nil
+}
+
+// fakeImages returns one rendition per size.
+type fakeImages struct {
+	err error
+}
+
+func (f fakeImages) SquareJPEGs(src []byte, sizes ...int) ([][]byte, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	renditions := make([][]byte, 0, len(sizes))
+	for range sizes {
+		renditions = append(renditions, []byte("jpeg"))
+	}
+	return renditions, nil
+}
+
+func TestAvatarStoreSave(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		imagesErr   error
+		failKey     func(key string) bool
+		wantErr     error
+		wantObjects int
+	}{
+		{
+			name:        "stores the avatar and its thumbnail",
+			data:        pngHeader + "image",
+			wantObjects: 2,
+		},
+		{
+			name:    "files over the size limit are refused",
+			data:    pngHeader + strings.Repeat("x", 64),
+			wantErr: domainerrors.ErrImageTooLarge,
+		},
+		{
+			name:    "the sniffed type decides, not the extension",
+			data:    "<svg></svg>",
+			wantErr: domainerrors.ErrUnsupportedImageType,
+		},
+		{
+			name:      "undecodable images are refused",
+			data:      pngHeader + "image",
+			imagesErr: errors.New("png: invalid format"),
+			wantErr:   domainerrors.ErrInvalidImage,
+		},
+		{
+			name:    "a failed thumbnail upload removes the main rendition",
+			data:    pngHeader + "image",
+			failKey: func(key string) bool { return strings.HasSuffix(key, avatarThumbnailSuffix) },
+			wantErr: errStorageUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := newFakeObjectStore()
+			objects.failKey = tt.failKey
+			avatars := NewAvatarStore(objects, fakeImages{err: tt.imagesErr}, AvatarOptions{MaxSize: 32})
+
+			key, err := avatars.Save(context.Background(), 7, strings.NewReader(tt.data))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if len(objects.objects) != tt.wantObjects {
+				t.Fatalf("stored objects = %v, want %d", objects.objects, tt.wantObjects)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if !strings.HasPrefix(key, "avatars/7/") || !strings.HasSuffix(key, ".jpg") {
+				t.Errorf("key = %q, want avatars/7/<token>.jpg", key)
+			}
+			for _, stored := range []string{key, thumbnailKey(key)} {
+				if _, ok := objects.objects[defaultAvatarBucket+"/"+stored]; !ok {
+					t.Errorf("object %s not stored in %v", stored, objects.objects)
+				}
+			}
+		})
+	}
+}
+
+func TestAvatarStoreURLs(t *testing.T) {
+	avatars := NewAvatarStore(newFakeObjectStore(), fakeImages{}, AvatarOptions{})
+
+	url, thumbnailURL, err := avatars.URLs(context.Background(), "avatars/7/abc.jpg")
+	if err != nil {
+		t.Fatalf("URLs: %v", err)
+	}
+	if url != "https://files.example.com/avatars/avatars/7/abc.jpg" {
+		t.Errorf("url = %q", url)
+	}
+	if thumbnailURL != "https://files.example.com/avatars/avatars/7/abc"+avatarThumbnailSuffix {
+		t.Errorf("thumbnail url = %q", thumbnailURL)
+	}
+
+	external := "https://cdn.example.com/me.png"
+	url, thumbnailURL, err = avatars.URLs(context.Background(), external)
+	if err != nil || url != external || thumbnailURL != external {
+		t.Errorf("URLs(external) = %q, %q, %v; want the URL unchanged", url, thumbnailURL, err)
+	}
+}
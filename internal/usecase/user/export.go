@@ -0,0 +1,133 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+// Compile-time interface compliance check
+var (
+	_ port.DataExporter = (*ProfileExporter)(nil)
+	_ port.DataExporter = (*UsernameHistoryExporter)(nil)
+)
+
+// profileExport is the profile.json record of a data export.
+type profileExport struct {
+	ID              int64      `json:"id"`
+	Username        string     `json:"username"`
+	Email           string     `json:"email"`
+	PhoneNumber     string     `json:"phone_number,omitempty"`
+	FirstName       string     `json:"first_name,omitempty"`
+	LastName        string     `json:"last_name,omitempty"`
+	Avatar          string     `json:"avatar,omitempty"`
+	Role            string     `json:"role"`
+	Status          string     `json:"status"`
+	StatusReason    string     `json:"status_reason,omitempty"`
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
+}
+
+// usernameChangeExport is a record of usernames.json in a data export.
+type usernameChangeExport struct {
+	Username      string    `json:"username"`
+	ChangedAt     time.Time `json:"changed_at"`
+	ReservedUntil time.Time `json:"reserved_until"`
+}
+
+// ProfileExporter exports the user's profile and uploaded avatar.
+type ProfileExporter struct {
+	userRepo repository.UserRepository
+	avatars  *AvatarStore
+}
+
+// NewProfileExporter creates the user module's data exporter.
+func NewProfileExporter(userRepo repository.UserRepository, avatars *AvatarStore) *ProfileExporter {
+	return &ProfileExporter{
+		userRepo: userRepo,
+		avatars:  avatars,
+	}
+}
+
+// Name identifies the exporter in logs.
+func (e *ProfileExporter) Name() string {
+	return "profile"
+}
+
+// Export writes profile.json and, for uploaded avatars, files/avatar.jpg.
+// The password hash is never exported.
+func (e *ProfileExporter) Export(ctx context.Context, userID int64, archive port.ExportArchive) error {
+	user, err := e.userRepo.FindByIDWithDeleted(ctx, userID)
+	if err != nil {
+		return domainerrors.ErrUserNotFound
+	}
+
+	profile := profileExport{
+		ID:              user.ID,
+		Username:        user.Username,
+		Email:           user.Email,
+		PhoneNumber:     user.PhoneNumber,
+		FirstName:       user.FirstName,
+		LastName:        user.LastName,
+		Avatar:          user.Avatar,
+		Role:            user.Role,
+		Status:          user.Status,
+		StatusReason:    user.StatusReason,
+		StatusChangedAt: user.StatusChangedAt,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+		DeletedAt:       user.DeletedAt,
+	}
+	if !isStoredAvatar(user.Avatar) {
+		return archive.WriteJSON("profile.json", profile)
+	}
+
+	profile.Avatar = "files/avatar.jpg"
+	if err := archive.WriteJSON("profile.json", profile); err != nil {
+		return err
+	}
+	avatar, err := e.avatars.Open(ctx, user.Avatar)
+	if err != nil {
+		return fmt.Errorf("failed to read avatar: %w", err)
+	}
+	defer avatar.Close()
+	return archive.WriteFile(profile.Avatar, avatar)
+}
+
+// UsernameHistoryExporter exports the usernames the user changed away from.
+type UsernameHistoryExporter struct {
+	usernameRepo repository.UsernameRepository
+}
+
+// NewUsernameHistoryExporter creates the username history data exporter.
+func NewUsernameHistoryExporter(usernameRepo repository.UsernameRepository) *UsernameHistoryExporter {
+	return &UsernameHistoryExporter{usernameRepo: usernameRepo}
+}
+
+// Name identifies the exporter in logs.
+func (e *UsernameHistoryExporter) Name() string {
+	return "usernames"
+}
+
+// Export writes usernames.json.
+func (e *UsernameHistoryExporter) Export(ctx context.Context, userID int64, archive port.ExportArchive) error {
+	changes, err := e.usernameRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	records := make([]usernameChangeExport, 0, len(changes))
+	for _, change := range changes {
+		records = append(records, usernameChangeExport{
+			Username:      change.Username,
+			ChangedAt:     change.ChangedAt,
+			ReservedUntil: change.ReservedUntil,
+		})
+	}
+	return archive.WriteJSON("usernames.json", records)
+}
@@ -0,0 +1,45 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+// Compile-time interface compliance check
+var _ port.DataPurger = (*ProfilePurger)(nil)
+
+// ProfilePurger deletes the uploaded avatar of a purged user. The profile
+// itself is deleted with the users row.
+type ProfilePurger struct {
+	userRepo repository.UserRepository
+	avatars  *AvatarStore
+}
+
+// NewProfilePurger creates the user module's data purger.
+func NewProfilePurger(userRepo repository.UserRepository, avatars *AvatarStore) *ProfilePurger {
+	return &ProfilePurger{
+		userRepo: userRepo,
+		avatars:  avatars,
+	}
+}
+
+// Name identifies the purger in logs.
+func (p *ProfilePurger) Name() string {
+	return "profile"
+}
+
+// Purge deletes the stored avatar objects.
+func (p *ProfilePurger) Purge(ctx context.Context, userID int64) error {
+	user, err := p.userRepo.FindByIDWithDeleted(ctx, userID)
+	if errors.Is(err, domainerrors.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return p.avatars.Delete(ctx, user.Avatar)
+}
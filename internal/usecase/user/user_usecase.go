@@ -2,6 +2,8 @@ package user
 
 import (
 	"context"
+	"io"
+	"slices"
 
 	"base-service/internal/domain/entity"
 	domainerrors "base-service/internal/domain/errors"
@@ -11,12 +13,14 @@ import (
 
 type userUseCase struct {
 	userRepo repository.UserRepository
+	avatars  *AvatarStore
 }
 
 // NewUserUseCase creates a new user use case.
-func NewUserUseCase(userRepo repository.UserRepository) port.UserUseCase {
+func NewUserUseCase(userRepo repository.UserRepository, avatars *AvatarStore) port.UserUseCase {
 	return &userUseCase{
 		userRepo: userRepo,
+		avatars:  avatars,
 	}
 }
 
@@ -62,3 +66,86 @@ func (uc *userUseCase) Update(ctx context.Context, user *entity.User) (*entity.U
 
 	return uc.userRepo.Update(ctx, user)
 }
+
+// PatchProfile partially updates a user's profile. The write is conditional on
+// the version that passed the If-Match check, so a concurrent edit between the
+// check and the write fails with ErrVersionMismatch instead of being overwritten.
+func (uc *userUseCase) PatchProfile(ctx context.Context, input *port.PatchProfileInput) (*entity.User, error) {
+	existing, err := uc.userRepo.FindByID(ctx, input.UserID)
+	if err != nil {
+		return nil, domainerrors.ErrUserNotFound
+	}
+
+	if existing.IsDeleted() {
+		return nil, domainerrors.ErrUserDeleted
+	}
+
+	patch := repository.ProfilePatch{
+		UserID:      input.UserID,
+		FirstName:   input.FirstName,
+		LastName:    input.LastName,
+		PhoneNumber: input.PhoneNumber,
+		Avatar:      input.Avatar,
+	}
+	if input.IfMatch != nil {
+		if !slices.Contains(input.IfMatch, existing.Version) {
+			return nil, domainerrors.ErrVersionMismatch
+		}
+		patch.ExpectedVersion = existing.Version
+	}
+
+	updated, err := uc.userRepo.PatchProfile(ctx, patch)
+	if err != nil {
+		return nil, err
+	}
+	if input.Avatar != nil && updated.Avatar != existing.Avatar {
+		uc.avatars.Remove(ctx, existing.Avatar)
+	}
+	return updated, nil
+}
+
+// UploadAvatar stores a new avatar and removes the replaced one.
+func (uc *userUseCase) UploadAvatar(ctx context.Context, userID int64, file io.Reader) (*entity.User, error) {
+	existing, err := uc.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := uc.avatars.Save(ctx, userID, file)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := uc.userRepo.PatchProfile(ctx, repository.ProfilePatch{UserID: userID, Avatar: &key})
+	if err != nil {
+		uc.avatars.Remove(ctx, key)
+		return nil, err
+	}
+	uc.avatars.Remove(ctx, existing.Avatar)
+	return updated, nil
+}
+
+// RemoveAvatar clears the avatar and deletes its stored objects.
+func (uc *userUseCase) RemoveAvatar(ctx context.Context, userID int64) (*entity.User, error) {
+	existing, err := uc.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	noAvatar := ""
+	updated, err := uc.userRepo.PatchProfile(ctx, repository.ProfilePatch{UserID: userID, Avatar: &noAvatar})
+	if err != nil {
+		return nil, err
+	}
+	uc.avatars.Remove(ctx, existing.Avatar)
+	return updated, nil
+}
+
+// AvatarURLs returns the download URLs of a user's avatar.
+func (uc *userUseCase) AvatarURLs(ctx context.Context, user *entity.User) (*port.AvatarURLs, error) {
+	url, thumbnailURL, err := uc.avatars.URLs(ctx, user.Avatar)
+	if err != nil {
+		return nil, err
+	}
+	return &port.AvatarURLs{URL: url, ThumbnailURL: thumbnailURL}, nil
+}
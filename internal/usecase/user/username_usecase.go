@@ -0,0 +1,171 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/event"
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+const (
+	defaultUsernameChangeCooldown = 30 * 24 * time.Hour
+	defaultUsernameReservation    = 90 * 24 * time.Hour
+)
+
+// UsernameOptions configures username changes. Zero values use the defaults.
+type UsernameOptions struct {
+	// ChangeCooldown is the minimum time between two changes of a user
+	ChangeCooldown time.Duration
+	// Reservation is how long an old username stays reserved for its user
+	// and lookups of it resolve to them
+	Reservation time.Duration
+}
+
+// TokenIssuer defines the interface for issuing the tokens of a new session.
+type TokenIssuer interface {
+	GenerateTokenPair(ctx context.Context, user *entity.User, auth port.Authentication) (*port.TokenPair, error)
+}
+
+type usernameUseCase struct {
+	userRepo     repository.UserRepository
+	usernameRepo repository.UsernameRepository
+	revoker      SessionRevoker
+	tokens       TokenIssuer
+	events       EventPublisher
+	opts         UsernameOptions
+}
+
+// NewUsernameUseCase creates a new username use case.
+func NewUsernameUseCase(userRepo repository.UserRepository, usernameRepo repository.UsernameRepository, revoker SessionRevoker, tokens TokenIssuer, events EventPublisher, opts UsernameOptions) port.UsernameUseCase {
+	if opts.ChangeCooldown <= 0 {
+		opts.ChangeCooldown = defaultUsernameChangeCooldown
+	}
+	if opts.Reservation <= 0 {
+		opts.Reservation = defaultUsernameReservation
+	}
+	return &usernameUseCase{
+		userRepo:     userRepo,
+		usernameRepo: usernameRepo,
+		revoker:      revoker,
+		tokens:       tokens,
+		events:       events,
+		opts:         opts,
+	}
+}
+
+// ChangeUsername renames the user once the cooldown since their last change
+// has passed. Names other users changed away from are refused while
+// reserved; the user's own old names can be taken back. Tokens carry the
+// username, so every token and session is revoked and the caller gets the
+// tokens of a new session instead.
+func (uc *usernameUseCase) ChangeUsername(ctx context.Context, input *port.ChangeUsernameInput) (*port.ChangeUsernameOutput, error) {
+	userID, username := input.UserID, input.Username
+	existing, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, domainerrors.ErrUserNotFound
+	}
+	if existing.IsDeleted() {
+		return nil, domainerrors.ErrUserDeleted
+	}
+	if existing.Username == username {
+		return nil, domainerrors.ErrUsernameUnchanged
+	}
+
+	now := time.Now()
+	latest, err := uc.usernameRepo.FindLatest(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && now.Before(latest.ChangedAt.Add(uc.opts.ChangeCooldown)) {
+		return nil, domainerrors.ErrUsernameChangeTooSoon
+	}
+
+	reservation, err := uc.usernameRepo.FindReservation(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if reservation != nil && reservation.UserID != userID {
+		return nil, domainerrors.ErrUsernameReserved
+	}
+
+	updated, err := uc.usernameRepo.Rename(ctx, repository.UsernameRename{
+		UserID:        userID,
+		From:          existing.Username,
+		To:            username,
+		ReservedUntil: now.Add(uc.opts.Reservation),
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("Username changed", "user_id", userID)
+	uc.events.Publish(ctx, event.UsernameChanged{
+		UserID:     userID,
+		From:       existing.Username,
+		To:         username,
+		OccurredAt: now,
+	})
+
+	// The rename is applied: failures from here on are logged, not returned.
+	// Refreshing reloads the user, but access tokens issued so far would
+	// keep the old username until they expire
+	if err := uc.revoker.RevokeUserSessions(ctx, userID); err != nil {
+		slog.Error("Failed to revoke tokens after username change; they keep the old username until they expire",
+			"error", err,
+			"user_id", userID,
+		)
+	}
+
+	// The old session was ended: start a new one, keeping when and how the
+	// user authenticated
+	output := &port.ChangeUsernameOutput{User: updated}
+	auth := input.Auth
+	auth.SessionID = ""
+	tokenPair, err := uc.tokens.GenerateTokenPair(ctx, updated, auth)
+	if err != nil {
+		slog.Error("Failed to issue tokens after username change; the user must sign in again",
+			"error", err,
+			"user_id", userID,
+		)
+		return output, nil
+	}
+
+	output.AccessToken = tokenPair.AccessToken
+	output.RefreshToken = tokenPair.RefreshToken
+	return output, nil
+}
+
+// ResolveUsername finds the user with username or, while the name is
+// reserved, the user who changed away from it.
+func (uc *usernameUseCase) ResolveUsername(ctx context.Context, username string) (*entity.User, bool, error) {
+	user, err := uc.userRepo.FindByUsername(ctx, username)
+	if err == nil {
+		return user, false, nil
+	}
+	if !errors.Is(err, domainerrors.ErrUserNotFound) {
+		return nil, false, err
+	}
+
+	reservation, err := uc.usernameRepo.FindReservation(ctx, username)
+	if err != nil {
+		return nil, false, err
+	}
+	if reservation == nil {
+		return nil, false, domainerrors.ErrUserNotFound
+	}
+	user, err = uc.userRepo.FindByID(ctx, reservation.UserID)
+	if err != nil {
+		return nil, false, domainerrors.ErrUserNotFound
+	}
+	return user, true, nil
+}
+
+// ListUsernameHistory returns the user's previous usernames, newest first.
+func (uc *usernameUseCase) ListUsernameHistory(ctx context.Context, userID int64) ([]*entity.UsernameChange, error) {
+	return uc.usernameRepo.ListByUser(ctx, userID)
+}
@@ -0,0 +1,175 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/event"
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+)
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	user *entity.User
+}
+
+func (r *fakeUserRepo) FindByID(ctx context.Context, id int64) (*entity.User, error) {
+	if r.user == nil || r.user.ID != id {
+		return nil, domainerrors.ErrUserNotFound
+	}
+	return r.user, nil
+}
+
+type fakeUsernameRepo struct {
+	repository.UsernameRepository
+	renamed *repository.UsernameRename
+}
+
+func (r *fakeUsernameRepo) FindLatest(ctx context.Context, userID int64) (*entity.UsernameChange, error) {
+	return nil, nil
+}
+
+func (r *fakeUsernameRepo) FindReservation(ctx context.Context, username string) (*entity.UsernameChange, error) {
+	return nil, nil
+}
+
+func (r *fakeUsernameRepo) Rename(ctx context.Context, rename repository.UsernameRename) (*entity.User, error) {
+	r.renamed = &rename
+	return &entity.User{ID: rename.UserID, Username: rename.To}, nil
+}
+
+type fakeRevoker struct {
+	revoked []int64
+	err     error
+}
+
+func (r *fakeRevoker) RevokeUserSessions(ctx context.Context, userID int64) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.revoked = append(r.revoked, userID)
+	return nil
+}
+
+// fakeTokenIssuer records the user and authentication of the issued pair.
+type fakeTokenIssuer struct {
+	user *entity.User
+	auth port.Authentication
+	err  error
+}
+
+func (f *fakeTokenIssuer) GenerateTokenPair(ctx context.Context, user *entity.User, auth port.Authentication) (*port.TokenPair, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.user, f.auth = user, auth
+	return &port.TokenPair{AccessToken: "access-" + user.Username, RefreshToken: "refresh-" + user.Username}, nil
+}
+
+type fakePublisher struct {
+	events []event.Event
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, e event.Event) {
+	p.events = append(p.events, e)
+}
+
+func TestChangeUsername(t *testing.T) {
+	authTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	revokeErr := errors.New("redis unavailable")
+
+	tests := []struct {
+		name       string
+		username   string
+		revokeErr  error
+		issueErr   error
+		wantErr    error
+		wantRename bool
+		wantTokens bool
+	}{
+		{
+			name:       "renames, revokes and issues tokens of a new session",
+			username:   "jane_doe",
+			wantRename: true,
+			wantTokens: true,
+		},
+		{
+			name:       "a failed revocation does not fail the applied change",
+			username:   "jane_doe",
+			revokeErr:  revokeErr,
+			wantRename: true,
+			wantTokens: true,
+		},
+		{
+			name:       "failed token issuance does not fail the applied change",
+			username:   "jane_doe",
+			issueErr:   errors.New("too many sessions"),
+			wantRename: true,
+		},
+		{
+			name:     "the current username is refused",
+			username: "jane",
+			wantErr:  domainerrors.ErrUsernameUnchanged,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usernameRepo := &fakeUsernameRepo{}
+			revoker := &fakeRevoker{err: tt.revokeErr}
+			tokens := &fakeTokenIssuer{err: tt.issueErr}
+			uc := NewUsernameUseCase(&fakeUserRepo{user: &entity.User{ID: 1, Username: "jane"}}, usernameRepo, revoker, tokens, &fakePublisher{}, UsernameOptions{})
+
+			output, err := uc.ChangeUsername(context.Background(), &port.ChangeUsernameInput{
+				UserID:   1,
+				Username: tt.username,
+				Auth: port.Authentication{
+					Time:      authTime,
+					Methods:   []string{port.AuthMethodPassword},
+					SessionID: "old-session",
+				},
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got := usernameRepo.renamed != nil; got != tt.wantRename {
+				t.Errorf("renamed = %v, want %v", got, tt.wantRename)
+			}
+			if tt.wantErr != nil {
+				if output != nil {
+					t.Errorf("output = %+v, want nil", output)
+				}
+				return
+			}
+			if output.User.Username != tt.username {
+				t.Errorf("output username = %q, want %q", output.User.Username, tt.username)
+			}
+			if got := output.AccessToken != ""; got != tt.wantTokens {
+				t.Fatalf("issued tokens = %v, want %v", got, tt.wantTokens)
+			}
+			if !tt.wantTokens {
+				return
+			}
+
+			if tt.revokeErr == nil && (len(revoker.revoked) != 1 || revoker.revoked[0] != 1) {
+				t.Errorf("revoked = %v, want [1]", revoker.revoked)
+			}
+			if tokens.user.Username != tt.username {
+				t.Errorf("tokens issued for %q, want %q", tokens.user.Username, tt.username)
+			}
+			if tokens.auth.SessionID != "" {
+				t.Errorf("tokens issued in session %q, want a new session", tokens.auth.SessionID)
+			}
+			if !tokens.auth.Time.Equal(authTime) {
+				t.Errorf("auth time = %v, want %v", tokens.auth.Time, authTime)
+			}
+			if output.AccessToken != "access-"+tt.username || output.RefreshToken != "refresh-"+tt.username {
+				t.Errorf("output tokens = %q, %q", output.AccessToken, output.RefreshToken)
+			}
+		})
+	}
+}
@@ -0,0 +1,373 @@
+package userimport
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+	"base-service/internal/domain/repository"
+	"base-service/internal/usecase/port"
+	"base-service/internal/validator"
+)
+
+const (
+	defaultImportBucket     = "imports"
+	defaultImportBatchSize  = 500
+	defaultImportStaleAfter = 5 * time.Minute
+	defaultImportTimeout    = time.Hour
+
+	defaultErrorsPageSize = 50
+	maxErrorsPageSize     = 500
+)
+
+// contentTypes are the content types import files are stored with.
+var contentTypes = map[string]string{
+	entity.ImportFormatCSV:    "text/csv",
+	entity.ImportFormatNDJSON: "application/x-ndjson",
+}
+
+// PasswordHasher hashes plain text passwords and checks pre-hashed ones.
+type PasswordHasher interface {
+	HashPassword(password string) (string, error)
+	ValidatePasswordHash(hash string) error
+}
+
+// SourceStore defines the object storage operations used for import files.
+type SourceStore interface {
+	Upload(ctx context.Context, bucket, key string, data io.Reader, contentType string) error
+	Download(ctx context.Context, bucket, key string) (io.ReadCloser, error)
+}
+
+// Options configures user imports. Zero values use the defaults.
+type Options struct {
+	// Bucket receives the uploaded files; they are read again on resume
+	Bucket     string
+	BatchSize  int           // rows inserted, and checkpointed, per transaction
+	StaleAfter time.Duration // a running import without progress for this long can be resumed
+	Timeout    time.Duration // limit for one background run
+}
+
+type userImportUseCase struct {
+	importRepo repository.UserImportRepository
+	store      SourceStore
+	hasher     PasswordHasher
+	opts       Options
+}
+
+// NewUserImportUseCase creates a new user import use case.
+func NewUserImportUseCase(importRepo repository.UserImportRepository, store SourceStore, hasher PasswordHasher, opts Options) port.UserImportUseCase {
+	if opts.Bucket == "" {
+		opts.Bucket = defaultImportBucket
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultImportBatchSize
+	}
+	if opts.StaleAfter <= 0 {
+		opts.StaleAfter = defaultImportStaleAfter
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultImportTimeout
+	}
+	return &userImportUseCase{
+		importRepo: importRepo,
+		store:      store,
+		hasher:     hasher,
+		opts:       opts,
+	}
+}
+
+// CreateImport stores the file and creates a pending import.
+func (uc *userImportUseCase) CreateImport(ctx context.Context, input *port.UserImportInput) (*entity.UserImport, error) {
+	contentType, ok := contentTypes[input.Format]
+	if !ok {
+		return nil, domainerrors.ErrUnsupportedImportFormat
+	}
+
+	key := fmt.Sprintf("%s/%s.%s", time.Now().UTC().Format("2006-01-02"), randomToken(), input.Format)
+	if err := uc.store.Upload(ctx, uc.opts.Bucket, key, input.File, contentType); err != nil {
+		return nil, fmt.Errorf("failed to store import file: %w", err)
+	}
+
+	return uc.importRepo.Create(ctx, &entity.UserImport{
+		Format:    input.Format,
+		Source:    key,
+		DryRun:    input.DryRun,
+		CreatedBy: input.CreatedBy,
+	})
+}
+
+// StartImport claims the import and runs it in the background.
+func (uc *userImportUseCase) StartImport(ctx context.Context, importID int64) (*entity.UserImport, error) {
+	imp, err := uc.claim(ctx, importID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The run outlives the request, so it gets its own context, scoped to the
+	// request's tenant
+	runCtx := entity.DetachTenant(ctx)
+	go func() {
+		ctx, cancel := context.WithTimeout(runCtx, uc.opts.Timeout)
+		defer cancel()
+		_, _ = uc.run(ctx, imp)
+	}()
+
+	return imp, nil
+}
+
+// RunImport claims the import and runs it to the end.
+func (uc *userImportUseCase) RunImport(ctx context.Context, importID int64) (*entity.UserImport, error) {
+	imp, err := uc.claim(ctx, importID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.run(ctx, imp)
+}
+
+// GetImport returns an import and its progress.
+func (uc *userImportUseCase) GetImport(ctx context.Context, importID int64) (*entity.UserImport, error) {
+	return uc.importRepo.FindByID(ctx, importID)
+}
+
+// ListImports returns the most recent imports, newest first.
+func (uc *userImportUseCase) ListImports(ctx context.Context, limit int) ([]*entity.UserImport, error) {
+	return uc.importRepo.List(ctx, limit)
+}
+
+// ListImportErrors returns one page of the rejected rows of an import.
+func (uc *userImportUseCase) ListImportErrors(ctx context.Context, importID int64, page, pageSize int) (*port.UserImportErrorsOutput, error) {
+	if _, err := uc.importRepo.FindByID(ctx, importID); err != nil {
+		return nil, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultErrorsPageSize
+	}
+	pageSize = min(pageSize, maxErrorsPageSize)
+
+	rowErrors, err := uc.importRepo.ListErrors(ctx, importID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+	total, err := uc.importRepo.CountErrors(ctx, importID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &port.UserImportErrorsOutput{
+		Errors:   rowErrors,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// claim marks the import running, so no other caller runs it meanwhile.
+func (uc *userImportUseCase) claim(ctx context.Context, importID int64) (*entity.UserImport, error) {
+	if _, err := uc.importRepo.FindByID(ctx, importID); err != nil {
+		return nil, err
+	}
+	return uc.importRepo.Claim(ctx, importID, time.Now().Add(-uc.opts.StaleAfter))
+}
+
+// =============================================================================
+// Processing
+// =============================================================================
+
+// run processes a claimed import and marks it completed or failed.
+func (uc *userImportUseCase) run(ctx context.Context, imp *entity.UserImport) (*entity.UserImport, error) {
+	start := time.Now()
+	err := uc.process(ctx, imp)
+	if errors.Is(err, domainerrors.ErrImportNotResumable) {
+		// Another caller took over the stalled import; it finishes it
+		slog.Warn("User import taken over by another run", "import_id", imp.ID)
+		return nil, err
+	}
+
+	status, reason := entity.ImportStatusCompleted, ""
+	if err != nil {
+		status, reason = entity.ImportStatusFailed, err.Error()
+		slog.Error("User import failed",
+			"error", err,
+			"import_id", imp.ID,
+		)
+	}
+
+	// Record the outcome even if ctx expired
+	finished, finishErr := uc.importRepo.Finish(context.WithoutCancel(ctx), imp.ID, status, reason)
+	if finishErr != nil {
+		slog.Error("Failed to finish user import",
+			"error", finishErr,
+			"import_id", imp.ID,
+		)
+		return nil, finishErr
+	}
+	if err != nil {
+		return finished, err
+	}
+
+	slog.Info("User import completed",
+		"import_id", imp.ID,
+		"dry_run", imp.DryRun,
+		"rows_imported", finished.RowsImported,
+		"rows_failed", finished.RowsFailed,
+		"duration", time.Since(start),
+	)
+	return finished, nil
+}
+
+// process reads the file and stores its rows in batches, continuing after
+// the import's checkpoint.
+func (uc *userImportUseCase) process(ctx context.Context, imp *entity.UserImport) error {
+	source, err := uc.store.Download(ctx, uc.opts.Bucket, imp.Source)
+	if err != nil {
+		return fmt.Errorf("failed to read import file: %w", err)
+	}
+	defer source.Close()
+
+	rows, err := newRowReader(imp.Format, source)
+	if err != nil {
+		return err
+	}
+
+	batch := repository.ImportBatch{ImportID: imp.ID, FromRow: imp.RowsProcessed, ToRow: imp.RowsProcessed, DryRun: imp.DryRun}
+	// seen maps the usernames and emails of the accepted rows to their rows
+	seen := make(map[string]int64)
+	for {
+		number, row, err := rows.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var rowErr *rowError
+		if err != nil && !errors.As(err, &rowErr) {
+			return fmt.Errorf("failed to read row %d: %w", batch.ToRow+1, err)
+		}
+		if number <= imp.RowsProcessed {
+			// Stored before the checkpoint: only check it again, so later
+			// rows duplicating it are still rejected
+			if rowErr == nil {
+				uc.validate(imp, number, row, seen)
+			}
+			continue
+		}
+
+		batch.ToRow = number
+		if rowErr != nil {
+			batch.Errors = append(batch.Errors, &entity.UserImportError{ImportID: imp.ID, Row: number, Message: rowErr.message})
+		} else if user, rejected := uc.prepare(imp, number, row, seen); rejected != nil {
+			batch.Errors = append(batch.Errors, rejected...)
+		} else {
+			batch.Users = append(batch.Users, repository.ImportedUser{Row: number, User: user})
+		}
+
+		if len(batch.Users)+len(batch.Errors) >= uc.opts.BatchSize {
+			if err := uc.flush(ctx, &batch); err != nil {
+				return err
+			}
+		}
+	}
+
+	if batch.ToRow > batch.FromRow {
+		return uc.flush(ctx, &batch)
+	}
+	return nil
+}
+
+// flush stores a batch and starts the next one after it.
+func (uc *userImportUseCase) flush(ctx context.Context, batch *repository.ImportBatch) error {
+	if _, err := uc.importRepo.ImportBatch(ctx, *batch); err != nil {
+		return fmt.Errorf("failed to store rows %d to %d: %w", batch.FromRow+1, batch.ToRow, err)
+	}
+	batch.FromRow = batch.ToRow
+	batch.Users = nil
+	batch.Errors = nil
+	return nil
+}
+
+// prepare validates a row and builds its user, or returns why it is rejected.
+func (uc *userImportUseCase) prepare(imp *entity.UserImport, number int64, row *importRow, seen map[string]int64) (*entity.User, []*entity.UserImportError) {
+	if rejected := uc.validate(imp, number, row, seen); rejected != nil {
+		return nil, rejected
+	}
+
+	hash := row.PasswordHash
+	if hash == "" && !imp.DryRun { // hashing is slow and a dry run stores nothing
+		hashed, err := uc.hasher.HashPassword(row.Password)
+		if err != nil {
+			return nil, []*entity.UserImportError{{ImportID: imp.ID, Row: number, Field: "password", Message: "failed to hash password"}}
+		}
+		hash = hashed
+	}
+
+	user := &entity.User{
+		Username:     row.Username,
+		Email:        row.Email,
+		PhoneNumber:  row.Phone,
+		FirstName:    row.FirstName,
+		LastName:     row.LastName,
+		HashPassword: hash,
+	}
+	if row.CreatedAt != "" {
+		user.CreatedAt, _ = time.Parse(time.RFC3339, row.CreatedAt) // validated above
+	}
+	return user, nil
+}
+
+// validate returns why a row is rejected, or records its username and email
+// in seen. It neither hashes nor stores anything, so rows before the
+// checkpoint of a resumed import are validated again without being stored.
+func (uc *userImportUseCase) validate(imp *entity.UserImport, number int64, row *importRow, seen map[string]int64) []*entity.UserImportError {
+	reject := func(field, message string) []*entity.UserImportError {
+		return []*entity.UserImportError{{ImportID: imp.ID, Row: number, Field: field, Message: message}}
+	}
+
+	if err := validator.Struct(row); err != nil {
+		var errs validator.ValidationErrors
+		if !errors.As(err, &errs) {
+			return reject("", err.Error())
+		}
+		rejected := make([]*entity.UserImportError, 0, len(errs))
+		for _, e := range errs {
+			rejected = append(rejected, &entity.UserImportError{ImportID: imp.ID, Row: number, Field: e.Field, Message: e.Message})
+		}
+		return rejected
+	}
+
+	switch {
+	case row.Password == "" && row.PasswordHash == "":
+		return reject("password", "password or password_hash is required")
+	case row.Password != "" && row.PasswordHash != "":
+		return reject("password_hash", "password and password_hash cannot both be set")
+	}
+
+	for _, key := range []struct{ field, value string }{{"username", row.Username}, {"email", row.Email}} {
+		if first, ok := seen[key.field+":"+key.value]; ok {
+			return reject(key.field, fmt.Sprintf("%s duplicates row %d", key.field, first))
+		}
+	}
+
+	if row.PasswordHash != "" {
+		if err := uc.hasher.ValidatePasswordHash(row.PasswordHash); err != nil {
+			return reject("password_hash", err.Error())
+		}
+	}
+	seen["username:"+row.Username] = number
+	seen["email:"+row.Email] = number
+	return nil
+}
+
+func randomToken() string {
+	token := make([]byte, 16)
+	_, _ = rand.Read(token)
+	return hex.EncodeToString(token)
+}
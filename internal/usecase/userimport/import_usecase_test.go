@@ -0,0 +1,132 @@
+package userimport
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"base-service/internal/domain/entity"
+	"base-service/internal/domain/repository"
+)
+
+type fakeSourceStore struct {
+	data string
+}
+
+func (s *fakeSourceStore) Upload(ctx context.Context, bucket, key string, data io.Reader, contentType string) error {
+	return nil
+}
+
+func (s *fakeSourceStore) Download(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(s.data)), nil
+}
+
+// fakeImportRepo records the stored batches.
+type fakeImportRepo struct {
+	repository.UserImportRepository
+	batches []repository.ImportBatch
+}
+
+func (r *fakeImportRepo) ImportBatch(ctx context.Context, batch repository.ImportBatch) (int64, error) {
+	r.batches = append(r.batches, batch)
+	return int64(len(batch.Users)), nil
+}
+
+type fakeHasher struct{}
+
+func (fakeHasher) HashPassword(password string) (string, error) {
+	return "hashed:" + password, nil
+}
+
+func (fakeHasher) ValidatePasswordHash(hash string) error {
+	return nil
+}
+
+// importFile has duplicates of the rows 1 and 2 further down, and an
+// invalid row 3 whose username is free for row 5.
+const importFile = `{"username": "alice", "email": "alice@example.com", "password_hash": "h"}
+{"username": "bob", "email": "bob@example.com", "password_hash": "h"}
+{"username": "carol", "email": "not-an-email", "password_hash": "h"}
+{"username": "alice", "email": "alice2@example.com", "password_hash": "h"}
+{"username": "carol", "email": "carol@example.com", "password_hash": "h"}
+{"username": "robert", "email": "bob@example.com", "password_hash": "h"}
+`
+
+func TestProcessResume(t *testing.T) {
+	tests := []struct {
+		name       string
+		checkpoint int64
+		wantUsers  []int64
+		wantErrors map[int64]string
+	}{
+		{
+			name:       "from the start",
+			checkpoint: 0,
+			wantUsers:  []int64{1, 2, 5},
+			wantErrors: map[int64]string{
+				3: "email",
+				4: "username duplicates row 1",
+				6: "email duplicates row 2",
+			},
+		},
+		{
+			name:       "resumed after the first rows",
+			checkpoint: 3,
+			wantUsers:  []int64{5},
+			wantErrors: map[int64]string{
+				4: "username duplicates row 1",
+				6: "email duplicates row 2",
+			},
+		},
+		{
+			name:       "resumed after the duplicates",
+			checkpoint: 4,
+			wantUsers:  []int64{5},
+			wantErrors: map[int64]string{
+				6: "email duplicates row 2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeImportRepo{}
+			uc := NewUserImportUseCase(repo, &fakeSourceStore{data: importFile}, fakeHasher{}, Options{}).(*userImportUseCase)
+			imp := &entity.UserImport{ID: 1, Format: entity.ImportFormatNDJSON, RowsProcessed: tt.checkpoint}
+
+			if err := uc.process(context.Background(), imp); err != nil {
+				t.Fatalf("process: %v", err)
+			}
+			if len(repo.batches) != 1 {
+				t.Fatalf("stored %d batches, want 1", len(repo.batches))
+			}
+			batch := repo.batches[0]
+			if batch.FromRow != tt.checkpoint || batch.ToRow != 6 {
+				t.Errorf("batch rows = (%d, %d], want (%d, 6]", batch.FromRow, batch.ToRow, tt.checkpoint)
+			}
+
+			var users []int64
+			for _, user := range batch.Users {
+				users = append(users, user.Row)
+			}
+			if !slices.Equal(users, tt.wantUsers) {
+				t.Errorf("imported rows = %v, want %v", users, tt.wantUsers)
+			}
+
+			if len(batch.Errors) != len(tt.wantErrors) {
+				t.Errorf("rejected %d rows, want %d", len(batch.Errors), len(tt.wantErrors))
+			}
+			for _, rowErr := range batch.Errors {
+				want, ok := tt.wantErrors[rowErr.Row]
+				if !ok {
+					t.Errorf("row %d rejected: %s", rowErr.Row, rowErr.Message)
+					continue
+				}
+				if !strings.Contains(rowErr.Field+" "+rowErr.Message, want) {
+					t.Errorf("row %d rejected with %s: %s, want %q", rowErr.Row, rowErr.Field, rowErr.Message, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package userimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"base-service/internal/domain/entity"
+	domainerrors "base-service/internal/domain/errors"
+)
+
+// importRow is a row of an import file. Its rules follow RegisterRequest;
+// exactly one of Password and PasswordHash is required.
+type importRow struct {
+	Username     string `json:"username" validate:"required,min=3,max=16,username"`
+	Email        string `json:"email" validate:"required,email"`
+	Phone        string `json:"phone" validate:"omitempty,max=20,regex=^\\+?[0-9 ]+$"`
+	FirstName    string `json:"first_name" validate:"max=50"`
+	LastName     string `json:"last_name" validate:"max=50"`
+	Password     string `json:"password" validate:"omitempty,password"`
+	PasswordHash string `json:"password_hash" validate:"omitempty,max=255"` // argon2id, or a legacy hash such as {bcrypt}$2a$...
+	CreatedAt    string `json:"created_at" validate:"omitempty,datetime"`   // RFC 3339; empty uses the import time
+}
+
+// rowError rejects a single row; reading continues with the next one.
+type rowError struct {
+	message string
+}
+
+func (e *rowError) Error() string {
+	return e.message
+}
+
+// rowReader reads the rows of an import file.
+type rowReader interface {
+	// Next returns the next row and its number: the CSV record after the
+	// header, or the NDJSON line. It returns io.EOF at the end of the file and
+	// a *rowError for a row that cannot be read; any other error is fatal.
+	Next() (int64, *importRow, error)
+}
+
+// newRowReader creates a reader of the rows of r in format.
+func newRowReader(format string, r io.Reader) (rowReader, error) {
+	switch format {
+	case entity.ImportFormatCSV:
+		return newCSVRowReader(r)
+	case entity.ImportFormatNDJSON:
+		return &ndjsonRowReader{reader: bufio.NewReader(r)}, nil
+	default:
+		return nil, domainerrors.ErrUnsupportedImportFormat
+	}
+}
+
+// =============================================================================
+// CSV
+// The header names the columns, in any order: username, email, phone,
+// first_name, last_name, password, password_hash and created_at. Unknown
+// columns are ignored.
+// =============================================================================
+
+// csvRequiredColumns must be present in the header.
+var csvRequiredColumns = []string{"username", "email"}
+
+type csvRowReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+	width   int
+	row     int64
+}
+
+func newCSVRowReader(r io.Reader) (*csvRowReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // reported per row instead of failing the file
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("the file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // byte order mark of spreadsheet exports
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range csvRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("the header has no %s column", name)
+		}
+	}
+
+	reader.ReuseRecord = true
+	return &csvRowReader{reader: reader, columns: columns, width: len(header)}, nil
+}
+
+func (r *csvRowReader) Next() (int64, *importRow, error) {
+	record, err := r.reader.Read()
+	if err != nil {
+		return 0, nil, err
+	}
+	r.row++
+
+	if len(record) != r.width {
+		return r.row, nil, &rowError{message: fmt.Sprintf("row has %d fields, the header has %d", len(record), r.width)}
+	}
+	return r.row, &importRow{
+		Username:     r.field(record, "username"),
+		Email:        r.field(record, "email"),
+		Phone:        r.field(record, "phone"),
+		FirstName:    r.field(record, "first_name"),
+		LastName:     r.field(record, "last_name"),
+		Password:     r.value(record, "password"),
+		PasswordHash: r.field(record, "password_hash"),
+		CreatedAt:    r.field(record, "created_at"),
+	}, nil
+}
+
+// field returns the trimmed value of column in record.
+func (r *csvRowReader) field(record []string, column string) string {
+	return strings.TrimSpace(r.value(record, column))
+}
+
+// value returns the value of column in record, "" if the file has no such column.
+func (r *csvRowReader) value(record []string, column string) string {
+	i, ok := r.columns[column]
+	if !ok {
+		return ""
+	}
+	return record[i]
+}
+
+// =============================================================================
+// NDJSON
+// One JSON object per line, with the fields of the CSV columns. Blank lines
+// are skipped.
+// =============================================================================
+
+type ndjsonRowReader struct {
+	reader *bufio.Reader
+	line   int64
+}
+
+func (r *ndjsonRowReader) Next() (int64, *importRow, error) {
+	for {
+		line, err := r.reader.ReadBytes('\n')
+		if len(line) == 0 && err != nil {
+			return 0, nil, err
+		}
+		r.line++
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		var row importRow
+		if err := json.Unmarshal(line, &row); err != nil {
+			return r.line, nil, &rowError{message: "line is not a valid JSON object"}
+		}
+		return r.line, &row, nil
+	}
+}
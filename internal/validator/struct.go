@@ -0,0 +1,450 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// =============================================================================
+// Struct Validation
+// Validates structs from `validate:"..."` tags, e.g.
+//
+//	UserName string `json:"user_name" validate:"required,min=3,max=16,username"`
+//
+// Rules are comma separated and checked in order; the first failing rule of a
+// field is reported. Field names in errors are taken from the json tag.
+// =============================================================================
+
+// TagName is the struct tag read by Struct
+const TagName = "validate"
+
+var (
+	// ErrMalformedBody is returned when a request body or query cannot be decoded
+	ErrMalformedBody = errors.New("malformed request body")
+	// ErrInvalidTag is returned by Struct when a validate tag names an unknown
+	// rule or has an invalid parameter
+	ErrInvalidTag = errors.New("validator: invalid validate tag")
+)
+
+// RuleFunc checks a field value against a rule parameter (the part after "=").
+// It returns the failure message, or "" when the value is valid.
+type RuleFunc func(field reflect.Value, param string) string
+
+// ruleCompiler checks the parameter of a rule once and returns the check of
+// field values.
+type ruleCompiler func(param string) (func(field reflect.Value) string, error)
+
+var (
+	rulesMu sync.RWMutex
+	rules   = map[string]ruleCompiler{
+		"required": withoutParam(ruleRequired),
+		"min":      boundRule("at least", func(got, limit float64) bool { return got >= limit }),
+		"max":      boundRule("at most", func(got, limit float64) bool { return got <= limit }),
+		"len":      boundRule("exactly", func(got, limit float64) bool { return got == limit }),
+		"email":    withoutParam(ruleEmail),
+		"username": withoutParam(ruleUsername),
+		"password": withoutParam(rulePassword),
+		"oneof":    ruleOneOf,
+		"regex":    ruleRegex,
+		"datetime": withoutParam(ruleDateTime),
+	}
+
+	// typeRules caches the compiled rules of each validated struct type
+	typeRules sync.Map // reflect.Type -> compiledStruct
+)
+
+// RegisterRule adds or replaces a named rule usable in validate tags. Types
+// validated before are compiled again with the new rule.
+func RegisterRule(name string, fn RuleFunc) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	rules[name] = func(param string) (func(reflect.Value) string, error) {
+		return func(field reflect.Value) string { return fn(field, param) }, nil
+	}
+	typeRules.Clear()
+}
+
+// Struct validates v (a struct or pointer to struct) against its validate tags.
+// Nested structs, pointers to structs and slices of structs are validated
+// recursively. It returns ValidationErrors listing every failed field, or an
+// ErrInvalidTag error when a tag of v's type cannot be compiled.
+func Struct(v any) error {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return ValidationError{Field: "body", Message: "body is required", Rule: "required"}
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("validator: Struct expects a struct, got %s", value.Kind())
+	}
+
+	var errs ValidationErrors
+	if err := validateStruct(value, "", &errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// =============================================================================
+// Compiled Rules
+// Tags are parsed and their parameters checked once per struct type, so a
+// malformed tag is an error of the first Struct call instead of a panic
+// whenever a request reaches the field.
+// =============================================================================
+
+type compiledStruct struct {
+	fields []compiledField
+	err    error
+}
+
+type compiledField struct {
+	index     int
+	name      string
+	omitEmpty bool
+	rules     []compiledRule
+}
+
+type compiledRule struct {
+	name  string
+	check func(field reflect.Value) string
+}
+
+// rulesFor returns the compiled rules of a struct type, compiling them on
+// first use.
+func rulesFor(typ reflect.Type) (compiledStruct, error) {
+	if cached, ok := typeRules.Load(typ); ok {
+		compiled := cached.(compiledStruct)
+		return compiled, compiled.err
+	}
+
+	rulesMu.RLock()
+	compiled := compileStruct(typ, make(map[reflect.Type]bool))
+	rulesMu.RUnlock()
+
+	cached, _ := typeRules.LoadOrStore(typ, compiled)
+	compiled = cached.(compiledStruct)
+	return compiled, compiled.err
+}
+
+// compileStruct compiles the tags of typ and checks those of the struct
+// types nested in it; seen breaks cycles of recursive types.
+func compileStruct(typ reflect.Type, seen map[reflect.Type]bool) compiledStruct {
+	seen[typ] = true
+
+	var compiled compiledStruct
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get(TagName)
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+
+		compiledField, err := compileField(field, tag)
+		if err != nil {
+			return compiledStruct{err: fmt.Errorf("%w: %s.%s: %w", ErrInvalidTag, typ, field.Name, err)}
+		}
+		compiled.fields = append(compiled.fields, compiledField)
+
+		if nested := nestedStruct(field.Type); nested != nil && !seen[nested] {
+			if err := compileStruct(nested, seen).err; err != nil {
+				return compiledStruct{err: err}
+			}
+		}
+	}
+	return compiled
+}
+
+func compileField(field reflect.StructField, tag string) (compiledField, error) {
+	compiled := compiledField{index: field.Index[0], name: fieldName(field)}
+	if tag == "" {
+		return compiled, nil
+	}
+
+	for _, spec := range strings.Split(tag, ",") {
+		ruleName, param, _ := strings.Cut(strings.TrimSpace(spec), "=")
+		switch ruleName {
+		case "":
+			continue
+		case "omitempty":
+			compiled.omitEmpty = true
+			continue
+		}
+
+		compile, ok := rules[ruleName]
+		if !ok {
+			return compiledField{}, fmt.Errorf("unknown rule %q", ruleName)
+		}
+		check, err := compile(param)
+		if err != nil {
+			return compiledField{}, fmt.Errorf("rule %q: %w", ruleName, err)
+		}
+		compiled.rules = append(compiled.rules, compiledRule{name: ruleName, check: check})
+	}
+	return compiled, nil
+}
+
+// nestedStruct returns the struct type validated recursively for fields of
+// type typ, or nil.
+func nestedStruct(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+		typ = typ.Elem()
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+	return typ
+}
+
+// =============================================================================
+// Validation
+// =============================================================================
+
+func validateStruct(value reflect.Value, prefix string, errs *ValidationErrors) error {
+	compiled, err := rulesFor(value.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, field := range compiled.fields {
+		name := prefix + field.name
+		fieldValue := value.Field(field.index)
+		if !field.validate(fieldValue, name, errs) {
+			continue // nested rules are meaningless once the field itself failed
+		}
+		if err := validateNested(fieldValue, name, errs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate applies the rules of the field and reports whether it passed.
+func (f compiledField) validate(value reflect.Value, name string, errs *ValidationErrors) bool {
+	// omitempty also skips pointers to empty values, e.g. a merge patch null
+	if f.omitEmpty && indirect(value).IsZero() {
+		return true
+	}
+
+	for _, rule := range f.rules {
+		if message := rule.check(indirect(value)); message != "" {
+			*errs = append(*errs, ValidationError{
+				Field:   name,
+				Message: fmt.Sprintf("%s %s", name, message),
+				Rule:    rule.name,
+			})
+			return false
+		}
+	}
+	return true
+}
+
+func validateNested(value reflect.Value, name string, errs *ValidationErrors) error {
+	value = indirect(value)
+	switch value.Kind() {
+	case reflect.Struct:
+		return validateStruct(value, name+".", errs)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			item := indirect(value.Index(i))
+			if item.Kind() != reflect.Struct {
+				continue
+			}
+			if err := validateStruct(item, fmt.Sprintf("%s[%d].", name, i), errs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fieldName returns the json name of a field, falling back to the Go name.
+func fieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return value
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+// =============================================================================
+// Built-in Rules
+// =============================================================================
+
+// withoutParam adapts a rule that takes no parameter.
+func withoutParam(check func(field reflect.Value) string) ruleCompiler {
+	return func(string) (func(reflect.Value) string, error) {
+		return check, nil
+	}
+}
+
+// boundRule checks the length of strings and collections, or the value of
+// numbers, against the numeric parameter (min, max and len).
+func boundRule(relation string, ok func(got, limit float64) bool) ruleCompiler {
+	return func(param string) (func(reflect.Value) string, error) {
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameter %q", param)
+		}
+		return func(value reflect.Value) string {
+			return compareBound(value, param, limit, relation, ok)
+		}, nil
+	}
+}
+
+func ruleRequired(value reflect.Value) string {
+	switch value.Kind() {
+	case reflect.Invalid:
+		return "is required"
+	case reflect.String:
+		if strings.TrimSpace(value.String()) == "" {
+			return "is required"
+		}
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		if value.IsNil() || (value.Kind() != reflect.Pointer && value.Len() == 0) {
+			return "is required"
+		}
+	default:
+		if value.IsZero() {
+			return "is required"
+		}
+	}
+	return ""
+}
+
+func compareBound(value reflect.Value, param string, limit float64, relation string, ok func(got, limit float64) bool) string {
+	switch value.Kind() {
+	case reflect.String:
+		if !ok(float64(utf8.RuneCountInString(value.String())), limit) {
+			return fmt.Sprintf("must be %s %s characters", relation, param)
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if !ok(float64(value.Len()), limit) {
+			return fmt.Sprintf("must contain %s %s items", relation, param)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !ok(float64(value.Int()), limit) {
+			return fmt.Sprintf("must be %s %s", relation, param)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if !ok(float64(value.Uint()), limit) {
+			return fmt.Sprintf("must be %s %s", relation, param)
+		}
+	case reflect.Float32, reflect.Float64:
+		if !ok(value.Float(), limit) {
+			return fmt.Sprintf("must be %s %s", relation, param)
+		}
+	}
+	return ""
+}
+
+func ruleEmail(value reflect.Value) string {
+	if err := ValidateEmail(stringValue(value)); err != nil {
+		return "must be a valid email address"
+	}
+	return ""
+}
+
+func ruleUsername(value reflect.Value) string {
+	if !UsernameRegex.MatchString(strings.TrimSpace(stringValue(value))) {
+		return "can only contain letters, numbers, underscores, and hyphens (3-30 characters)"
+	}
+	return ""
+}
+
+// rulePassword applies DefaultPasswordRequirements.
+func rulePassword(value reflect.Value) string {
+	err := ValidatePassword(stringValue(value), DefaultPasswordRequirements)
+	if err == nil {
+		return ""
+	}
+
+	var messages []string
+	var fieldErrs ValidationErrors
+	var fieldErr ValidationError
+	switch {
+	case errors.As(err, &fieldErrs):
+		for _, e := range fieldErrs {
+			messages = append(messages, strings.TrimPrefix(e.Message, "password "))
+		}
+	case errors.As(err, &fieldErr):
+		messages = append(messages, strings.TrimPrefix(fieldErr.Message, "password "))
+	}
+	return strings.Join(messages, ", ")
+}
+
+// ruleOneOf accepts space separated values, e.g. oneof=asc desc.
+func ruleOneOf(param string) (func(reflect.Value) string, error) {
+	allowed := strings.Fields(param)
+	if len(allowed) == 0 {
+		return nil, errors.New("no values")
+	}
+	message := fmt.Sprintf("must be one of [%s]", param)
+	return func(value reflect.Value) string {
+		if slices.Contains(allowed, fmt.Sprint(value.Interface())) {
+			return ""
+		}
+		return message
+	}, nil
+}
+
+// ruleRegex matches the whole value against the pattern, e.g. regex=^[0-9]+$.
+// Patterns cannot contain commas; register a named rule for those.
+func ruleRegex(param string) (func(reflect.Value) string, error) {
+	re, err := regexp.Compile(param)
+	if err != nil {
+		return nil, err
+	}
+	return func(value reflect.Value) string {
+		if !re.MatchString(stringValue(value)) {
+			return "has an invalid format"
+		}
+		return ""
+	}, nil
+}
+
+// ruleDateTime accepts RFC 3339 timestamps, e.g. 2026-01-02T15:04:05Z.
+func ruleDateTime(value reflect.Value) string {
+	if _, err := time.Parse(time.RFC3339, stringValue(value)); err != nil {
+		return "must be an RFC 3339 date-time"
+	}
+	return ""
+}
+
+func stringValue(value reflect.Value) string {
+	if value.Kind() == reflect.String {
+		return value.String()
+	}
+	if !value.IsValid() {
+		return ""
+	}
+	return fmt.Sprint(value.Interface())
+}
@@ -0,0 +1,197 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// checkRule validates value against a single-field struct built for tag and
+// returns the failed rule, "" when the value is valid.
+func checkRule(t *testing.T, tag string, value any) string {
+	t.Helper()
+	typ := reflect.StructOf([]reflect.StructField{{
+		Name: "Value",
+		Type: reflect.TypeOf(value),
+		Tag:  reflect.StructTag(`json:"value" validate:"` + tag + `"`),
+	}})
+	v := reflect.New(typ)
+	v.Elem().Field(0).Set(reflect.ValueOf(value))
+
+	err := Struct(v.Interface())
+	if err == nil {
+		return ""
+	}
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("Struct(%q) err = %v, want one ValidationError", tag, err)
+	}
+	if errs[0].Field != "value" || !strings.HasPrefix(errs[0].Message, "value ") {
+		t.Errorf("error = %+v, want the field named value", errs[0])
+	}
+	return errs[0].Rule
+}
+
+func TestRules(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		tag   string
+		value any
+		valid bool
+	}{
+		{tag: "required", value: "jane", valid: true},
+		{tag: "required", value: "  ", valid: false},
+		{tag: "required", value: 0, valid: false},
+		{tag: "required", value: []string{}, valid: false},
+		{tag: "required", value: (*string)(nil), valid: false},
+		{tag: "required", value: str(""), valid: false},
+
+		{tag: "min=3", value: "héé", valid: true},
+		{tag: "min=3", value: "ab", valid: false},
+		{tag: "min=1", value: []int{}, valid: false},
+		{tag: "min=1", value: 0, valid: false},
+		{tag: "min=0.5", value: 0.5, valid: true},
+		{tag: "max=3", value: "abc", valid: true},
+		{tag: "max=3", value: "abcd", valid: false},
+		{tag: "max=100", value: uint(101), valid: false},
+		{tag: "len=6", value: "123456", valid: true},
+		{tag: "len=6", value: "12345", valid: false},
+
+		{tag: "email", value: "jane@example.com", valid: true},
+		{tag: "email", value: "jane@example", valid: false},
+		{tag: "username", value: "jane_doe-1", valid: true},
+		{tag: "username", value: "jane doe", valid: false},
+		{tag: "password", value: "Str0ng!pass", valid: true},
+		{tag: "password", value: "weakpass", valid: false},
+
+		{tag: "oneof=asc desc", value: "desc", valid: true},
+		{tag: "oneof=asc desc", value: "up", valid: false},
+		{tag: "oneof=1 2", value: 2, valid: true},
+		{tag: "regex=^[0-9]+$", value: "123", valid: true},
+		{tag: "regex=^[0-9]+$", value: "12a", valid: false},
+		{tag: "datetime", value: "2026-01-02T15:04:05Z", valid: true},
+		{tag: "datetime", value: "2026-01-02", valid: false},
+
+		{tag: "omitempty,email", value: "", valid: true},
+		{tag: "omitempty,email", value: str(""), valid: true},
+		{tag: "omitempty,email", value: str("jane"), valid: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag+"/"+strings.TrimPrefix(reflect.TypeOf(tt.value).String(), "*"), func(t *testing.T) {
+			failed := checkRule(t, tt.tag, tt.value)
+			if tt.valid && failed != "" {
+				t.Errorf("%v failed %s, want valid", tt.value, failed)
+			}
+			if !tt.valid && failed == "" {
+				t.Errorf("%v passed, want invalid", tt.value)
+			}
+		})
+	}
+}
+
+func TestStructReportsFirstFailedRuleOfEveryField(t *testing.T) {
+	type address struct {
+		City string `json:"city" validate:"required"`
+	}
+	type request struct {
+		Name      string    `json:"name" validate:"required,min=3,max=16"`
+		Email     string    `json:"email" validate:"required,email"`
+		Address   *address  `json:"address"`
+		Addresses []address `json:"addresses"`
+		Ignored   string    `json:"ignored" validate:"-"`
+	}
+
+	err := Struct(&request{
+		Name:      "jo",
+		Address:   &address{},
+		Addresses: []address{{City: "Hanoi"}, {}},
+	})
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("err = %v, want ValidationErrors", err)
+	}
+
+	want := []ValidationError{
+		{Field: "name", Message: "name must be at least 3 characters", Rule: "min"},
+		{Field: "email", Message: "email is required", Rule: "required"},
+		{Field: "address.city", Message: "address.city is required", Rule: "required"},
+		{Field: "addresses[1].city", Message: "addresses[1].city is required", Rule: "required"},
+	}
+	if !reflect.DeepEqual([]ValidationError(errs), want) {
+		t.Errorf("errors = %+v, want %+v", errs, want)
+	}
+	if got := errs[0].Error(); got != "name must be at least 3 characters" {
+		t.Errorf("Error() = %q, want the message once", got)
+	}
+}
+
+func TestStructInvalidTags(t *testing.T) {
+	type node struct {
+		Name     string  `json:"name" validate:"required"`
+		Children []*node `json:"children"`
+	}
+	type unknownRule struct {
+		Name string `validate:"required,uuid"`
+	}
+	type badBound struct {
+		Name string `validate:"min=three"`
+	}
+	type badRegex struct {
+		Code string `validate:"regex=^[0-9+$"`
+	}
+	type emptyOneOf struct {
+		Sort string `validate:"oneof="`
+	}
+	type nestedBadTag struct {
+		Inner *badBound `json:"inner"`
+	}
+
+	tests := []struct {
+		name    string
+		value   any
+		wantErr bool
+	}{
+		{name: "recursive type", value: &node{Name: "root", Children: []*node{{Name: "leaf"}}}},
+		{name: "unknown rule", value: &unknownRule{Name: "a"}, wantErr: true},
+		{name: "non-numeric bound", value: &badBound{Name: "a"}, wantErr: true},
+		{name: "invalid regex", value: &badRegex{Code: "1"}, wantErr: true},
+		{name: "oneof without values", value: &emptyOneOf{Sort: "asc"}, wantErr: true},
+		// Found although the nil field is never validated
+		{name: "nested type", value: &nestedBadTag{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 2 { // the second call is served from the cache
+				err := Struct(tt.value)
+				if got := errors.Is(err, ErrInvalidTag); got != tt.wantErr {
+					t.Fatalf("err = %v, want ErrInvalidTag %v", err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterRule(t *testing.T) {
+	type request struct {
+		Slug string `json:"slug" validate:"lowercase"`
+	}
+	if err := Struct(&request{Slug: "abc"}); !errors.Is(err, ErrInvalidTag) {
+		t.Fatalf("err = %v before RegisterRule, want ErrInvalidTag", err)
+	}
+
+	RegisterRule("lowercase", func(field reflect.Value, _ string) string {
+		if field.String() != strings.ToLower(field.String()) {
+			return "must be lowercase"
+		}
+		return ""
+	})
+	if err := Struct(&request{Slug: "abc"}); err != nil {
+		t.Errorf("Struct(abc): %v", err)
+	}
+	var errs ValidationErrors
+	if err := Struct(&request{Slug: "ABC"}); !errors.As(err, &errs) || errs[0].Rule != "lowercase" {
+		t.Errorf("Struct(ABC) err = %v, want a lowercase failure", err)
+	}
+}
@@ -3,9 +3,12 @@ package validator
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 	"unicode"
+
+	"base-service/internal/common"
 )
 
 var (
@@ -38,12 +41,34 @@ type PasswordRequirements struct {
 
 // ValidationError represents a field-specific validation error
 type ValidationError struct {
-	Field   string
-	Message string
+	Field string `json:"field"`
+	// Message names the field, e.g. "email is required"
+	Message string `json:"message"`
+	// Rule is the failed validate tag rule (e.g. "required"), if any
+	Rule string `json:"rule,omitempty"`
 }
 
+// Error returns the message, which already names the field.
 func (e ValidationError) Error() string {
-	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+	if e.Message == "" {
+		return e.Field + " is invalid"
+	}
+	return e.Message
+}
+
+// ErrorCode is the error catalog code of validation failures
+func (e ValidationError) ErrorCode() string {
+	return common.ValidationFailedCode
+}
+
+// HTTPStatus reports validation failures as 422 Unprocessable Entity
+func (e ValidationError) HTTPStatus() int {
+	return http.StatusUnprocessableEntity
+}
+
+// ProblemExtensions lists the failed field in the error response
+func (e ValidationError) ProblemExtensions() map[string]any {
+	return map[string]any{"errors": ValidationErrors{e}}
 }
 
 // ValidationErrors is a collection of validation errors
@@ -60,6 +85,21 @@ func (e ValidationErrors) Error() string {
 	return strings.Join(messages, "; ")
 }
 
+// ErrorCode is the error catalog code of validation failures
+func (e ValidationErrors) ErrorCode() string {
+	return common.ValidationFailedCode
+}
+
+// HTTPStatus reports validation failures as 422 Unprocessable Entity
+func (e ValidationErrors) HTTPStatus() int {
+	return http.StatusUnprocessableEntity
+}
+
+// ProblemExtensions lists every failed field in the error response
+func (e ValidationErrors) ProblemExtensions() map[string]any {
+	return map[string]any{"errors": e}
+}
+
 // ValidateEmail checks if an email is valid
 func ValidateEmail(email string) error {
 	email = strings.TrimSpace(email)
@@ -200,7 +240,8 @@ func NewValidator() *Validator {
 	}
 }
 
-// AddError adds a validation error
+// AddError adds a validation error; message names the field, e.g.
+// "email is required"
 func (v *Validator) AddError(field, message string) {
 	v.Errors = append(v.Errors, ValidationError{Field: field, Message: message})
 }
@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"log/slog"
+	"os"
 
 	"base-service/config"
 	"base-service/internal/infra"
@@ -50,6 +51,11 @@ func LoadConfig() *config.Config {
 func main() {
 	conf := LoadConfig()
 	infra.InitLogger(*conf)
+
+	// Commands run instead of the server
+	if flag.Arg(0) == "import" {
+		os.Exit(runImport(conf, flag.Args()[1:]))
+	}
 	StartServer(conf)
 }
 
@@ -58,7 +64,7 @@ func StartServer(cfg *config.Config) {
 	registry := infra.NewRegistry()
 
 	// Initialize and register database
-	db, err := initDatabase(&cfg.Database)
+	db, err := initDatabase(&cfg.Database, cfg.Tenancy)
 	if err != nil {
 		slog.Error("Failed to initialize database", "error", err)
 		panic(err)
@@ -68,6 +74,20 @@ func StartServer(cfg *config.Config) {
 		panic(err)
 	}
 
+	// The system role bypasses row-level security, for jobs across tenants
+	var systemDB *infra.DatabaseClient
+	if cfg.Database.SystemConfig() != nil {
+		systemDB, err = infra.NewSystemDatabaseClient(&cfg.Database)
+		if err != nil {
+			slog.Error("Failed to initialize system database connection", "error", err)
+			panic(err)
+		}
+		if err := registry.RegisterConnection(systemDB); err != nil {
+			slog.Error("Failed to register system database connection", "error", err)
+			panic(err)
+		}
+	}
+
 	// Initialize and register cache
 	cache, err := initRedis(&cfg.Redis)
 	if err != nil {
@@ -79,11 +99,29 @@ func StartServer(cfg *config.Config) {
 		panic(err)
 	}
 
+	// Initialize and register object storage
+	storage, err := infra.NewObjectStorage(cfg.Storage)
+	if err != nil {
+		slog.Error("Failed to initialize object storage", "error", err)
+		panic(err)
+	}
+	if err := registry.RegisterConnection(storage); err != nil {
+		slog.Error("Failed to register object storage", "error", err)
+		panic(err)
+	}
+
+	// Initialize notifications (email)
+	notifier, err := infra.NewNotificationSender(cfg.Notification)
+	if err != nil {
+		slog.Error("Failed to initialize notifications", "error", err)
+		panic(err)
+	}
+
 	// Log infrastructure stats
 	logInfraStats(registry)
 
 	// Initialize routes (backward compatible - using pool and redis client)
-	route.InitRoute(cfg, db.GetPool(), cache)
+	route.InitRoute(cfg, db, systemDB, cache, storage, notifier)
 
 	// Graceful shutdown - close all connections via registry
 	defer func() {
@@ -105,8 +143,8 @@ func initRedis(conf *config.RedisConfig) (*infra.RedisClient, error) {
 	return client, nil
 }
 
-func initDatabase(conf *config.DatabaseConfig) (*infra.DatabaseClient, error) {
-	client, err := infra.NewDatabaseClient(conf)
+func initDatabase(conf *config.DatabaseConfig, tenancy config.TenancyConfig) (*infra.DatabaseClient, error) {
+	client, err := infra.NewDatabaseClient(conf, tenancy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}